@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"springcli/internal/buildfile"
+	"springcli/internal/maven"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	addDependencyCmd.Flags().String("scope", "compile", "Scope Maven: compile, runtime, test ou provided")
+	addCmd.AddCommand(addDependencyCmd)
+	rootCmd.AddCommand(addCmd)
+}
+
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Ajoute des dépendances au projet",
+	Long:  `Cette commande regroupe les ajouts au fichier de build (pom.xml ou script Gradle).`,
+}
+
+// ==================== ADD DEPENDENCY ====================
+var addDependencyCmd = &cobra.Command{
+	Use:   "dependency <groupId:artifactId[:version]>",
+	Short: "Ajoute une dépendance Maven au fichier de build.",
+	Long: `Cette commande ajoute une dépendance au pom.xml ou au script Gradle du projet, sans
+toucher au reste du fichier (mise en forme et commentaires conservés).
+
+Sans version, la dépendance n'en reçoit pas si le BOM de Spring Boot la gère; sinon, la
+dernière version stable est lue dans le maven-metadata.xml du dépôt Maven, Maven Central
+par défaut ou celui de ` + "`maven.repository`" + ` dans .springcli.yaml.
+
+Une dépendance déjà déclarée est refusée.`,
+	Example: `  springcli add dependency org.mapstruct:mapstruct
+  springcli add dependency org.springframework.boot:spring-boot-starter-validation
+  springcli add dependency org.assertj:assertj-core:3.27.3 --scope test`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scope, _ := cmd.Flags().GetString("scope")
+
+		dep, err := parseDependency(args[0])
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+		if _, ok := gradleConfigurations[scope]; !ok {
+			utils.PrintError(fmt.Sprintf("Scope %s invalide (compile, runtime, test ou provided)", scope))
+			os.Exit(1)
+		}
+		dep.Scope = scope
+
+		utils.PrintTitle("📦 AJOUT DE DÉPENDANCE")
+		if err := addDependency(dep); err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+// parseDependency lit des coordonnées groupId:artifactId[:version].
+func parseDependency(coordinates string) (buildfile.Dependency, error) {
+	parts := strings.Split(strings.TrimSpace(coordinates), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return buildfile.Dependency{}, fmt.Errorf("coordonnées %s invalides (attendu: groupId:artifactId[:version])", coordinates)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return buildfile.Dependency{}, fmt.Errorf("coordonnées %s invalides (attendu: groupId:artifactId[:version])", coordinates)
+		}
+	}
+	dep := buildfile.Dependency{GroupID: parts[0], ArtifactID: parts[1]}
+	if len(parts) == 3 {
+		dep.Version = parts[2]
+	}
+	return dep, nil
+}
+
+// mavenRepository renvoie le dépôt Maven configuré pour le projet.
+func mavenRepository() *maven.Repository {
+	return maven.NewRepository(projectConfig().Maven.Repository)
+}
+
+// addDependency résout la version de la dépendance puis l'ajoute au fichier de build. La
+// version est omise lorsque le BOM de Spring Boot la gère.
+func addDependency(dep buildfile.Dependency) error {
+	if hasBuildDependency(dep.GroupID, dep.ArtifactID) {
+		return fmt.Errorf("%s est déjà déclarée dans %s", dep.Coordinates(), buildFileName())
+	}
+
+	repository := mavenRepository()
+	managed := false
+	if dep.Version == "" {
+		if boot := springBootVersion(); boot != "" {
+			bom, err := repository.BOM("org.springframework.boot", "spring-boot-dependencies", boot)
+			if err != nil {
+				utils.PrintWarning(fmt.Sprintf("BOM de Spring Boot %s illisible (%v): la version sera fixée", boot, err))
+			} else {
+				managed = bom.Manages(dep.GroupID, dep.ArtifactID)
+			}
+		}
+		if !managed {
+			version, err := repository.LatestVersion(dep.GroupID, dep.ArtifactID)
+			if errors.Is(err, maven.ErrNotFound) {
+				return fmt.Errorf("%s introuvable dans %s", dep.Coordinates(), repository.URL)
+			}
+			if err != nil {
+				return fmt.Errorf("impossible de résoudre la version de %s: %v", dep.Coordinates(), err)
+			}
+			dep.Version = version
+		}
+	} else if err := checkVersion(repository, dep); err != nil {
+		return err
+	}
+
+	err := addBuildDependency(dep, gradleConfigurations[dep.Scope])
+	if errors.Is(err, buildfile.ErrDuplicate) {
+		return fmt.Errorf("%s est déjà déclarée dans %s", dep.Coordinates(), buildFileName())
+	}
+	if err != nil {
+		return fmt.Errorf("impossible de modifier %s: %v", buildFileName(), err)
+	}
+
+	detail := "version " + dep.Version
+	if managed {
+		detail = "version gérée par Spring Boot " + springBootVersion()
+	}
+	if dep.Scope != "compile" {
+		detail += ", scope " + dep.Scope
+	}
+	utils.PrintSuccess(fmt.Sprintf("%s ajouté à %s (%s)", dep.Coordinates(), buildFileName(), detail))
+	return nil
+}
+
+// checkVersion vérifie qu'une version donnée explicitement est publiée. Un dépôt injoignable
+// n'empêche pas l'ajout: la version est alors prise telle quelle.
+func checkVersion(repository *maven.Repository, dep buildfile.Dependency) error {
+	metadata, err := repository.Metadata(dep.GroupID, dep.ArtifactID)
+	if errors.Is(err, maven.ErrNotFound) {
+		return fmt.Errorf("%s introuvable dans %s", dep.Coordinates(), repository.URL)
+	}
+	if err != nil {
+		utils.PrintWarning(fmt.Sprintf("Version %s non vérifiée: %v", dep.Version, err))
+		return nil
+	}
+	if !containsString(metadata.Versions, dep.Version) {
+		return fmt.Errorf("version %s de %s introuvable dans %s", dep.Version, dep.Coordinates(), repository.URL)
+	}
+	return nil
+}
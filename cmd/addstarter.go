@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"springcli/internal/buildfile"
+	"springcli/internal/initializr"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	addCmd.AddCommand(addStarterCmd)
+}
+
+// starterProperties liste, par starter, les propriétés à renseigner avant de démarrer
+// l'application.
+var starterProperties = map[string][]string{
+	"security":               {"spring.security.user.name=admin", "spring.security.user.password=changeme"},
+	"oauth2-client":          {"spring.security.oauth2.client.registration.<fournisseur>.client-id=", "spring.security.oauth2.client.registration.<fournisseur>.client-secret="},
+	"oauth2-resource-server": {"spring.security.oauth2.resourceserver.jwt.issuer-uri=https://<serveur>/realms/<realm>"},
+	"data-jpa":               {"spring.datasource.url=jdbc:postgresql://localhost:5432/<base>", "spring.datasource.username=", "spring.datasource.password="},
+	"data-mongodb":           {"spring.data.mongodb.uri=mongodb://localhost:27017/<base>"},
+	"data-redis":             {"spring.data.redis.host=localhost", "spring.data.redis.port=6379"},
+	"data-elasticsearch":     {"spring.elasticsearch.uris=http://localhost:9200"},
+	"flyway":                 {"spring.flyway.locations=classpath:db/migration"},
+	"liquibase":              {"spring.liquibase.change-log=classpath:db/changelog/db.changelog-master.yaml"},
+	"kafka":                  {"spring.kafka.bootstrap-servers=localhost:9092", "spring.kafka.consumer.group-id=<application>"},
+	"amqp":                   {"spring.rabbitmq.host=localhost", "spring.rabbitmq.port=5672", "spring.rabbitmq.username=guest", "spring.rabbitmq.password=guest"},
+	"mail":                   {"spring.mail.host=smtp.example.com", "spring.mail.port=587", "spring.mail.username=", "spring.mail.password="},
+	"cloud-config-client":    {"spring.config.import=optional:configserver:http://localhost:8888"},
+	"cloud-eureka":           {"eureka.client.service-url.defaultZone=http://localhost:8761/eureka/"},
+}
+
+const applicationPropertiesPath = "src/main/resources/application.properties"
+
+// ==================== ADD STARTER ====================
+var addStarterCmd = &cobra.Command{
+	Use:   "starter <id>[,<id>...]",
+	Short: "Ajoute des starters Spring Boot par leur identifiant Spring Initializr.",
+	Long: `Cette commande ajoute au fichier de build les starters désignés par leur identifiant
+Spring Initializr (security, kafka, flyway, cloud-config-client...), comme lors de la
+création du projet avec 'springcli new'.
+
+Les coordonnées sont lues dans les métadonnées d'Initializr pour la version de Spring Boot
+du projet, avec les BOMs (Spring Cloud...) et les dépôts dont certains starters ont besoin.
+Les propriétés à renseigner ensuite dans application.properties sont affichées.
+
+L'instance d'Initializr se configure avec ` + "`initializr.url`" + ` dans .springcli.yaml.`,
+	Example: `  springcli add starter security
+  springcli add starter security,kafka,flyway
+  springcli add starter cloud-config-client`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var ids []string
+		for _, arg := range args {
+			for _, id := range strings.Split(arg, ",") {
+				if id = strings.TrimSpace(id); id != "" && !containsString(ids, id) {
+					ids = append(ids, id)
+				}
+			}
+		}
+
+		utils.PrintTitle("🧩 AJOUT DE STARTERS")
+		metadata, err := initializr.Fetch(projectConfig().Initializr.URL, springBootVersion())
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire les métadonnées de Spring Initializr: %v", err))
+			os.Exit(1)
+		}
+		for _, id := range ids {
+			if _, ok := metadata.Dependencies[id]; !ok {
+				utils.PrintError(fmt.Sprintf("Starter %s inconnu de Spring Initializr pour Spring Boot %s", id, metadata.BootVersion))
+				os.Exit(1)
+			}
+		}
+
+		var added []string
+		for _, id := range ids {
+			ok, err := addStarter(metadata, id)
+			if err != nil {
+				utils.PrintError(fmt.Sprintf("Impossible d'ajouter %s à %s: %v", id, buildFileName(), err))
+				os.Exit(1)
+			}
+			if ok {
+				added = append(added, id)
+			}
+		}
+		printStarterProperties(added)
+	},
+}
+
+// addStarter ajoute le starter au fichier de build, précédé du BOM et des dépôts qu'il
+// requiert. Un starter déjà déclaré est ignoré.
+func addStarter(metadata *initializr.Metadata, id string) (bool, error) {
+	starter := metadata.Dependencies[id]
+	if hasBuildDependency(starter.GroupID, starter.ArtifactID) {
+		utils.PrintInfo(fmt.Sprintf("%s (%s) est déjà déclaré dans %s", id, starter.ArtifactID, buildFileName()))
+		return false, nil
+	}
+
+	var repositories []string
+	if starter.BOM != "" {
+		bom, ok := metadata.BOMs[starter.BOM]
+		if !ok {
+			return false, fmt.Errorf("BOM %s absent des métadonnées", starter.BOM)
+		}
+		if err := addStarterBOM(starter.BOM, bom); err != nil {
+			return false, err
+		}
+		repositories = append(repositories, bom.Repositories...)
+	}
+	if starter.Repository != "" {
+		repositories = append(repositories, starter.Repository)
+	}
+	for _, repoID := range repositories {
+		repo, ok := metadata.Repositories[repoID]
+		if !ok {
+			return false, fmt.Errorf("dépôt %s absent des métadonnées", repoID)
+		}
+		if err := addBuildRepository(buildfile.Repository{ID: repoID, Name: repo.Name, URL: repo.URL, Snapshots: repo.SnapshotEnabled}); err != nil {
+			return false, err
+		}
+	}
+
+	dep := buildfile.Dependency{GroupID: starter.GroupID, ArtifactID: starter.ArtifactID, Version: starter.Version}
+	var err error
+	switch starter.Scope {
+	case "annotationProcessor", "compileOnly":
+		dep.Optional = true
+		err = addBuildDependency(dep, "compileOnly")
+		if err == nil && starter.Scope == "annotationProcessor" {
+			err = addAnnotationProcessor(dep)
+		}
+	case "", "compile":
+		err = addBuildDependency(dep, gradleConfigurations["compile"])
+	default:
+		dep.Scope = starter.Scope
+		err = addBuildDependency(dep, gradleConfigurations[starter.Scope])
+	}
+	if err != nil {
+		return false, err
+	}
+	utils.PrintSuccess(fmt.Sprintf("%s ajouté à %s (%s)", id, buildFileName(), dep.Coordinates()))
+	return true, nil
+}
+
+// addStarterBOM importe un BOM: dans le dependencyManagement du pom.xml, sa version portée
+// par une propriété (spring-cloud.version), ou avec platform() dans le script Gradle.
+func addStarterBOM(id string, bom initializr.BOM) error {
+	dep := buildfile.Dependency{GroupID: bom.GroupID, ArtifactID: bom.ArtifactID, Version: bom.Version}
+	if gradleFile := gradleBuildFile(); gradleFile != "" {
+		return updateBuildFile(gradleFile, func(content string) (string, error) {
+			return buildfile.AddGradlePlatform(content, "implementation", dep, strings.HasSuffix(gradleFile, ".kts"))
+		})
+	}
+
+	data, err := os.ReadFile(pomPath)
+	if err != nil {
+		return err
+	}
+	property := id + ".version"
+	if _, ok := buildfile.Property(string(data), property); !ok {
+		if err := setBuildProperty(property, bom.Version); err != nil {
+			return err
+		}
+	}
+	dep.Version = "${" + property + "}"
+	return updateBuildFile(pomPath, func(content string) (string, error) {
+		return buildfile.AddManagedImport(content, dep)
+	})
+}
+
+// addBuildRepository déclare un dépôt Maven dans le fichier de build.
+func addBuildRepository(repo buildfile.Repository) error {
+	if gradleFile := gradleBuildFile(); gradleFile != "" {
+		return updateBuildFile(gradleFile, func(content string) (string, error) {
+			return buildfile.AddGradleRepository(content, repo.URL, strings.HasSuffix(gradleFile, ".kts"))
+		})
+	}
+	return updateBuildFile(pomPath, func(content string) (string, error) {
+		return buildfile.AddRepository(content, repo)
+	})
+}
+
+// printStarterProperties affiche les propriétés que les starters ajoutés attendent et
+// qu'application.properties ne définit pas encore.
+func printStarterProperties(ids []string) {
+	existing := ""
+	if data, err := os.ReadFile(applicationPropertiesPath); err == nil {
+		existing = "\n" + string(data)
+	}
+	var lines []string
+	for _, id := range ids {
+		for _, property := range starterProperties[id] {
+			key, _, _ := strings.Cut(property, "=")
+			if !strings.Contains(existing, "\n"+key+"=") {
+				lines = append(lines, property)
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	utils.PrintSubtitle("Propriétés à renseigner (application.properties)")
+	utils.PrintBox(strings.Join(lines, "\n"))
+}
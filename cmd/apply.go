@@ -0,0 +1,619 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"springcli/internal/config"
+	"springcli/internal/migration"
+	"springcli/internal/model"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	applyCmd.Flags().String("style", "", "Style de code: generated, lombok ou record (défaut: options.style, sinon lombok si présent dans le build)")
+	applyCmd.Flags().String("migration", "", "Outil de migration: flyway, liquibase ou none (défaut: options.migration, sinon détecté)")
+	applyCmd.Flags().String("dialect", "", "Base de données des migrations: postgres, mysql, mariadb ou h2 (défaut: options.dialect, sinon détectée)")
+	applyCmd.Flags().String("mapper", "", "Type de mapper: mapstruct ou manual (défaut: options.mapper, sinon mapstruct si présent dans le build)")
+	applyCmd.Flags().Bool("with-tests", false, "Génère aussi les tests des couches créées (défaut: generate.with-tests de "+config.FileName+")")
+	applyCmd.Flags().Bool("dry-run", false, "Affiche le plan sans modifier le projet")
+	applyCmd.Flags().BoolP("yes", "y", false, "Applique le plan sans demander de confirmation")
+	rootCmd.AddCommand(applyCmd)
+}
+
+// ==================== APPLY ====================
+var applyCmd = &cobra.Command{
+	Use:   "apply [model.yaml]",
+	Short: "Génère ou met à jour l'application décrite par un fichier de modèle.",
+	Long: `Cette commande lit un modèle déclaratif décrivant les énumérations et les entités
+de l'application, calcule le plan des modifications à apporter au projet puis l'applique:
+  - énumérations et entités manquantes créées, avec leur migration
+  - champs et relations ajoutés aux entités existantes, avec une migration ALTER
+  - couches manquantes générées (dto, mapper, repository, service, controller), DTO
+    et mapper régénérés lorsque leur entité change
+Relancer la commande après avoir modifié le modèle n'applique que la différence. Les
+propriétés absentes du modèle ne sont jamais supprimées du code.
+
+Exemple de model.yaml:
+  options:
+    migration: flyway
+    layers: [dto, mapper, repository, service, controller]
+  enums:
+    OrderStatus: [PENDING, PAID, SHIPPED]
+  entities:
+    Customer:
+      email: string:required,email,unique
+      name: string:required,max=80
+      orders: OneToMany:Order
+    Order:
+      reference: string:required
+      status: OrderStatus:required
+      customer: ManyToOne:Customer`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("📐 APPLICATION D'UN MODÈLE DE DOMAINE")
+
+		m, err := model.Load(args[0])
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire %s: %v", args[0], err))
+			os.Exit(1)
+		}
+
+		// Les options du modèle servent de valeurs par défaut aux flags
+		for flag, value := range map[string]string{
+			"style":     m.Options.Style,
+			"migration": m.Options.Migration,
+			"dialect":   m.Options.Dialect,
+			"mapper":    m.Options.Mapper,
+		} {
+			if value != "" && !cmd.Flags().Changed(flag) {
+				_ = cmd.Flags().Set(flag, value)
+			}
+		}
+
+		settings := modelSettings{
+			Migration: resolveMigration(cmd),
+			Style:     resolveCodeStyle(cmd),
+			Mapper:    resolveModelMapper(cmd),
+			Layers:    m.Options.Layers,
+			WithTests: withTests(cmd),
+		}
+		if len(settings.Layers) == 0 {
+			settings.Layers = model.Layers
+		}
+
+		steps, err := planModel(m, settings)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+		if len(steps) == 0 {
+			utils.PrintSuccess(fmt.Sprintf("Le projet est à jour avec %s", args[0]))
+			return
+		}
+
+		fmt.Println(formatPlanTable(steps))
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			utils.PrintInfo(fmt.Sprintf("%d action(s) à appliquer, relancez sans --dry-run pour modifier le projet", len(steps)))
+			return
+		}
+		if yes, _ := cmd.Flags().GetBool("yes"); !yes && !AskYesNo() {
+			utils.PrintInfo("Aucune modification effectuée")
+			return
+		}
+
+		for _, s := range steps {
+			utils.PrintSubtitle(fmt.Sprintf("%s: %s", s.Action, s.Element))
+			s.run()
+		}
+		utils.PrintSuccess(fmt.Sprintf("Modèle %s appliqué: %d action(s)", args[0], len(steps)))
+	},
+}
+
+func formatPlanTable(steps []modelStep) string {
+	headers := []string{"Action", "Élément", "Détail"}
+	widths := []int{16, 22, 56}
+
+	var table strings.Builder
+
+	// En-têtes
+	headerRow := ""
+	for i, header := range headers {
+		headerRow += utils.TableHeaderStyle.Width(widths[i]).Render(header)
+	}
+	table.WriteString(headerRow + "\n")
+
+	// Lignes
+	for _, step := range steps {
+		rowStr := ""
+		for i, cell := range []string{step.Action, step.Element, step.Detail} {
+			rowStr += utils.TableCellStyle.Width(widths[i]).Render(cell)
+		}
+		table.WriteString(rowStr + "\n")
+	}
+
+	return utils.BoxStyle.Render(table.String())
+}
+
+// resolveModelMapper détermine le type de mapper, comme generate mapper.
+func resolveModelMapper(cmd *cobra.Command) string {
+	mapperType, _ := cmd.Flags().GetString("mapper")
+	switch mapperType {
+	case "":
+		mapperType = mapperManual
+		if hasBuildDependency("org.mapstruct", "mapstruct") {
+			mapperType = mapperMapStruct
+		}
+	case mapperMapStruct, mapperManual:
+	default:
+		utils.PrintError(fmt.Sprintf("Type de mapper inconnu: %s (valeurs possibles: mapstruct, manual)", mapperType))
+		os.Exit(1)
+	}
+	if mapperType == mapperMapStruct && isKotlin() {
+		utils.PrintWarning("MapStruct n'est pas supporté pour Kotlin: génération de mappers manuels")
+		mapperType = mapperManual
+	}
+	return mapperType
+}
+
+// ==================== PLAN ====================
+
+type modelSettings struct {
+	Migration migrationSettings
+	Style     string
+	Mapper    string
+	Layers    []string
+	WithTests bool
+}
+
+// entityStyle renvoie le style des entités, les records étant réservés aux DTO.
+func (s modelSettings) entityStyle() string {
+	if s.Style != styleRecord {
+		return s.Style
+	}
+	if hasLombok() && !isKotlin() {
+		return styleLombok
+	}
+	return styleGenerated
+}
+
+// modelStep est une action du plan, exécutée par run après confirmation.
+type modelStep struct {
+	Action  string
+	Element string
+	Detail  string
+	run     func()
+}
+
+// modelEntity est une entité du modèle, avec ses champs et relations résolus.
+type modelEntity struct {
+	Name      string
+	Fields    []Field
+	Relations []Relation
+}
+
+// planModel compare le modèle au projet et renvoie les actions nécessaires, dans l'ordre:
+// énumérations, entités (tables référencées d'abord), puis couches de chaque entité.
+func planModel(m *model.Model, s modelSettings) ([]modelStep, error) {
+	entities, err := resolveModelEntities(m)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := planEnums(m)
+	changed := map[string]bool{}
+	for _, e := range entities {
+		step, ok := planEntity(e, s)
+		if ok {
+			steps = append(steps, step)
+			changed[e.Name] = true
+		}
+	}
+
+	mapstructAdded := false
+	for _, e := range entities {
+		missing, regenerated := entityLayers(e.Name, s.Layers, changed[e.Name])
+		needsMapStruct := s.Mapper == mapperMapStruct && !hasBuildDependency("org.mapstruct", "mapstruct") &&
+			(containsString(missing, "mapper") || containsString(regenerated, "mapper"))
+		if needsMapStruct && !mapstructAdded {
+			mapstructAdded = true
+			steps = append(steps, modelStep{Action: "mise à jour", Element: buildFileName(), Detail: "dépendance MapStruct", run: addMapStruct})
+		}
+
+		name := e.Name
+		if len(regenerated) > 0 {
+			steps = append(steps, modelStep{
+				Action:  "régénération",
+				Element: name,
+				Detail:  strings.Join(regenerated, ", ") + " (entité modifiée)",
+				run: func() {
+					for _, layer := range regenerated {
+						for _, path := range layerFiles(name, layer) {
+							_ = os.Remove(path)
+						}
+						generateLayer(name, layer, s)
+					}
+				},
+			})
+		}
+		if len(missing) > 0 {
+			steps = append(steps, modelStep{
+				Action:  "création",
+				Element: name,
+				Detail:  strings.Join(missing, ", "),
+				run: func() {
+					for _, layer := range missing {
+						generateLayer(name, layer, s)
+					}
+				},
+			})
+		}
+	}
+	return steps, nil
+}
+
+// resolveModelEntities convertit les propriétés du modèle en champs et relations, vérifie
+// les types et les cibles, déduit les côtés inverses (mappedBy) et trie les entités.
+func resolveModelEntities(m *model.Model) ([]modelEntity, error) {
+	existing := map[string]bool{}
+	for _, name := range listEntities() {
+		existing[name] = true
+	}
+
+	var entities []modelEntity
+	for _, e := range m.Entities {
+		var args []string
+		for _, p := range e.Properties {
+			parts := strings.Split(p.Definition, ":")
+			if isRelationType(parts[0]) && (len(parts) != 2 || parts[1] == "") {
+				return nil, fmt.Errorf("relation %s.%s: la cible est requise (ex: %s:Customer)", e.Name, p.Name, parts[0])
+			}
+			args = append(args, p.Arg())
+		}
+
+		entity := modelEntity{Name: e.Name, Fields: parseFields(args), Relations: parseRelations(args)}
+		for _, f := range entity.Fields {
+			if _, err := migration.SQLType(migration.Postgres, f.Type, 0); err != nil && m.Enum(f.Type) == nil && !isEnumType(f.Type) {
+				return nil, fmt.Errorf("type inconnu %s pour %s.%s (types possibles: %s ou une énumération du modèle)",
+					f.Type, e.Name, f.Name, strings.Join(allTypes(), ", "))
+			}
+		}
+		for _, r := range entity.Relations {
+			if m.Entity(r.Target) == nil && !existing[r.Target] {
+				return nil, fmt.Errorf("relation %s.%s: l'entité cible %s n'existe ni dans le modèle ni dans le projet", e.Name, r.Name, r.Target)
+			}
+		}
+		entities = append(entities, entity)
+	}
+
+	inferMappedBy(entities)
+	return sortEntities(entities), nil
+}
+
+// inferMappedBy marque comme inverses (mappedBy) les relations déclarées des deux côtés:
+// la collection @OneToMany face au @ManyToOne, et pour @OneToOne et @ManyToMany le côté
+// déclaré en second dans le modèle.
+func inferMappedBy(entities []modelEntity) {
+	index := map[string]int{}
+	for i, e := range entities {
+		index[e.Name] = i
+	}
+	inverseType := map[string]string{"@OneToMany": "@ManyToOne", "@OneToOne": "@OneToOne", "@ManyToMany": "@ManyToMany"}
+
+	for i, e := range entities {
+		for j, r := range e.Relations {
+			k, ok := index[r.Target]
+			if !ok || r.Target == e.Name || inverseType[r.Type] == "" {
+				continue
+			}
+			var back *Relation
+			for _, candidate := range entities[k].Relations {
+				candidate := candidate
+				if candidate.Target == e.Name && candidate.Type == inverseType[r.Type] &&
+					(back == nil || candidate.Name == uncapitalize(e.Name)) {
+					back = &candidate
+				}
+			}
+			if back != nil && (r.Type == "@OneToMany" || k < i) {
+				entities[i].Relations[j].MappedBy = back.Name
+			}
+		}
+	}
+}
+
+// sortEntities place chaque entité après celles dont elle référence la table (clé
+// étrangère ou table de jointure), afin que les migrations s'exécutent dans l'ordre.
+func sortEntities(entities []modelEntity) []modelEntity {
+	index := map[string]int{}
+	for i, e := range entities {
+		index[e.Name] = i
+	}
+	state := make([]int, len(entities))
+	var sorted []modelEntity
+	var visit func(i int)
+	visit = func(i int) {
+		if state[i] != 0 {
+			// Entité déjà placée, ou cycle de références
+			return
+		}
+		state[i] = 1
+		for _, r := range entities[i].Relations {
+			if k, ok := index[r.Target]; ok && r.MappedBy == "" {
+				visit(k)
+			}
+		}
+		state[i] = 2
+		sorted = append(sorted, entities[i])
+	}
+	for i := range entities {
+		visit(i)
+	}
+	return sorted
+}
+
+// planEnums renvoie la création des énumérations manquantes et la réécriture de celles
+// dont les valeurs ont changé.
+func planEnums(m *model.Model) []modelStep {
+	types, _ := scanSourceTypes(getSourcePath() + "/entity")
+	var steps []modelStep
+	for _, e := range m.Enums {
+		e := e
+		existing, ok := types[e.Name]
+		if !ok {
+			steps = append(steps, modelStep{
+				Action:  "création",
+				Element: e.Name,
+				Detail:  "énumération: " + strings.Join(e.Values, ", "),
+				run:     func() { generateEnum("entity", e.Name, e.Values) },
+			})
+			continue
+		}
+
+		current := existing.enumConstants()
+		var changes []string
+		for _, v := range e.Values {
+			if !containsString(current, v) {
+				changes = append(changes, "+"+v)
+			}
+		}
+		for _, v := range current {
+			if !containsString(e.Values, v) {
+				changes = append(changes, "-"+v)
+			}
+		}
+		if len(changes) == 0 && strings.Join(current, ",") == strings.Join(e.Values, ",") {
+			continue
+		}
+		if len(changes) == 0 {
+			changes = []string{"ordre des valeurs"}
+		}
+		steps = append(steps, modelStep{
+			Action:  "mise à jour",
+			Element: e.Name,
+			Detail:  "énumération: " + strings.Join(changes, ", "),
+			run: func() {
+				generateFile(getSourcePath()+"/entity", sourceFile(e.Name), renderEnum("entity", e.Name, e.Values))
+			},
+		})
+	}
+	return steps
+}
+
+// planEntity renvoie la création de l'entité, ou sa mise à jour si des champs ou des
+// relations ont été ajoutés ou modifiés. Les propriétés absentes du modèle sont signalées.
+func planEntity(e modelEntity, s modelSettings) (modelStep, bool) {
+	_, existingFields, existingRelations, err := readEntity(e.Name)
+	if err != nil {
+		detail := fmt.Sprintf("entité: %d champ(s), %d relation(s)", len(e.Fields), len(e.Relations))
+		if s.Migration.Tool != migrationNone {
+			detail += ", migration " + s.Migration.Tool
+		}
+		return modelStep{Action: "création", Element: e.Name, Detail: detail, run: func() {
+			generateEntity(e.Name, e.Fields, e.Relations, s.entityStyle(), defaultEntityMapping(e.Name))
+			generateCreateMigration(s.Migration, e.Name, e.Fields, e.Relations)
+		}}, true
+	}
+
+	var changes, modified []string
+	for i, f := range e.Fields {
+		current, ok := findField(existingFields, f.Name)
+		if !ok {
+			changes = append(changes, "+"+f.Name)
+			continue
+		}
+		// Le nom de colonne explicite (entité issue d'un schéma existant) est conservé
+		if f.Column == "" {
+			f.Column = current.Column
+			e.Fields[i] = f
+		}
+		if !sameField(current, f) {
+			changes = append(changes, "~"+f.Name)
+			modified = append(modified, f.Name)
+		}
+	}
+	for i, r := range e.Relations {
+		current, ok := findRelation(existingRelations, r.Name)
+		if !ok {
+			changes = append(changes, "+"+r.Name)
+			continue
+		}
+		if current.Type == r.Type && current.Target == r.Target {
+			if r.JoinColumn == "" && r.JoinTable == "" {
+				r.JoinColumn, r.JoinTable, r.InverseJoinColumn = current.JoinColumn, current.JoinTable, current.InverseJoinColumn
+			}
+			if r.MappedBy == "" {
+				r.MappedBy = current.MappedBy
+			}
+			e.Relations[i] = r
+		}
+		if current.Type != r.Type || current.Target != r.Target || current.MappedBy != r.MappedBy {
+			changes = append(changes, "~"+r.Name)
+			modified = append(modified, r.Name)
+		}
+	}
+	for _, f := range existingFields {
+		if _, ok := findField(e.Fields, f.Name); !ok {
+			utils.PrintWarning(fmt.Sprintf("%s.%s est absent du modèle: conservé dans le code", e.Name, f.Name))
+		}
+	}
+	for _, r := range existingRelations {
+		if _, ok := findRelation(e.Relations, r.Name); !ok {
+			utils.PrintWarning(fmt.Sprintf("%s.%s est absent du modèle: conservé dans le code", e.Name, r.Name))
+		}
+	}
+	if len(changes) == 0 {
+		return modelStep{}, false
+	}
+
+	return modelStep{Action: "mise à jour", Element: e.Name, Detail: "entité: " + strings.Join(changes, ", "), run: func() {
+		updateEntity(e.Name, e.Fields, e.Relations, "")
+		generateAlterMigration(s.Migration, e.Name, addedFields(existingFields, e.Fields), addedRelations(existingRelations, e.Relations))
+		if len(modified) > 0 && s.Migration.Tool != migrationNone {
+			utils.PrintInfo(fmt.Sprintf("%s modifié(s) dans %s: lancez 'springcli db diff --write' pour migrer les colonnes existantes",
+				strings.Join(modified, ", "), e.Name))
+		}
+	}}, true
+}
+
+func findField(fields []Field, name string) (Field, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+func findRelation(relations []Relation, name string) (Relation, bool) {
+	for _, r := range relations {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Relation{}, false
+}
+
+// sameField compare le type et les annotations générées de deux champs, ce qui ignore
+// les contraintes implicites (@Email d'un champ email) et l'ordre des contraintes.
+func sameField(a, b Field) bool {
+	if isKotlin() {
+		if kotlinType(a.Type) != kotlinType(b.Type) {
+			return false
+		}
+	} else if a.Type != b.Type {
+		return false
+	}
+	x, y := entityFieldAnnotations(a), entityFieldAnnotations(b)
+	sort.Strings(x)
+	sort.Strings(y)
+	return strings.Join(x, "\n") == strings.Join(y, "\n")
+}
+
+// ==================== COUCHES ====================
+
+// entityLayers renvoie les couches à créer et, si l'entité change, celles à régénérer
+// parce qu'elles reprennent ses champs (DTO et mapper).
+func entityLayers(entityName string, layers []string, changed bool) ([]string, []string) {
+	var missing, regenerated []string
+	for _, layer := range model.Layers {
+		if !containsString(layers, layer) {
+			continue
+		}
+		exists := false
+		for _, path := range layerFiles(entityName, layer) {
+			exists = exists || utils.Exists(path)
+		}
+		switch {
+		case !exists:
+			missing = append(missing, layer)
+		case changed && (layer == "dto" || layer == "mapper"):
+			regenerated = append(regenerated, layer)
+		}
+	}
+	return missing, regenerated
+}
+
+// layerFiles renvoie les fichiers générés pour une couche d'une entité.
+func layerFiles(entityName, layer string) []string {
+	base := getSourcePath()
+	switch layer {
+	case "dto":
+		var files []string
+		for _, kind := range []string{dtoCreate, dtoUpdate, dtoResponse} {
+			files = append(files, base+"/dto/"+sourceFile(dtoClassName(entityName, kind)))
+		}
+		return files
+	case "mapper":
+		return []string{base + "/mapper/" + sourceFile(entityName+"Mapper")}
+	case "repository":
+		return []string{base + "/repository/" + sourceFile(entityName+"Repository")}
+	case "service":
+		return []string{base + "/service/" + sourceFile(entityName+"Service")}
+	case "controller":
+		return []string{base + "/controller/" + sourceFile(entityName+"Controller")}
+	}
+	return nil
+}
+
+// generateLayer génère une couche d'une entité à partir de son code source actuel.
+func generateLayer(entityName, layer string, s modelSettings) {
+	switch layer {
+	case "dto", "mapper":
+		_, fields, relations, err := readEntity(entityName)
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire l'entité %s: %v", entityName, err))
+			os.Exit(1)
+		}
+		if layer == "dto" {
+			dtoFields := applyJSONNaming(dtoFieldsFromEntity(entityName, fields, relations), "camel")
+			for _, kind := range []string{dtoCreate, dtoUpdate, dtoResponse} {
+				generateDto(entityName, kind, dtoFields, s.Style)
+			}
+			return
+		}
+		dtos := readEntityDtos(entityName)
+		if len(dtos) == 0 {
+			utils.PrintWarning(fmt.Sprintf("Aucun DTO pour %s: mapper non généré", entityName))
+			return
+		}
+		generateMapper(entityName, s.Mapper, fields, relations, dtos)
+	case "repository":
+		generateRepository(entityName)
+		if s.WithTests {
+			generateRepositoryTest(entityName)
+		}
+	case "service":
+		generateService(entityName)
+		if s.WithTests {
+			generateServiceTest(entityName)
+		}
+	case "controller":
+		generateController(entityName)
+		if s.WithTests {
+			generateControllerTest(entityName)
+		}
+	}
+}
+
+// buildFileName renvoie le nom du fichier de build du projet.
+func buildFileName() string {
+	if gradle := gradleBuildFile(); gradle != "" {
+		return gradle
+	}
+	return "pom.xml"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"springcli/internal/buildfile"
+	"springcli/internal/config"
+	"springcli/internal/maven"
+	"springcli/internal/utils"
+)
+
+// ===================== CONFIGURATION DU PROJET =======================
+
+// projectConfig lit la configuration du projet, qui fixe les valeurs par défaut des flags.
+func projectConfig() *config.Config {
+	c, err := config.Load(config.FileName)
+	if err != nil {
+		utils.PrintError(fmt.Sprintf("Impossible de lire %s: %v", config.FileName, err))
+		os.Exit(1)
+	}
+	return c
+}
+
+// ===================== FICHIER DE BUILD =======================
+const pomPath = "./pom.xml"
+
+// gradleBuildFile renvoie le script Gradle du projet, ou une chaîne vide pour un projet Maven.
+func gradleBuildFile() string {
+	if utils.Exists(pomPath) {
+		return ""
+	}
+	for _, f := range []string{"build.gradle.kts", "build.gradle"} {
+		if utils.Exists(f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// hasBuildDependency indique si la dépendance est déclarée dans le pom.xml ou le script Gradle.
+func hasBuildDependency(groupID, artifactID string) bool {
+	if gradleFile := gradleBuildFile(); gradleFile != "" {
+		data, err := os.ReadFile(gradleFile)
+		return err == nil && buildfile.HasGradleDependency(string(data), groupID, artifactID)
+	}
+	data, err := os.ReadFile(pomPath)
+	if err != nil {
+		return false
+	}
+	return buildfile.HasDependency(string(data), groupID, artifactID)
+}
+
+// addBuildDependency ajoute une dépendance au fichier de build du projet. Pour Gradle,
+// gradleConfiguration désigne la configuration cible (implementation, compileOnly...).
+func addBuildDependency(dep buildfile.Dependency, gradleConfiguration string) error {
+	if gradleFile := gradleBuildFile(); gradleFile != "" {
+		return updateBuildFile(gradleFile, func(content string) (string, error) {
+			return buildfile.AddGradleDependency(content, gradleConfiguration, dep, strings.HasSuffix(gradleFile, ".kts"))
+		})
+	}
+	return updateBuildFile(pomPath, func(content string) (string, error) {
+		return buildfile.AddDependency(content, dep)
+	})
+}
+
+// gradleConfigurations associe chaque scope Maven à sa configuration Gradle.
+var gradleConfigurations = map[string]string{
+	"compile":  "implementation",
+	"runtime":  "runtimeOnly",
+	"test":     "testImplementation",
+	"provided": "compileOnly",
+}
+
+// updateBuildFile applique une transformation au fichier de build et l'enregistre.
+func updateBuildFile(path string, update func(string) (string, error)) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	updated, err := update(string(data))
+	if err != nil {
+		return err
+	}
+	if updated == string(data) {
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("impossible d'écrire %s: %w", path, err)
+	}
+	return nil
+}
+
+// addAnnotationProcessor déclare un processeur d'annotations (Lombok, MapStruct...) dans le
+// maven-compiler-plugin ou dans la configuration annotationProcessor de Gradle.
+func addAnnotationProcessor(dep buildfile.Dependency) error {
+	if gradleFile := gradleBuildFile(); gradleFile != "" {
+		err := updateBuildFile(gradleFile, func(content string) (string, error) {
+			return buildfile.AddGradleDependency(content, "annotationProcessor", dep, strings.HasSuffix(gradleFile, ".kts"))
+		})
+		if errors.Is(err, buildfile.ErrDuplicate) {
+			return nil
+		}
+		return err
+	}
+	return updateBuildFile(pomPath, func(content string) (string, error) {
+		return buildfile.AddAnnotationProcessor(content, dep)
+	})
+}
+
+// setBuildProperty définit une propriété Maven (ignoré pour Gradle).
+func setBuildProperty(name, value string) error {
+	if gradleBuildFile() != "" {
+		return nil
+	}
+	return updateBuildFile(pomPath, func(content string) (string, error) {
+		return buildfile.SetProperty(content, name, value)
+	})
+}
+
+var (
+	pomParentRegexp  = regexp.MustCompile(`(?s)<parent>.*?</parent>`)
+	pomVersionRegexp = regexp.MustCompile(`<version>\s*([^<\s]+)\s*</version>`)
+	bootPluginRegexp = regexp.MustCompile(`id\s*\(?\s*["']org\.springframework\.boot["']\s*\)?\s*version\s*["']([^"']+)["']`)
+)
+
+// springBootVersion renvoie la version de Spring Boot du projet, lue dans le parent du
+// pom.xml ou le plugin Gradle, vide si elle est introuvable.
+func springBootVersion() string {
+	if gradleFile := gradleBuildFile(); gradleFile != "" {
+		data, err := os.ReadFile(gradleFile)
+		if err != nil {
+			return ""
+		}
+		if m := bootPluginRegexp.FindStringSubmatch(string(data)); m != nil {
+			return m[1]
+		}
+		return ""
+	}
+	data, err := os.ReadFile(pomPath)
+	if err != nil {
+		return ""
+	}
+	parent := pomParentRegexp.FindString(string(data))
+	if !strings.Contains(parent, "<artifactId>spring-boot-starter-parent</artifactId>") {
+		return ""
+	}
+	if m := pomVersionRegexp.FindStringSubmatch(parent); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// isBootAtLeast indique si le projet utilise au moins la version de Spring Boot donnée,
+// version supposée atteinte lorsque celle du projet est introuvable.
+func isBootAtLeast(version string) bool {
+	current := springBootVersion()
+	return current == "" || maven.CompareVersions(current, version) >= 0
+}
+
+// isBoot4 indique si le projet utilise Spring Boot 4 ou plus, version supposée par défaut.
+func isBoot4() bool {
+	major, _, _ := strings.Cut(springBootVersion(), ".")
+	n, err := strconv.Atoi(major)
+	return err != nil || n >= 4
+}
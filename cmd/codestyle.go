@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"springcli/internal/buildfile"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ===================== STYLE DE CODE =========================
+const (
+	styleGenerated = "generated" // accesseurs, constructeurs, equals/hashCode et toString écrits explicitement
+	styleLombok    = "lombok"    // annotations Lombok
+	styleRecord    = "record"    // records Java (réservé aux DTO)
+)
+
+func allStyles() []string {
+	return []string{styleGenerated, styleLombok, styleRecord}
+}
+
+// resolveCodeStyle détermine le style de code à partir du flag --style.
+// Sans flag, Lombok est utilisé s'il est déjà présent dans le fichier de build.
+func resolveCodeStyle(cmd *cobra.Command) string {
+	style, _ := cmd.Flags().GetString("style")
+	style = strings.ToLower(strings.TrimSpace(style))
+
+	// Les classes Kotlin n'ont besoin ni de Lombok ni d'accesseurs explicites
+	if isKotlin() {
+		if style == styleLombok {
+			utils.PrintWarning("Le style Lombok est ignoré pour un projet Kotlin")
+		}
+		if style == styleRecord {
+			return styleRecord
+		}
+		return styleGenerated
+	}
+
+	switch style {
+	case "":
+		if hasLombok() {
+			return styleLombok
+		}
+		return styleGenerated
+	case styleGenerated, styleRecord:
+		return style
+	case styleLombok:
+		if hasLombok() {
+			return styleLombok
+		}
+		utils.PrintWarning(fmt.Sprintf("Lombok n'est pas déclaré dans %s. Voulez-vous l'ajouter ?", buildFileName()))
+		if AskYesNo() && addLombok() {
+			return styleLombok
+		}
+		utils.PrintInfo("Utilisation du style 'generated' à la place de Lombok")
+		return styleGenerated
+	default:
+		utils.PrintError(fmt.Sprintf("Style inconnu: %s (valeurs possibles: %s)", style, strings.Join(allStyles(), ", ")))
+		os.Exit(1)
+		return ""
+	}
+}
+
+// entityStyle renvoie le style applicable à une entité JPA, les records n'étant pas supportés par JPA.
+func entityStyle(cmd *cobra.Command) string {
+	style := resolveCodeStyle(cmd)
+	if style == styleRecord {
+		utils.PrintError("Une entité JPA ne peut pas être un record Java: utilisez --style record pour les DTO")
+		os.Exit(1)
+	}
+	return style
+}
+
+// detectEntityStyle retrouve le style d'une entité existante à partir de son code source.
+func detectEntityStyle(javaContent string) string {
+	if strings.Contains(javaContent, "@Getter") || strings.Contains(javaContent, "import lombok.") {
+		return styleLombok
+	}
+	return styleGenerated
+}
+
+func hasLombok() bool {
+	return hasBuildDependency("org.projectlombok", "lombok")
+}
+
+func addLombok() bool {
+	err := addBuildDependency(buildfile.Dependency{
+		GroupID:    "org.projectlombok",
+		ArtifactID: "lombok",
+		Optional:   true,
+	}, "compileOnly")
+	// Maven détecte Lombok via le classpath, Gradle doit le déclarer explicitement
+	if err == nil && gradleBuildFile() != "" {
+		err = addAnnotationProcessor(buildfile.Dependency{GroupID: "org.projectlombok", ArtifactID: "lombok"})
+	}
+	if err != nil {
+		utils.PrintError(fmt.Sprintf("Impossible d'ajouter Lombok à %s: %v", buildFileName(), err))
+		return false
+	}
+	utils.PrintSuccess(fmt.Sprintf("Lombok ajouté à %s", buildFileName()))
+	return true
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"springcli/internal/migration"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	dbDiffCmd.Flags().String("migration", "", "Outil de migration: flyway ou liquibase (défaut: détecté dans le fichier de build)")
+	dbDiffCmd.Flags().String("dialect", "", "Base de données des migrations: postgres, mysql, mariadb ou h2 (défaut: détectée)")
+	dbDiffCmd.Flags().Bool("write", false, "Écrit une nouvelle migration corrigeant les écarts")
+	dbCmd.AddCommand(dbDiffCmd)
+	rootCmd.AddCommand(dbCmd)
+}
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Outils pour le schéma de base de données",
+	Long:  `Cette commande regroupe les outils de vérification du schéma de base de données.`,
+}
+
+// ==================== DB DIFF ====================
+var dbDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare les entités JPA à l'historique des migrations.",
+	Long: `Cette commande lit toutes les entités du package entity, rejoue les migrations
+Flyway (scripts SQL) ou le changelog Liquibase du projet pour reconstituer le schéma,
+puis signale les écarts: tables et colonnes manquantes, types ou nullabilité différents,
+clés étrangères et index manquants. Avec --write, une migration corrigeant ces écarts
+est générée.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("🔍 COMPARAISON DES ENTITÉS ET DES MIGRATIONS")
+
+		settings := resolveMigration(cmd)
+		if settings.Tool == migrationNone {
+			utils.PrintError("Aucun outil de migration détecté (flyway-core ou liquibase-core), précisez --migration")
+			os.Exit(1)
+		}
+
+		expected, defaults := expectedTables(settings.Dialect)
+		if len(expected) == 0 {
+			utils.PrintError("Aucune entité trouvée dans " + getSourcePath() + "/entity")
+			os.Exit(1)
+		}
+
+		schema, err := replayMigrations(settings.Tool)
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de rejouer les migrations: %v", err))
+			os.Exit(1)
+		}
+
+		diffs := migration.Diff(settings.Dialect, expected, schema)
+		if len(diffs) == 0 {
+			utils.PrintSuccess(fmt.Sprintf("Les migrations %s sont à jour avec les %d table(s) des entités", settings.Tool, len(expected)))
+			return
+		}
+
+		utils.PrintWarning(fmt.Sprintf("%d écart(s) entre les entités et les migrations:", len(diffs)))
+		fmt.Println(formatDiffTable(diffs))
+
+		if write, _ := cmd.Flags().GetBool("write"); !write {
+			utils.PrintInfo("Relancez avec --write pour générer la migration correspondante")
+			return
+		}
+
+		changes := migration.Changes(diffs)
+		for i, change := range changes {
+			// Valeur par défaut des colonnes primitives ajoutées à une table existante
+			if c, ok := change.(migration.AddColumn); ok && c.Column.Default == "" {
+				c.Column.Default = defaults[c.Table+"."+c.Column.Name]
+				changes[i] = c
+			}
+		}
+		writeMigration(settings, "sync_schema", changes)
+	},
+}
+
+// expectedTables construit les tables attendues par les entités du projet, ainsi que les
+// valeurs par défaut des colonnes primitives (table.colonne -> valeur).
+func expectedTables(d migration.Dialect) ([]migration.Table, map[string]string) {
+	var tables []migration.Table
+	defaults := map[string]string{}
+	for _, entity := range listEntities() {
+		_, fields, relations, err := readEntity(entity)
+		if err != nil {
+			utils.PrintWarning(fmt.Sprintf("Entité %s ignorée: %v", entity, err))
+			continue
+		}
+		owned, err := entityTables(d, entity, fields, relations)
+		if err != nil {
+			utils.PrintWarning(fmt.Sprintf("Entité %s ignorée: %v", entity, err))
+			continue
+		}
+		tables = append(tables, owned...)
+		for _, f := range fields {
+			if value := primitiveDefault(f); value != "" {
+				defaults[tableName(entity)+"."+columnName(f.Name)] = value
+			}
+		}
+	}
+	return tables, defaults
+}
+
+// replayMigrations reconstitue le schéma produit par les migrations du projet.
+func replayMigrations(tool string) (*migration.Schema, error) {
+	schema := migration.NewSchema()
+	if tool == migrationLiquibase {
+		if !utils.Exists(liquibaseMaster) {
+			return schema, nil
+		}
+		return schema, schema.ReplayLiquibase(filepath.Dir(filepath.Dir(liquibasePath)), liquibaseMaster)
+	}
+
+	scripts, err := flywayScripts()
+	if err != nil {
+		return nil, err
+	}
+	for _, script := range scripts {
+		data, err := os.ReadFile(filepath.Join(flywayPath, script))
+		if err != nil {
+			return nil, err
+		}
+		schema.ReplaySQL(string(data))
+	}
+	return schema, nil
+}
+
+var flywayScriptRegexp = regexp.MustCompile(`^(V|R)(.*?)__.*\.sql$`)
+
+// flywayScripts renvoie les scripts Flyway dans leur ordre d'exécution: les migrations
+// versionnées par version croissante (1.10 après 1.9), puis les migrations répétables.
+func flywayScripts() ([]string, error) {
+	entries, err := os.ReadDir(flywayPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var versioned, repeatable []string
+	for _, e := range entries {
+		m := flywayScriptRegexp.FindStringSubmatch(e.Name())
+		switch {
+		case m == nil:
+		case m[1] == "V":
+			versioned = append(versioned, e.Name())
+		default:
+			repeatable = append(repeatable, e.Name())
+		}
+	}
+	sort.Slice(versioned, func(i, j int) bool {
+		a := strings.FieldsFunc(flywayScriptRegexp.FindStringSubmatch(versioned[i])[2], isVersionSeparator)
+		b := strings.FieldsFunc(flywayScriptRegexp.FindStringSubmatch(versioned[j])[2], isVersionSeparator)
+		for k := 0; k < len(a) && k < len(b); k++ {
+			x, _ := strconv.ParseInt(a[k], 10, 64)
+			y, _ := strconv.ParseInt(b[k], 10, 64)
+			if x != y {
+				return x < y
+			}
+		}
+		return len(a) < len(b)
+	})
+	sort.Strings(repeatable)
+	return append(versioned, repeatable...), nil
+}
+
+func isVersionSeparator(r rune) bool {
+	return r == '.' || r == '_'
+}
+
+var diffTableWidths = []int{20, 30, 60}
+
+func formatDiffTable(diffs []migration.Difference) string {
+	rows := make([][]string, 0, len(diffs))
+	for _, diff := range diffs {
+		rows = append(rows, []string{diff.Table, diff.Element, diff.Description})
+	}
+	return formatQueryTable([]string{"Table", "Élément", "Écart"}, rows, diffTableWidths...)
+}
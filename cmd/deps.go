@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"springcli/internal/buildfile"
+	"springcli/internal/maven"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	depsUpgradeCmd.Flags().Bool("minor-only", false, "Reste sur la version majeure actuelle de chaque artefact")
+	depsUpgradeCmd.Flags().BoolP("yes", "y", false, "Applique les mises à jour sans demander de confirmation")
+	depsCmd.AddCommand(depsOutdatedCmd)
+	depsCmd.AddCommand(depsUpgradeCmd)
+	rootCmd.AddCommand(depsCmd)
+}
+
+var depsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Suivi des versions des dépendances",
+	Long:  `Cette commande regroupe les outils de mise à jour des dépendances du fichier de build.`,
+}
+
+// ==================== DEPS OUTDATED ====================
+var depsOutdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "Liste les dépendances et plugins qui ont une version plus récente.",
+	Long: `Cette commande compare la version du parent Spring Boot, des dépendances, des BOMs
+importés et des plugins du pom.xml (ou des dépendances versionnées du script Gradle) à la
+dernière version stable publiée dans le dépôt Maven configuré.
+
+Pour chaque artefact sont indiquées la dernière version de même majeure (mise à jour
+mineure) et la dernière version tout court. Les dépendances dont le BOM de Spring Boot
+fixe la version ne sont pas mises à jour individuellement.`,
+	Example: `  springcli deps outdated`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("📋 DÉPENDANCES OBSOLÈTES")
+		_, _, updates := analyzeVersions()
+		if len(updates) == 0 {
+			utils.PrintInfo(fmt.Sprintf("Aucune version fixée dans %s", buildFileName()))
+			return
+		}
+		fmt.Println(formatUpdateTable(updates))
+
+		outdated := 0
+		for _, u := range updates {
+			if u.target(false) != "" {
+				outdated++
+			}
+		}
+		if outdated == 0 {
+			utils.PrintSuccess("Toutes les versions sont à jour")
+			return
+		}
+		utils.PrintInfo(fmt.Sprintf("%d artefact(s) à mettre à jour: springcli deps upgrade [--minor-only]", outdated))
+	},
+}
+
+// ==================== DEPS UPGRADE ====================
+var depsUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Met à jour les versions du fichier de build.",
+	Long: `Cette commande remplace, dans le fichier de build, les versions signalées par
+'springcli deps outdated' par la dernière version stable (ou, avec --minor-only, par la
+dernière version de même majeure). Une version portée par une propriété est mise à jour
+dans la propriété. Les modifications sont affichées avant d'être appliquées.
+
+Le passage à une nouvelle version majeure de Spring Boot n'est pas appliqué, seule la
+dernière version de même majeure l'est: la migration du code se fait avec
+'springcli upgrade boot'.`,
+	Example: `  springcli deps upgrade
+  springcli deps upgrade --minor-only --yes`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		minorOnly, _ := cmd.Flags().GetBool("minor-only")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		utils.PrintTitle("⬆️  MISE À JOUR DES DÉPENDANCES")
+		path, content, updates := analyzeVersions()
+
+		var artifacts []buildfile.VersionedArtifact
+		versions := map[int]string{}
+		for _, u := range updates {
+			target := u.target(minorOnly)
+			if u.isBoot() && target != "" && maven.Major(target) != maven.Major(u.Version) {
+				utils.PrintWarning(fmt.Sprintf("Spring Boot %s disponible: migration majeure non appliquée (springcli upgrade boot --to %s)", target, target))
+				target = u.Minor
+			}
+			if target == "" {
+				continue
+			}
+			if _, ok := versions[u.Start]; ok {
+				continue
+			}
+			artifacts = append(artifacts, u.VersionedArtifact)
+			versions[u.Start] = target
+		}
+		if len(versions) == 0 {
+			utils.PrintSuccess("Toutes les versions sont à jour")
+			return
+		}
+
+		updated := buildfile.ReplaceVersions(content, artifacts, versions)
+		printLineDiff(path, content, updated)
+		if !yes && !AskYesNo() {
+			utils.PrintInfo("Mise à jour annulée")
+			return
+		}
+		err := updateBuildFile(path, func(string) (string, error) {
+			return updated, nil
+		})
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+		utils.PrintSuccess(fmt.Sprintf("%d version(s) mise(s) à jour dans %s", len(versions), path))
+	},
+}
+
+// versionUpdate est un artefact versionné du fichier de build et ses versions plus récentes.
+type versionUpdate struct {
+	buildfile.VersionedArtifact
+	Minor  string
+	Latest string
+	// Status explique pourquoi l'artefact n'est pas comparé (version gérée par le BOM...)
+	Status string
+}
+
+// target renvoie la version vers laquelle mettre à jour l'artefact, vide s'il est à jour.
+func (u versionUpdate) target(minorOnly bool) string {
+	if minorOnly {
+		return u.Minor
+	}
+	return u.Latest
+}
+
+// isBoot indique si l'artefact porte la version de Spring Boot du projet.
+func (u versionUpdate) isBoot() bool {
+	return u.Kind == buildfile.KindParent && u.ArtifactID == "spring-boot-starter-parent" ||
+		u.ArtifactID == "spring-boot-gradle-plugin" ||
+		u.Kind == buildfile.KindBOM && u.ArtifactID == "spring-boot-dependencies"
+}
+
+// analyzeVersions lit les versions fixées dans le fichier de build et cherche, pour chacune,
+// les versions plus récentes publiées dans le dépôt Maven.
+func analyzeVersions() (string, string, []versionUpdate) {
+	path := pomPath
+	if gradleFile := gradleBuildFile(); gradleFile != "" {
+		path = gradleFile
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		utils.PrintError(fmt.Sprintf("Impossible de lire %s: %v", path, err))
+		os.Exit(1)
+	}
+	content := string(data)
+
+	var artifacts []buildfile.VersionedArtifact
+	if path == pomPath {
+		artifacts, err = buildfile.VersionedArtifacts(content)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+	} else {
+		artifacts = buildfile.GradleVersionedArtifacts(content)
+	}
+
+	repository := mavenRepository()
+	var bom *maven.BOM
+	if boot := springBootVersion(); boot != "" {
+		bom, err = repository.BOM("org.springframework.boot", "spring-boot-dependencies", boot)
+		if err != nil {
+			utils.PrintWarning(fmt.Sprintf("BOM de Spring Boot %s illisible: %v", boot, err))
+		}
+	}
+
+	updates := make([]versionUpdate, len(artifacts))
+	var wg sync.WaitGroup
+	for i, a := range artifacts {
+		updates[i] = versionUpdate{VersionedArtifact: a}
+		if a.Kind == buildfile.KindDependency && bom != nil && bom.Manages(a.GroupID, a.ArtifactID) {
+			updates[i].Status = "version fixée par Spring Boot"
+			continue
+		}
+		wg.Add(1)
+		go func(u *versionUpdate) {
+			defer wg.Done()
+			metadata, err := repository.Metadata(u.GroupID, u.ArtifactID)
+			if errors.Is(err, maven.ErrNotFound) {
+				u.Status = "introuvable dans le dépôt"
+				return
+			}
+			if err != nil {
+				u.Status = "dépôt injoignable"
+				return
+			}
+			if v := metadata.LatestStable(u.Version); v != "" && maven.CompareVersions(v, u.Version) > 0 {
+				u.Minor = v
+			}
+			if v := metadata.LatestStable(""); v != "" && maven.CompareVersions(v, u.Version) > 0 {
+				u.Latest = v
+			}
+		}(&updates[i])
+	}
+	wg.Wait()
+	return path, content, updates
+}
+
+func formatUpdateTable(updates []versionUpdate) string {
+	headers := []string{"Type", "Artefact", "Actuelle", "Mineure", "Dernière", "Remarque"}
+	widths := []int{12, 54, 14, 14, 14, 30}
+
+	var table strings.Builder
+
+	// En-têtes
+	headerRow := ""
+	for i, header := range headers {
+		headerRow += utils.TableHeaderStyle.Width(widths[i]).Render(header)
+	}
+	table.WriteString(headerRow + "\n")
+
+	// Lignes
+	for _, u := range updates {
+		minor, latest := u.Minor, u.Latest
+		if u.Status == "" {
+			if minor == "" {
+				minor = "à jour"
+			}
+			if latest == "" {
+				latest = "à jour"
+			}
+		}
+		remark := u.Status
+		if u.Property != "" && remark == "" {
+			remark = "propriété " + u.Property
+		}
+		rowStr := ""
+		for i, cell := range []string{u.Kind, u.Coordinates(), u.Version, minor, latest, remark} {
+			rowStr += utils.TableCellStyle.Width(widths[i]).Render(truncate(cell, widths[i]-2))
+		}
+		table.WriteString(rowStr + "\n")
+	}
+
+	return utils.BoxStyle.Render(table.String())
+}
+
+// printLineDiff affiche les lignes modifiées d'un fichier, avant et après.
+func printLineDiff(path, before, after string) {
+	oldLines := strings.Split(before, "\n")
+	newLines := strings.Split(after, "\n")
+	utils.PrintSubtitle("Modifications de " + path)
+	for i := range oldLines {
+		if i >= len(newLines) || oldLines[i] == newLines[i] {
+			continue
+		}
+		fmt.Println(utils.TableRowStyle.Render(path + ":" + strconv.Itoa(i+1)))
+		fmt.Println(utils.ErrorStyle.Render("- " + strings.TrimSpace(oldLines[i])))
+		fmt.Println(utils.SuccessStyle.Render("+ " + strings.TrimSpace(newLines[i])))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"springcli/internal/config"
+)
+
+const depsPom = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>3.4.1</version>
+  </parent>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework</groupId>
+      <artifactId>spring-core</artifactId>
+      <version>6.2.0</version>
+    </dependency>
+    <dependency>
+      <groupId>org.springframework.cloud</groupId>
+      <artifactId>spring-cloud-starter-config</artifactId>
+      <version>4.1.0</version>
+    </dependency>
+  </dependencies>
+</project>`
+
+const depsBootBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <properties>
+    <spring-framework.version>6.2.1</spring-framework.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.springframework</groupId>
+        <artifactId>spring-framework-bom</artifactId>
+        <version>${spring-framework.version}</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`
+
+const depsFrameworkBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.springframework</groupId>
+        <artifactId>spring-core</artifactId>
+        <version>6.2.1</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`
+
+const depsCloudMetadata = `<?xml version="1.0" encoding="UTF-8"?>
+<metadata>
+  <versioning>
+    <versions>
+      <version>4.1.0</version>
+      <version>4.1.4</version>
+      <version>4.2.0</version>
+    </versions>
+  </versioning>
+</metadata>`
+
+// TestAnalyzeVersions vérifie qu'une dépendance dont le groupe commence comme celui d'un
+// BOM importé par Spring Boot (org.springframework.cloud sous spring-framework-bom) reste
+// comparée au dépôt: seules les entrées réelles des BOMs fixent la version.
+func TestAnalyzeVersions(t *testing.T) {
+	files := map[string]string{
+		"/org/springframework/boot/spring-boot-dependencies/3.4.1/spring-boot-dependencies-3.4.1.pom": depsBootBOM,
+		"/org/springframework/spring-framework-bom/6.2.1/spring-framework-bom-6.2.1.pom":              depsFrameworkBOM,
+		"/org/springframework/cloud/spring-cloud-starter-config/maven-metadata.xml":                   depsCloudMetadata,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	settings := "maven:\n  repository: " + server.URL + "\n"
+	if err := os.WriteFile(filepath.Join(dir, config.FileName), []byte(settings), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(depsPom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, dir)
+
+	_, _, updates := analyzeVersions()
+	got := map[string]versionUpdate{}
+	for _, u := range updates {
+		got[u.ArtifactID] = u
+	}
+
+	if u := got["spring-core"]; u.Status != "version fixée par Spring Boot" {
+		t.Errorf("spring-core: remarque %q, attendu %q", u.Status, "version fixée par Spring Boot")
+	}
+	cloud, ok := got["spring-cloud-starter-config"]
+	if !ok {
+		t.Fatalf("spring-cloud-starter-config absente de l'analyse: %+v", updates)
+	}
+	if cloud.Status != "" {
+		t.Errorf("spring-cloud-starter-config: remarque %q, aucune attendue", cloud.Status)
+	}
+	if cloud.Minor != "4.2.0" || cloud.Latest != "4.2.0" {
+		t.Errorf("spring-cloud-starter-config: mineure %q, dernière %q, attendu 4.2.0", cloud.Minor, cloud.Latest)
+	}
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"springcli/internal/diagram"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	diagramCmd.Flags().String("format", "mermaid", "Format du diagramme (mermaid, plantuml, dot, svg)")
+	diagramCmd.Flags().StringP("output", "o", "", "Fichier à écrire (défaut: sortie standard)")
+	rootCmd.AddCommand(diagramCmd)
+}
+
+// ==================== DIAGRAM ====================
+var diagramCmd = &cobra.Command{
+	Use:   "diagram",
+	Short: "Génère le diagramme entité-relation des entités du projet.",
+	Long: `Cette commande lit toutes les classes @Entity du projet (champs, identifiants, relations
+@OneToOne, @OneToMany, @ManyToOne et @ManyToMany, côtés inverses mappedBy) et produit leur
+diagramme entité-relation:
+  - mermaid:  erDiagram, affiché directement par GitHub et GitLab dans un README
+  - plantuml: diagramme d'entités en notation IE
+  - dot:      graphe Graphviz
+  - svg:      image, rendue par Graphviz s'il est installé, sinon par springcli
+
+Une relation bidirectionnelle n'est tracée qu'une fois, libellée « propriétaire / inverse ».
+Avec un fichier .md, le diagramme est écrit dans un bloc de code prêt à être inclus.`,
+	Example: `  springcli diagram
+  springcli diagram --format mermaid --output docs/model.md
+  springcli diagram --format svg --output docs/model.svg`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		entities := listEntities()
+		if len(entities) == 0 {
+			utils.PrintError("Aucune entité trouvée dans " + getSourcePath() + "/entity")
+			os.Exit(1)
+		}
+		d, err := buildDiagram(entities)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		var content string
+		switch format {
+		case "mermaid":
+			content = diagram.Mermaid(d)
+		case "plantuml":
+			content = diagram.PlantUML(d)
+		case "dot":
+			content = diagram.Dot(d)
+		case "svg":
+			content = renderSVG(d)
+		default:
+			utils.PrintError(fmt.Sprintf("Format inconnu: %s (valeurs possibles: mermaid, plantuml, dot, svg)", format))
+			os.Exit(1)
+		}
+
+		if output == "" {
+			fmt.Print(content)
+			return
+		}
+
+		utils.PrintTitle("🗺️ DIAGRAMME DES ENTITÉS")
+		if filepath.Ext(output) == ".md" && format != "svg" {
+			content = "```" + format + "\n" + content + "```\n"
+		}
+		if dir := filepath.Dir(output); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				utils.PrintError(fmt.Sprintf("Impossible de créer le dossier %s: %v", dir, err))
+				os.Exit(1)
+			}
+		}
+		if err := os.WriteFile(output, []byte(content), 0644); err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible d'écrire %s: %v", output, err))
+			os.Exit(1)
+		}
+		utils.PrintSuccess(fmt.Sprintf("%s écrit: %d entité(s), %d relation(s)", output, len(d.Entities), len(d.Relations)))
+	},
+}
+
+// buildDiagram lit les entités et leurs relations. Une relation bidirectionnelle n'est
+// représentée qu'une fois, depuis son côté propriétaire.
+func buildDiagram(entities []string) (diagram.Diagram, error) {
+	var d diagram.Diagram
+	relations := make(map[string][]Relation, len(entities))
+	for _, name := range entities {
+		_, fields, rels, err := readEntity(name)
+		if err != nil {
+			return d, fmt.Errorf("impossible de lire l'entité %s: %v", name, err)
+		}
+		relations[name] = rels
+		mapping := readEntityMapping(name)
+
+		e := diagram.Entity{Name: name}
+		if mapping.composite() {
+			for _, f := range mapping.KeyFields {
+				e.Attributes = append(e.Attributes, diagram.Attribute{Name: f.Name, Type: f.Type, Key: "PK", Required: true})
+			}
+		} else {
+			e.Attributes = append(e.Attributes, diagram.Attribute{Name: "id", Type: mapping.KeyType, Key: "PK", Required: true})
+		}
+		for _, f := range fields {
+			a := diagram.Attribute{Name: f.Name, Type: f.Type, Required: f.hasConstraint("required")}
+			if f.hasConstraint("unique") {
+				a.Key = "UK"
+			}
+			e.Attributes = append(e.Attributes, a)
+		}
+		// Seules les relations simples côté propriétaire portent une clé étrangère
+		for _, r := range rels {
+			if !isCollection(r) && r.MappedBy == "" {
+				e.Attributes = append(e.Attributes, diagram.Attribute{Name: r.Name, Type: r.Target, Key: "FK"})
+			}
+		}
+		d.Entities = append(d.Entities, e)
+	}
+
+	// Côtés inverses rattachés à la relation propriétaire qu'ils désignent
+	drawn := map[string]bool{}
+	for _, name := range entities {
+		for _, r := range relations[name] {
+			if r.MappedBy != "" {
+				continue
+			}
+			label := r.Name
+			for _, inverse := range relations[r.Target] {
+				if inverse.Target == name && inverse.MappedBy == r.Name {
+					label += " / " + inverse.Name
+					drawn[r.Target+"."+inverse.Name] = true
+				}
+			}
+			d.Relations = append(d.Relations, diagramRelation(name, r, label))
+		}
+	}
+	// Côtés inverses dont le propriétaire est introuvable
+	for _, name := range entities {
+		for _, r := range relations[name] {
+			if r.MappedBy != "" && !drawn[name+"."+r.Name] {
+				d.Relations = append(d.Relations, diagramRelation(name, r, r.Name))
+			}
+		}
+	}
+	return d, nil
+}
+
+// diagramRelation renvoie la relation du diagramme avec les cardinalités de son annotation.
+func diagramRelation(entityName string, r Relation, label string) diagram.Relation {
+	return diagram.Relation{
+		From:     entityName,
+		To:       r.Target,
+		Label:    label,
+		FromMany: r.Type == "@ManyToOne" || r.Type == "@ManyToMany",
+		ToMany:   isCollection(r),
+	}
+}
+
+// renderSVG délègue le rendu à Graphviz lorsqu'il est installé, pour une meilleure mise en
+// page, et utilise sinon le rendu intégré.
+func renderSVG(d diagram.Diagram) string {
+	dot, err := exec.LookPath("dot")
+	if err != nil {
+		return diagram.SVG(d)
+	}
+	var out bytes.Buffer
+	c := exec.Command(dot, "-Tsvg")
+	c.Stdin = strings.NewReader(diagram.Dot(d))
+	c.Stdout = &out
+	if err := c.Run(); err != nil {
+		utils.PrintWarning(fmt.Sprintf("Graphviz a échoué (%v), rendu SVG intégré utilisé", err))
+		return diagram.SVG(d)
+	}
+	return out.String()
+}
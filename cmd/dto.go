@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	generateDtoCmd.Flags().StringSlice("kind", []string{dtoCreate, dtoUpdate, dtoResponse}, "Types de DTO à générer: create, update, response")
+	generateDtoCmd.Flags().StringSlice("include", nil, "Champs de l'entité à inclure (tous par défaut)")
+	generateDtoCmd.Flags().StringSlice("exclude", nil, "Champs de l'entité à exclure")
+	generateDtoCmd.Flags().String("json-naming", "camel", "Convention de nommage JSON: camel, snake ou kebab")
+	generateCmd.AddCommand(generateDtoCmd)
+}
+
+// ==================== GENERATE DTO ====================
+const (
+	dtoCreate   = "create"
+	dtoUpdate   = "update"
+	dtoResponse = "response"
+)
+
+var generateDtoCmd = &cobra.Command{
+	Use:   "dto [entity-name]",
+	Short: "Génère les DTO d'une entité existante.",
+	Long: `Cette commande lit le code source d'une entité existante et génère les DTO
+de création, de mise à jour et de réponse correspondants. Les relations simples
+sont exposées sous forme d'identifiant (ex: roleId).`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("📦 GÉNÉRATEUR DE DTO SPRING BOOT")
+
+		entityName := args[0]
+		kinds, _ := cmd.Flags().GetStringSlice("kind")
+		include, _ := cmd.Flags().GetStringSlice("include")
+		exclude, _ := cmd.Flags().GetStringSlice("exclude")
+		naming, _ := cmd.Flags().GetString("json-naming")
+
+		_, fields, relations, err := readEntity(entityName)
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire l'entité %s: %v", entityName, err))
+			os.Exit(1)
+		}
+
+		style := resolveCodeStyle(cmd)
+		dtoFields := selectDtoFields(dtoFieldsFromEntity(entityName, fields, relations), include, exclude)
+		dtoFields = applyJSONNaming(dtoFields, naming)
+
+		for _, kind := range kinds {
+			if dtoClassName(entityName, kind) == "" {
+				utils.PrintError(fmt.Sprintf("Type de DTO inconnu: %s (valeurs possibles: create, update, response)", kind))
+				os.Exit(1)
+			}
+			utils.PrintInfo(fmt.Sprintf("Génération du DTO: %s", dtoClassName(entityName, kind)))
+			generateDto(entityName, kind, dtoFields, style)
+		}
+	},
+}
+
+const dtoTemplate = `package {{.packageName}}.dto;
+{{range .imports}}
+import {{.}};
+{{- end}}
+{{if eq .style "record"}}
+public record {{.className}}(
+{{- range $i, $f := .fields}}{{if $i}},{{end}}
+        {{range annotations $f $.validate}}{{.}} {{end}}{{$f.Type}} {{$f.Name}}
+{{- end}}
+) {
+}
+{{- else}}
+{{- if eq .style "lombok"}}
+@Getter
+@Setter
+@NoArgsConstructor
+@AllArgsConstructor
+@Builder
+{{- end}}
+public class {{.className}} {
+{{- range $i, $f := .fields}}
+{{- if $i}}
+{{end}}
+{{- range annotations $f $.validate}}
+    {{.}}{{end}}
+    private {{$f.Type}} {{$f.Name}};
+{{- end}}
+{{- if ne .style "lombok"}}
+
+    public {{.className}}() {
+    }
+{{- range .fields}}
+
+    public {{.Type}} {{getter .Name .Type}}() {
+        return {{.Name}};
+    }
+
+    public void set{{capitalize .Name}}({{.Type}} {{.Name}}) {
+        this.{{.Name}} = {{.Name}};
+    }
+{{- end}}
+{{- end}}
+}
+{{- end}}
+`
+
+const kotlinDtoTemplate = `package {{.packageName}}.dto
+{{range .imports}}
+import {{.}}
+{{- end}}
+
+data class {{.className}}(
+{{- range .fields}}
+{{- range annotations . $.validate}}
+    {{.}}{{end}}
+    val {{kotlinProperty .}},
+{{- end}}
+)
+`
+
+func generateDto(entityName, kind string, fields []Field, style string) {
+	if kind != dtoResponse {
+		fields = withoutID(fields)
+	}
+	writeDto(dtoClassName(entityName, kind), fields, style, kind != dtoResponse)
+}
+
+// writeDto génère une classe DTO (ou un record) avec, si validate, ses contraintes Bean Validation.
+func writeDto(className string, fields []Field, style string, validate bool) {
+	params := map[string]interface{}{
+		"className":   className,
+		"fields":      fields,
+		"style":       style,
+		"validate":    validate,
+		"imports":     dtoImports(fields, style, validate),
+		"packageName": basePackage(),
+	}
+
+	buf := renderTemplate("dto", languageTemplate(dtoTemplate, kotlinDtoTemplate), params)
+	writeNewFile(getSourcePath()+"/dto", sourceFile(className), buf)
+}
+
+// dtoClassName renvoie le nom de la classe DTO pour un type donné.
+func dtoClassName(entityName, kind string) string {
+	switch kind {
+	case dtoCreate:
+		return entityName + "CreateRequest"
+	case dtoUpdate:
+		return entityName + "UpdateRequest"
+	case dtoResponse:
+		return entityName + "Response"
+	default:
+		return ""
+	}
+}
+
+// dtoFieldsFromEntity construit les champs d'un DTO à partir de l'entité: l'identifiant,
+// les champs simples puis l'identifiant des relations simples (les collections sont ignorées).
+// Chaque identifiant a le type de la clé de son entité (Long, UUID...).
+func dtoFieldsFromEntity(entityName string, fields []Field, relations []Relation) []Field {
+	keyType := readEntityMapping(entityName).KeyType
+	dtoFields := []Field{{Name: "id", Type: keyType, JSONName: "id"}}
+	dtoFields = append(dtoFields, fields...)
+	for _, r := range relations {
+		if isCollection(r) {
+			continue
+		}
+		targetKey := keyType
+		if r.Target != entityName {
+			targetKey = readEntityMapping(r.Target).KeyType
+		}
+		dtoFields = append(dtoFields, Field{Name: r.Name + "Id", Type: targetKey, JSONName: r.Name + "Id"})
+	}
+	return dtoFields
+}
+
+func withoutID(fields []Field) []Field {
+	filtered := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		if f.Name != "id" {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// selectDtoFields applique les options --include et --exclude. Une relation peut être
+// désignée par son nom (role) ou par le nom de son identifiant (roleId).
+func selectDtoFields(fields []Field, include, exclude []string) []Field {
+	matches := func(f Field, names []string) bool {
+		for _, n := range names {
+			if n == f.Name || n+"Id" == f.Name {
+				return true
+			}
+		}
+		return false
+	}
+
+	selected := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		if len(include) > 0 && f.Name != "id" && !matches(f, include) {
+			continue
+		}
+		if matches(f, exclude) {
+			continue
+		}
+		selected = append(selected, f)
+	}
+	return selected
+}
+
+// applyJSONNaming renseigne le nom JSON de chaque champ selon la convention choisie.
+func applyJSONNaming(fields []Field, naming string) []Field {
+	named := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		switch naming {
+		case "snake":
+			f.JSONName = splitCamelCase(f.Name, "_")
+		case "kebab":
+			f.JSONName = splitCamelCase(f.Name, "-")
+		case "camel", "":
+			f.JSONName = f.Name
+		default:
+			utils.PrintError(fmt.Sprintf("Convention de nommage JSON inconnue: %s (valeurs possibles: camel, snake, kebab)", naming))
+			os.Exit(1)
+		}
+		named = append(named, f)
+	}
+	return named
+}
+
+// splitCamelCase convertit firstName en first_name (ou first-name).
+func splitCamelCase(name, sep string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// dtoAnnotations renvoie les annotations d'un champ de DTO: le nom JSON et, pour les DTO
+// de requête, les contraintes Bean Validation héritées de l'entité.
+func dtoAnnotations(f Field, validate bool) []string {
+	var annotations []string
+	if f.JSONName != "" && f.JSONName != f.Name {
+		annotations = append(annotations, fmt.Sprintf(`@JsonProperty("%s")`, f.JSONName))
+	}
+	if validate {
+		for _, a := range validationAnnotations(f) {
+			if isKotlin() {
+				a = "@field:" + strings.TrimPrefix(a, "@")
+			}
+			annotations = append(annotations, a)
+		}
+	}
+	return annotations
+}
+
+// fieldTypeImports renvoie les imports requis par un type, y compris les arguments d'un
+// type générique (List<LocalDate> -> java.util.List, java.time.LocalDate).
+func fieldTypeImports(t string) []string {
+	var imports []string
+	for _, name := range regexp.MustCompile(`\w+`).FindAllString(t, -1) {
+		switch {
+		case (name == "List" || name == "Set" || name == "Map") && !isKotlin():
+			imports = append(imports, "java.util."+name)
+		case typeImport(name) != "":
+			imports = append(imports, typeImport(name))
+		case isEnumType(name) || isEntityKeyType(name):
+			imports = append(imports, basePackage()+".entity."+name)
+		}
+	}
+	return imports
+}
+
+func dtoImports(fields []Field, style string, validate bool) []string {
+	imports := make(map[string]bool)
+	if style == styleLombok && !isKotlin() {
+		for _, a := range []string{"Getter", "Setter", "NoArgsConstructor", "AllArgsConstructor", "Builder"} {
+			imports["lombok."+a] = true
+		}
+	}
+	for _, f := range fields {
+		for _, imp := range fieldTypeImports(f.Type) {
+			imports[imp] = true
+		}
+		for _, imp := range annotationImports(dtoAnnotations(f, validate)) {
+			imports[imp] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(imports))
+	for imp := range imports {
+		sorted = append(sorted, imp)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestDtoFieldsFromEntity vérifie que l'identifiant du DTO et ceux de ses relations ont le
+// type de la clé de leur entité.
+func TestDtoFieldsFromEntity(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"pom.xml": "<project><groupId>com.example</groupId><artifactId>shop</artifactId></project>",
+		"src/main/java/com/example/shop/entity/Account.java": `@Entity
+public class Account {
+    @Id
+    private UUID id;
+}`,
+		"src/main/java/com/example/shop/entity/Session.java": `@Entity
+public class Session {
+    @Id
+    @GeneratedValue(strategy = GenerationType.IDENTITY)
+    private Long id;
+
+    private String token;
+
+    @ManyToOne(optional = false)
+    private Account account;
+
+    @ManyToOne
+    private Session parent;
+}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chdir(t, dir)
+
+	cases := []struct {
+		entity string
+		want   map[string]string
+	}{
+		{entity: "Account", want: map[string]string{"id": "UUID"}},
+		{entity: "Session", want: map[string]string{"id": "Long", "token": "String", "accountId": "UUID", "parentId": "Long"}},
+	}
+	for _, c := range cases {
+		t.Run(c.entity, func(t *testing.T) {
+			_, fields, relations, err := readEntity(c.entity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]string{}
+			for _, f := range dtoFieldsFromEntity(c.entity, fields, relations) {
+				got[f.Name] = f.Type
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("champs %v, attendu %v", got, c.want)
+			}
+		})
+	}
+}
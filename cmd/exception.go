@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	generateExceptionCmd.Flags().String("status", "400", "Statut HTTP associé à l'exception (code ou nom, ex: 404 ou NOT_FOUND)")
+	generateCmd.AddCommand(generateExceptionHandlerCmd)
+	generateCmd.AddCommand(generateExceptionCmd)
+}
+
+// ==================== GENERATE EXCEPTION HANDLER ====================
+var generateExceptionHandlerCmd = &cobra.Command{
+	Use:   "exception-handler",
+	Short: "Génère un gestionnaire global d'exceptions renvoyant des ProblemDetail (RFC 9457).",
+	Long: `Cette commande génère un @RestControllerAdvice qui transforme les erreurs de validation,
+les entités introuvables, les violations de contraintes, les exceptions métier et les
+erreurs inattendues en réponses ProblemDetail (RFC 9457, anciennement RFC 7807).
+
+Le gestionnaire hérite de ResponseEntityExceptionHandler: les erreurs de Spring MVC (JSON
+illisible, méthode non supportée, paramètre manquant, ResponseStatusException...) gardent
+leur statut 4xx ou 5xx, seules les exceptions inconnues deviennent des erreurs 500.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("🚨 GÉNÉRATEUR DE GESTION DES ERREURS SPRING BOOT")
+
+		utils.PrintInfo("Génération du gestionnaire global d'exceptions")
+		generateDomainException()
+		generateExceptionSource("GlobalExceptionHandler", languageTemplate(exceptionHandlerTemplate, kotlinExceptionHandlerTemplate), nil)
+	},
+}
+
+// ==================== GENERATE EXCEPTION ====================
+var generateExceptionCmd = &cobra.Command{
+	Use:   "exception [exception-name]",
+	Short: "Génère une exception métier associée à un statut HTTP.",
+	Long: `Cette commande génère une exception métier héritant de DomainException.
+Le gestionnaire global d'exceptions la traduit automatiquement en ProblemDetail
+avec le statut HTTP choisi.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("🚨 GÉNÉRATEUR D'EXCEPTION SPRING BOOT")
+
+		className := args[0]
+		if !strings.HasSuffix(className, "Exception") {
+			className += "Exception"
+		}
+
+		status, _ := cmd.Flags().GetString("status")
+		httpStatus, err := httpStatusConstant(status)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		utils.PrintInfo(fmt.Sprintf("Génération de l'exception: %s (%s)", className, status))
+		generateDomainException()
+		generateExceptionSource(className, languageTemplate(exceptionTemplate, kotlinExceptionTemplate), map[string]string{
+			"httpStatus": httpStatus,
+		})
+
+		if !utils.Exists(getSourcePath() + "/exception/" + sourceFile("GlobalExceptionHandler")) {
+			utils.PrintInfo("Astuce: lancez 'springcli generate exception-handler' pour traduire les exceptions en ProblemDetail")
+		}
+	},
+}
+
+const domainExceptionTemplate = `package {{.packageName}}.exception;
+
+import org.springframework.http.HttpStatus;
+
+/**
+ * Exception métier portant le statut HTTP à renvoyer au client.
+ */
+public abstract class DomainException extends RuntimeException {
+    private final HttpStatus status;
+
+    protected DomainException(HttpStatus status, String message) {
+        super(message);
+        this.status = status;
+    }
+
+    public HttpStatus getStatus() {
+        return status;
+    }
+}
+`
+
+const exceptionTemplate = `package {{.packageName}}.exception;
+
+import org.springframework.http.HttpStatus;
+
+public class {{.className}} extends DomainException {
+    public {{.className}}(String message) {
+        super({{.httpStatus}}, message);
+    }
+}
+`
+
+const exceptionHandlerTemplate = `package {{.packageName}}.exception;
+
+import jakarta.persistence.EntityNotFoundException;
+import jakarta.validation.ConstraintViolation;
+import jakarta.validation.ConstraintViolationException;
+import java.util.LinkedHashMap;
+import java.util.Map;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+import org.springframework.http.HttpHeaders;
+import org.springframework.http.HttpStatus;
+import org.springframework.http.HttpStatusCode;
+import org.springframework.http.ProblemDetail;
+import org.springframework.http.ResponseEntity;
+import org.springframework.validation.FieldError;
+import org.springframework.web.bind.MethodArgumentNotValidException;
+import org.springframework.web.bind.annotation.ExceptionHandler;
+import org.springframework.web.bind.annotation.RestControllerAdvice;
+import org.springframework.web.context.request.WebRequest;
+import org.springframework.web.servlet.mvc.method.annotation.ResponseEntityExceptionHandler;
+
+/**
+ * Les erreurs de Spring MVC (JSON illisible, méthode non supportée, paramètre manquant,
+ * ResponseStatusException...) sont traitées par ResponseEntityExceptionHandler avec leur statut.
+ */
+@RestControllerAdvice
+public class GlobalExceptionHandler extends ResponseEntityExceptionHandler {
+    private static final Logger log = LoggerFactory.getLogger(GlobalExceptionHandler.class);
+
+    @Override
+    protected ResponseEntity<Object> handleMethodArgumentNotValid(
+            MethodArgumentNotValidException ex, HttpHeaders headers, HttpStatusCode status, WebRequest request) {
+        Map<String, String> errors = new LinkedHashMap<>();
+        for (FieldError error : ex.getBindingResult().getFieldErrors()) {
+            errors.putIfAbsent(error.getField(), error.getDefaultMessage());
+        }
+        ProblemDetail problem = ProblemDetail.forStatusAndDetail(HttpStatus.BAD_REQUEST, "La requête contient des champs invalides");
+        problem.setTitle("Validation failed");
+        problem.setProperty("errors", errors);
+        return handleExceptionInternal(ex, problem, headers, HttpStatus.BAD_REQUEST, request);
+    }
+
+    @ExceptionHandler(ConstraintViolationException.class)
+    public ProblemDetail handleConstraintViolation(ConstraintViolationException ex) {
+        Map<String, String> errors = new LinkedHashMap<>();
+        for (ConstraintViolation<?> violation : ex.getConstraintViolations()) {
+            errors.putIfAbsent(violation.getPropertyPath().toString(), violation.getMessage());
+        }
+        ProblemDetail problem = ProblemDetail.forStatusAndDetail(HttpStatus.BAD_REQUEST, "Certaines contraintes ne sont pas respectées");
+        problem.setTitle("Constraint violation");
+        problem.setProperty("errors", errors);
+        return problem;
+    }
+
+    @ExceptionHandler(EntityNotFoundException.class)
+    public ProblemDetail handleEntityNotFound(EntityNotFoundException ex) {
+        ProblemDetail problem = ProblemDetail.forStatusAndDetail(HttpStatus.NOT_FOUND, ex.getMessage());
+        problem.setTitle("Resource not found");
+        return problem;
+    }
+
+    @ExceptionHandler(DomainException.class)
+    public ProblemDetail handleDomain(DomainException ex) {
+        ProblemDetail problem = ProblemDetail.forStatusAndDetail(ex.getStatus(), ex.getMessage());
+        problem.setTitle(ex.getStatus().getReasonPhrase());
+        return problem;
+    }
+
+    @ExceptionHandler(Exception.class)
+    public ProblemDetail handleUnexpected(Exception ex) {
+        log.error("Erreur inattendue", ex);
+        ProblemDetail problem = ProblemDetail.forStatusAndDetail(HttpStatus.INTERNAL_SERVER_ERROR, "Une erreur inattendue est survenue");
+        problem.setTitle("Internal server error");
+        return problem;
+    }
+}
+`
+
+const kotlinDomainExceptionTemplate = `package {{.packageName}}.exception
+
+import org.springframework.http.HttpStatus
+
+/**
+ * Exception métier portant le statut HTTP à renvoyer au client.
+ */
+abstract class DomainException(val status: HttpStatus, message: String) : RuntimeException(message)
+`
+
+const kotlinExceptionTemplate = `package {{.packageName}}.exception
+
+import org.springframework.http.HttpStatus
+
+class {{.className}}(message: String) : DomainException({{.httpStatus}}, message)
+`
+
+const kotlinExceptionHandlerTemplate = `package {{.packageName}}.exception
+
+import jakarta.persistence.EntityNotFoundException
+import jakarta.validation.ConstraintViolationException
+import org.slf4j.LoggerFactory
+import org.springframework.http.HttpHeaders
+import org.springframework.http.HttpStatus
+import org.springframework.http.HttpStatusCode
+import org.springframework.http.ProblemDetail
+import org.springframework.http.ResponseEntity
+import org.springframework.web.bind.MethodArgumentNotValidException
+import org.springframework.web.bind.annotation.ExceptionHandler
+import org.springframework.web.bind.annotation.RestControllerAdvice
+import org.springframework.web.context.request.WebRequest
+import org.springframework.web.servlet.mvc.method.annotation.ResponseEntityExceptionHandler
+
+/**
+ * Les erreurs de Spring MVC (JSON illisible, méthode non supportée, paramètre manquant,
+ * ResponseStatusException...) sont traitées par ResponseEntityExceptionHandler avec leur statut.
+ */
+@RestControllerAdvice
+class GlobalExceptionHandler : ResponseEntityExceptionHandler() {
+    private val log = LoggerFactory.getLogger(GlobalExceptionHandler::class.java)
+
+    override fun handleMethodArgumentNotValid(
+        ex: MethodArgumentNotValidException,
+        headers: HttpHeaders,
+        status: HttpStatusCode,
+        request: WebRequest,
+    ): ResponseEntity<Any>? {
+        val errors = linkedMapOf<String, String?>()
+        ex.bindingResult.fieldErrors.forEach { errors.putIfAbsent(it.field, it.defaultMessage) }
+        val problem = ProblemDetail.forStatusAndDetail(HttpStatus.BAD_REQUEST, "La requête contient des champs invalides").apply {
+            title = "Validation failed"
+            setProperty("errors", errors)
+        }
+        return handleExceptionInternal(ex, problem, headers, HttpStatus.BAD_REQUEST, request)
+    }
+
+    @ExceptionHandler(ConstraintViolationException::class)
+    fun handleConstraintViolation(ex: ConstraintViolationException): ProblemDetail {
+        val errors = linkedMapOf<String, String>()
+        ex.constraintViolations.forEach { errors.putIfAbsent(it.propertyPath.toString(), it.message) }
+        return ProblemDetail.forStatusAndDetail(HttpStatus.BAD_REQUEST, "Certaines contraintes ne sont pas respectées").apply {
+            title = "Constraint violation"
+            setProperty("errors", errors)
+        }
+    }
+
+    @ExceptionHandler(EntityNotFoundException::class)
+    fun handleEntityNotFound(ex: EntityNotFoundException): ProblemDetail =
+        ProblemDetail.forStatusAndDetail(HttpStatus.NOT_FOUND, ex.message ?: "Resource not found").apply {
+            title = "Resource not found"
+        }
+
+    @ExceptionHandler(DomainException::class)
+    fun handleDomain(ex: DomainException): ProblemDetail =
+        ProblemDetail.forStatusAndDetail(ex.status, ex.message ?: ex.status.reasonPhrase).apply {
+            title = ex.status.reasonPhrase
+        }
+
+    @ExceptionHandler(Exception::class)
+    fun handleUnexpected(ex: Exception): ProblemDetail {
+        log.error("Erreur inattendue", ex)
+        return ProblemDetail.forStatusAndDetail(HttpStatus.INTERNAL_SERVER_ERROR, "Une erreur inattendue est survenue").apply {
+            title = "Internal server error"
+        }
+    }
+}
+`
+
+// generateDomainException génère la classe de base des exceptions métier si elle n'existe pas encore.
+func generateDomainException() {
+	if utils.Exists(getSourcePath() + "/exception/" + sourceFile("DomainException")) {
+		return
+	}
+	generateExceptionSource("DomainException", languageTemplate(domainExceptionTemplate, kotlinDomainExceptionTemplate), nil)
+}
+
+func generateExceptionSource(className, exceptionTmpl string, extra map[string]string) {
+	params := map[string]string{
+		"className":   className,
+		"packageName": basePackage(),
+	}
+	for k, v := range extra {
+		params[k] = v
+	}
+
+	buf := renderTemplate("exception", exceptionTmpl, params)
+	writeNewFile(getSourcePath()+"/exception", sourceFile(className), buf)
+}
+
+var httpStatusNames = map[int]string{
+	400: "BAD_REQUEST",
+	401: "UNAUTHORIZED",
+	402: "PAYMENT_REQUIRED",
+	403: "FORBIDDEN",
+	404: "NOT_FOUND",
+	405: "METHOD_NOT_ALLOWED",
+	406: "NOT_ACCEPTABLE",
+	408: "REQUEST_TIMEOUT",
+	409: "CONFLICT",
+	410: "GONE",
+	412: "PRECONDITION_FAILED",
+	413: "PAYLOAD_TOO_LARGE",
+	415: "UNSUPPORTED_MEDIA_TYPE",
+	422: "UNPROCESSABLE_ENTITY",
+	423: "LOCKED",
+	429: "TOO_MANY_REQUESTS",
+	500: "INTERNAL_SERVER_ERROR",
+	501: "NOT_IMPLEMENTED",
+	502: "BAD_GATEWAY",
+	503: "SERVICE_UNAVAILABLE",
+	504: "GATEWAY_TIMEOUT",
+}
+
+// httpStatusConstant convertit un statut (404 ou NOT_FOUND) en expression Java/Kotlin HttpStatus.
+func httpStatusConstant(status string) (string, error) {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	if code, err := strconv.Atoi(status); err == nil {
+		if code < 400 || code > 599 {
+			return "", fmt.Errorf("statut HTTP invalide pour une exception: %d (attendu: 4xx ou 5xx)", code)
+		}
+		if name, ok := httpStatusNames[code]; ok {
+			return "HttpStatus." + name, nil
+		}
+		return fmt.Sprintf("HttpStatus.valueOf(%d)", code), nil
+	}
+	for _, name := range httpStatusNames {
+		if name == status {
+			return "HttpStatus." + name, nil
+		}
+	}
+	return "", fmt.Errorf("statut HTTP inconnu: %s", status)
+}
@@ -0,0 +1,678 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"springcli/internal/fixtures"
+	"springcli/internal/migration"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	fixturesGenerateCmd.Flags().IntP("count", "n", 20, "Nombre de lignes par entité")
+	fixturesGenerateCmd.Flags().String("format", "sql", "Sortie: sql (script data-*.sql) ou seeder (CommandLineRunner du profil dev)")
+	fixturesGenerateCmd.Flags().StringP("output", "o", "", "Script SQL à écrire (défaut: "+fixturesScriptPath+")")
+	fixturesGenerateCmd.Flags().String("dialect", "", "Base de données du script: postgres, mysql, mariadb ou h2 (défaut: détectée)")
+	fixturesGenerateCmd.Flags().Int64("seed", 42, "Graine du générateur: une même graine produit les mêmes données")
+	fixturesCmd.AddCommand(fixturesGenerateCmd)
+	rootCmd.AddCommand(fixturesCmd)
+}
+
+const (
+	fixturesScriptPath = "src/main/resources/data-dev.sql"
+	fixturesSeederName = "DevDataSeeder"
+)
+
+// ==================== FIXTURES ====================
+var fixturesCmd = &cobra.Command{
+	Use:   "fixtures",
+	Short: "Gère les données de développement (fixtures).",
+}
+
+var fixturesGenerateCmd = &cobra.Command{
+	Use:   "generate <entité>...",
+	Short: "Génère des données factices réalistes pour des entités.",
+	Long: `Cette commande lit les champs et relations des entités et génère des données factices
+réalistes: la valeur de chaque champ dépend de son type et de son nom (email, firstName,
+phone, city, price...), les énumérations prennent l'une de leurs constantes et les
+contraintes sont respectées (longueur, unique, required).
+
+Les entités référencées par une relation @ManyToOne ou @OneToOne sont générées aussi, avant
+les entités qui les référencent: chaque clé étrangère désigne une ligne existante. Les
+collections (@OneToMany, @ManyToMany) restent vides.
+
+Deux sorties sont possibles:
+  - sql:    un script data-dev.sql chargé par Spring Boot avec spring.sql.init.platform=dev
+  - seeder: un CommandLineRunner actif avec le profil dev, qui enregistre les entités par
+            leurs repositories si la base est vide`,
+	Example: `  springcli fixtures generate User --count 50
+  springcli fixtures generate Order --format seeder
+  springcli fixtures generate User Order --dialect mysql --output src/main/resources/data-mysql.sql`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		count, _ := cmd.Flags().GetInt("count")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		dialectName, _ := cmd.Flags().GetString("dialect")
+		seed, _ := cmd.Flags().GetInt64("seed")
+
+		if count < 1 {
+			utils.PrintError("--count doit être supérieur à 0")
+			os.Exit(1)
+		}
+		if format != "sql" && format != "seeder" {
+			utils.PrintError(fmt.Sprintf("Format inconnu: %s (valeurs possibles: sql, seeder)", format))
+			os.Exit(1)
+		}
+		for _, name := range args {
+			if !utils.Exists(getSourcePath() + "/entity/" + sourceFile(name)) {
+				utils.PrintError(fmt.Sprintf("Entité %s introuvable dans %s/entity", name, getSourcePath()))
+				os.Exit(1)
+			}
+		}
+
+		utils.PrintTitle("🌱 FIXTURES")
+		sets := generateFixtures(args, count, seed)
+		if len(sets) == 0 {
+			utils.PrintError("Aucune entité à remplir")
+			os.Exit(1)
+		}
+
+		if format == "seeder" {
+			writeFixtureSeeder(sets, seed)
+			return
+		}
+
+		dialect := detectDialect()
+		if dialectName != "" {
+			d, err := migration.ParseDialect(dialectName)
+			if err != nil {
+				utils.PrintError(err.Error())
+				os.Exit(1)
+			}
+			dialect = d
+		}
+		if output == "" {
+			output = fixturesScriptPath
+		}
+		writeFixtureScript(sets, dialect, seed, output)
+	},
+}
+
+// fixtureEntity est une entité à remplir, avec les relations simples dont elle porte la clé
+// étrangère.
+type fixtureEntity struct {
+	Name       string
+	Mapping    entityMapping
+	Fields     []Field
+	References []Relation
+}
+
+// fixtureRow est une ligne générée. Refs donne, pour chaque référence, l'indice de la ligne
+// cible, -1 pour une clé étrangère vide.
+type fixtureRow struct {
+	Key    fixtures.Value
+	Values []fixtures.Value
+	Refs   []int
+}
+
+// fixtureSet regroupe les lignes générées d'une entité.
+type fixtureSet struct {
+	Entity fixtureEntity
+	Rows   []fixtureRow
+}
+
+// entityDependencyOrder renvoie les entités, complétées par celles qu'elles référencent,
+// dans l'ordre où elles doivent être insérées. Une relation qui ferme un cycle, y compris
+// vers l'entité elle-même, est ajoutée à broken (Entité.relation): sa clé étrangère ne peut
+// pas être renseignée à l'insertion.
+func entityDependencyOrder(names []string) (order []string, broken map[string]bool) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	broken = map[string]bool{}
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		_, _, relations, err := readEntity(name)
+		if err == nil {
+			for _, r := range relations {
+				if isCollection(r) || r.MappedBy != "" || !utils.Exists(getSourcePath()+"/entity/"+sourceFile(r.Target)) {
+					continue
+				}
+				switch state[r.Target] {
+				case visiting:
+					broken[name+"."+r.Name] = true
+				case 0:
+					visit(r.Target)
+				}
+			}
+		}
+		state[name] = done
+		order = append(order, name)
+	}
+	for _, name := range names {
+		if state[name] == 0 {
+			visit(name)
+		}
+	}
+	return order, broken
+}
+
+// generateFixtures génère count lignes pour chaque entité et celles qu'elle référence.
+func generateFixtures(names []string, count int, seed int64) []fixtureSet {
+	order, broken := entityDependencyOrder(names)
+	for ref := range broken {
+		utils.PrintWarning(fmt.Sprintf("Relation circulaire: %s reste vide", ref))
+	}
+	enums := map[string][]string{}
+	if types, err := scanSourceTypes(getSourcePath() + "/entity"); err == nil {
+		for name, t := range types {
+			if constants := t.enumConstants(); len(constants) > 0 {
+				enums[name] = constants
+			}
+		}
+	}
+
+	faker := fixtures.New(seed)
+	generated := map[string]bool{}
+	var sets []fixtureSet
+	for _, name := range order {
+		_, fields, relations, err := readEntity(name)
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire l'entité %s: %v", name, err))
+			os.Exit(1)
+		}
+		mapping := readEntityMapping(name)
+		if mapping.composite() {
+			utils.PrintWarning(fmt.Sprintf("%s a une clé composite: aucune donnée générée", name))
+			continue
+		}
+
+		entity := fixtureEntity{Name: name, Mapping: mapping, Fields: fields}
+		for _, r := range relations {
+			if !isCollection(r) && r.MappedBy == "" {
+				entity.References = append(entity.References, r)
+			}
+		}
+		specs := make([]fixtures.Field, len(fields))
+		for i, f := range fields {
+			specs[i] = fixtureField(f, enums[f.Type])
+			if _, ok := f.constraintText("pattern"); ok {
+				utils.PrintWarning(fmt.Sprintf("%s.%s: la contrainte pattern n'est pas prise en compte", name, f.Name))
+			}
+		}
+
+		set := fixtureSet{Entity: entity}
+		for row := 0; row < count; row++ {
+			r := fixtureRow{}
+			if !mapping.Generated {
+				r.Key = fixtureKey(faker, mapping.KeyType, row)
+			}
+			r.Values = faker.Row(specs, row)
+			for _, ref := range entity.References {
+				switch {
+				case !generated[ref.Target] || broken[name+"."+ref.Name]:
+					r.Refs = append(r.Refs, -1)
+				case ref.Type == "@OneToOne":
+					// Une relation @OneToOne est unique: chaque ligne a sa propre cible
+					r.Refs = append(r.Refs, row)
+				default:
+					r.Refs = append(r.Refs, faker.Intn(count))
+				}
+			}
+			set.Rows = append(set.Rows, r)
+		}
+		generated[name] = true
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+// fixtureField traduit le type et les contraintes d'un champ pour le générateur.
+func fixtureField(f Field, enum []string) fixtures.Field {
+	spec := fixtures.Field{
+		Name:     f.Name,
+		Type:     f.Type,
+		Enum:     enum,
+		Required: f.hasConstraint("required") || isPrimitive(f.Type),
+		Unique:   f.hasConstraint("unique"),
+		Email:    f.hasConstraint("email"),
+		Past:     f.hasConstraint("past"),
+		Future:   f.hasConstraint("future"),
+		Positive: f.hasConstraint("positive"),
+	}
+	min, hasMin := f.constraintValue("min")
+	max, hasMax := f.constraintValue("max")
+	if isTextType(f.Type) {
+		spec.MinLength, spec.MaxLength = min, max
+		if !hasMax {
+			// Longueur par défaut d'une colonne VARCHAR générée par Hibernate
+			spec.MaxLength = 255
+		}
+		return spec
+	}
+	min, hasMin, max, hasMax = f.numericBounds()
+	if hasMin {
+		spec.Min = &min
+	}
+	if hasMax {
+		spec.Max = &max
+	}
+	return spec
+}
+
+// fixtureKey renvoie l'identifiant d'une ligne dont la clé n'est pas générée par la base.
+func fixtureKey(faker *fixtures.Faker, keyType string, row int) fixtures.Value {
+	if keyType == "UUID" {
+		return fixtures.Value{Kind: fixtures.UUID, Text: faker.UUID()}
+	}
+	if keyType == "String" {
+		return fixtures.Value{Kind: fixtures.Text, Text: strconv.Itoa(row + 1)}
+	}
+	return fixtures.Value{Kind: fixtures.Number, Text: strconv.Itoa(row + 1)}
+}
+
+// ===================== SCRIPT SQL =======================
+
+// writeFixtureScript écrit les lignes sous forme d'INSERT, dans l'ordre des dépendances.
+// Une clé étrangère est résolue par une sous-requête sur la n-ième ligne de la table cible,
+// ce qui fonctionne avec des identifiants générés par la base.
+func writeFixtureScript(sets []fixtureSet, d migration.Dialect, seed int64, output string) {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("-- Données de développement générées par springcli fixtures generate (graine %d, %s)\n", seed, d))
+	b.WriteString("-- Le script suppose des tables vides: les clés étrangères désignent les lignes par leur rang\n")
+
+	for _, set := range sets {
+		e := set.Entity
+		table := migration.Quote(d, e.Mapping.Table)
+		keyColumn := migration.Quote(d, keyColumnName(e.Mapping))
+
+		var columns []string
+		if !e.Mapping.Generated {
+			columns = append(columns, keyColumn)
+		}
+		for _, f := range e.Fields {
+			column, err := fieldColumn(d, f)
+			if err != nil {
+				utils.PrintError(fmt.Sprintf("%s: %v", e.Name, err))
+				os.Exit(1)
+			}
+			columns = append(columns, migration.Quote(d, column.Name))
+		}
+		for _, r := range e.References {
+			columns = append(columns, migration.Quote(d, joinColumn(r)))
+		}
+
+		b.WriteString(fmt.Sprintf("\n-- %s: %d ligne(s)\n", e.Name, len(set.Rows)))
+		for _, row := range set.Rows {
+			var values []string
+			if !e.Mapping.Generated {
+				values = append(values, sqlLiteral(d, row.Key))
+			}
+			for _, v := range row.Values {
+				values = append(values, sqlLiteral(d, v))
+			}
+			for i, r := range e.References {
+				values = append(values, sqlReference(d, r.Target, row.Refs[i]))
+			}
+			b.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);\n", table, strings.Join(columns, ", "), strings.Join(values, ", ")))
+		}
+	}
+
+	existed := utils.Exists(output)
+	if dir := filepath.Dir(output); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de créer le dossier %s: %v", dir, err))
+			os.Exit(1)
+		}
+	}
+	if err := os.WriteFile(output, []byte(b.String()), 0644); err != nil {
+		utils.PrintError(fmt.Sprintf("Impossible d'écrire %s: %v", output, err))
+		os.Exit(1)
+	}
+	if existed {
+		utils.PrintWarning(fmt.Sprintf("%s remplacé", output))
+	}
+	utils.PrintSuccess(fmt.Sprintf("%s écrit: %s", output, fixtureSummary(sets)))
+	utils.PrintInfo("Pour le charger au démarrage, ajoutez à application-dev.properties:")
+	utils.PrintInfo("  spring.sql.init.mode=always")
+	utils.PrintInfo("  spring.sql.init.platform=" + strings.TrimSuffix(strings.TrimPrefix(filepath.Base(output), "data-"), ".sql"))
+	utils.PrintInfo("  spring.jpa.defer-datasource-initialization=true (si Hibernate crée le schéma)")
+}
+
+// fixtureSummary résume le nombre de lignes générées par entité.
+func fixtureSummary(sets []fixtureSet) string {
+	var parts []string
+	for _, set := range sets {
+		parts = append(parts, fmt.Sprintf("%d %s", len(set.Rows), set.Entity.Name))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sqlLiteral écrit une valeur générée en SQL.
+func sqlLiteral(d migration.Dialect, v fixtures.Value) string {
+	mysqlLike := d == migration.MySQL || d == migration.MariaDB
+	switch v.Kind {
+	case fixtures.Null:
+		return "NULL"
+	case fixtures.Number:
+		return v.Text
+	case fixtures.Boolean:
+		return strings.ToUpper(v.Text)
+	case fixtures.DateTime:
+		return "'" + strings.Replace(v.Text, "T", " ", 1) + "'"
+	case fixtures.Instant:
+		text := strings.TrimSuffix(strings.Replace(v.Text, "T", " ", 1), "Z")
+		if !mysqlLike {
+			text += "+00"
+		}
+		return "'" + text + "'"
+	case fixtures.UUID:
+		if d == migration.MySQL {
+			return "UUID_TO_BIN('" + v.Text + "')"
+		}
+		return "'" + v.Text + "'"
+	}
+	text := strings.ReplaceAll(v.Text, "'", "''")
+	if mysqlLike {
+		text = strings.ReplaceAll(text, `\`, `\\`)
+	}
+	return "'" + text + "'"
+}
+
+// sqlReference renvoie la sous-requête qui désigne la ligne row (à partir de 0) d'une entité.
+func sqlReference(d migration.Dialect, entityName string, row int) string {
+	if row < 0 {
+		return "NULL"
+	}
+	mapping := readEntityMapping(entityName)
+	key := migration.Quote(d, keyColumnName(mapping))
+	return fmt.Sprintf("(SELECT %s FROM %s ORDER BY %s LIMIT 1 OFFSET %d)", key, migration.Quote(d, mapping.Table), key, row)
+}
+
+// ===================== SEEDER =======================
+
+// seederEntity décrit l'enregistrement des lignes d'une entité par le seeder.
+type seederEntity struct {
+	Name          string
+	Var           string
+	ListVar       string
+	RepositoryVar string
+	// Referenced indique si des entités suivantes désignent ses lignes
+	Referenced bool
+	Params     string
+	Setters    []string
+	Rows       []string
+}
+
+const fixtureSeederTemplate = `package {{.packageName}}.config;
+{{range .imports}}
+import {{.}};
+{{- end}}
+
+// Remplit la base au démarrage avec le profil dev, si elle ne contient encore aucun
+// {{.guard.Name}}. Données générées par springcli fixtures generate (graine {{.seed}}).
+@Component
+@Profile("dev")
+public class {{.seederName}} implements CommandLineRunner {
+{{- range .entities}}
+    private final {{.Name}}Repository {{.RepositoryVar}};
+{{- end}}
+
+    public {{.seederName}}({{.constructorParams}}) {
+{{- range .entities}}
+        this.{{.RepositoryVar}} = {{.RepositoryVar}};
+{{- end}}
+    }
+
+    @Override
+    @Transactional
+    public void run(String... args) {
+        if ({{.guard.RepositoryVar}}.count() > 0) {
+            return;
+        }
+{{- range .entities}}
+
+        {{if .Referenced}}List<{{.Name}}> {{.ListVar}} = {{end}}{{.RepositoryVar}}.saveAll(List.of(
+{{- range $i, $row := .Rows}}{{if $i}},{{end}}
+                {{$row}}
+{{- end}}));
+{{- end}}
+    }
+{{- range .entities}}
+
+    private static {{.Name}} {{.Var}}({{.Params}}) {
+        {{.Name}} {{.Var}} = new {{.Name}}();
+{{- range .Setters}}
+        {{.}}
+{{- end}}
+        return {{.Var}};
+    }
+{{- end}}
+}
+`
+
+const kotlinFixtureSeederTemplate = `package {{.packageName}}.config
+{{range .imports}}
+import {{.}}
+{{- end}}
+
+// Remplit la base au démarrage avec le profil dev, si elle ne contient encore aucun
+// {{.guard.Name}}. Données générées par springcli fixtures generate (graine {{.seed}}).
+@Component
+@Profile("dev")
+class {{.seederName}}(
+{{- range .entities}}
+    private val {{.RepositoryVar}}: {{.Name}}Repository,
+{{- end}}
+) : CommandLineRunner {
+    @Transactional
+    override fun run(vararg args: String) {
+        if ({{.guard.RepositoryVar}}.count() > 0) {
+            return
+        }
+{{- range .entities}}
+
+        {{if .Referenced}}val {{.ListVar}} = {{end}}{{.RepositoryVar}}.saveAll(
+            listOf(
+{{- range .Rows}}
+                {{.}},
+{{- end}}
+            ),
+        )
+{{- end}}
+    }
+}
+`
+
+// writeFixtureSeeder génère le CommandLineRunner qui enregistre les lignes par les
+// repositories des entités, dans l'ordre des dépendances.
+func writeFixtureSeeder(sets []fixtureSet, seed int64) {
+	referenced := map[string]bool{}
+	for _, set := range sets {
+		for i, r := range set.Entity.References {
+			for _, row := range set.Rows {
+				if row.Refs[i] >= 0 {
+					referenced[r.Target] = true
+				}
+			}
+		}
+	}
+
+	imports := map[string]bool{
+		"org.springframework.boot.CommandLineRunner":               true,
+		"org.springframework.context.annotation.Profile":           true,
+		"org.springframework.stereotype.Component":                 true,
+		"org.springframework.transaction.annotation.Transactional": true,
+	}
+	if !isKotlin() {
+		imports["java.util.List"] = true
+	}
+
+	var entities []seederEntity
+	var constructorParams []string
+	for _, set := range sets {
+		e := set.Entity
+		if !utils.Exists(getSourcePath() + "/repository/" + sourceFile(e.Name+"Repository")) {
+			utils.PrintError(fmt.Sprintf("Repository de %s introuvable: lancez d'abord 'springcli generate repository %s'", e.Name, e.Name))
+			os.Exit(1)
+		}
+		imports[basePackage()+".entity."+e.Name] = true
+		imports[basePackage()+".repository."+e.Name+"Repository"] = true
+
+		s := seederEntity{
+			Name:          e.Name,
+			Var:           uncapitalize(e.Name),
+			ListVar:       pluralize(uncapitalize(e.Name)),
+			RepositoryVar: uncapitalize(e.Name) + "Repository",
+			Referenced:    referenced[e.Name],
+		}
+		constructorParams = append(constructorParams, e.Name+"Repository "+s.RepositoryVar)
+
+		// Paramètres de la méthode de fabrique Java, dans l'ordre des valeurs de chaque ligne
+		type property struct{ name, javaType string }
+		var properties []property
+		if !e.Mapping.Generated {
+			properties = append(properties, property{"id", e.Mapping.KeyType})
+		}
+		for _, f := range e.Fields {
+			properties = append(properties, property{f.Name, f.Type})
+			if imp := typeImport(f.Type); imp != "" {
+				imports[imp] = true
+			}
+			if isEnumType(f.Type) {
+				imports[basePackage()+".entity."+f.Type] = true
+			}
+		}
+		for _, r := range e.References {
+			properties = append(properties, property{r.Name, r.Target})
+		}
+		if !e.Mapping.Generated {
+			if imp := typeImport(e.Mapping.KeyType); imp != "" {
+				imports[imp] = true
+			}
+		}
+		var factoryParams []string
+		for _, p := range properties {
+			factoryParams = append(factoryParams, p.javaType+" "+p.name)
+			s.Setters = append(s.Setters, fmt.Sprintf("%s.set%s(%s);", s.Var, capitalize(p.name), p.name))
+		}
+		s.Params = strings.Join(factoryParams, ", ")
+
+		for _, row := range set.Rows {
+			var args []string
+			if !e.Mapping.Generated {
+				args = append(args, codeLiteral(e.Mapping.KeyType, row.Key))
+			}
+			for i, f := range e.Fields {
+				args = append(args, codeLiteral(f.Type, row.Values[i]))
+			}
+			for i, r := range e.References {
+				args = append(args, codeReference(r.Target, row.Refs[i]))
+			}
+			if isKotlin() {
+				for i, p := range properties {
+					args[i] = p.name + " = " + args[i]
+				}
+				s.Rows = append(s.Rows, e.Name+"("+strings.Join(args, ", ")+")")
+			} else {
+				s.Rows = append(s.Rows, s.Var+"("+strings.Join(args, ", ")+")")
+			}
+		}
+		entities = append(entities, s)
+	}
+
+	// La présence de données est testée sur les entités demandées, insérées en dernier
+	params := map[string]interface{}{
+		"packageName":       basePackage(),
+		"seederName":        fixturesSeederName,
+		"seed":              seed,
+		"imports":           sortedKeys(imports),
+		"entities":          entities,
+		"guard":             entities[len(entities)-1],
+		"constructorParams": strings.Join(constructorParams, ", "),
+	}
+	buf := renderTemplate("fixtureSeeder", languageTemplate(fixtureSeederTemplate, kotlinFixtureSeederTemplate), params)
+
+	path := getSourcePath() + "/config"
+	filename := sourceFile(fixturesSeederName)
+	if utils.Exists(path + "/" + filename) {
+		utils.PrintWarning(fmt.Sprintf("%s existant remplacé", filename))
+	}
+	if !utils.Exists(path) {
+		if err := utils.CreateFolder(path); err != nil {
+			utils.PrintError(fmt.Sprintf("Erreur lors de la création du dossier: %v", err))
+			os.Exit(1)
+		}
+	}
+	generateFile(path, filename, buf)
+	utils.PrintInfo(fmt.Sprintf("%s: lancez l'application avec le profil dev (--spring.profiles.active=dev)", fixtureSummary(sets)))
+}
+
+// codeLiteral écrit une valeur générée en Java ou en Kotlin, selon le type du champ.
+func codeLiteral(javaType string, v fixtures.Value) string {
+	kotlin := isKotlin()
+	switch v.Kind {
+	case fixtures.Null:
+		return "null"
+	case fixtures.Enum:
+		return javaType + "." + v.Text
+	case fixtures.Boolean:
+		return v.Text
+	case fixtures.Number:
+		switch javaType {
+		case "long", "Long":
+			return v.Text + "L"
+		case "short", "Short":
+			if kotlin {
+				return v.Text
+			}
+			return "(short) " + v.Text
+		case "float", "Float":
+			return v.Text + "f"
+		case "double", "Double":
+			if !strings.Contains(v.Text, ".") {
+				return v.Text + ".0"
+			}
+			return v.Text
+		case "BigDecimal":
+			if kotlin {
+				return `BigDecimal("` + v.Text + `")`
+			}
+			return `new BigDecimal("` + v.Text + `")`
+		}
+		return v.Text
+	case fixtures.Date, fixtures.DateTime, fixtures.Time, fixtures.Instant:
+		return javaType + `.parse("` + v.Text + `")`
+	case fixtures.UUID:
+		return `UUID.fromString("` + v.Text + `")`
+	}
+	quoted := strconv.Quote(v.Text)
+	if kotlin {
+		quoted = strings.ReplaceAll(quoted, "$", `\$`)
+	}
+	return quoted
+}
+
+// codeReference désigne l'entité enregistrée à la ligne row dans la liste de sa classe.
+func codeReference(entityName string, row int) string {
+	if row < 0 {
+		return "null"
+	}
+	list := pluralize(uncapitalize(entityName))
+	if isKotlin() {
+		return fmt.Sprintf("%s[%d]", list, row)
+	}
+	return fmt.Sprintf("%s.get(%d)", list, row)
+}
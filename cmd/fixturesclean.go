@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"springcli/internal/config"
+	"springcli/internal/migration"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	fixturesCleanCmd.Flags().String("only", "", "Entités à vider, séparées par des virgules (celles qui les référencent sont vidées aussi)")
+	fixturesCleanCmd.Flags().Bool("truncate", false, "Vide les tables avec TRUNCATE plutôt que DELETE")
+	fixturesCleanCmd.Flags().String("dialect", "", "Base de données du script: postgres, mysql, mariadb ou h2 (défaut: détectée)")
+	fixturesCleanCmd.Flags().StringP("output", "o", "", "Fichier où écrire le script (défaut: sortie standard)")
+	fixturesCleanCmd.Flags().Bool("execute", false, "Exécute le script sur la base de développement avec psql, mysql ou mariadb")
+	fixturesCleanCmd.Flags().String("url", "", "URL JDBC de la base (défaut: database.url de "+config.FileName+")")
+	fixturesCleanCmd.Flags().BoolP("yes", "y", false, "Exécute le script sans demander de confirmation")
+	fixturesCmd.AddCommand(fixturesCleanCmd)
+}
+
+// ==================== FIXTURES CLEAN ====================
+var fixturesCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Vide les tables des entités, dans l'ordre imposé par les clés étrangères.",
+	Long: `Cette commande génère le script qui vide les tables des entités et remet leurs
+identifiants à 1. L'ordre des suppressions est calculé à partir des relations entre entités:
+les tables de jointure et les tables qui référencent une autre table sont vidées avant elle.
+
+Avec --only, seules les entités indiquées sont vidées, ainsi que celles qui les référencent
+(sans quoi leurs clés étrangères empêcheraient la suppression).
+
+Le script est affiché, écrit avec --output, ou exécuté avec --execute sur la base décrite dans
+` + config.FileName + ` (ou --url), grâce au client installé localement (psql, mysql ou mariadb):
+
+  database:
+    url: jdbc:postgresql://localhost:5432/shop
+    username: shop
+    password: secret`,
+	Example: `  springcli fixtures clean
+  springcli fixtures clean --only User,Order --truncate --output reset.sql
+  springcli fixtures clean --execute`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		only, _ := cmd.Flags().GetString("only")
+		truncate, _ := cmd.Flags().GetBool("truncate")
+		dialectName, _ := cmd.Flags().GetString("dialect")
+		output, _ := cmd.Flags().GetString("output")
+		execute, _ := cmd.Flags().GetBool("execute")
+		jdbcURL, _ := cmd.Flags().GetString("url")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		var names []string
+		for _, name := range strings.Split(only, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		for _, name := range names {
+			if !utils.Exists(getSourcePath() + "/entity/" + sourceFile(name)) {
+				utils.PrintError(fmt.Sprintf("Entité %s introuvable dans %s/entity", name, getSourcePath()))
+				os.Exit(1)
+			}
+		}
+
+		var db config.Database
+		var conn jdbcConnection
+		if execute {
+			db = projectConfig().Database
+			if jdbcURL != "" {
+				db.URL = jdbcURL
+			}
+			if db.URL == "" {
+				utils.PrintError(fmt.Sprintf("Aucune base configurée: renseignez database.url dans %s ou utilisez --url", config.FileName))
+				os.Exit(1)
+			}
+			var err error
+			conn, err = parseJDBCURL(db.URL)
+			if err != nil {
+				utils.PrintError(err.Error())
+				os.Exit(1)
+			}
+		}
+
+		dialect := conn.Dialect
+		switch {
+		case dialect != "":
+		case dialectName != "":
+			d, err := migration.ParseDialect(dialectName)
+			if err != nil {
+				utils.PrintError(err.Error())
+				os.Exit(1)
+			}
+			dialect = d
+		default:
+			dialect = detectDialect()
+		}
+
+		tables, added := cleanTables(dialect, names)
+		if len(tables) == 0 {
+			utils.PrintError("Aucune table à vider")
+			os.Exit(1)
+		}
+		script := migration.CleanSQL(dialect, tables, truncate)
+
+		if !execute && output == "" {
+			fmt.Print(script)
+			return
+		}
+
+		utils.PrintTitle("🧹 NETTOYAGE DES DONNÉES")
+		for _, name := range added {
+			utils.PrintInfo(fmt.Sprintf("%s référence une entité vidée: elle est vidée aussi", name))
+		}
+		if output != "" {
+			if dir := filepath.Dir(output); dir != "." {
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					utils.PrintError(fmt.Sprintf("Impossible de créer le dossier %s: %v", dir, err))
+					os.Exit(1)
+				}
+			}
+			if err := os.WriteFile(output, []byte(script), 0644); err != nil {
+				utils.PrintError(fmt.Sprintf("Impossible d'écrire %s: %v", output, err))
+				os.Exit(1)
+			}
+			utils.PrintSuccess(fmt.Sprintf("%s écrit: %d table(s)", output, len(tables)))
+		}
+		if !execute {
+			return
+		}
+
+		tableNames := make([]string, len(tables))
+		for i, t := range tables {
+			tableNames[i] = t.Name
+		}
+		utils.PrintWarning(fmt.Sprintf("Toutes les données de %d table(s) de %s seront supprimées: %s",
+			len(tables), conn.Database, strings.Join(tableNames, ", ")))
+		if !yes && !AskYesNo() {
+			utils.PrintInfo("Nettoyage annulé")
+			return
+		}
+		if err := runSQLScript(conn, db, script); err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+		utils.PrintSuccess(fmt.Sprintf("%d table(s) vidée(s)", len(tables)))
+	},
+}
+
+// cleanTables renvoie les tables à vider dans l'ordre de suppression: tables de jointure,
+// puis tables des entités, celles qui référencent avant celles qui sont référencées. Sans
+// entité demandée, toutes sont vidées; sinon, les entités qui référencent une entité vidée le
+// sont aussi, et sont renvoyées dans added.
+func cleanTables(d migration.Dialect, only []string) (tables []migration.CleanTable, added []string) {
+	entities := listEntities()
+	relations := map[string][]Relation{}
+	for _, name := range entities {
+		_, _, rels, err := readEntity(name)
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire l'entité %s: %v", name, err))
+			os.Exit(1)
+		}
+		relations[name] = rels
+	}
+
+	selected := map[string]bool{}
+	for _, name := range only {
+		selected[name] = true
+	}
+	if len(only) == 0 {
+		for _, name := range entities {
+			selected[name] = true
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, name := range entities {
+			if selected[name] {
+				continue
+			}
+			for _, r := range relations[name] {
+				if !isCollection(r) && r.MappedBy == "" && selected[r.Target] {
+					selected[name] = true
+					added = append(added, name)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	// Tables de jointure des collections: elles référencent les deux entités
+	for _, name := range entities {
+		for _, r := range relations[name] {
+			if isCollection(r) && r.MappedBy == "" && (selected[name] || selected[r.Target]) {
+				tables = append(tables, migration.CleanTable{Name: joinTable(d, name, r).Name})
+			}
+		}
+	}
+
+	var names []string
+	for name := range selected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	order, broken := entityDependencyOrder(names)
+	for ref := range broken {
+		utils.PrintWarning(fmt.Sprintf("Relation circulaire (%s): la suppression peut échouer, préférez --truncate", ref))
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		if !selected[order[i]] {
+			continue
+		}
+		mapping := readEntityMapping(order[i])
+		t := migration.CleanTable{Name: mapping.Table}
+		if mapping.Generated && !mapping.composite() {
+			t.Identity = keyColumnName(mapping)
+		}
+		tables = append(tables, t)
+	}
+	return tables, added
+}
+
+// jdbcConnection décrit la base désignée par une URL JDBC.
+type jdbcConnection struct {
+	Dialect  migration.Dialect
+	Host     string
+	Port     string
+	Database string
+}
+
+// parseJDBCURL lit une URL JDBC PostgreSQL, MySQL ou MariaDB
+// (jdbc:postgresql://localhost:5432/shop).
+func parseJDBCURL(jdbcURL string) (jdbcConnection, error) {
+	u, err := url.Parse(strings.TrimPrefix(jdbcURL, "jdbc:"))
+	if err != nil || !strings.HasPrefix(jdbcURL, "jdbc:") || u.Host == "" {
+		return jdbcConnection{}, fmt.Errorf("URL JDBC invalide: %s (attendu: jdbc:postgresql://hôte:port/base)", jdbcURL)
+	}
+	conn := jdbcConnection{Host: u.Hostname(), Port: u.Port(), Database: strings.TrimPrefix(u.Path, "/")}
+	switch u.Scheme {
+	case "postgresql":
+		conn.Dialect = migration.Postgres
+	case "mysql":
+		conn.Dialect = migration.MySQL
+	case "mariadb":
+		conn.Dialect = migration.MariaDB
+	default:
+		return jdbcConnection{}, fmt.Errorf("base %s non prise en charge pour l'exécution: utilisez --output pour obtenir le script", u.Scheme)
+	}
+	return conn, nil
+}
+
+// runSQLScript exécute le script avec le client en ligne de commande de la base. Le mot de
+// passe est transmis par l'environnement, pour ne pas apparaître dans la liste des processus.
+func runSQLScript(conn jdbcConnection, db config.Database, script string) error {
+	var tools, args []string
+	env := os.Environ()
+	switch conn.Dialect {
+	case migration.Postgres:
+		tools = []string{"psql"}
+		args = []string{"-h", conn.Host, "-d", conn.Database, "-v", "ON_ERROR_STOP=1", "-q"}
+		if conn.Port != "" {
+			args = append(args, "-p", conn.Port)
+		}
+		if db.Username != "" {
+			args = append(args, "-U", db.Username)
+		}
+		env = append(env, "PGPASSWORD="+db.Password)
+	default:
+		tools = []string{"mysql"}
+		if conn.Dialect == migration.MariaDB {
+			tools = []string{"mariadb", "mysql"}
+		}
+		args = []string{"-h", conn.Host}
+		if conn.Port != "" {
+			args = append(args, "-P", conn.Port)
+		}
+		if db.Username != "" {
+			args = append(args, "-u", db.Username)
+		}
+		args = append(args, conn.Database)
+		env = append(env, "MYSQL_PWD="+db.Password)
+	}
+
+	tool := ""
+	for _, name := range tools {
+		if path, err := exec.LookPath(name); err == nil {
+			tool = path
+			break
+		}
+	}
+	if tool == "" {
+		return fmt.Errorf("client %s introuvable: installez-le ou utilisez --output pour obtenir le script", tools[0])
+	}
+
+	var stderr bytes.Buffer
+	c := exec.Command(tool, args...)
+	c.Stdin = strings.NewReader(script)
+	c.Stdout = os.Stdout
+	c.Stderr = &stderr
+	c.Env = env
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			return fmt.Errorf("échec du script: %s", strings.TrimSpace(stderr.String()))
+		}
+		return fmt.Errorf("impossible d'exécuter %s: %v", filepath.Base(tool), err)
+	}
+	return nil
+}
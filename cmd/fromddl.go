@@ -0,0 +1,457 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"springcli/internal/migration"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	generateFromDdlCmd.Flags().StringSlice("tables", nil, "Tables à convertir (défaut: toutes les tables du script)")
+	generateFromDdlCmd.Flags().Bool("crud", false, "Génère aussi les DTO, le mapper, le repository, le service et le contrôleur de chaque entité")
+	generateFromDdlCmd.Flags().String("mapper", "", "Type de mapper avec --crud: mapstruct ou manual (défaut: mapstruct si présent dans le build)")
+	generateCmd.AddCommand(generateFromDdlCmd)
+}
+
+// ==================== GENERATE FROM-DDL ====================
+var generateFromDdlCmd = &cobra.Command{
+	Use:   "from-ddl [schema.sql]",
+	Short: "Génère les entités JPA d'un schéma SQL existant.",
+	Long: `Cette commande lit les instructions CREATE TABLE / ALTER TABLE d'un script SQL
+(PostgreSQL ou MySQL) et génère une entité par table: types Java déduits des types de
+colonnes, clés primaires simples ou composites (@EmbeddedId), clés étrangères en
+@ManyToOne avec la collection @OneToMany inverse (optional = false si la colonne est
+NOT NULL, @MapsId si elle fait partie de la clé composite), tables de jointure en
+@ManyToMany.
+Avec --crud, les DTO, le mapper, le repository, le service et le contrôleur sont aussi
+générés: comme avec 'springcli apply', l'API REST n'expose que les DTO, jamais les entités.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("🔁 GÉNÉRATION D'ENTITÉS DEPUIS UN SCHÉMA SQL")
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire %s: %v", args[0], err))
+			os.Exit(1)
+		}
+		schema := migration.NewSchema()
+		schema.ReplaySQL(string(data))
+		if len(schema.Tables()) == 0 {
+			utils.PrintError(fmt.Sprintf("Aucune instruction CREATE TABLE trouvée dans %s", args[0]))
+			os.Exit(1)
+		}
+
+		tableNames, _ := cmd.Flags().GetStringSlice("tables")
+		selected, err := selectTables(schema, tableNames)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		style := entityStyle(cmd)
+		crud, _ := cmd.Flags().GetBool("crud")
+		tests := crud && withTests(cmd)
+		entities := reverseEngineer(schema, selected)
+
+		var rows [][]string
+		var generated []*ddlEntity
+		for _, e := range entities {
+			if utils.Exists(getSourcePath() + "/entity/" + sourceFile(e.Name)) {
+				utils.PrintWarning(fmt.Sprintf("L'entité %s existe déjà, table %s ignorée", e.Name, e.Mapping.Table))
+				continue
+			}
+			utils.PrintInfo(fmt.Sprintf("Création de l'entité %s (table %s)", e.Name, e.Mapping.Table))
+			generateEntity(e.Name, e.Fields, e.Relations, style, e.Mapping)
+			if e.Mapping.composite() {
+				generateEntityKey(e.Name+"Id", e.Mapping.KeyFields)
+			}
+			rows = append(rows, []string{e.Mapping.Table, e.Name, ddlKeyDescription(e.Mapping)})
+			generated = append(generated, e)
+		}
+
+		// Les couches sont générées une fois toutes les entités écrites, dans l'ordre
+		// d'apply: les DTO et le mapper d'abord, pour que service et contrôleur les utilisent
+		if crud && len(generated) > 0 {
+			settings := modelSettings{Style: style, Mapper: resolveModelMapper(cmd), WithTests: tests}
+			if settings.Mapper == mapperMapStruct && !hasBuildDependency("org.mapstruct", "mapstruct") {
+				addMapStruct()
+			}
+			for _, e := range generated {
+				generateLayer(e.Name, "repository", settings)
+				if e.Mapping.KeyType != "Long" {
+					utils.PrintWarning(fmt.Sprintf("Clé %s de %s: DTO, mapper, service et contrôleur non générés (clé Long requise)", e.Mapping.KeyType, e.Name))
+					continue
+				}
+				for _, layer := range []string{"dto", "mapper", "service", "controller"} {
+					generateLayer(e.Name, layer, settings)
+				}
+			}
+		}
+
+		if len(rows) > 0 {
+			fmt.Println(formatQueryTable([]string{"Table", "Entité", "Clé"}, rows))
+		}
+	},
+}
+
+// ddlEntity est une entité déduite d'une table.
+type ddlEntity struct {
+	Name      string
+	Mapping   entityMapping
+	Fields    []Field
+	Relations []Relation
+}
+
+// selectTables vérifie les tables demandées avec --tables (toutes par défaut).
+func selectTables(schema *migration.Schema, names []string) (map[string]bool, error) {
+	selected := map[string]bool{}
+	if len(names) == 0 {
+		for _, t := range schema.Tables() {
+			selected[strings.ToLower(t.Name)] = true
+		}
+		return selected, nil
+	}
+	for _, name := range names {
+		if schema.Table(name) == nil {
+			var available []string
+			for _, t := range schema.Tables() {
+				available = append(available, t.Name)
+			}
+			return nil, fmt.Errorf("la table %s n'existe pas dans le script (tables: %s)", name, strings.Join(available, ", "))
+		}
+		selected[strings.ToLower(name)] = true
+	}
+	return selected, nil
+}
+
+// isJoinTable indique si une table est une table de jointure pure: sa clé primaire est
+// composée de deux clés étrangères et elle n'a pas d'autre colonne.
+func isJoinTable(t migration.Table) bool {
+	if len(t.PrimaryKey) != 2 || len(t.Columns) != 2 {
+		return false
+	}
+	for _, column := range t.PrimaryKey {
+		if _, ok := tableForeignKey(t, column); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func isKeyColumn(t migration.Table, column string) bool {
+	for _, key := range t.PrimaryKey {
+		if strings.EqualFold(key, column) {
+			return true
+		}
+	}
+	return false
+}
+
+func tableForeignKey(t migration.Table, column string) (migration.ForeignKey, bool) {
+	for _, fk := range t.ForeignKeys {
+		if strings.EqualFold(fk.Column, column) {
+			return fk, true
+		}
+	}
+	return migration.ForeignKey{}, false
+}
+
+// entityNameForTable déduit le nom d'entité d'une table (order_items -> OrderItem).
+func entityNameForTable(table string) string {
+	return capitalize(singularize(snakeToCamel(table)))
+}
+
+// reverseEngineer construit les entités des tables sélectionnées.
+func reverseEngineer(schema *migration.Schema, selected map[string]bool) []*ddlEntity {
+	// Entités connues: tables du script puis entités déjà présentes dans le projet
+	entityOf := map[string]string{}
+	for _, entity := range listEntities() {
+		entityOf[strings.ToLower(tableName(entity))] = entity
+	}
+	for _, t := range schema.Tables() {
+		if !isJoinTable(t) {
+			entityOf[strings.ToLower(t.Name)] = entityNameForTable(t.Name)
+		}
+	}
+
+	var entities []*ddlEntity
+	byName := map[string]*ddlEntity{}
+	for _, t := range schema.Tables() {
+		if !selected[strings.ToLower(t.Name)] || isJoinTable(t) {
+			continue
+		}
+		e, err := tableEntity(t, entityOf)
+		if err != nil {
+			utils.PrintWarning(err.Error())
+			continue
+		}
+		entities = append(entities, e)
+		byName[e.Name] = e
+	}
+
+	// Collections inverses des clés étrangères vers les entités générées
+	for _, e := range entities {
+		for _, r := range e.Relations {
+			target, ok := byName[r.Target]
+			if !ok || r.Type != "@ManyToOne" {
+				continue
+			}
+			name := pluralize(uncapitalize(e.Name))
+			if target.hasProperty(name) {
+				name += capitalize(r.Name)
+			}
+			target.Relations = append(target.Relations, Relation{Name: name, Type: "@OneToMany", Target: e.Name, MappedBy: r.Name})
+		}
+	}
+
+	// Tables de jointure: @ManyToMany portée par l'entité de la première clé étrangère
+	for _, t := range schema.Tables() {
+		if !isJoinTable(t) {
+			continue
+		}
+		ownerFK, _ := tableForeignKey(t, t.PrimaryKey[0])
+		targetFK, _ := tableForeignKey(t, t.PrimaryKey[1])
+		owner, ok := byName[entityOf[strings.ToLower(ownerFK.RefTable)]]
+		target, known := entityOf[strings.ToLower(targetFK.RefTable)]
+		if !ok || !known {
+			continue
+		}
+		r := Relation{Name: pluralize(uncapitalize(target)), Type: "@ManyToMany", Target: target}
+		if owner.hasProperty(r.Name) {
+			r.Name = snakeToCamel(t.Name)
+		}
+		// Le mapping n'est explicite que s'il s'écarte des conventions
+		ownerTable := owner.Mapping.Table
+		if !strings.EqualFold(t.Name, ownerTable+"_"+columnName(r.Name)) ||
+			!strings.EqualFold(ownerFK.Column, ownerTable+"_id") ||
+			!strings.EqualFold(targetFK.Column, columnName(r.Name)+"_id") {
+			r.JoinTable, r.JoinColumn, r.InverseJoinColumn = t.Name, ownerFK.Column, targetFK.Column
+		}
+		owner.Relations = append(owner.Relations, r)
+	}
+	return entities
+}
+
+// tableEntity convertit une table en entité.
+func tableEntity(t migration.Table, entityOf map[string]string) (*ddlEntity, error) {
+	e := &ddlEntity{Name: entityOf[strings.ToLower(t.Name)], Mapping: entityMapping{Table: t.Name}}
+
+	switch len(t.PrimaryKey) {
+	case 0:
+		return nil, fmt.Errorf("table %s ignorée: aucune clé primaire", t.Name)
+	case 1:
+		column, _ := t.Column(t.PrimaryKey[0])
+		javaType, _ := javaTypeForColumn(column.Type, true)
+		e.Mapping.KeyType = boxedType(javaType)
+		e.Mapping.Generated = column.AutoIncrement
+		if !strings.EqualFold(column.Name, "id") {
+			e.Mapping.KeyColumn = column.Name
+		}
+	default:
+		e.Mapping.KeyType = e.Name + "Id"
+		for _, name := range t.PrimaryKey {
+			column, _ := t.Column(name)
+			f := columnField(t.Name, column)
+			f.Type = boxedType(f.Type)
+			f.Column = column.Name
+			f.Constraints = nil
+			e.Mapping.KeyFields = append(e.Mapping.KeyFields, f)
+		}
+	}
+
+	for _, column := range t.Columns {
+		fk, isForeignKey := tableForeignKey(t, column.Name)
+		target, known := entityOf[strings.ToLower(fk.RefTable)]
+		if isKeyColumn(t, column.Name) {
+			// Une clé étrangère de la clé composite devient une relation @MapsId vers le
+			// champ de <Entité>Id qui porte la colonne
+			if isForeignKey && known && e.Mapping.composite() {
+				r := foreignKeyRelation(column, target)
+				r.MapsID = snakeToCamel(column.Name)
+				e.Relations = append(e.Relations, r)
+			}
+			continue
+		}
+		if !isForeignKey || !known {
+			if isForeignKey {
+				utils.PrintWarning(fmt.Sprintf("%s.%s: table %s inconnue, colonne générée comme simple champ", t.Name, column.Name, fk.RefTable))
+			}
+			e.Fields = append(e.Fields, columnField(t.Name, column))
+			continue
+		}
+		e.Relations = append(e.Relations, foreignKeyRelation(column, target))
+	}
+	return e, nil
+}
+
+// foreignKeyRelation convertit une colonne de clé étrangère en relation vers l'entité cible,
+// obligatoire si la colonne est NOT NULL.
+func foreignKeyRelation(column migration.Column, target string) Relation {
+	r := Relation{Name: snakeToCamel(strings.TrimSuffix(strings.ToLower(column.Name), "_id")), Type: "@ManyToOne", Target: target}
+	if column.Unique {
+		r.Type = "@OneToOne"
+	}
+	if !strings.EqualFold(column.Name, columnName(r.Name)+"_id") {
+		r.JoinColumn = column.Name
+	}
+	r.Required = !column.Nullable
+	return r
+}
+
+func (e *ddlEntity) hasProperty(name string) bool {
+	for _, f := range e.Fields {
+		if f.Name == name {
+			return true
+		}
+	}
+	for _, r := range e.Relations {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+var varcharLengthRegexp = regexp.MustCompile(`^VARCHAR\((\d+)\)$`)
+
+// columnField convertit une colonne en champ d'entité, avec ses contraintes.
+func columnField(table string, c migration.Column) Field {
+	javaType, ok := javaTypeForColumn(c.Type, !c.Nullable)
+	if !ok {
+		utils.PrintWarning(fmt.Sprintf("%s.%s: type %s non reconnu, String utilisé", table, c.Name, c.Type))
+	}
+	name := snakeToCamel(c.Name)
+	f := Field{Name: name, Type: javaType, JSONName: name}
+	if !strings.EqualFold(columnName(name), c.Name) {
+		f.Column = c.Name
+	}
+	if !c.Nullable && !isPrimitive(javaType) {
+		f.Constraints = append(f.Constraints, "required")
+	}
+	if c.Unique {
+		f.Constraints = append(f.Constraints, "unique")
+	}
+	if m := varcharLengthRegexp.FindStringSubmatch(migration.NormalizeType(c.Type)); m != nil && m[1] != "255" {
+		length, _ := strconv.Atoi(m[1])
+		f.Constraints = append(f.Constraints, fmt.Sprintf("max=%d", length))
+	}
+	return f
+}
+
+// javaTypeForColumn renvoie le type Java d'un type de colonne SQL: type primitif pour une
+// colonne NOT NULL, type objet sinon.
+func javaTypeForColumn(sqlType string, notNull bool) (string, bool) {
+	normalized := migration.NormalizeType(sqlType)
+	base, _, _ := strings.Cut(normalized, "(")
+	base = strings.TrimSpace(strings.TrimSuffix(base, " UNSIGNED"))
+
+	primitive := func(p, boxed string) (string, bool) {
+		if notNull {
+			return p, true
+		}
+		return boxed, true
+	}
+	switch base {
+	case "VARCHAR", "CHAR", "CHARACTER", "NCHAR", "TEXT", "TINYTEXT", "MEDIUMTEXT", "LONGTEXT", "CLOB", "CITEXT", "ENUM", "JSON", "JSONB":
+		return "String", true
+	case "INTEGER", "TINYINT", "MEDIUMINT":
+		return primitive("int", "Integer")
+	case "SMALLINT":
+		return primitive("short", "Short")
+	case "BIGINT":
+		return primitive("long", "Long")
+	case "BOOLEAN":
+		return primitive("boolean", "Boolean")
+	case "DOUBLE PRECISION":
+		return primitive("double", "Double")
+	case "REAL":
+		return primitive("float", "Float")
+	case "NUMERIC", "NUMBER", "MONEY":
+		return "BigDecimal", true
+	case "DATE":
+		return "LocalDate", true
+	case "TIME":
+		return "LocalTime", true
+	case "TIMESTAMP", "DATETIME":
+		return "LocalDateTime", true
+	case "TIMESTAMP WITH TIME ZONE":
+		return "Instant", true
+	case "UUID":
+		return "UUID", true
+	case "BINARY":
+		if normalized == "BINARY(16)" {
+			return "UUID", true
+		}
+	}
+	return "String", false
+}
+
+// ddlKeyDescription résume la clé primaire d'une entité pour le récapitulatif.
+func ddlKeyDescription(m entityMapping) string {
+	if !m.composite() {
+		return m.KeyType + " " + keyColumnName(m)
+	}
+	var columns []string
+	for _, f := range m.KeyFields {
+		columns = append(columns, f.Column)
+	}
+	return strings.Join(columns, ", ")
+}
+
+// ==================== CLÉ COMPOSITE ====================
+const entityKeyTemplate = `package {{.packageName}}.entity;
+{{range .imports}}
+import {{.}};
+{{- end}}
+
+@Embeddable
+public record {{.className}}(
+{{- range $i, $f := .fields}}{{if $i}},{{end}}
+        @Column(name = "{{$f.Column}}") {{$f.Type}} {{$f.Name}}
+{{- end}}
+) implements Serializable {
+}
+`
+
+const kotlinEntityKeyTemplate = `package {{.packageName}}.entity
+{{range .imports}}
+import {{.}}
+{{- end}}
+
+@Embeddable
+data class {{.className}}(
+{{- range .fields}}
+    @Column(name = "{{.Column}}")
+    val {{.Name}}: {{kotlinType .Type}},
+{{- end}}
+) : Serializable
+`
+
+// generateEntityKey génère la classe @Embeddable de la clé composite d'une entité.
+func generateEntityKey(className string, fields []Field) {
+	imports := []string{"jakarta.persistence.Column", "jakarta.persistence.Embeddable", "java.io.Serializable"}
+	for _, f := range fields {
+		if imp := typeImport(f.Type); imp != "" {
+			imports = append(imports, imp)
+		}
+	}
+	sort.Strings(imports)
+
+	params := map[string]interface{}{
+		"className":   className,
+		"fields":      fields,
+		"imports":     imports,
+		"packageName": basePackage(),
+	}
+	content := renderTemplate("entity-key", languageTemplate(entityKeyTemplate, kotlinEntityKeyTemplate), params)
+	writeNewFile(getSourcePath()+"/entity", sourceFile(className), content)
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"springcli/internal/migration"
+)
+
+const shopSchema = `
+CREATE TABLE customers (
+  id BIGSERIAL PRIMARY KEY,
+  name VARCHAR(100) NOT NULL
+);
+CREATE TABLE orders (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL REFERENCES customers(id),
+  referrer BIGINT REFERENCES customers(id)
+);
+CREATE TABLE products (
+  id BIGSERIAL PRIMARY KEY,
+  label VARCHAR(80)
+);
+CREATE TABLE order_items (
+  order_id BIGINT NOT NULL,
+  product_id BIGINT NOT NULL,
+  quantity INTEGER NOT NULL,
+  PRIMARY KEY (order_id, product_id),
+  CONSTRAINT fk_item_order FOREIGN KEY (order_id) REFERENCES orders(id),
+  CONSTRAINT fk_item_product FOREIGN KEY (product_id) REFERENCES products(id)
+);
+`
+
+// TestReverseEngineer vérifie les relations déduites des clés étrangères: obligatoires pour
+// une colonne NOT NULL, et @MapsId pour celles qui font partie d'une clé composite.
+func TestReverseEngineer(t *testing.T) {
+	dir := t.TempDir()
+	pom := "<project><groupId>com.example</groupId><artifactId>shop</artifactId></project>"
+	if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(pom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, dir)
+
+	schema := migration.NewSchema()
+	schema.ReplaySQL(shopSchema)
+	selected, err := selectTables(schema, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entities := map[string]*ddlEntity{}
+	for _, e := range reverseEngineer(schema, selected) {
+		entities[e.Name] = e
+	}
+
+	item, ok := entities["OrderItem"]
+	if !ok {
+		t.Fatalf("entité OrderItem absente: %v", entities)
+	}
+	var keyFields []string
+	for _, f := range item.Mapping.KeyFields {
+		keyFields = append(keyFields, f.Name+":"+f.Column)
+	}
+	if want := []string{"orderId:order_id", "productId:product_id"}; !reflect.DeepEqual(keyFields, want) {
+		t.Errorf("clé de OrderItem %v, attendu %v", keyFields, want)
+	}
+	if len(item.Fields) != 1 || item.Fields[0].Name != "quantity" {
+		t.Errorf("champs de OrderItem %+v, attendu quantity seul", item.Fields)
+	}
+
+	cases := []struct {
+		entity, relation string
+		want             Relation
+		annotations      []string
+	}{
+		{
+			entity: "OrderItem", relation: "order",
+			want:        Relation{Name: "order", Type: "@ManyToOne", Target: "Order", Required: true, MapsID: "orderId"},
+			annotations: []string{"@ManyToOne(optional = false)", `@MapsId("orderId")`},
+		},
+		{
+			entity: "OrderItem", relation: "product",
+			want:        Relation{Name: "product", Type: "@ManyToOne", Target: "Product", Required: true, MapsID: "productId"},
+			annotations: []string{"@ManyToOne(optional = false)", `@MapsId("productId")`},
+		},
+		{
+			entity: "Order", relation: "customer",
+			want:        Relation{Name: "customer", Type: "@ManyToOne", Target: "Customer", Required: true},
+			annotations: []string{"@ManyToOne(optional = false)"},
+		},
+		{
+			entity: "Order", relation: "referrer",
+			want:        Relation{Name: "referrer", Type: "@ManyToOne", Target: "Customer", JoinColumn: "referrer"},
+			annotations: []string{"@ManyToOne", `@JoinColumn(name = "referrer")`},
+		},
+		{
+			entity: "Order", relation: "orderItems",
+			want:        Relation{Name: "orderItems", Type: "@OneToMany", Target: "OrderItem", MappedBy: "order"},
+			annotations: []string{`@OneToMany(mappedBy = "order")`},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.entity+"."+c.relation, func(t *testing.T) {
+			e, ok := entities[c.entity]
+			if !ok {
+				t.Fatalf("entité %s absente", c.entity)
+			}
+			var got *Relation
+			for i := range e.Relations {
+				if e.Relations[i].Name == c.relation {
+					got = &e.Relations[i]
+				}
+			}
+			if got == nil {
+				t.Fatalf("relation %s absente de %s: %+v", c.relation, c.entity, e.Relations)
+			}
+			if !reflect.DeepEqual(*got, c.want) {
+				t.Errorf("relation %+v, attendu %+v", *got, c.want)
+			}
+			annotations := relationAnnotations(*got)
+			if !reflect.DeepEqual(annotations, c.annotations) {
+				t.Errorf("annotations %q, attendu %q", annotations, c.annotations)
+			}
+
+			// Le mapping est relu à l'identique depuis les annotations générées
+			reread := readRelationMapping(Relation{Name: got.Name, Type: got.Type, Target: got.Target},
+				strings.TrimPrefix(strings.Join(annotations, "\n"), got.Type))
+			if !reflect.DeepEqual(reread, *got) {
+				t.Errorf("relation relue %+v, attendu %+v", reread, *got)
+			}
+		})
+	}
+}
@@ -0,0 +1,956 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"springcli/internal/openapi"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	generateFromOpenAPICmd.Flags().StringSlice("entities", nil, "Schémas à générer aussi comme entités JPA (ex: Pet,Owner)")
+	generateFromOpenAPICmd.Flags().String("migration", "", "Outil de migration des entités: flyway, liquibase ou none (défaut: détecté dans le fichier de build)")
+	generateFromOpenAPICmd.Flags().String("dialect", "", "Base de données des migrations: postgres, mysql, mariadb ou h2 (défaut: détectée)")
+	generateCmd.AddCommand(generateFromOpenAPICmd)
+}
+
+// ==================== GENERATE FROM-OPENAPI ====================
+var generateFromOpenAPICmd = &cobra.Command{
+	Use:   "from-openapi [api.yaml]",
+	Short: "Génère les DTO et les contrôleurs d'une spécification OpenAPI 3.",
+	Long: `Cette commande lit une spécification OpenAPI 3.0 ou 3.1 (YAML ou JSON) et génère:
+  - un DTO par schéma objet, avec les contraintes Bean Validation déduites de required,
+    minLength/maxLength, minimum/maximum (@DecimalMin/@DecimalMax pour une borne décimale
+    ou exclusiveMinimum/exclusiveMaximum), pattern et format: email
+  - une énumération par schéma enum
+  - par tag, une interface <Tag>Api portant les @GetMapping/@PostMapping/... et un
+    contrôleur <Tag>Controller qui l'implémente, à compléter
+Avec --entities, les schémas choisis sont aussi générés comme entités JPA; leurs
+énumérations sont recopiées dans le package entity (@Enumerated(EnumType.STRING)).`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("🧩 GÉNÉRATION DEPUIS UNE SPÉCIFICATION OPENAPI")
+
+		doc, err := openapi.Load(args[0])
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire %s: %v", args[0], err))
+			os.Exit(1)
+		}
+
+		entityNames, _ := cmd.Flags().GetStringSlice("entities")
+		for _, name := range entityNames {
+			if s := doc.Resolve(doc.Components.Schemas.Get(name)); s == nil || !s.IsObject() {
+				utils.PrintError(fmt.Sprintf("Le schéma objet %s n'existe pas dans components.schemas", name))
+				os.Exit(1)
+			}
+		}
+
+		g := newAPIGenerator(doc)
+		groups := g.operations()
+		g.resolveModels()
+
+		style := resolveCodeStyle(cmd)
+		var rows [][]string
+		for _, m := range g.models {
+			utils.PrintInfo(fmt.Sprintf("Génération de %s", m.Name))
+			if m.isEnum() {
+				generateEnum("dto", m.Name, m.Enum)
+				rows = append(rows, []string{m.Name, "énumération", m.Source})
+			} else {
+				writeDto(m.Name, m.Fields, style, true)
+				rows = append(rows, []string{m.Name, "DTO", m.Source})
+			}
+		}
+		for _, group := range groups {
+			utils.PrintInfo(fmt.Sprintf("Génération de %sApi et %sController", group.Name, group.Name))
+			generateAPIInterface(group, g.basePath())
+			generateAPIController(group)
+			source := fmt.Sprintf("%d opération(s)", len(group.Operations))
+			rows = append(rows, []string{group.Name + "Api", "interface", source}, []string{group.Name + "Controller", "contrôleur", source})
+		}
+
+		if len(entityNames) > 0 {
+			rows = append(rows, g.generateEntities(cmd, entityNames, style)...)
+		}
+		fmt.Println(formatQueryTable([]string{"Classe", "Type", "Origine"}, rows))
+	},
+}
+
+// apiModel est une classe générée pour un schéma: DTO ou énumération.
+type apiModel struct {
+	Name   string
+	Source string
+	Enum   []string
+	Fields []Field
+	schema *openapi.Schema
+}
+
+func (m *apiModel) isEnum() bool {
+	return len(m.Enum) > 0
+}
+
+// apiOperation est une méthode d'une interface <Tag>Api.
+type apiOperation struct {
+	Name    string
+	Summary string
+	Mapping string
+	Status  string
+	// Declaration est la signature annotée de l'interface, Override celle du contrôleur
+	Declaration string
+	Override    string
+	types       []string
+}
+
+type apiGroup struct {
+	Name       string
+	Operations []*apiOperation
+}
+
+type apiGenerator struct {
+	doc    *openapi.Document
+	models []*apiModel
+	byName map[string]*apiModel
+	// components associe un schéma de components.schemas au modèle qui le représente
+	components map[string]*apiModel
+	// inline évite de générer deux fois un schéma anonyme hérité par allOf
+	inline map[*openapi.Schema]*apiModel
+}
+
+func newAPIGenerator(doc *openapi.Document) *apiGenerator {
+	g := &apiGenerator{doc: doc, byName: map[string]*apiModel{}, components: map[string]*apiModel{}, inline: map[*openapi.Schema]*apiModel{}}
+	for _, s := range doc.Components.Schemas {
+		if isEnumSchema(s.Schema) || s.Schema.IsObject() {
+			g.components[s.Name] = g.addModel(apiClassName(s.Name), s.Schema, "schéma "+s.Name)
+		}
+	}
+	return g
+}
+
+func isEnumSchema(s *openapi.Schema) bool {
+	return len(s.Enum) > 0 && (len(s.Type) == 0 || s.Is("string"))
+}
+
+// addModel enregistre un modèle à générer, sous un nom libre.
+func (g *apiGenerator) addModel(name string, s *openapi.Schema, source string) *apiModel {
+	unique := name
+	for i := 2; g.byName[unique] != nil; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	m := &apiModel{Name: unique, Source: source, schema: s}
+	if isEnumSchema(s) {
+		m.Enum = s.Enum
+	}
+	g.models = append(g.models, m)
+	g.byName[unique] = m
+	return m
+}
+
+// resolveModels calcule les champs des DTO; les objets et énumérations imbriqués
+// ajoutent de nouveaux modèles, traités à leur tour.
+func (g *apiGenerator) resolveModels() {
+	for i := 0; i < len(g.models); i++ {
+		m := g.models[i]
+		if m.isEnum() {
+			continue
+		}
+		properties, required := g.properties(m.schema)
+		for _, p := range properties {
+			m.Fields = append(m.Fields, g.field(m.Name, p, required[p.Name]))
+		}
+	}
+}
+
+// properties renvoie les propriétés d'un objet, y compris celles héritées par allOf.
+func (g *apiGenerator) properties(s *openapi.Schema) (openapi.Schemas, map[string]bool) {
+	var properties openapi.Schemas
+	required := map[string]bool{}
+	seen := map[string]bool{}
+	var collect func(s *openapi.Schema)
+	collect = func(s *openapi.Schema) {
+		s = g.doc.Resolve(s)
+		if s == nil {
+			return
+		}
+		for _, part := range s.AllOf {
+			collect(part)
+		}
+		for _, p := range s.Properties {
+			if !seen[p.Name] {
+				seen[p.Name] = true
+				properties = append(properties, p)
+			}
+		}
+		for _, name := range s.Required {
+			required[name] = true
+		}
+	}
+	collect(s)
+	return properties, required
+}
+
+// field convertit une propriété en champ de DTO avec ses contraintes.
+func (g *apiGenerator) field(owner string, p openapi.NamedSchema, required bool) Field {
+	name := apiIdentifier(p.Name)
+	f := Field{Name: name, Type: g.javaType(p.Schema, owner+capitalize(name)), JSONName: p.Name}
+
+	s := g.doc.Resolve(p.Schema)
+	if s == nil {
+		return f
+	}
+	if required && !s.ReadOnly {
+		f.Constraints = append(f.Constraints, "required")
+	}
+	if s.Format == "email" {
+		f.Constraints = append(f.Constraints, "email")
+	}
+	for _, c := range []struct {
+		name  string
+		value *int
+	}{{"min", s.MinLength}, {"max", s.MaxLength}, {"min", s.MinItems}, {"max", s.MaxItems}} {
+		if c.value != nil {
+			f.Constraints = append(f.Constraints, fmt.Sprintf("%s=%d", c.name, *c.value))
+		}
+	}
+	if value, exclusive := s.LowerBound(); value != nil {
+		f.Constraints = append(f.Constraints, boundConstraint("min", *value, exclusive))
+	}
+	if value, exclusive := s.UpperBound(); value != nil {
+		f.Constraints = append(f.Constraints, boundConstraint("max", *value, exclusive))
+	}
+	if s.Pattern != "" {
+		f.Constraints = append(f.Constraints, "pattern="+s.Pattern)
+	}
+	if g.hasModel(f.Type) {
+		f.Constraints = append(f.Constraints, "valid")
+	}
+	return f
+}
+
+// boundConstraint renvoie la contrainte d'une borne numérique: min/max (@Min, @Max) pour un
+// entier inclus, positive pour un minimum exclusif de 0, decimalmin/decimalmax
+// (@DecimalMin, @DecimalMax) pour un décimal et exclusivemin/exclusivemax pour une borne
+// exclue.
+func boundConstraint(name string, value float64, exclusive bool) string {
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+	switch {
+	case exclusive && name == "min" && value == 0:
+		return "positive"
+	case exclusive:
+		return "exclusive" + name + "=" + text
+	case value == float64(int64(value)):
+		return fmt.Sprintf("%s=%d", name, int64(value))
+	}
+	return "decimal" + name + "=" + text
+}
+
+// hasModel indique si un type (ou l'un de ses arguments) est un DTO généré.
+func (g *apiGenerator) hasModel(t string) bool {
+	for _, name := range regexp.MustCompile(`\w+`).FindAllString(t, -1) {
+		if m := g.byName[name]; m != nil && !m.isEnum() {
+			return true
+		}
+	}
+	return false
+}
+
+// javaType renvoie le type Java d'un schéma; un objet ou une énumération anonyme devient
+// un modèle nommé d'après son contexte (owner).
+func (g *apiGenerator) javaType(s *openapi.Schema, owner string) string {
+	if s == nil {
+		return "Object"
+	}
+	if s.Ref != "" {
+		name := openapi.RefName(s.Ref)
+		if m, ok := g.components[name]; ok {
+			return m.Name
+		}
+		target := g.doc.Resolve(s)
+		if target == nil {
+			utils.PrintWarning(fmt.Sprintf("Référence introuvable: %s (Object utilisé)", s.Ref))
+			return "Object"
+		}
+		// Alias d'un tableau ou d'un scalaire
+		return g.javaType(target, apiClassName(name))
+	}
+
+	if m, ok := g.inline[s]; ok {
+		return m.Name
+	}
+	switch {
+	case isEnumSchema(s):
+		g.inline[s] = g.addModel(owner, s, "schéma imbriqué")
+		return g.inline[s].Name
+	case s.Is("array"):
+		return "List<" + g.javaType(s.Items, singularize(owner)) + ">"
+	case s.AdditionalProperties != nil && len(s.Properties) == 0:
+		return "Map<String, " + g.javaType(s.AdditionalProperties, owner+"Value") + ">"
+	case s.IsObject():
+		if len(s.Properties) == 0 && len(s.AllOf) == 0 {
+			return "Object"
+		}
+		g.inline[s] = g.addModel(owner, s, "schéma imbriqué")
+		return g.inline[s].Name
+	case s.Is("string"):
+		switch s.Format {
+		case "date":
+			return "LocalDate"
+		case "date-time":
+			return "OffsetDateTime"
+		case "time":
+			return "LocalTime"
+		case "uuid":
+			return "UUID"
+		case "binary":
+			return "byte[]"
+		}
+		return "String"
+	case s.Is("integer"):
+		if s.Format == "int64" {
+			return "Long"
+		}
+		return "Integer"
+	case s.Is("number"):
+		switch s.Format {
+		case "float":
+			return "Float"
+		case "double":
+			return "Double"
+		}
+		return "BigDecimal"
+	case s.Is("boolean"):
+		return "Boolean"
+	}
+	return "Object"
+}
+
+// ==================== OPÉRATIONS ====================
+
+// operations regroupe les opérations par tag (à défaut, par premier segment du chemin).
+func (g *apiGenerator) operations() []*apiGroup {
+	var groups []*apiGroup
+	byName := map[string]*apiGroup{}
+	methodNames := map[string]map[string]bool{}
+
+	for _, path := range g.doc.Paths {
+		for _, mo := range path.Item.Operations() {
+			groupName := g.groupName(path.Path, mo.Operation)
+			group := byName[groupName]
+			if group == nil {
+				group = &apiGroup{Name: groupName}
+				groups = append(groups, group)
+				byName[groupName] = group
+				methodNames[groupName] = map[string]bool{}
+			}
+
+			name := operationName(mo.Method, path.Path, mo.Operation)
+			unique := name
+			for i := 2; methodNames[groupName][unique]; i++ {
+				unique = name + strconv.Itoa(i)
+			}
+			methodNames[groupName][unique] = true
+
+			group.Operations = append(group.Operations, g.operation(unique, mo.Method, path, mo.Operation))
+		}
+	}
+	return groups
+}
+
+func (g *apiGenerator) groupName(path string, op *openapi.Operation) string {
+	if len(op.Tags) > 0 {
+		return apiClassName(op.Tags[0])
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(path, g.basePath()), "/") {
+		if segment != "" && !strings.HasPrefix(segment, "{") {
+			return apiClassName(segment)
+		}
+	}
+	return "Default"
+}
+
+// basePath renvoie le chemin du premier serveur déclaré (https://api.example.com/v1 -> /v1).
+func (g *apiGenerator) basePath() string {
+	if len(g.doc.Servers) == 0 {
+		return ""
+	}
+	u, err := url.Parse(g.doc.Servers[0].URL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(u.Path, "/")
+}
+
+// operationName renvoie le nom de méthode d'une opération: son operationId ou, à défaut,
+// la méthode HTTP suivie du chemin (GET /pets/{id} -> getPetsById).
+func operationName(method, path string, op *openapi.Operation) string {
+	if op.OperationID != "" {
+		return apiIdentifier(op.OperationID)
+	}
+	name := strings.ToLower(method)
+	for _, segment := range strings.Split(path, "/") {
+		if param, ok := strings.CutPrefix(segment, "{"); ok {
+			name += "By" + capitalize(apiIdentifier(strings.TrimSuffix(param, "}")))
+		} else if segment != "" {
+			name += capitalize(apiIdentifier(segment))
+		}
+	}
+	return name
+}
+
+// apiParameter est un paramètre de méthode: annotation Spring, type et nom.
+type apiParameter struct {
+	Annotation string
+	Type       string
+	Name       string
+	Required   bool
+}
+
+func (g *apiGenerator) operation(name, method string, path openapi.NamedPath, op *openapi.Operation) *apiOperation {
+	o := &apiOperation{Name: name, Summary: op.Summary}
+
+	// Paramètres du chemin, redéfinis le cas échéant par ceux de l'opération
+	var parameters []*openapi.Parameter
+	index := map[string]int{}
+	for _, p := range append(append([]*openapi.Parameter{}, path.Item.Parameters...), op.Parameters...) {
+		p = g.doc.Parameter(p)
+		key := p.In + ":" + p.Name
+		if i, ok := index[key]; ok {
+			parameters[i] = p
+			continue
+		}
+		index[key] = len(parameters)
+		parameters = append(parameters, p)
+	}
+
+	var params []apiParameter
+	for _, p := range parameters {
+		param := apiParameter{
+			Type:     g.javaType(p.Schema, capitalize(name)+capitalize(apiIdentifier(p.Name))),
+			Name:     apiIdentifier(p.Name),
+			Required: p.Required || p.In == "path",
+		}
+		var annotation string
+		switch p.In {
+		case "path":
+			annotation = "PathVariable"
+		case "query":
+			annotation = "RequestParam"
+		case "header":
+			annotation = "RequestHeader"
+		case "cookie":
+			annotation = "CookieValue"
+		default:
+			continue
+		}
+		if param.Required {
+			param.Annotation = fmt.Sprintf(`@%s("%s")`, annotation, p.Name)
+		} else {
+			param.Annotation = fmt.Sprintf(`@%s(name = "%s", required = false)`, annotation, p.Name)
+		}
+		switch param.Type {
+		case "LocalDate":
+			param.Annotation += " @DateTimeFormat(iso = DateTimeFormat.ISO.DATE)"
+		case "OffsetDateTime":
+			param.Annotation += " @DateTimeFormat(iso = DateTimeFormat.ISO.DATE_TIME)"
+		}
+		params = append(params, param)
+	}
+
+	var consumes, produces string
+	if body := g.doc.RequestBody(op.RequestBody); body != nil {
+		schema, contentType := body.Content.Schema()
+		param := apiParameter{Type: g.javaType(schema, capitalize(name)+"Request"), Name: "body", Required: body.Required}
+		if _, ok := g.byName[param.Type]; ok && !hasParameter(params, uncapitalize(param.Type)) {
+			param.Name = uncapitalize(param.Type)
+		}
+		param.Annotation = "@RequestBody"
+		if !body.Required {
+			param.Annotation = "@RequestBody(required = false)"
+		}
+		if g.hasModel(param.Type) {
+			param.Annotation = "@Valid " + param.Annotation
+		}
+		if !strings.Contains(contentType, "json") {
+			consumes = contentType
+		}
+		params = append(params, param)
+	}
+
+	returnType := "void"
+	if status, response := g.successResponse(op); response != nil {
+		schema, contentType := response.Content.Schema()
+		if schema != nil {
+			returnType = g.javaType(schema, capitalize(name)+"Response")
+			if !strings.Contains(contentType, "json") {
+				produces = contentType
+			}
+		}
+		o.Status = successStatusNames[status]
+	}
+
+	// Annotation de mapping: @GetMapping("/pets") ou avec consumes/produces
+	mapping := "@" + capitalize(strings.ToLower(method)) + "Mapping"
+	if consumes == "" && produces == "" {
+		o.Mapping = fmt.Sprintf(`%s("%s")`, mapping, strings.TrimPrefix(path.Path, g.basePath()))
+	} else {
+		args := []string{fmt.Sprintf(`value = "%s"`, strings.TrimPrefix(path.Path, g.basePath()))}
+		if consumes != "" {
+			args = append(args, fmt.Sprintf(`consumes = "%s"`, consumes))
+		}
+		if produces != "" {
+			args = append(args, fmt.Sprintf(`produces = "%s"`, produces))
+		}
+		o.Mapping = mapping + "(" + strings.Join(args, ", ") + ")"
+	}
+
+	// Signatures Java ou Kotlin
+	var declared, plain []string
+	o.types = append(o.types, returnType)
+	for _, p := range params {
+		o.types = append(o.types, p.Type)
+		if isKotlin() {
+			t := kotlinType(p.Type)
+			if !p.Required {
+				t += "?"
+			}
+			declared = append(declared, fmt.Sprintf("%s %s: %s", p.Annotation, p.Name, t))
+			plain = append(plain, fmt.Sprintf("%s: %s", p.Name, t))
+		} else {
+			declared = append(declared, fmt.Sprintf("%s %s %s", p.Annotation, p.Type, p.Name))
+			plain = append(plain, fmt.Sprintf("%s %s", p.Type, p.Name))
+		}
+	}
+	if isKotlin() {
+		returns := ""
+		if returnType != "void" {
+			returns = ": " + kotlinType(returnType)
+		}
+		o.Declaration = fmt.Sprintf("fun %s(%s)%s", name, strings.Join(declared, ", "), returns)
+		o.Override = fmt.Sprintf("override fun %s(%s)%s", name, strings.Join(plain, ", "), returns)
+	} else {
+		o.Declaration = fmt.Sprintf("%s %s(%s)", returnType, name, strings.Join(declared, ", "))
+		o.Override = fmt.Sprintf("public %s %s(%s)", returnType, name, strings.Join(plain, ", "))
+	}
+	return o
+}
+
+func hasParameter(params []apiParameter, name string) bool {
+	for _, p := range params {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+var successStatusNames = map[string]string{
+	"201": "CREATED",
+	"202": "ACCEPTED",
+	"204": "NO_CONTENT",
+	"206": "PARTIAL_CONTENT",
+}
+
+// successResponse renvoie la première réponse 2xx d'une opération, ou à défaut la réponse default.
+func (g *apiGenerator) successResponse(op *openapi.Operation) (string, *openapi.Response) {
+	for _, r := range op.Responses {
+		if strings.HasPrefix(r.Status, "2") {
+			return r.Status, g.doc.Response(r.Response)
+		}
+	}
+	for _, r := range op.Responses {
+		if r.Status == "default" {
+			return "200", g.doc.Response(r.Response)
+		}
+	}
+	return "200", nil
+}
+
+// ==================== NOMMAGE ====================
+
+var reservedIdentifiers = map[string]bool{
+	"abstract": true, "as": true, "assert": true, "boolean": true, "break": true, "byte": true, "case": true,
+	"catch": true, "char": true, "class": true, "const": true, "continue": true, "default": true, "do": true,
+	"double": true, "else": true, "enum": true, "extends": true, "false": true, "final": true, "finally": true,
+	"float": true, "for": true, "fun": true, "goto": true, "if": true, "implements": true, "import": true,
+	"in": true, "instanceof": true, "int": true, "interface": true, "is": true, "long": true, "native": true,
+	"new": true, "null": true, "object": true, "package": true, "private": true, "protected": true,
+	"public": true, "return": true, "short": true, "static": true, "super": true, "switch": true,
+	"synchronized": true, "this": true, "throw": true, "throws": true, "true": true, "try": true,
+	"typealias": true, "val": true, "var": true, "void": true, "when": true, "while": true,
+}
+
+var nonIdentifierRegexp = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// apiIdentifier convertit un nom OpenAPI en identifiant Java/Kotlin (born-after -> bornAfter).
+func apiIdentifier(name string) string {
+	id := snakeToCamel(nonIdentifierRegexp.ReplaceAllString(name, "_"))
+	if id == "" || (id[0] >= '0' && id[0] <= '9') {
+		id = "_" + id
+	}
+	if reservedIdentifiers[id] {
+		id += "Value"
+	}
+	return id
+}
+
+// apiClassName convertit un nom OpenAPI en nom de classe (pet-store -> PetStore).
+func apiClassName(name string) string {
+	return capitalize(apiIdentifier(name))
+}
+
+// enumConstant convertit une valeur d'énumération en constante (in-progress -> IN_PROGRESS).
+func enumConstant(value string) string {
+	name := strings.Trim(nonIdentifierRegexp.ReplaceAllString(value, "_"), "_")
+	if strings.ToUpper(name) != name {
+		name = splitCamelCase(name, "_")
+	}
+	name = strings.ToUpper(name)
+	if name == "" {
+		return "EMPTY"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// ==================== ÉNUMÉRATIONS ====================
+const enumTemplate = `package {{.packageName}}.{{.subpackage}};
+{{- if .annotated}}
+
+import com.fasterxml.jackson.annotation.JsonProperty;
+{{- end}}
+
+public enum {{.className}} {
+{{- range $i, $c := .constants}}{{if $i}},{{end}}
+{{- if ne $c.Name $c.Value}}
+    @JsonProperty("{{$c.Value}}")
+{{- end}}
+    {{$c.Name}}
+{{- end}}
+}
+`
+
+const kotlinEnumTemplate = `package {{.packageName}}.{{.subpackage}}
+{{- if .annotated}}
+
+import com.fasterxml.jackson.annotation.JsonProperty
+{{- end}}
+
+enum class {{.className}} {
+{{- range $i, $c := .constants}}{{if $i}},{{end}}
+{{- if ne $c.Name $c.Value}}
+    @JsonProperty("{{$c.Value}}")
+{{- end}}
+    {{$c.Name}}
+{{- end}}
+}
+`
+
+type enumValue struct {
+	Name  string
+	Value string
+}
+
+// generateEnum génère, dans le sous-package donné (dto, entity), une énumération dont les
+// constantes sont sérialisées avec leur valeur d'origine.
+func generateEnum(subpackage, className string, values []string) {
+	writeNewFile(getSourcePath()+"/"+subpackage, sourceFile(className), renderEnum(subpackage, className, values))
+}
+
+func renderEnum(subpackage, className string, values []string) []byte {
+	var constants []enumValue
+	annotated := false
+	seen := map[string]bool{}
+	for _, value := range values {
+		name := enumConstant(value)
+		for i := 2; seen[name]; i++ {
+			name = enumConstant(value) + "_" + strconv.Itoa(i)
+		}
+		seen[name] = true
+		constants = append(constants, enumValue{name, value})
+		annotated = annotated || name != value
+	}
+
+	params := map[string]interface{}{
+		"className":   className,
+		"constants":   constants,
+		"annotated":   annotated,
+		"subpackage":  subpackage,
+		"packageName": basePackage(),
+	}
+	return renderTemplate("enum", languageTemplate(enumTemplate, kotlinEnumTemplate), params)
+}
+
+// ==================== INTERFACES ET CONTRÔLEURS ====================
+const apiInterfaceTemplate = `package {{.packageName}}.controller;
+{{range .imports}}
+import {{.}};
+{{- end}}
+{{if .basePath}}
+@RequestMapping("{{.basePath}}")
+{{- end}}
+public interface {{.interfaceName}} {
+{{- range $i, $op := .operations}}
+{{- if $i}}
+{{end}}
+{{- if $op.Summary}}
+    // {{$op.Summary}}
+{{- end}}
+    {{$op.Mapping}}
+{{- if $op.Status}}
+    @ResponseStatus(HttpStatus.{{$op.Status}})
+{{- end}}
+    {{$op.Declaration}};
+{{- end}}
+}
+`
+
+const kotlinAPIInterfaceTemplate = `package {{.packageName}}.controller
+{{range .imports}}
+import {{.}}
+{{- end}}
+{{if .basePath}}
+@RequestMapping("{{.basePath}}")
+{{- end}}
+interface {{.interfaceName}} {
+{{- range $i, $op := .operations}}
+{{- if $i}}
+{{end}}
+{{- if $op.Summary}}
+    // {{$op.Summary}}
+{{- end}}
+    {{$op.Mapping}}
+{{- if $op.Status}}
+    @ResponseStatus(HttpStatus.{{$op.Status}})
+{{- end}}
+    {{$op.Declaration}}
+{{- end}}
+}
+`
+
+const apiControllerTemplate = `package {{.packageName}}.controller;
+{{range .imports}}
+import {{.}};
+{{- end}}
+
+@RestController
+public class {{.controllerName}} implements {{.interfaceName}} {
+{{- range $i, $op := .operations}}
+{{- if $i}}
+{{end}}
+    @Override
+    {{$op.Override}} {
+        // TODO: implémenter {{$op.Name}}
+        throw new ResponseStatusException(HttpStatus.NOT_IMPLEMENTED);
+    }
+{{- end}}
+}
+`
+
+const kotlinAPIControllerTemplate = `package {{.packageName}}.controller
+{{range .imports}}
+import {{.}}
+{{- end}}
+
+@RestController
+class {{.controllerName}} : {{.interfaceName}} {
+{{- range $i, $op := .operations}}
+{{- if $i}}
+{{end}}
+    {{$op.Override}} {
+        // TODO: implémenter {{$op.Name}}
+        throw ResponseStatusException(HttpStatus.NOT_IMPLEMENTED)
+    }
+{{- end}}
+}
+`
+
+// generateAPIInterface génère l'interface <Tag>Api portant les annotations de mapping.
+func generateAPIInterface(group *apiGroup, basePath string) {
+	imports := map[string]bool{}
+	if basePath != "" {
+		imports["org.springframework.web.bind.annotation.RequestMapping"] = true
+	}
+	annotationRegexp := regexp.MustCompile(`@(\w+)`)
+	for _, op := range group.Operations {
+		for _, m := range annotationRegexp.FindAllStringSubmatch(op.Mapping+" "+op.Declaration, -1) {
+			switch m[1] {
+			case "Valid":
+				imports["jakarta.validation.Valid"] = true
+			case "DateTimeFormat":
+				imports["org.springframework.format.annotation.DateTimeFormat"] = true
+			default:
+				imports["org.springframework.web.bind.annotation."+m[1]] = true
+			}
+		}
+		if op.Status != "" {
+			imports["org.springframework.http.HttpStatus"] = true
+			imports["org.springframework.web.bind.annotation.ResponseStatus"] = true
+		}
+		for _, imp := range apiTypeImports(op.types) {
+			imports[imp] = true
+		}
+	}
+
+	params := map[string]interface{}{
+		"interfaceName": group.Name + "Api",
+		"basePath":      basePath,
+		"operations":    group.Operations,
+		"imports":       sortedKeys(imports),
+		"packageName":   basePackage(),
+	}
+	content := renderTemplate("api", languageTemplate(apiInterfaceTemplate, kotlinAPIInterfaceTemplate), params)
+	writeNewFile(getSourcePath()+"/controller", sourceFile(group.Name+"Api"), content)
+}
+
+// generateAPIController génère le contrôleur <Tag>Controller implémentant l'interface.
+func generateAPIController(group *apiGroup) {
+	imports := map[string]bool{
+		"org.springframework.http.HttpStatus":                    true,
+		"org.springframework.web.bind.annotation.RestController": true,
+		"org.springframework.web.server.ResponseStatusException": true,
+	}
+	for _, op := range group.Operations {
+		for _, imp := range apiTypeImports(op.types) {
+			imports[imp] = true
+		}
+	}
+
+	params := map[string]interface{}{
+		"controllerName": group.Name + "Controller",
+		"interfaceName":  group.Name + "Api",
+		"operations":     group.Operations,
+		"imports":        sortedKeys(imports),
+		"packageName":    basePackage(),
+	}
+	content := renderTemplate("api-controller", languageTemplate(apiControllerTemplate, kotlinAPIControllerTemplate), params)
+	writeNewFile(getSourcePath()+"/controller", sourceFile(group.Name+"Controller"), content)
+}
+
+// apiTypeImports renvoie les imports des types d'une signature: DTO générés, collections
+// et types java.time/java.math/java.util.
+func apiTypeImports(types []string) []string {
+	var imports []string
+	dtoPackage := basePackage() + ".dto."
+	for _, t := range types {
+		imports = append(imports, fieldTypeImports(t)...)
+		for _, name := range regexp.MustCompile(`\w+`).FindAllString(t, -1) {
+			if name != "" && name[0] >= 'A' && name[0] <= 'Z' && !isJavaLangType(name) && typeImport(name) == "" {
+				imports = append(imports, dtoPackage+name)
+			}
+		}
+	}
+	return imports
+}
+
+func isJavaLangType(name string) bool {
+	switch name {
+	case "String", "Integer", "Long", "Short", "Float", "Double", "Boolean", "Object", "List", "Set", "Map":
+		return true
+	}
+	return false
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ==================== ENTITÉS ====================
+
+// generateEntities génère les entités JPA des schémas choisis: les propriétés scalaires
+// deviennent des champs, les références à un autre schéma choisi des relations.
+func (g *apiGenerator) generateEntities(cmd *cobra.Command, names []string, style string) [][]string {
+	if style == styleRecord {
+		style = styleGenerated
+		if hasLombok() && !isKotlin() {
+			style = styleLombok
+		}
+	}
+
+	selected := map[string]string{}
+	for _, name := range names {
+		selected[g.components[name].Name] = name
+	}
+
+	var rows [][]string
+	generatedEnums := map[string]bool{}
+	for _, name := range names {
+		m := g.components[name]
+		var fields []Field
+		var relations []Relation
+		var enums []*apiModel
+		for _, f := range m.Fields {
+			if f.Name == "id" {
+				continue
+			}
+			target := strings.TrimSuffix(strings.TrimPrefix(f.Type, "List<"), ">")
+			_, isEntity := selected[target]
+			switch {
+			case isEntity && target == f.Type:
+				relations = append(relations, Relation{Name: f.Name, Type: "@ManyToOne", Target: target})
+			case isEntity:
+				relations = append(relations, Relation{Name: f.Name, Type: "@OneToMany", Target: target})
+			case g.byName[f.Type] != nil && g.byName[f.Type].isEnum():
+				// L'énumération est recopiée dans le package entity et stockée par son nom
+				// (@Enumerated(EnumType.STRING))
+				enums = append(enums, g.byName[f.Type])
+				fields = append(fields, withoutConstraint(f, "valid"))
+			case g.hasModel(f.Type) || isCollectionType(f.Type) || f.Type == "Object" || f.Type == "byte[]":
+				utils.PrintWarning(fmt.Sprintf("%s.%s: type %s non mappé dans l'entité", m.Name, f.Name, f.Type))
+			default:
+				if f.Type == "OffsetDateTime" {
+					f.Type = "Instant"
+				}
+				f.JSONName = f.Name
+				fields = append(fields, withoutConstraint(f, "valid"))
+			}
+		}
+
+		if utils.Exists(getSourcePath() + "/entity/" + sourceFile(m.Name)) {
+			utils.PrintWarning(fmt.Sprintf("L'entité %s existe déjà", m.Name))
+			continue
+		}
+		for _, e := range enums {
+			if generatedEnums[e.Name] {
+				continue
+			}
+			generatedEnums[e.Name] = true
+			if utils.Exists(getSourcePath() + "/entity/" + sourceFile(e.Name)) {
+				continue
+			}
+			utils.PrintInfo(fmt.Sprintf("Création de l'énumération %s", e.Name))
+			generateEnum("entity", e.Name, e.Enum)
+			rows = append(rows, []string{e.Name, "énumération", "entité " + m.Name})
+		}
+		utils.PrintInfo(fmt.Sprintf("Création de l'entité %s", m.Name))
+		generateEntity(m.Name, fields, relations, style, defaultEntityMapping(m.Name))
+		generateCreateMigration(resolveMigration(cmd), m.Name, fields, relations)
+		rows = append(rows, []string{m.Name, "entité", "schéma " + name})
+	}
+	return rows
+}
+
+func withoutConstraint(f Field, name string) Field {
+	var constraints []string
+	for _, c := range f.Constraints {
+		if c != name {
+			constraints = append(constraints, c)
+		}
+	}
+	f.Constraints = constraints
+	return f
+}
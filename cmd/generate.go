@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -26,15 +27,27 @@ type TemplateData struct {
 }
 
 type Field struct {
-	Name     string
-	Type     string
-	JSONName string
+	Name        string
+	Type        string
+	JSONName    string
+	Constraints []string
+	// Column est le nom de colonne explicite, vide s'il suit la convention (first_name)
+	Column string
 }
 
 type Relation struct {
 	Name   string
 	Type   string
 	Target string
+	// Mapping explicite, pour les entités issues d'un schéma existant
+	MappedBy          string
+	JoinColumn        string
+	JoinTable         string
+	InverseJoinColumn string
+	// Required marque une relation simple obligatoire (clé étrangère NOT NULL)
+	Required bool
+	// MapsID est le champ de la clé composite porté par la relation (@MapsId)
+	MapsID string
 }
 
 type Project struct {
@@ -47,11 +60,16 @@ type Project struct {
 
 // ===================== INIT ==================================
 func init() {
+	generateControllerCmd.Flags().StringSlice("filterable", nil, "Champs filtrables dans la liste (génère une Specification JPA)")
+	generateServiceCmd.Flags().StringSlice("filterable", nil, "Champs filtrables dans la liste (génère une Specification JPA)")
+
 	generateCmd.AddCommand(generateControllerCmd)
 	generateCmd.AddCommand(generateServiceCmd)
 	generateCmd.AddCommand(generateRepositoryCmd)
 	generateCmd.AddCommand(generateEntityCmd)
 	generateCmd.AddCommand(generateJwtCmd)
+
+	generateCmd.PersistentFlags().String("style", "", "Style de code: generated, lombok ou record (défaut: lombok si présent dans le pom.xml)")
 }
 
 // ===================== GENERATE ==============================
@@ -77,36 +95,121 @@ structurés et conformes aux bonnes pratiques du projet.`,
 		}
 		controllerName := args[0]
 
+		if filterable, _ := cmd.Flags().GetStringSlice("filterable"); len(filterable) > 0 {
+			if err := checkServiceFilters(controllerName); err != nil {
+				utils.PrintError(err.Error())
+				os.Exit(1)
+			}
+			generateSpecification(controllerName, filterable)
+		}
+
 		utils.PrintInfo(fmt.Sprintf("Génération du contrôleur: %s", controllerName))
 		generateController(controllerName)
+		if withTests(cmd) {
+			generateControllerTest(controllerName)
+		}
 	},
 }
 
 const controllerTemplate = `package {{.packageName}}.controller;
-	
-import {{.packageName}}.service.{{.serviceName}};
-import {{.packageName}}.repository.{{.repositoryName}};
+
+{{- if .useDto}}
+
+import {{.packageName}}.dto.{{.createType}};
+import {{.packageName}}.dto.{{.responseType}};
+import {{.packageName}}.dto.{{.updateType}};
+{{- else}}
+
 import {{.packageName}}.entity.{{.entityName}};
+{{- end}}
+import {{.packageName}}.service.{{.serviceName}};
+import jakarta.validation.Valid;
+{{- if .filterable}}
+import java.util.Map;
+{{- end}}
+import java.util.Set;
 import org.springframework.beans.factory.annotation.Autowired;
+import org.springframework.data.domain.Pageable;
+import org.springframework.data.domain.Sort;
+import org.springframework.data.web.PageableDefault;
+import org.springframework.data.web.PagedModel;
+import org.springframework.http.HttpStatus;
+import org.springframework.web.bind.annotation.DeleteMapping;
 import org.springframework.web.bind.annotation.GetMapping;
+import org.springframework.web.bind.annotation.PathVariable;
+import org.springframework.web.bind.annotation.PostMapping;
+import org.springframework.web.bind.annotation.PutMapping;
+import org.springframework.web.bind.annotation.RequestBody;
+import org.springframework.web.bind.annotation.RequestMapping;
+{{- if .filterable}}
+import org.springframework.web.bind.annotation.RequestParam;
+{{- end}}
+import org.springframework.web.bind.annotation.ResponseStatus;
 import org.springframework.web.bind.annotation.RestController;
+import org.springframework.web.server.ResponseStatusException;
 
 @RestController
+@RequestMapping("{{.resourcePath}}")
 public class {{.controllerName}} {
+    // Propriétés autorisées dans le paramètre sort
+    private static final Set<String> SORTABLE_FIELDS = Set.of({{range $i, $f := .sortableFields}}{{if $i}}, {{end}}"{{$f}}"{{end}});
+
     @Autowired
-    private {{.serviceName}} {{.serviceName}}Service;
-}`
+    private {{.serviceName}} {{.serviceVar}};
+
+    @GetMapping
+    public PagedModel<{{.responseType}}> findAll(
+{{- if .filterable}}
+            @RequestParam Map<String, String> filters,
+{{- end}}
+            @PageableDefault(size = 20, sort = "id") Pageable pageable) {
+        checkSort(pageable.getSort());
+        return new PagedModel<>({{.serviceVar}}.findAll({{if .filterable}}filters, {{end}}pageable));
+    }
+
+    @GetMapping("/{id}")
+    public {{.responseType}} findById(@PathVariable Long id) {
+        return {{.serviceVar}}.findById(id);
+    }
+
+    @PostMapping
+    @ResponseStatus(HttpStatus.CREATED)
+    public {{.responseType}} create(@Valid @RequestBody {{.createType}} request) {
+        return {{.serviceVar}}.create(request);
+    }
+
+    @PutMapping("/{id}")
+    public {{.responseType}} update(@PathVariable Long id, @Valid @RequestBody {{.updateType}} request) {
+        return {{.serviceVar}}.update(id, request);
+    }
+
+    @DeleteMapping("/{id}")
+    @ResponseStatus(HttpStatus.NO_CONTENT)
+    public void delete(@PathVariable Long id) {
+        {{.serviceVar}}.delete(id);
+    }
+
+    private void checkSort(Sort sort) {
+        for (Sort.Order order : sort) {
+            if (!SORTABLE_FIELDS.contains(order.getProperty())) {
+                throw new ResponseStatusException(HttpStatus.BAD_REQUEST, "Tri non autorisé: " + order.getProperty());
+            }
+        }
+    }
+}
+`
 
 func generateController(controllerName string) {
-	params := map[string]string{
-		"controllerName": controllerName + "Controller",
-		"serviceName":    controllerName + "Service",
-		"repositoryName": controllerName + "Repository",
-		"entityName":     controllerName,
-		"packageName":    strings.ReplaceAll(getJavaSourcePath()[len("src/main/java/"):], "/", "."),
+	params := crudParams(controllerName)
+	params["controllerName"] = controllerName + "Controller"
+	if params["filterable"] == true {
+		if err := checkServiceFilters(controllerName); err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
 	}
 
-	tmpl, err := template.New("controller").Parse(controllerTemplate)
+	tmpl, err := template.New("controller").Funcs(templateFuncs).Parse(languageTemplate(controllerTemplate, kotlinControllerTemplate))
 	if err != nil {
 		utils.PrintError(fmt.Sprintf("Erreur lors du parsing du template: %v", err))
 		os.Exit(1)
@@ -118,8 +221,8 @@ func generateController(controllerName string) {
 		os.Exit(1)
 	}
 
-	path := getJavaSourcePath() + "/controller"
-	filename := controllerName + "Controller.java"
+	path := getSourcePath() + "/controller"
+	filename := sourceFile(controllerName + "Controller")
 	fullPath := path + "/" + filename
 
 	// Crée le dossier s'il n'existe pas
@@ -156,59 +259,357 @@ structurés et conformes aux bonnes pratiques du projet.`,
 		}
 		serviceName := args[0]
 
+		if filterable, _ := cmd.Flags().GetStringSlice("filterable"); len(filterable) > 0 {
+			generateSpecification(serviceName, filterable)
+		}
+
 		utils.PrintInfo(fmt.Sprintf("Génération du service: %s", serviceName))
 		generateService(serviceName)
+		if withTests(cmd) {
+			generateServiceTest(serviceName)
+		}
 	},
 }
 
 const serviceTemplate = `package {{.packageName}}.service;
-
-import {{.packageName}}.repository.{{.repositoryName}};
+{{if .useDto}}
+import {{.packageName}}.dto.{{.createType}};
+import {{.packageName}}.dto.{{.responseType}};
+import {{.packageName}}.dto.{{.updateType}};
+{{- else}}
 import {{.packageName}}.entity.{{.entityName}};
+{{- end}}
+{{- if .filterable}}
+import java.util.Map;
+{{- end}}
+import org.springframework.data.domain.Page;
+import org.springframework.data.domain.Pageable;
 
 public interface {{.serviceName}} {
-}`
+    Page<{{.responseType}}> findAll({{if .filterable}}Map<String, String> filters, {{end}}Pageable pageable);
+
+    {{.responseType}} findById(Long id);
+
+    {{.responseType}} create({{.createType}} request);
+
+    {{.responseType}} update(Long id, {{.updateType}} request);
+
+    void delete(Long id);
+}
+`
+
+const serviceImplTemplate = `package {{.packageName}}.service.impl;
+{{if .useDto}}
+import {{.packageName}}.dto.{{.createType}};
+import {{.packageName}}.dto.{{.responseType}};
+import {{.packageName}}.dto.{{.updateType}};
+{{- end}}
+{{- range .serviceEntities}}
+import {{$.packageName}}.entity.{{.}};
+{{- end}}
+{{- if .useDto}}
+import {{.packageName}}.mapper.{{.mapperName}};
+{{- end}}
+{{- range .serviceRepositories}}
+import {{$.packageName}}.repository.{{.}};
+{{- end}}
+import {{.packageName}}.service.{{.serviceName}};
+{{- if .filterable}}
+import {{.packageName}}.specification.{{.specificationName}};
+{{- end}}
+import jakarta.persistence.EntityNotFoundException;
+{{- range .serviceJavaImports}}
+import {{.}};
+{{- end}}
+import org.springframework.data.domain.Page;
+import org.springframework.data.domain.Pageable;
+import org.springframework.stereotype.Service;
+import org.springframework.transaction.annotation.Transactional;
+
+@Service
+@Transactional
+public class {{.serviceName}}Impl implements {{.serviceName}} {
+    private final {{.repositoryName}} {{.repositoryVar}};
+{{- range .relationRepositories}}{{if .Injected}}
+    private final {{.RepositoryName}} {{.RepositoryVar}};
+{{- end}}{{end}}
+{{- if .useDto}}
+    private final {{.mapperName}} {{.mapperVar}};
+{{- end}}
+
+    public {{.serviceName}}Impl({{.repositoryName}} {{.repositoryVar}}
+{{- range .relationRepositories}}{{if .Injected}}, {{.RepositoryName}} {{.RepositoryVar}}{{end}}{{end}}
+{{- if .useDto}}, {{.mapperName}} {{.mapperVar}}{{end}}) {
+        this.{{.repositoryVar}} = {{.repositoryVar}};
+{{- range .relationRepositories}}{{if .Injected}}
+        this.{{.RepositoryVar}} = {{.RepositoryVar}};
+{{- end}}{{end}}
+{{- if .useDto}}
+        this.{{.mapperVar}} = {{.mapperVar}};
+{{- end}}
+    }
+
+    @Override
+    @Transactional(readOnly = true)
+    public Page<{{.responseType}}> findAll({{if .filterable}}Map<String, String> filters, {{end}}Pageable pageable) {
+{{- if .filterable}}
+        return {{.repositoryVar}}.findAll({{.specificationName}}.fromParams(filters), pageable)
+{{- else}}
+        return {{.repositoryVar}}.findAll(pageable)
+{{- end}}
+{{- if .useDto}}
+                .map({{.mapperVar}}::toResponse);
+{{- else}};{{end}}
+    }
+
+    @Override
+    @Transactional(readOnly = true)
+    public {{.responseType}} findById(Long id) {
+{{- if .useDto}}
+        return {{.mapperVar}}.toResponse(getEntity(id));
+{{- else}}
+        return getEntity(id);
+{{- end}}
+    }
+
+    @Override
+    public {{.responseType}} create({{.createType}} request) {
+{{- if .useDto}}
+        {{.entityName}} {{.entityVar}} = {{.mapperVar}}.toEntity(request);
+{{- range .relations}}{{if .CreateRead}}
+        {{$.entityVar}}.set{{capitalize .Name}}(find{{.Target}}({{.CreateRead}}));
+{{- end}}{{end}}
+        return {{.mapperVar}}.toResponse({{.repositoryVar}}.save({{.entityVar}}));
+{{- else}}
+        return {{.repositoryVar}}.save(request);
+{{- end}}
+    }
+
+    @Override
+    public {{.responseType}} update(Long id, {{.updateType}} request) {
+{{- if .useDto}}
+        {{.entityName}} {{.entityVar}} = getEntity(id);
+        {{.mapperVar}}.updateEntity(request, {{.entityVar}});
+{{- range .relations}}{{if .UpdateRead}}
+        {{$.entityVar}}.set{{capitalize .Name}}(find{{.Target}}({{.UpdateRead}}));
+{{- end}}{{end}}
+        return {{.mapperVar}}.toResponse({{.repositoryVar}}.save({{.entityVar}}));
+{{- else}}
+        getEntity(id);
+        request.setId(id);
+        return {{.repositoryVar}}.save(request);
+{{- end}}
+    }
+
+    @Override
+    public void delete(Long id) {
+        {{.repositoryVar}}.delete(getEntity(id));
+    }
+
+    private {{.entityName}} getEntity(Long id) {
+        return {{.repositoryVar}}.findById(id)
+                .orElseThrow(() -> new EntityNotFoundException("{{.entityName}} " + id + " introuvable"));
+    }
+{{- range .relationRepositories}}
+
+    private {{.Target}} find{{.Target}}({{.KeyType}} id) {
+        if (id == null) {
+            return null;
+        }
+        return {{.RepositoryVar}}.findById(id)
+                .orElseThrow(() -> new EntityNotFoundException("{{.Target}} " + id + " introuvable"));
+    }
+{{- end}}
+}
+`
 
 func generateService(serviceName string) {
-	params := map[string]string{
-		"serviceName":    serviceName + "Service",
-		"repositoryName": serviceName + "Repository",
-		"entityName":     serviceName,
-		"packageName":    strings.ReplaceAll(getJavaSourcePath()[len("src/main/java/"):], "/", "."),
+	params := crudParams(serviceName)
+
+	buf := renderTemplate("service", languageTemplate(serviceTemplate, kotlinServiceTemplate), params)
+	writeNewFile(getSourcePath()+"/service", sourceFile(serviceName+"Service"), buf)
+
+	// En Java, le service est une interface accompagnée de son implémentation
+	if !isKotlin() {
+		impl := renderTemplate("serviceImpl", serviceImplTemplate, params)
+		writeNewFile(getSourcePath()+"/service/impl", serviceName+"ServiceImpl.java", impl)
 	}
+}
 
-	tmpl, err := template.New("service").Parse(serviceTemplate)
-	if err != nil {
-		utils.PrintError(fmt.Sprintf("Erreur lors du parsing du template: %v", err))
-		os.Exit(1)
+// crudParams calcule les paramètres communs aux templates du contrôleur et du service.
+// Les DTO et le mapper sont utilisés dès qu'ils ont tous été générés pour l'entité,
+// sinon l'entité est exposée directement. De même, la liste accepte des filtres dès
+// que les Specifications de l'entité existent.
+func crudParams(entityName string) map[string]interface{} {
+	useDto := len(readEntityDtos(entityName)) == 3 &&
+		utils.Exists(getSourcePath()+"/mapper/"+sourceFile(entityName+"Mapper"))
+
+	// Les propriétés triables sont l'identifiant et les champs simples de l'entité
+	sortableFields := []string{"id"}
+	if _, fields, _, err := readEntity(entityName); err == nil {
+		for _, f := range fields {
+			sortableFields = append(sortableFields, f.Name)
+		}
 	}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, params); err != nil {
-		utils.PrintError(fmt.Sprintf("Erreur lors de l'exécution du template: %v", err))
-		os.Exit(1)
+	params := map[string]interface{}{
+		"entityName":     entityName,
+		"entityVar":      uncapitalize(entityName),
+		"serviceName":    entityName + "Service",
+		"serviceVar":     uncapitalize(entityName) + "Service",
+		"repositoryName": entityName + "Repository",
+		"repositoryVar":  uncapitalize(entityName) + "Repository",
+		"mapperName":     entityName + "Mapper",
+		"mapperVar":      uncapitalize(entityName) + "Mapper",
+		"resourcePath":   "/api/" + pluralize(splitCamelCase(entityName, "-")),
+		"useDto":         useDto,
+		"sortableFields": sortableFields,
+		"filterable":     utils.Exists(specificationPath(entityName)),
+
+		"specificationName": entityName + "Specifications",
+		"createType":        entityName,
+		"updateType":        entityName,
+		"responseType":      entityName,
+		"packageName":       basePackage(),
+	}
+	if useDto {
+		params["createType"] = dtoClassName(entityName, dtoCreate)
+		params["updateType"] = dtoClassName(entityName, dtoUpdate)
+		params["responseType"] = dtoClassName(entityName, dtoResponse)
+	}
+	relations, repositories := requestRelations(entityName, useDto)
+	params["relations"] = relations
+	params["relationRepositories"] = repositories
+
+	// Imports du service: l'entité et son repository, puis ceux des entités liées
+	entities := []string{entityName}
+	repositoryNames := []string{entityName + "Repository"}
+	for _, r := range repositories {
+		if r.Injected {
+			entities = append(entities, r.Target)
+			repositoryNames = append(repositoryNames, r.RepositoryName)
+		}
 	}
+	sort.Strings(entities)
+	sort.Strings(repositoryNames)
+	params["serviceEntities"] = entities
+	params["serviceRepositories"] = repositoryNames
+
+	// Imports java.* du service: types des clés des entités liées (UUID...) et, en Java,
+	// la Map des filtres
+	keyImports := map[string]bool{}
+	for _, r := range repositories {
+		if imp := typeImport(r.KeyType); imp != "" {
+			keyImports[imp] = true
+		}
+	}
+	params["keyImports"] = sortedKeys(keyImports)
+	if params["filterable"] == true {
+		keyImports["java.util.Map"] = true
+	}
+	params["serviceJavaImports"] = sortedKeys(keyImports)
+	return params
+}
 
-	path := getJavaSourcePath() + "/service"
-	filename := serviceName + "Service.java"
-	fullPath := path + "/" + filename
+// requestRelation est une relation dont un DTO de requête porte l'identifiant (customerId):
+// le service charge l'entité liée et la renvoie en 404 si elle n'existe pas.
+type requestRelation struct {
+	Name   string
+	Target string
+	// CreateRead et UpdateRead lisent l'identifiant dans chaque DTO, vides s'il n'y figure pas
+	CreateRead string
+	UpdateRead string
+}
 
-	// Crée le dossier s'il n'existe pas
-	if !utils.Exists(path) {
-		err := utils.CreateFolder(path)
-		if err != nil {
-			utils.PrintError(fmt.Sprintf("Erreur lors de la création du dossier: %v", err))
-			os.Exit(1)
+// relationRepository est le repository d'une entité liée, injecté dans le service sauf
+// pour une relation de l'entité vers elle-même.
+type relationRepository struct {
+	Target         string
+	RepositoryName string
+	RepositoryVar  string
+	// KeyType est le type de la clé de l'entité liée
+	KeyType  string
+	Injected bool
+	// Create et Update indiquent si create et update chargent des entités de ce repository
+	Create bool
+	Update bool
+}
+
+// requestRelations renvoie les relations simples dont les DTO de requête portent
+// l'identifiant, et les repositories qui permettent de les charger.
+func requestRelations(entityName string, useDto bool) ([]requestRelation, []relationRepository) {
+	if !useDto {
+		return nil, nil
+	}
+	_, _, relations, err := readEntity(entityName)
+	if err != nil {
+		return nil, nil
+	}
+	dtos := map[string]dtoSource{}
+	for _, dto := range readEntityDtos(entityName) {
+		dtos[dto.Kind] = dto
+	}
+	read := func(kind, field string) string {
+		dto := dtos[kind]
+		for _, f := range dto.Fields {
+			switch {
+			case f.Name != field:
+				continue
+			case isKotlin():
+				return "request." + field
+			case dto.Record:
+				return "request." + field + "()"
+			default:
+				return "request." + getterName(f.Name, f.Type) + "()"
+			}
 		}
+		return ""
 	}
 
-	// Vérifie si le fichier existe déjà
-	if utils.Exists(fullPath) {
-		utils.PrintWarning(fmt.Sprintf("Le fichier %s existe déjà", filename))
-		return
+	var result []requestRelation
+	var repositories []relationRepository
+	seen := map[string]int{}
+	for _, r := range relations {
+		if isCollection(r) || r.MappedBy != "" {
+			continue
+		}
+		rel := requestRelation{
+			Name:       r.Name,
+			Target:     r.Target,
+			CreateRead: read(dtoCreate, r.Name+"Id"),
+			UpdateRead: read(dtoUpdate, r.Name+"Id"),
+		}
+		if rel.CreateRead == "" && rel.UpdateRead == "" {
+			continue
+		}
+		result = append(result, rel)
+		if _, ok := seen[r.Target]; !ok {
+			seen[r.Target] = len(repositories)
+			repositories = append(repositories, relationRepository{
+				Target:         r.Target,
+				RepositoryName: r.Target + "Repository",
+				RepositoryVar:  uncapitalize(r.Target) + "Repository",
+				KeyType:        readEntityMapping(r.Target).KeyType,
+				Injected:       r.Target != entityName,
+			})
+		}
+		repository := &repositories[seen[r.Target]]
+		repository.Create = repository.Create || rel.CreateRead != ""
+		repository.Update = repository.Update || rel.UpdateRead != ""
 	}
+	return result, repositories
+}
 
-	generateFile(path, filename, buf.Bytes())
+// pluralize renvoie le pluriel anglais (simplifié) d'un nom de ressource.
+func pluralize(name string) string {
+	switch {
+	case strings.HasSuffix(name, "y") && !strings.HasSuffix(name, "ay") && !strings.HasSuffix(name, "ey") && !strings.HasSuffix(name, "oy"):
+		return strings.TrimSuffix(name, "y") + "ies"
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
 }
 
 // ==================== GENERATE REPOSITORY ====================
@@ -229,27 +630,40 @@ structurés et conformes aux bonnes pratiques du projet.`,
 
 		utils.PrintInfo(fmt.Sprintf("Génération du repository: %s", repositoryName))
 		generateRepository(repositoryName)
+		if withTests(cmd) {
+			generateRepositoryTest(repositoryName)
+		}
 	},
 }
 
 const repositoryTemplate = `package {{.packageName}}.repository;
 
 import {{.packageName}}.entity.{{.entityName}};
+{{- if .keyImport}}
+import {{.keyImport}};
+{{- end}}
 import org.springframework.data.jpa.repository.JpaRepository;
 import org.springframework.stereotype.Repository;
 
 @Repository
-public interface {{.repositoryName}} extends JpaRepository<{{.entityName}}, Long> {
+public interface {{.repositoryName}} extends JpaRepository<{{.entityName}}, {{.keyType}}> {
 }`
 
 func generateRepository(repositoryName string) {
+	mapping := readEntityMapping(repositoryName)
+	keyImport := typeImport(mapping.KeyType)
+	if mapping.composite() {
+		keyImport = basePackage() + ".entity." + mapping.KeyType
+	}
 	params := map[string]string{
 		"repositoryName": repositoryName + "Repository",
 		"entityName":     repositoryName,
-		"packageName":    strings.ReplaceAll(getJavaSourcePath()[len("src/main/java/"):], "/", "."),
+		"keyType":        mapping.KeyType,
+		"keyImport":      keyImport,
+		"packageName":    basePackage(),
 	}
 
-	tmpl, err := template.New("repository").Parse(repositoryTemplate)
+	tmpl, err := template.New("repository").Funcs(templateFuncs).Parse(languageTemplate(repositoryTemplate, kotlinRepositoryTemplate))
 	if err != nil {
 		utils.PrintError(fmt.Sprintf("Erreur lors du parsing du template: %v", err))
 		os.Exit(1)
@@ -261,8 +675,8 @@ func generateRepository(repositoryName string) {
 		os.Exit(1)
 	}
 
-	path := getJavaSourcePath() + "/repository"
-	filename := repositoryName + "Repository.java"
+	path := getSourcePath() + "/repository"
+	filename := sourceFile(repositoryName + "Repository")
 	fullPath := path + "/" + filename
 
 	// Crée le dossier s'il n'existe pas
@@ -300,19 +714,27 @@ Elle facilite la création rapide d'entités structurées et conformes aux bonne
 		entityName := args[0]
 		var fields []Field
 		var relations []Relation
-		path := getJavaSourcePath() + "/entity"
-		filename := entityName + ".java"
+		path := getSourcePath() + "/entity"
+		filename := sourceFile(entityName)
 		fullPath := path + "/" + filename
 
 		if utils.Exists(fullPath) {
 			utils.PrintInfo(fmt.Sprintf("L'entité %s existe déjà", entityName))
 			utils.PrintSubtitle("Que voulez-vous ajouter à cette entité ?")
+			style := ""
+			if cmd.Flags().Changed("style") {
+				style = entityStyle(cmd)
+			}
+			_, existingFields, existingRelations, _ := readEntity(entityName)
 			fields, relations = askFieldsAndRelations()
-			updateEntity(entityName, fields, relations)
+			updateEntity(entityName, fields, relations, style)
+			generateAlterMigration(resolveMigration(cmd), entityName,
+				addedFields(existingFields, fields), addedRelations(existingRelations, relations))
 			return
 		}
 
 		utils.PrintInfo(fmt.Sprintf("Création de l'entité: %s", entityName))
+		style := entityStyle(cmd)
 
 		if len(args) == 1 {
 			fields, relations = askFieldsAndRelations()
@@ -321,44 +743,165 @@ Elle facilite la création rapide d'entités structurées et conformes aux bonne
 			relations = parseRelations(args[1:])
 		}
 
-		generateEntity(entityName, fields, relations)
+		generateEntity(entityName, fields, relations, style, defaultEntityMapping(entityName))
+		generateCreateMigration(resolveMigration(cmd), entityName, fields, relations)
+		if withTests(cmd) {
+			generateTestDataBuilder(entityName, false)
+		}
 	},
 }
 
 const entityTemplate = `package {{.packageName}}.entity;
-
-import jakarta.persistence.Entity;
-import jakarta.persistence.GeneratedValue;
-import jakarta.persistence.GenerationType;
-import jakarta.persistence.Id;
-import jakarta.persistence.Table;
+{{range .imports}}
+import {{.}};
+{{- end}}
 
 @Entity
 @Table(name = "{{.tableName}}")
+{{- if .lombok}}
+@Getter
+@Setter
+@NoArgsConstructor
+@AllArgsConstructor
+@Builder
+@ToString(onlyExplicitlyIncluded = true)
+{{- end}}
 public class {{.entityName}} {
-    @Id
-    @GeneratedValue(strategy = GenerationType.IDENTITY)
-    private Long id;
-		
-		{{range .fields}}
-		private {{.Type}} {{.Name}};
-		{{end}}
-		{{range .relations}}
-		{{.Type}}
-		private {{.Target}} {{.Name}};
-		{{end}}
-}`
+{{- range .keyAnnotations}}
+    {{.}}
+{{- end}}
+{{- if .lombok}}
+    @ToString.Include
+{{- end}}
+    private {{.keyType}} id;
+{{range .fields}}
+{{- range entityFieldAnnotations .}}
+    {{.}}
+{{- end}}
+{{- if $.lombok}}
+    @ToString.Include
+{{- end}}
+    private {{.Type}} {{.Name}};
+{{end}}
+{{- range .relations}}
+{{- range relationAnnotations .}}
+    {{.}}
+{{- end}}
+{{- if isCollection .}}{{if $.lombok}}
+    @Builder.Default{{end}}
+    private List<{{.Target}}> {{.Name}} = new ArrayList<>();
+{{- else}}
+    private {{.Target}} {{.Name}};
+{{- end}}
+{{end}}
+{{- if not .lombok}}
+    public {{.entityName}}() {
+    }
+{{- if .fields}}
+
+    public {{.entityName}}({{parameters .fields}}) {
+{{- range .fields}}
+        this.{{.Name}} = {{.Name}};
+{{- end}}
+    }
+{{- end}}
+
+    public {{.keyType}} getId() {
+        return id;
+    }
+
+    public void setId({{.keyType}} id) {
+        this.id = id;
+    }
+{{range .fields}}
+    public {{.Type}} {{getter .Name .Type}}() {
+        return {{.Name}};
+    }
+
+    public void set{{capitalize .Name}}({{.Type}} {{.Name}}) {
+        this.{{.Name}} = {{.Name}};
+    }
+{{end}}
+{{- range .relations}}
+    public {{relationType .}} get{{capitalize .Name}}() {
+        return {{.Name}};
+    }
+
+    public void set{{capitalize .Name}}({{relationType .}} {{.Name}}) {
+        this.{{.Name}} = {{.Name}};
+    }
+{{end}}
+{{- end}}
+    @Override
+    public boolean equals(Object o) {
+        if (this == o) {
+            return true;
+        }
+        if (!(o instanceof {{.entityName}} other)) {
+            return false;
+        }
+        return id != null && id.equals(other.id);
+    }
+
+    @Override
+    public int hashCode() {
+        // Constant pour rester stable avant et après la persistance
+        return getClass().hashCode();
+    }
+{{- if not .lombok}}
+
+    @Override
+    public String toString() {
+        return "{{.entityName}}{" +
+                "id=" + id +
+{{- range .fields}}
+                ", {{.Name}}=" + {{.Name}} +
+{{- end}}
+                "}";
+    }
+{{- end}}
+}
+`
+
+func generateEntity(entityName string, fields []Field, relations []Relation, style string, mapping entityMapping) {
+	buf := renderEntity(entityName, fields, relations, style, mapping)
 
-func generateEntity(entityName string, fields []Field, relations []Relation) {
+	path := getSourcePath() + "/entity"
+	filename := sourceFile(entityName)
+	fullPath := path + "/" + filename
+
+	// Crée le dossier s'il n'existe pas
+	if !utils.Exists(path) {
+		err := utils.CreateFolder(path)
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Erreur lors de la création du dossier: %v", err))
+			os.Exit(1)
+		}
+	}
+
+	// Vérifie si le fichier existe déjà
+	if utils.Exists(fullPath) {
+		utils.PrintWarning(fmt.Sprintf("Le fichier %s existe déjà", filename))
+		return
+	}
+
+	generateFile(path, filename, buf)
+}
+
+func renderEntity(entityName string, fields []Field, relations []Relation, style string, mapping entityMapping) []byte {
 	params := map[string]interface{}{
-		"entityName":  entityName,
-		"tableName":   strings.ToLower(entityName),
-		"fields":      fields,
-		"relations":   relations,
-		"packageName": strings.ReplaceAll(getJavaSourcePath()[len("src/main/java/"):], "/", "."),
+		"entityName":     entityName,
+		"tableName":      mapping.Table,
+		"keyType":        mapping.KeyType,
+		"keyAnnotations": mapping.keyAnnotations(),
+		"fields":         fields,
+		"relations":      relations,
+		"lombok":         style == styleLombok,
+		"imports":        entityImports(fields, relations, style, mapping),
+		"packageName":    basePackage(),
 	}
 
-	tmpl, err := template.New("entity").Parse(entityTemplate)
+	tmpl, err := template.New("entity").Funcs(templateFuncs).Parse(languageTemplate(entityTemplate, kotlinEntityTemplate))
 	if err != nil {
 		utils.PrintError(fmt.Sprintf("Erreur lors du parsing du template: %v", err))
 		os.Exit(1)
@@ -369,42 +912,75 @@ func generateEntity(entityName string, fields []Field, relations []Relation) {
 		utils.PrintError(fmt.Sprintf("Erreur lors de l'exécution du template: %v", err))
 		os.Exit(1)
 	}
+	return buf.Bytes()
+}
 
-	path := getJavaSourcePath() + "/entity"
-	filename := entityName + ".java"
-	fullPath := path + "/" + filename
-
-	// Crée le dossier s'il n'existe pas
-	if !utils.Exists(path) {
-		err := utils.CreateFolder(path)
-		if err != nil {
-			utils.PrintError(fmt.Sprintf("Erreur lors de la création du dossier: %v", err))
-			os.Exit(1)
+// entityImports calcule les imports nécessaires à une entité selon ses champs et relations.
+func entityImports(fields []Field, relations []Relation, style string, mapping entityMapping) []string {
+	imports := map[string]bool{
+		"jakarta.persistence.Entity": true,
+		"jakarta.persistence.Table":  true,
+	}
+	for _, imp := range mapping.keyImports() {
+		imports[imp] = true
+	}
+	if style == styleLombok {
+		for _, a := range []string{"Getter", "Setter", "NoArgsConstructor", "AllArgsConstructor", "Builder", "ToString"} {
+			imports["lombok."+a] = true
+		}
+	}
+	for _, f := range fields {
+		if imp := typeImport(f.Type); imp != "" {
+			imports[imp] = true
+		}
+		for _, imp := range annotationImports(entityFieldAnnotations(f)) {
+			imports[imp] = true
+		}
+	}
+	for _, r := range relations {
+		for _, imp := range relationImports(r) {
+			imports[imp] = true
+		}
+		if isCollection(r) && !isKotlin() {
+			imports["java.util.List"] = true
+			imports["java.util.ArrayList"] = true
 		}
 	}
 
-	// Vérifie si le fichier existe déjà
-	if utils.Exists(fullPath) {
-		utils.PrintWarning(fmt.Sprintf("Le fichier %s existe déjà", filename))
-		return
+	sorted := make([]string, 0, len(imports))
+	for imp := range imports {
+		sorted = append(sorted, imp)
 	}
+	sort.Strings(sorted)
+	return sorted
+}
 
-	generateFile(path, filename, buf.Bytes())
+// typeImport renvoie l'import Java requis par un type de champ, s'il y en a un.
+func typeImport(t string) string {
+	switch t {
+	case "LocalDate", "LocalDateTime", "LocalTime", "Instant", "OffsetDateTime":
+		return "java.time." + t
+	case "BigDecimal":
+		return "java.math.BigDecimal"
+	case "UUID":
+		return "java.util.UUID"
+	default:
+		return ""
+	}
 }
 
-func updateEntity(entityName string, fields []Field, relations []Relation) {
-	path := getJavaSourcePath() + "/entity"
-	filename := entityName + ".java"
+func updateEntity(entityName string, fields []Field, relations []Relation, style string) {
+	path := getSourcePath() + "/entity"
+	filename := sourceFile(entityName)
 	fullPath := path + "/" + filename
 
-	existingContent, err := os.ReadFile(fullPath)
-	var existingFields []Field
-	var existingRelations []Relation
+	existingContent, existingFields, existingRelations, err := readEntity(entityName)
 
 	if err == nil {
 		utils.PrintInfo(fmt.Sprintf("Mise à jour du fichier %s...", filename))
-		existingFields = extractFields(string(existingContent))
-		existingRelations = extractRelations(string(existingContent))
+		if style == "" {
+			style = detectEntityStyle(existingContent)
+		}
 	} else if !os.IsNotExist(err) {
 		utils.PrintError(fmt.Sprintf("Erreur lors de la lecture du fichier existant: %v", err))
 		os.Exit(1)
@@ -413,64 +989,90 @@ func updateEntity(entityName string, fields []Field, relations []Relation) {
 	mergedFields := mergeFields(existingFields, fields)
 	mergedRelations := mergeRelations(existingRelations, relations)
 
-	params := map[string]interface{}{
-		"entityName":  entityName,
-		"tableName":   strings.ToLower(entityName),
-		"fields":      mergedFields,
-		"relations":   mergedRelations,
-		"packageName": strings.ReplaceAll(getJavaSourcePath()[len("src/main/java/"):], "/", "."),
-	}
+	buf := renderEntity(entityName, mergedFields, mergedRelations, style, readEntityMapping(entityName))
 
-	tmpl, err := template.New("entity").Parse(entityTemplate)
+	err = os.WriteFile(fullPath, buf, 0o644)
 	if err != nil {
-		utils.PrintError(fmt.Sprintf("Erreur lors du parsing du template: %v", err))
+		utils.PrintError(fmt.Sprintf("Erreur lors de l'écriture du fichier: %v", err))
 		os.Exit(1)
 	}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, params); err != nil {
-		utils.PrintError(fmt.Sprintf("Erreur lors de l'exécution du template: %v", err))
-		os.Exit(1)
-	}
+	utils.PrintSuccess(fmt.Sprintf("Fichier %s mis à jour avec succès", filename))
+	refreshTestDataBuilder(entityName)
+}
 
-	err = os.WriteFile(fullPath, buf.Bytes(), 0o644)
+// listEntities renvoie le nom des entités JPA du package entity, triés.
+func listEntities() []string {
+	entries, err := os.ReadDir(getSourcePath() + "/entity")
 	if err != nil {
-		utils.PrintError(fmt.Sprintf("Erreur lors de l'écriture du fichier: %v", err))
-		os.Exit(1)
+		return nil
 	}
+	var entities []string
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), sourceFile(""))
+		if !ok || e.IsDir() {
+			continue
+		}
+		if data, err := os.ReadFile(getSourcePath() + "/entity/" + e.Name()); err == nil && strings.Contains(string(data), "@Entity") {
+			entities = append(entities, name)
+		}
+	}
+	sort.Strings(entities)
+	return entities
+}
 
-	utils.PrintSuccess(fmt.Sprintf("Fichier %s mis à jour avec succès", filename))
+// readEntity lit le code source d'une entité existante et en extrait les champs et relations.
+func readEntity(entityName string) (string, []Field, []Relation, error) {
+	data, err := os.ReadFile(getSourcePath() + "/entity/" + sourceFile(entityName))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	content := string(data)
+	if isKotlin() {
+		relations := extractKotlinRelations(content)
+		return content, extractKotlinFields(content, relations), relations, nil
+	}
+	relations := extractRelations(content)
+	return content, extractFields(content, relations), relations, nil
 }
 
-func extractFields(javaContent string) []Field {
-	fieldRegexp := regexp.MustCompile(`(?m)^\s*private\s+(\w+)\s+(\w+);`)
+// extractFields renvoie les champs simples de l'entité, en ignorant ceux déjà reconnus comme relations.
+// Les contraintes sont relues depuis les annotations qui précèdent chaque champ.
+func extractFields(javaContent string, relations []Relation) []Field {
+	fieldRegexp := regexp.MustCompile(`(?m)((?:^[ \t]*@[^\n]*\n)*)^[ \t]*private\s+(\w+)\s+(\w+);`)
 	matches := fieldRegexp.FindAllStringSubmatch(javaContent, -1)
+	isRelation := make(map[string]bool)
+	for _, r := range relations {
+		isRelation[r.Name] = true
+	}
 	var fields []Field
 	for _, m := range matches {
+		if isRelation[m[3]] || strings.ToLower(m[3]) == "id" {
+			continue
+		}
 		fields = append(fields, Field{
-			Type: m[1],
-			Name: m[2],
+			Type:        m[2],
+			Name:        m[3],
+			JSONName:    m[3],
+			Constraints: constraintsFromAnnotations(m[1]),
+			Column:      fieldColumnName(m[1]),
 		})
 	}
 	return fields
 }
 
 func mergeFields(existing, added []Field) []Field {
-	fieldMap := make(map[string]Field)
-	for _, f := range existing {
+	merged := make([]Field, 0, len(existing)+len(added))
+	index := make(map[string]int)
+	for _, f := range append(append([]Field{}, existing...), added...) {
 		if strings.ToLower(f.Name) == "id" {
 			continue
 		}
-		fieldMap[f.Name] = f
-	}
-	for _, f := range added {
-		if strings.ToLower(f.Name) == "id" {
+		if i, ok := index[f.Name]; ok {
+			merged[i] = f
 			continue
 		}
-		fieldMap[f.Name] = f
-	}
-	merged := make([]Field, 0, len(fieldMap))
-	for _, f := range fieldMap {
+		index[f.Name] = len(merged)
 		merged = append(merged, f)
 	}
 	return merged
@@ -520,10 +1122,26 @@ func askFieldsAndRelations() ([]Field, []Relation) {
 			break
 		}
 
+		var constraints string
+		for {
+			utils.PrintPrompt("Contraintes (ex: required,max=50, '?' pour la liste, vide pour aucune): ")
+			fmt.Scanln(&constraints)
+			if constraints == "?" {
+				utils.PrintSubtitle("Contraintes disponibles:")
+				for _, c := range constraintDescriptions {
+					fmt.Println(utils.ListItemStyle.Render(fmt.Sprintf("%-10s %s", c[0], c[1])))
+				}
+				constraints = ""
+				continue
+			}
+			break
+		}
+
 		fields = append(fields, Field{
-			Name:     name,
-			Type:     javaType(typ),
-			JSONName: name,
+			Name:        name,
+			Type:        javaType(typ),
+			JSONName:    name,
+			Constraints: parseConstraints(constraints),
 		})
 
 		utils.PrintSuccess(fmt.Sprintf("Champ ajouté: %s (%s)", name, javaType(typ)))
@@ -566,14 +1184,20 @@ func javaType(t string) string {
 func parseFields(fieldArgs []string) []Field {
 	fields := make([]Field, 0)
 	for _, arg := range fieldArgs {
-		parts := strings.SplitN(arg, ":", 2)
-		if len(parts) == 2 {
-			fields = append(fields, Field{
-				Name:     parts[0],
-				Type:     javaType(parts[1]),
-				JSONName: parts[0],
-			})
+		// Les arguments nom:Relation:Cible sont des relations, nom:type[:contraintes] des champs
+		parts := strings.SplitN(arg, ":", 3)
+		if len(parts) < 2 || isRelationType(parts[1]) {
+			continue
+		}
+		field := Field{
+			Name:     parts[0],
+			Type:     javaType(parts[1]),
+			JSONName: parts[0],
+		}
+		if len(parts) == 3 {
+			field.Constraints = parseConstraints(parts[2])
 		}
+		fields = append(fields, field)
 	}
 	return fields
 }
@@ -582,7 +1206,7 @@ func parseRelations(fieldArgs []string) []Relation {
 	relations := make([]Relation, 0)
 	for _, arg := range fieldArgs {
 		parts := strings.SplitN(arg, ":", 3)
-		if len(parts) == 3 {
+		if len(parts) == 3 && isRelationType(parts[1]) {
 			relations = append(relations, Relation{
 				Name:   parts[0],
 				Type:   relationsType(parts[1]),
@@ -663,6 +1287,15 @@ func allRelations() string {
 	return formatRelationsTable()
 }
 
+// isRelationType indique si le type saisi désigne une relation JPA.
+func isRelationType(typ string) bool {
+	switch strings.TrimPrefix(typ, "@") {
+	case "OneToOne", "OneToMany", "ManyToOne", "ManyToMany":
+		return true
+	}
+	return false
+}
+
 func relationsType(typ string) string {
 	switch typ {
 	case "OneToOne":
@@ -680,36 +1313,53 @@ func relationsType(typ string) string {
 
 func extractRelations(javaContent string) []Relation {
 	// regex pour trouver les relations de type @ManyToOne private User user;
-	relationRegexp := regexp.MustCompile(`@(\w+)\s+private\s+(\w+)\s+(\w+);`)
+	// ou @OneToMany private List<Order> orders = new ArrayList<>();
+	relationRegexp := regexp.MustCompile(`@(OneToOne|OneToMany|ManyToOne|ManyToMany)((?:` + annotationArgs + `)?\s+(?:@[\w.]+(?:` + annotationArgs + `)?\s+)*)private\s+(?:(?:List|Set)<(\w+)>|(\w+))\s+(\w+)`)
 	matches := relationRegexp.FindAllStringSubmatch(javaContent, -1)
 	var relations []Relation
 	for _, m := range matches {
-		relations = append(relations, Relation{
+		target := m[3]
+		if target == "" {
+			target = m[4]
+		}
+		relations = append(relations, readRelationMapping(Relation{
 			Type:   "@" + m[1],
-			Target: m[2],
-			Name:   m[3],
-		})
+			Target: target,
+			Name:   m[5],
+		}, m[2]))
 	}
 	return relations
 }
 
+// mergeRelations ajoute les relations aux relations existantes; une relation de même nom
+// remplace l'existante (changement de type ou de cible).
 func mergeRelations(existing, added []Relation) []Relation {
-	relationMap := make(map[string]Relation)
-	for _, r := range existing {
-		key := r.Name + "|" + r.Type + "|" + r.Target
-		relationMap[key] = r
-	}
-	for _, r := range added {
-		key := r.Name + "|" + r.Type + "|" + r.Target
-		relationMap[key] = r
-	}
-	merged := make([]Relation, 0, len(relationMap))
-	for _, r := range relationMap {
+	merged := make([]Relation, 0, len(existing)+len(added))
+	index := make(map[string]int)
+	for _, r := range append(append([]Relation{}, existing...), added...) {
+		if i, ok := index[r.Name]; ok {
+			merged[i] = r
+			continue
+		}
+		index[r.Name] = len(merged)
 		merged = append(merged, r)
 	}
 	return merged
 }
 
+// isCollection indique si la relation porte sur une collection d'entités.
+func isCollection(r Relation) bool {
+	return r.Type == "@OneToMany" || r.Type == "@ManyToMany"
+}
+
+// relationType renvoie le type Java du champ portant la relation.
+func relationType(r Relation) string {
+	if isCollection(r) {
+		return "List<" + r.Target + ">"
+	}
+	return r.Target
+}
+
 //====================== END ENTITY =========================================================
 
 // ====================== START JWT =========================================================
@@ -738,6 +1388,56 @@ var generateJwtCmd = &cobra.Command{
 // ===================== END JWT ===============================================================
 
 // =======================FUNCIONS UTILES =====================================================
+var templateFuncs = template.FuncMap{
+	"capitalize":   capitalize,
+	"uncapitalize": uncapitalize,
+	"getter":       getterName,
+	"parameters":   parameterList,
+	"isCollection": isCollection,
+	"relationType": relationType,
+	"annotations":  dtoAnnotations,
+
+	"entityFieldAnnotations": entityFieldAnnotations,
+	"relationAnnotations":    relationAnnotations,
+
+	"kotlinType":             kotlinType,
+	"kotlinProperty":         kotlinProperty,
+	"kotlinRelationProperty": kotlinRelationProperty,
+	"kotlinValueType":        kotlinValueType,
+	"kotlinNullableType":     kotlinNullableType,
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func uncapitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// getterName renvoie le nom de l'accesseur Java d'un champ (isXxx pour les booléens).
+func getterName(name, typ string) string {
+	if typ == "boolean" {
+		return "is" + capitalize(name)
+	}
+	return "get" + capitalize(name)
+}
+
+// parameterList renvoie la liste des paramètres d'un constructeur pour les champs donnés.
+func parameterList(fields []Field) string {
+	params := make([]string, 0, len(fields))
+	for _, f := range fields {
+		params = append(params, f.Type+" "+f.Name)
+	}
+	return strings.Join(params, ", ")
+}
+
 func generateFieldsTemplate(fields []Field) string {
 	var buffer bytes.Buffer
 	t := template.Must(template.New("fields").Parse("{{range .}}{{.Name}} {{.Type}};\n{{end}}"))
@@ -749,8 +1449,9 @@ func generateFieldsTemplate(fields []Field) string {
 	return buffer.String()
 }
 
-func getJavaSourcePath() string {
-	base := "src/main/java/" + getPackageName()
+// getSourcePath renvoie le dossier du package principal (ex: src/main/java/com/example/demo).
+func getSourcePath() string {
+	base := sourceRoot() + "/" + getPackageName()
 	entries, err := os.ReadDir(base)
 	if err != nil {
 		// fallback to base groupId path
@@ -765,10 +1466,21 @@ func getJavaSourcePath() string {
 	return base
 }
 
+// basePackage renvoie le package principal du projet (ex: com.example.demo).
+func basePackage() string {
+	return strings.ReplaceAll(strings.TrimPrefix(getSourcePath(), sourceRoot()+"/"), "/", ".")
+}
+
 func getPackageName() string {
 	pomPath := "./pom.xml"
 	strict := true // Si strict est vrai, on ne prend pas le groupId du parent
 
+	if !utils.Exists(pomPath) {
+		if group := gradleGroup(); group != "" {
+			return strings.ReplaceAll(group, ".", "/")
+		}
+	}
+
 	data, err := os.ReadFile(pomPath)
 	if err != nil {
 		utils.PrintError(fmt.Sprintf("Impossible de lire %s: %v", pomPath, err))
@@ -798,6 +1510,42 @@ func getPackageName() string {
 	return ""
 }
 
+// renderTemplate exécute un template avec les fonctions communes et renvoie le code généré.
+func renderTemplate(name, text string, params interface{}) []byte {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		utils.PrintError(fmt.Sprintf("Erreur lors du parsing du template: %v", err))
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		utils.PrintError(fmt.Sprintf("Erreur lors de l'exécution du template: %v", err))
+		os.Exit(1)
+	}
+	return buf.Bytes()
+}
+
+// writeNewFile écrit un fichier généré en créant son dossier, sans écraser un fichier existant.
+func writeNewFile(path, filename string, content []byte) {
+	// Crée le dossier s'il n'existe pas
+	if !utils.Exists(path) {
+		err := utils.CreateFolder(path)
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Erreur lors de la création du dossier: %v", err))
+			os.Exit(1)
+		}
+	}
+
+	// Vérifie si le fichier existe déjà
+	if utils.Exists(path + "/" + filename) {
+		utils.PrintWarning(fmt.Sprintf("Le fichier %s existe déjà", filename))
+		return
+	}
+
+	generateFile(path, filename, content)
+}
+
 func generateFile(path string, filename string, content []byte) {
 	err := os.WriteFile(path+"/"+filename, content, 0o644)
 	if err != nil {
@@ -22,6 +22,7 @@ func init() {
 	newCmd.Flags().StringP("type", "t", "maven-project", "Type of project to create")
 	newCmd.Flags().StringP("spring-boot-version", "s", "4.0.2", "Spring Boot version")
 	newCmd.Flags().StringP("java-version", "j", "21", "Java version")
+	newCmd.Flags().StringP("language", "l", "java", "Language of the project (java, kotlin or groovy)")
 }
 
 // ==================== NEW PROJECT ====================
@@ -69,22 +70,41 @@ var newCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		createNewProject(projectName, groupId, artifactId, typeName, springBootVersion, javaVersion)
+		language, err := cmd.Flags().GetString("language")
+		if err != nil {
+			utils.PrintError("Failed to get language flag")
+			os.Exit(1)
+		}
+		if !isSupportedLanguage(language) {
+			utils.PrintError(fmt.Sprintf("Unsupported language: %s (expected one of: %s)", language, strings.Join(allLanguages(), ", ")))
+			os.Exit(1)
+		}
+
+		createNewProject(projectName, groupId, artifactId, typeName, springBootVersion, javaVersion, language)
 	},
 }
 
-func createNewProject(projectName, groupId, artifactId, typeName, bootVersion, javaVersion string) {
+func isSupportedLanguage(language string) bool {
+	for _, l := range allLanguages() {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+func createNewProject(projectName, groupId, artifactId, typeName, bootVersion, javaVersion, language string) {
 	if artifactId == "" {
 		artifactId = projectName
 	}
 
 	// Affichage de la configuration du projet
-	displayProjectConfig(projectName, groupId, artifactId, typeName, bootVersion, javaVersion)
+	displayProjectConfig(projectName, groupId, artifactId, typeName, bootVersion, javaVersion, language)
 
 	defaultDependencies := "web,data-jpa,validation,actuator"
 	params := map[string]string{
 		"type":         typeName,
-		"language":     "java",
+		"language":     language,
 		"bootVersion":  bootVersion,
 		"baseDir":      projectName,
 		"groupId":      groupId,
@@ -146,7 +166,7 @@ func createNewProject(projectName, groupId, artifactId, typeName, bootVersion, j
 //	func printStep(message string) {
 //		fmt.Println(stepStyle.Render("➤ " + message))
 //	}
-func displayProjectConfig(projectName, groupId, artifactId, typeName, bootVersion, javaVersion string) {
+func displayProjectConfig(projectName, groupId, artifactId, typeName, bootVersion, javaVersion, language string) {
 	fmt.Println(utils.SubtitleStyle.Render("📋 Project Configuration"))
 	fmt.Println()
 
@@ -156,6 +176,7 @@ func displayProjectConfig(projectName, groupId, artifactId, typeName, bootVersio
 	configBox.WriteString(formatConfigLine("Artifact ID", artifactId))
 	configBox.WriteString(formatConfigLine("Project Type", typeName))
 	configBox.WriteString(formatConfigLine("Spring Boot", bootVersion))
+	configBox.WriteString(formatConfigLine("Language", language))
 	configBox.WriteString(formatConfigLine("Java Version", javaVersion))
 	configBox.WriteString(formatConfigLine("Dependencies", "web, data-jpa, validation, actuator"))
 
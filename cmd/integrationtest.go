@@ -0,0 +1,534 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"springcli/internal/buildfile"
+	"springcli/internal/migration"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	generateIntegrationTestsCmd.Flags().String("db", "", "Base de données du conteneur: postgres, mysql ou mariadb (défaut: driver JDBC déclaré)")
+	generateCmd.AddCommand(generateIntegrationTestsCmd)
+}
+
+// ==================== GENERATE INTEGRATION-TESTS ====================
+var generateIntegrationTestsCmd = &cobra.Command{
+	Use:   "integration-tests [entité]",
+	Short: "Génère la configuration des tests d'intégration avec Testcontainers.",
+	Long: `Cette commande prépare les tests d'intégration du projet sur une vraie base de données:
+  - les dépendances Testcontainers (et le driver JDBC s'il manque) sont ajoutées au fichier de build
+  - AbstractIntegrationTest démarre l'application sur un port aléatoire, connectée par
+    @ServiceConnection à une base lancée dans un conteneur partagé par tous les tests
+  - <Entité>IntegrationTest appelle les endpoints CRUD du contrôleur de l'entité avec RestClient
+
+Sans entité, l'exemple porte sur la première entité du projet qui a un contrôleur. Sans
+Flyway ni Liquibase, le schéma est créé par Hibernate au démarrage des tests.`,
+	Example: `  springcli generate integration-tests
+  springcli generate integration-tests Order --db mysql`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dbName, _ := cmd.Flags().GetString("db")
+
+		dialect, err := integrationTestDialect(dbName)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		entityName := ""
+		if len(args) == 1 {
+			entityName = args[0]
+		}
+
+		utils.PrintTitle("🧪 TESTS D'INTÉGRATION")
+		generateIntegrationTests(dialect, entityName)
+	},
+}
+
+// integrationTestDialect renvoie la base du conteneur: celle de --db, sinon celle du driver
+// JDBC déclaré. H2 est une base embarquée et n'a pas de conteneur.
+func integrationTestDialect(name string) (migration.Dialect, error) {
+	if name == "" {
+		for _, d := range jdbcDrivers {
+			if d.dialect != migration.H2 && hasBuildDependency(d.groupID, d.artifactID) {
+				return d.dialect, nil
+			}
+		}
+		return migration.Postgres, nil
+	}
+	dialect, err := migration.ParseDialect(name)
+	if err != nil {
+		return "", err
+	}
+	if dialect == migration.H2 {
+		return "", errors.New("H2 est une base embarquée: choisissez postgres, mysql ou mariadb")
+	}
+	return dialect, nil
+}
+
+// testcontainer décrit le conteneur Testcontainers d'une base de données.
+type testcontainer struct {
+	Module string // module Testcontainers, sans le préfixe testcontainers- de la version 2
+	Class  string
+	Image  string
+}
+
+var testcontainers = map[migration.Dialect]testcontainer{
+	migration.Postgres: {Module: "postgresql", Class: "PostgreSQLContainer", Image: "postgres:17-alpine"},
+	migration.MySQL:    {Module: "mysql", Class: "MySQLContainer", Image: "mysql:8.4"},
+	migration.MariaDB:  {Module: "mariadb", Class: "MariaDBContainer", Image: "mariadb:11.4"},
+}
+
+// containerImport renvoie la classe du conteneur: Testcontainers 2 (Spring Boot 4) a rangé
+// chaque module dans son propre package.
+func containerImport(c testcontainer) string {
+	if isBoot4() {
+		return "org.testcontainers." + c.Module + "." + c.Class
+	}
+	return "org.testcontainers.containers." + c.Class
+}
+
+// localServerPortImport renvoie l'import de @LocalServerPort, déplacé par Spring Boot 4.
+func localServerPortImport() string {
+	if isBoot4() {
+		return "org.springframework.boot.web.server.test.LocalServerPort"
+	}
+	return "org.springframework.boot.test.web.server.LocalServerPort"
+}
+
+const abstractIntegrationTestTemplate = `package {{.packageName}};
+
+import org.springframework.boot.test.context.SpringBootTest;
+import org.springframework.boot.testcontainers.service.connection.ServiceConnection;
+import {{.containerImport}};
+
+// Démarre l'application sur un port aléatoire, connectée à une base {{.databaseName}} lancée par
+// Testcontainers. Le conteneur est démarré une seule fois pour tous les tests d'intégration,
+// qui partagent ainsi le même contexte Spring; Testcontainers l'arrête à la fin des tests.
+@SpringBootTest(webEnvironment = SpringBootTest.WebEnvironment.RANDOM_PORT
+{{- if eq (len .properties) 1}},
+        properties = "{{index .properties 0}}"
+{{- else if .properties}},
+        properties = {
+{{- range $i, $p := .properties}}{{if $i}},{{end}}
+                "{{$p}}"
+{{- end}}
+        }
+{{- end}})
+public abstract class AbstractIntegrationTest {
+    @ServiceConnection
+    static final {{.containerType}} database = new {{.containerNew}}("{{.image}}");
+
+    static {
+        database.start();
+    }
+}
+`
+
+const kotlinAbstractIntegrationTestTemplate = `package {{.packageName}}
+
+import org.springframework.boot.test.context.SpringBootTest
+import org.springframework.boot.testcontainers.service.connection.ServiceConnection
+import {{.containerImport}}
+
+// Démarre l'application sur un port aléatoire, connectée à une base {{.databaseName}} lancée par
+// Testcontainers. Le conteneur est démarré une seule fois pour tous les tests d'intégration,
+// qui partagent ainsi le même contexte Spring; Testcontainers l'arrête à la fin des tests.
+@SpringBootTest(webEnvironment = SpringBootTest.WebEnvironment.RANDOM_PORT
+{{- if eq (len .properties) 1}},
+    properties = ["{{index .properties 0}}"]
+{{- else if .properties}},
+    properties = [
+{{- range .properties}}
+        "{{.}}",
+{{- end}}
+    ]
+{{- end}})
+abstract class AbstractIntegrationTest {
+    companion object {
+        @ServiceConnection
+        @JvmStatic
+        val database = {{.containerNew}}("{{.image}}")
+
+        init {
+            database.start()
+        }
+    }
+}
+`
+
+const integrationTestTemplate = `package {{.packageName}}.controller;
+
+import static org.assertj.core.api.Assertions.assertThat;
+{{- if .notFoundHandled}}
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+{{- end}}
+{{range .imports}}
+import {{.}};
+{{- end}}
+
+class {{.testName}} extends AbstractIntegrationTest {
+    private static final ParameterizedTypeReference<Map<String, Object>> JSON_OBJECT =
+            new ParameterizedTypeReference<>() {
+            };
+
+    private static final String BODY = """
+{{- range .body}}
+            {{.}}
+{{- end}}
+            """;
+
+    @LocalServerPort
+    private int port;
+
+    private RestClient client;
+
+    @BeforeEach
+    void setUp() {
+        client = RestClient.create("http://localhost:" + port);
+    }
+
+    @Test
+    void createReturnsCreated() {
+        ResponseEntity<Map<String, Object>> response = client.post()
+                .uri("{{.resourcePath}}")
+                .contentType(MediaType.APPLICATION_JSON)
+                .body(BODY)
+                .retrieve()
+                .toEntity(JSON_OBJECT);
+
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.CREATED);
+        assertThat(response.getBody()).containsKey("id");
+    }
+
+    @Test
+    void findByIdReturnsCreated{{.entityName}}() {
+        Object id = create();
+
+        ResponseEntity<Map<String, Object>> response = client.get()
+                .uri("{{.resourcePath}}/{id}", id)
+                .retrieve()
+                .toEntity(JSON_OBJECT);
+
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getBody()).containsEntry("id", id);
+    }
+
+    @Test
+    void findAllReturnsPage() {
+        create();
+
+        ResponseEntity<Map<String, Object>> response = client.get()
+                .uri("{{.resourcePath}}")
+                .retrieve()
+                .toEntity(JSON_OBJECT);
+
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat((List<?>) response.getBody().get("content")).isNotEmpty();
+    }
+
+    @Test
+    void deleteRemoves{{.entityName}}() {
+        Object id = create();
+
+        ResponseEntity<Void> response = client.delete()
+                .uri("{{.resourcePath}}/{id}", id)
+                .retrieve()
+                .toBodilessEntity();
+
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.NO_CONTENT);
+{{- if .notFoundHandled}}
+        assertThatThrownBy(() -> client.get().uri("{{.resourcePath}}/{id}", id).retrieve().toBodilessEntity())
+                .isInstanceOf(HttpClientErrorException.NotFound.class);
+{{- end}}
+    }
+
+    private Object create() {
+        Map<String, Object> created = client.post()
+                .uri("{{.resourcePath}}")
+                .contentType(MediaType.APPLICATION_JSON)
+                .body(BODY)
+                .retrieve()
+                .body(JSON_OBJECT);
+        return created.get("id");
+    }
+}
+`
+
+const kotlinIntegrationTestTemplate = `package {{.packageName}}.controller
+{{range .imports}}
+import {{.}}
+{{- end}}
+
+class {{.testName}} : AbstractIntegrationTest() {
+    @LocalServerPort
+    private var port: Int = 0
+
+    private lateinit var client: RestClient
+
+    @BeforeEach
+    fun setUp() {
+        client = RestClient.create("http://localhost:$port")
+    }
+
+    @Test
+    fun createReturnsCreated() {
+        val response = client.post()
+            .uri("{{.resourcePath}}")
+            .contentType(MediaType.APPLICATION_JSON)
+            .body(BODY)
+            .retrieve()
+            .toEntity<Map<String, Any>>()
+
+        assertThat(response.statusCode).isEqualTo(HttpStatus.CREATED)
+        assertThat(response.body).containsKey("id")
+    }
+
+    @Test
+    fun findByIdReturnsCreated{{.entityName}}() {
+        val id = create()
+
+        val response = client.get()
+            .uri("{{.resourcePath}}/{id}", id)
+            .retrieve()
+            .toEntity<Map<String, Any>>()
+
+        assertThat(response.statusCode).isEqualTo(HttpStatus.OK)
+        assertThat(response.body).containsEntry("id", id)
+    }
+
+    @Test
+    fun findAllReturnsPage() {
+        create()
+
+        val response = client.get()
+            .uri("{{.resourcePath}}")
+            .retrieve()
+            .toEntity<Map<String, Any>>()
+
+        assertThat(response.statusCode).isEqualTo(HttpStatus.OK)
+        assertThat(response.body!!["content"] as List<*>).isNotEmpty()
+    }
+
+    @Test
+    fun deleteRemoves{{.entityName}}() {
+        val id = create()
+
+        val response = client.delete()
+            .uri("{{.resourcePath}}/{id}", id)
+            .retrieve()
+            .toBodilessEntity()
+
+        assertThat(response.statusCode).isEqualTo(HttpStatus.NO_CONTENT)
+{{- if .notFoundHandled}}
+        assertThatThrownBy { client.get().uri("{{.resourcePath}}/{id}", id).retrieve().toBodilessEntity() }
+            .isInstanceOf(HttpClientErrorException.NotFound::class.java)
+{{- end}}
+    }
+
+    private fun create(): Any = client.post()
+        .uri("{{.resourcePath}}")
+        .contentType(MediaType.APPLICATION_JSON)
+        .body(BODY)
+        .retrieve()
+        .body<Map<String, Any>>()!!
+        .getValue("id")
+
+    companion object {
+        private val BODY = """
+{{- range .body}}
+            {{.}}
+{{- end}}
+        """.trimIndent()
+    }
+}
+`
+
+// generateIntegrationTests déclare les dépendances Testcontainers, puis génère la classe de
+// base des tests d'intégration et l'exemple de test de l'entité.
+func generateIntegrationTests(dialect migration.Dialect, entityName string) {
+	if entityName == "" {
+		entityName = integrationTestEntity()
+	} else if !utils.Exists(getSourcePath() + "/controller/" + sourceFile(entityName+"Controller")) {
+		utils.PrintError(fmt.Sprintf("Contrôleur %s introuvable: lancez d'abord 'springcli generate controller %s'", entityName+"Controller", entityName))
+		os.Exit(1)
+	}
+
+	addIntegrationTestDependencies(dialect)
+	generateAbstractIntegrationTest(dialect)
+	if entityName == "" {
+		utils.PrintInfo("Aucun contrôleur trouvé: seule la classe de base des tests d'intégration est générée")
+		return
+	}
+	if readEntityMapping(entityName).composite() {
+		utils.PrintWarning(fmt.Sprintf("%s a une clé composite: aucun exemple de test d'intégration n'est généré", entityName))
+		return
+	}
+	generateIntegrationTest(entityName)
+}
+
+// integrationTestEntity renvoie la première entité à clé simple qui a un contrôleur.
+func integrationTestEntity() string {
+	for _, name := range listEntities() {
+		if utils.Exists(getSourcePath()+"/controller/"+sourceFile(name+"Controller")) && !readEntityMapping(name).composite() {
+			return name
+		}
+	}
+	return ""
+}
+
+// addIntegrationTestDependencies déclare spring-boot-testcontainers, le module Testcontainers
+// de la base et son driver JDBC s'il manque. Leurs versions sont gérées par Spring Boot.
+func addIntegrationTestDependencies(dialect migration.Dialect) {
+	container := testcontainers[dialect]
+	module := container.Module
+	if isBoot4() {
+		module = "testcontainers-" + module
+	}
+	deps := []buildfile.Dependency{
+		{GroupID: "org.springframework.boot", ArtifactID: "spring-boot-testcontainers", Scope: "test"},
+		{GroupID: "org.testcontainers", ArtifactID: module, Scope: "test"},
+	}
+	if !hasJdbcDriver(dialect) {
+		for _, d := range jdbcDrivers {
+			if d.dialect == dialect {
+				deps = append(deps, buildfile.Dependency{GroupID: d.groupID, ArtifactID: d.artifactID, Scope: "runtime"})
+				break
+			}
+		}
+	}
+
+	for _, dep := range deps {
+		if hasBuildDependency(dep.GroupID, dep.ArtifactID) {
+			continue
+		}
+		err := addBuildDependency(dep, gradleConfigurations[dep.Scope])
+		if errors.Is(err, buildfile.ErrDuplicate) {
+			continue
+		}
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible d'ajouter %s à %s: %v", dep.ArtifactID, buildFileName(), err))
+			os.Exit(1)
+		}
+		utils.PrintSuccess(fmt.Sprintf("%s ajouté à %s (scope %s)", dep.ArtifactID, buildFileName(), dep.Scope))
+	}
+}
+
+// hasJdbcDriver indique si un driver JDBC de la base est déclaré.
+func hasJdbcDriver(dialect migration.Dialect) bool {
+	for _, d := range jdbcDrivers {
+		if d.dialect == dialect && hasBuildDependency(d.groupID, d.artifactID) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateAbstractIntegrationTest génère la classe de base des tests d'intégration.
+func generateAbstractIntegrationTest(dialect migration.Dialect) {
+	container := testcontainers[dialect]
+	names := map[migration.Dialect]string{
+		migration.Postgres: "PostgreSQL",
+		migration.MySQL:    "MySQL",
+		migration.MariaDB:  "MariaDB",
+	}
+
+	// Testcontainers 1 type ses conteneurs par eux-mêmes (PostgreSQLContainer<SELF>)
+	containerType, containerNew := container.Class, container.Class
+	if !isBoot4() {
+		containerType += "<?>"
+		containerNew += "<>"
+		if isKotlin() {
+			containerNew = container.Class + "<Nothing>"
+		}
+	}
+
+	params := map[string]interface{}{
+		"packageName":     basePackage(),
+		"databaseName":    names[dialect],
+		"containerImport": containerImport(container),
+		"containerType":   containerType,
+		"containerNew":    containerNew,
+		"image":           container.Image,
+		"properties":      integrationTestProperties(),
+	}
+	buf := renderTemplate("abstractIntegrationTest", languageTemplate(abstractIntegrationTestTemplate, kotlinAbstractIntegrationTestTemplate), params)
+	writeNewFile(testSourcePath(), sourceFile("AbstractIntegrationTest"), buf)
+}
+
+// integrationTestProperties renvoie les propriétés imposées aux tests d'intégration. Sans
+// migrations, Hibernate crée le schéma; une table au nom réservé (order, user...) doit alors
+// être protégée par des guillemets, dans le schéma comme dans les requêtes.
+func integrationTestProperties() []string {
+	var properties []string
+	if detectMigrationTool() == migrationNone {
+		properties = append(properties, "spring.jpa.hibernate.ddl-auto=create-drop")
+	}
+	for _, name := range listEntities() {
+		if migration.IsReserved(tableName(name)) {
+			properties = append(properties, "spring.jpa.properties.hibernate.auto_quote_keyword=true")
+			break
+		}
+	}
+	return properties
+}
+
+// generateIntegrationTest génère le test d'intégration des endpoints CRUD d'une entité.
+func generateIntegrationTest(entityName string) {
+	params := crudParams(entityName)
+	params["testName"] = entityName + "IntegrationTest"
+	params["notFoundHandled"] = utils.Exists(getSourcePath() + "/exception/" + sourceFile("GlobalExceptionHandler"))
+
+	// Les références vers d'autres entités sont omises: l'entité est créée seule
+	_, _, relations, _ := readEntity(entityName)
+	references := map[string]bool{}
+	for _, r := range relations {
+		references[r.Name] = true
+		references[r.Name+"Id"] = true
+		references[r.Name+"Ids"] = true
+	}
+	var body []requestField
+	for _, f := range requestFields(entityName, params["useDto"].(bool)) {
+		if !references[f.Name] {
+			body = append(body, f)
+		}
+	}
+	params["body"] = jsonBodyLines(body)
+
+	imports := map[string]bool{
+		basePackage() + ".AbstractIntegrationTest":  true,
+		"org.junit.jupiter.api.BeforeEach":          true,
+		"org.junit.jupiter.api.Test":                true,
+		localServerPortImport():                     true,
+		"org.springframework.http.HttpStatus":       true,
+		"org.springframework.http.MediaType":        true,
+		"org.springframework.web.client.RestClient": true,
+	}
+	if params["notFoundHandled"].(bool) {
+		imports["org.springframework.web.client.HttpClientErrorException"] = true
+	}
+	if isKotlin() {
+		imports["org.assertj.core.api.Assertions.assertThat"] = true
+		imports["org.springframework.web.client.body"] = true
+		imports["org.springframework.web.client.toEntity"] = true
+		if params["notFoundHandled"].(bool) {
+			imports["org.assertj.core.api.Assertions.assertThatThrownBy"] = true
+		}
+	} else {
+		imports["java.util.List"] = true
+		imports["java.util.Map"] = true
+		imports["org.springframework.core.ParameterizedTypeReference"] = true
+		imports["org.springframework.http.ResponseEntity"] = true
+	}
+	params["imports"] = sortedKeys(imports)
+
+	buf := renderTemplate("integrationTest", languageTemplate(integrationTestTemplate, kotlinIntegrationTestTemplate), params)
+	writeNewFile(testSourcePath()+"/controller", sourceFile(entityName+"IntegrationTest"), buf)
+}
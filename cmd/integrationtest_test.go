@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "réécrit les fichiers attendus des tests golden")
+
+// TestGenerateIntegrationTests génère les tests d'intégration dans une copie de chaque projet
+// de testdata/integration-tests/<cas>/project et compare les fichiers créés ou modifiés à
+// ceux de <cas>/want (go test ./cmd -run TestGenerateIntegrationTests -update pour les
+// régénérer).
+func TestGenerateIntegrationTests(t *testing.T) {
+	cases := []struct {
+		name   string
+		db     string
+		entity string
+		// project est le cas dont le projet est repris, le cas lui-même par défaut
+		project string
+	}{
+		{name: "java-postgres", db: "postgres"},
+		{name: "java-mariadb", db: "mariadb", project: "java-postgres"},
+		{name: "kotlin-mysql", entity: "Order"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			root, err := filepath.Abs(filepath.Join("testdata", "integration-tests", c.name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			project := filepath.Join(root, "project")
+			if c.project != "" {
+				project = filepath.Join(root, "..", c.project, "project")
+			}
+			dir := t.TempDir()
+			copyTree(t, project, dir)
+			chdir(t, dir)
+
+			dialect, err := integrationTestDialect(c.db)
+			if err != nil {
+				t.Fatal(err)
+			}
+			generateIntegrationTests(dialect, c.entity)
+
+			assertGolden(t, project, dir, filepath.Join(root, "want"))
+		})
+	}
+}
+
+// chdir place le test dans dir, les générateurs travaillant sur le dossier courant.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	detectedLanguage = ""
+	t.Cleanup(func() {
+		detectedLanguage = ""
+		if err := os.Chdir(previous); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// copyTree copie les fichiers de src dans dst.
+func copyTree(t *testing.T, src, dst string) {
+	t.Helper()
+	writeTree(t, dst, readTree(t, src))
+}
+
+// writeTree écrit les fichiers sous dst, par chemin relatif.
+func writeTree(t *testing.T, dst string, files map[string][]byte) {
+	t.Helper()
+	for name, data := range files {
+		path := filepath.Join(dst, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// readTree renvoie le contenu des fichiers d'un dossier, par chemin relatif.
+func readTree(t *testing.T, root string) map[string][]byte {
+	t.Helper()
+	files := map[string][]byte{}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// assertGolden compare les fichiers de dir créés ou modifiés par rapport au projet d'origine
+// aux fichiers attendus de want.
+func assertGolden(t *testing.T, project, dir, want string) {
+	t.Helper()
+	original := readTree(t, project)
+	got := map[string][]byte{}
+	for name, data := range readTree(t, dir) {
+		if !bytes.Equal(original[name], data) {
+			got[name] = data
+		}
+	}
+
+	if *updateGolden {
+		if err := os.RemoveAll(want); err != nil {
+			t.Fatal(err)
+		}
+		writeTree(t, want, got)
+		return
+	}
+
+	expected := readTree(t, want)
+	var names []string
+	for name := range got {
+		names = append(names, name)
+	}
+	for name := range expected {
+		if _, ok := got[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g, gotOK := got[name]
+		e, expectedOK := expected[name]
+		switch {
+		case !expectedOK:
+			t.Errorf("%s: fichier inattendu\n%s", name, g)
+		case !gotOK:
+			t.Errorf("%s: fichier attendu non généré", name)
+		case !bytes.Equal(g, e):
+			t.Errorf("%s: contenu différent\n--- obtenu ---\n%s\n--- attendu ---\n%s", name, g, e)
+		}
+	}
+}
@@ -0,0 +1,423 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ==================== LECTURE DES SOURCES JAVA / KOTLIN ====================
+// Analyse statique volontairement simple des classes du projet: elle reconnaît le code
+// produit par les générateurs et les déclarations Spring usuelles, sans compiler le projet.
+
+// sourceType est une classe, une interface, un record ou une énumération de premier niveau.
+type sourceType struct {
+	Name   string
+	Kind   string
+	Kotlin bool
+	// Header précède la déclaration (annotations de la classe), Body la suit
+	Header     string
+	Body       string
+	Supertypes []string
+}
+
+// sourceAnnotation est une annotation et le texte de ses arguments, sans les parenthèses.
+type sourceAnnotation struct {
+	Name string
+	Args string
+}
+
+// sourceMember est une méthode, un paramètre, un champ ou une propriété.
+type sourceMember struct {
+	Annotations []sourceAnnotation
+	// AnnotationText est le texte brut des annotations, relu par constraintsFromAnnotations
+	AnnotationText string
+	Name           string
+	Type           string
+	Nullable       bool
+	HasDefault     bool
+	Comment        string
+	Params         []sourceMember
+}
+
+var typeDeclarationRegexp = regexp.MustCompile(`(?m)^(?:(?:public|abstract|final|open|data|sealed|internal)\s+)*(class|interface|record|enum(?:\s+class)?)\s+(\w+)`)
+
+// scanSourceTypes lit les types de premier niveau des fichiers sources sous root.
+func scanSourceTypes(root string) (map[string]*sourceType, error) {
+	types := map[string]*sourceType{}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		kotlin := strings.HasSuffix(path, ".kt")
+		if info.IsDir() || !(kotlin || strings.HasSuffix(path, ".java")) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		content := string(data)
+		loc := typeDeclarationRegexp.FindStringSubmatchIndex(content)
+		if loc == nil {
+			return nil
+		}
+		t := &sourceType{
+			Name:   content[loc[4]:loc[5]],
+			Kind:   strings.Fields(content[loc[2]:loc[3]])[0],
+			Kotlin: kotlin,
+			Header: content[:loc[0]],
+			Body:   content[loc[0]:],
+		}
+		t.Supertypes = supertypes(content[loc[1]:], kotlin)
+		// Un DTO et une entité peuvent porter le même nom: le DTO est celui exposé par l'API
+		if _, exists := types[t.Name]; exists && strings.Contains(t.Header, "@Entity") {
+			return nil
+		}
+		types[t.Name] = t
+		return nil
+	})
+	return types, err
+}
+
+var supertypeRegexp = regexp.MustCompile(`\b[A-Z]\w*`)
+
+// supertypes renvoie les types étendus ou implémentés, lus entre le nom et le corps.
+func supertypes(rest string, kotlin bool) []string {
+	rest = strings.TrimLeft(rest, " \t")
+	if strings.HasPrefix(rest, "<") {
+		rest = rest[strings.Index(rest, ">")+1:]
+		rest = strings.TrimLeft(rest, " \t")
+	}
+	if strings.HasPrefix(rest, "(") {
+		if end := matchingParen(rest, 0); end > 0 {
+			rest = rest[end+1:]
+		}
+	}
+	if end := strings.IndexAny(rest, "{\n"); end >= 0 {
+		rest = rest[:end]
+	}
+	if kotlin {
+		_, rest, _ = strings.Cut(rest, ":")
+	} else if _, after, ok := strings.Cut(rest, "implements"); ok {
+		rest = after
+	} else {
+		_, rest, _ = strings.Cut(rest, "extends")
+	}
+	// Les arguments génériques ne sont pas des supertypes
+	rest = regexp.MustCompile(`<[^>]*>|\([^)]*\)`).ReplaceAllString(rest, "")
+	return supertypeRegexp.FindAllString(rest, -1)
+}
+
+// matchingParen renvoie l'indice de la parenthèse fermant celle ouverte en open, en
+// ignorant les chaînes littérales.
+func matchingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			for i++; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel découpe s sur les virgules qui ne sont pas imbriquées.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			for i++; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+		case '(', '<', '[', '{':
+			depth++
+		case ')', '>', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		parts = append(parts, last)
+	}
+	return parts
+}
+
+var annotationNameRegexp = regexp.MustCompile(`^@(?:\w+:)?([\w.]+)`)
+
+// parseAnnotations lit les annotations en tête de s et renvoie le texte qui les suit.
+func parseAnnotations(s string) ([]sourceAnnotation, string) {
+	var annotations []sourceAnnotation
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		m := annotationNameRegexp.FindStringSubmatch(s)
+		if m == nil {
+			return annotations, s
+		}
+		name := m[1][strings.LastIndex(m[1], ".")+1:]
+		s = s[len(m[0]):]
+		a := sourceAnnotation{Name: name}
+		if strings.HasPrefix(s, "(") {
+			if end := matchingParen(s, 0); end > 0 {
+				a.Args = strings.TrimSpace(s[1:end])
+				s = s[end+1:]
+			}
+		}
+		annotations = append(annotations, a)
+	}
+}
+
+// annotation renvoie l'annotation de nom donné, si elle est présente.
+func (m sourceMember) annotation(name string) (sourceAnnotation, bool) {
+	return findAnnotation(m.Annotations, name)
+}
+
+func findAnnotation(annotations []sourceAnnotation, name string) (sourceAnnotation, bool) {
+	for _, a := range annotations {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return sourceAnnotation{}, false
+}
+
+var stringLiteralRegexp = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+
+// value renvoie la valeur d'un argument d'annotation: l'argument positionnel pour
+// "value", sinon l'argument nommé (name = "x"). Un tableau donne sa première valeur.
+func (a sourceAnnotation) value(keys ...string) (string, bool) {
+	for _, arg := range splitTopLevel(a.Args) {
+		key, value, named := strings.Cut(arg, "=")
+		if !named || strings.HasPrefix(strings.TrimSpace(value), "=") {
+			key, value = "value", arg
+		}
+		key = strings.TrimSpace(key)
+		for _, k := range keys {
+			if k != key {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			if m := stringLiteralRegexp.FindStringSubmatch(value); m != nil {
+				return unquoteLiteral(m[1]), true
+			}
+			return strings.Trim(value, "{}[] "), true
+		}
+	}
+	return "", false
+}
+
+var mappingAnnotationRegexp = regexp.MustCompile(`@(Get|Post|Put|Delete|Patch|Request)Mapping\b`)
+
+var (
+	javaMethodRegexp   = regexp.MustCompile(`^(?:(?:public|protected|abstract|default|static|final|synchronized)\s+)*([\w.<>,?\[\] ]+?)\s+(\w+)\s*\(`)
+	kotlinMethodRegexp = regexp.MustCompile(`^(?:(?:public|override|open|suspend|internal)\s+)*fun\s+(\w+)\s*\(`)
+	kotlinReturnRegexp = regexp.MustCompile(`^\s*:\s*([\w.<>,?\[\] ]+?)\s*(?:=|\{|$|\n)`)
+)
+
+// mappedMethods renvoie les méthodes d'un type portant une annotation @XxxMapping.
+func (t *sourceType) mappedMethods() []sourceMember {
+	var methods []sourceMember
+	body := t.Body
+	open := strings.Index(body, "{")
+	if open < 0 {
+		return nil
+	}
+	cursor := open
+	for _, loc := range mappingAnnotationRegexp.FindAllStringIndex(body, -1) {
+		if loc[0] < cursor {
+			continue
+		}
+		start, comment := annotationBlockStart(body, loc[0])
+		annotations, rest := parseAnnotations(body[start:])
+		method := sourceMember{Annotations: annotations, Comment: comment}
+
+		var params int
+		if t.Kotlin {
+			m := kotlinMethodRegexp.FindStringSubmatch(rest)
+			if m == nil {
+				continue
+			}
+			method.Name = m[1]
+			params = len(m[0]) - 1
+		} else {
+			m := javaMethodRegexp.FindStringSubmatch(rest)
+			if m == nil {
+				continue
+			}
+			method.Type, method.Name = strings.TrimSpace(m[1]), m[2]
+			params = len(m[0]) - 1
+		}
+		end := matchingParen(rest, params)
+		if end < 0 {
+			continue
+		}
+		for _, p := range splitTopLevel(rest[params+1 : end]) {
+			method.Params = append(method.Params, parseMember(p, t.Kotlin))
+		}
+		if t.Kotlin {
+			if m := kotlinReturnRegexp.FindStringSubmatch(rest[end+1:]); m != nil {
+				method.Type = strings.TrimSpace(m[1])
+			}
+		}
+		cursor = len(body) - len(rest) + end
+		methods = append(methods, method)
+	}
+	return methods
+}
+
+// annotationBlockStart remonte aux annotations précédant celle trouvée en pos (une par
+// ligne) et renvoie le début du bloc ainsi que le commentaire éventuel qui le précède.
+func annotationBlockStart(body string, pos int) (int, string) {
+	start := strings.LastIndex(body[:pos], "\n") + 1
+	for start > 0 {
+		prevStart := strings.LastIndex(body[:start-1], "\n") + 1
+		line := strings.TrimSpace(body[prevStart : start-1])
+		if !strings.HasPrefix(line, "@") {
+			return start, commentBefore(body[:start])
+		}
+		start = prevStart
+	}
+	return start, ""
+}
+
+// commentBefore renvoie le commentaire (// ou /** */) terminant text.
+func commentBefore(text string) string {
+	text = strings.TrimRight(text, " \t\n")
+	lines := strings.Split(text, "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	if comment, ok := strings.CutPrefix(last, "//"); ok {
+		return strings.TrimSpace(comment)
+	}
+	if !strings.HasSuffix(last, "*/") {
+		return ""
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "/*") {
+			// Première ligne de texte du commentaire
+			for _, l := range lines[i:] {
+				l = strings.TrimSpace(strings.Trim(strings.TrimSpace(l), "/*"))
+				if l != "" && !strings.HasPrefix(l, "@") {
+					return l
+				}
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+var (
+	javaParamRegexp   = regexp.MustCompile(`^(?:final\s+)?([\w.<>,?\[\] ]+?)(?:\.\.\.)?\s+(\w+)$`)
+	kotlinParamRegexp = regexp.MustCompile(`^(?:(?:override|private|protected|public|internal|open|vararg)\s+)*(?:(?:val|var)\s+)?(\w+)\s*:\s*([^=]+?)\s*(=.*)?$`)
+)
+
+// parseMember lit un paramètre Java (Type nom) ou Kotlin (nom: Type = défaut), précédé
+// de ses annotations.
+func parseMember(s string, kotlin bool) sourceMember {
+	annotations, rest := parseAnnotations(s)
+	m := sourceMember{Annotations: annotations, AnnotationText: strings.TrimSuffix(s, rest)}
+	rest = strings.TrimSpace(rest)
+	if kotlin {
+		if p := kotlinParamRegexp.FindStringSubmatch(rest); p != nil {
+			m.Name, m.Type, m.HasDefault = p[1], strings.TrimSpace(p[2]), p[3] != ""
+			m.Nullable = strings.HasSuffix(m.Type, "?")
+			m.Type = strings.TrimSuffix(m.Type, "?")
+		}
+	} else if p := javaParamRegexp.FindStringSubmatch(rest); p != nil {
+		m.Type, m.Name = strings.TrimSpace(p[1]), p[2]
+	}
+	return m
+}
+
+var javaFieldRegexp = regexp.MustCompile(`(?m)((?:^[ \t]*@[^\n]*\n)*)^[ \t]*(?:private|protected|public)\s+((?:static\s+)?(?:final\s+)?)([\w.<>,?\[\] ]+?)\s+(\w+)\s*(?:=[^;]*)?;`)
+
+// properties renvoie les propriétés sérialisables d'une classe, d'un record ou d'une
+// classe Kotlin (paramètres du constructeur principal).
+func (t *sourceType) properties() []sourceMember {
+	var members []sourceMember
+	if t.Kind == "record" || t.Kotlin {
+		open := strings.Index(t.Body, "(")
+		brace := strings.Index(t.Body, "{")
+		if open < 0 || (brace >= 0 && brace < open) {
+			return nil
+		}
+		end := matchingParen(t.Body, open)
+		if end < 0 {
+			return nil
+		}
+		for _, p := range splitTopLevel(t.Body[open+1 : end]) {
+			if m := parseMember(p, t.Kotlin); m.Name != "" {
+				members = append(members, m)
+			}
+		}
+		return members
+	}
+
+	for _, f := range javaFieldRegexp.FindAllStringSubmatch(t.Body, -1) {
+		if strings.Contains(f[2], "static") {
+			continue
+		}
+		annotations, _ := parseAnnotations(f[1])
+		members = append(members, sourceMember{Annotations: annotations, AnnotationText: f[1], Type: strings.TrimSpace(f[3]), Name: f[4]})
+	}
+	return members
+}
+
+// enumConstants renvoie les constantes d'une énumération et leur valeur JSON (@JsonProperty).
+func (t *sourceType) enumConstants() []string {
+	open := strings.Index(t.Body, "{")
+	if open < 0 {
+		return nil
+	}
+	body := t.Body[open+1:]
+	if end := strings.IndexAny(body, ";}"); end >= 0 {
+		body = body[:end]
+	}
+	var values []string
+	for _, c := range splitTopLevel(body) {
+		annotations, rest := parseAnnotations(c)
+		name := regexp.MustCompile(`^\w+`).FindString(rest)
+		if name == "" {
+			continue
+		}
+		if a, ok := findAnnotation(annotations, "JsonProperty"); ok {
+			if v, ok := a.value("value"); ok {
+				name = v
+			}
+		}
+		values = append(values, name)
+	}
+	return values
+}
+
+// sortedTypeNames renvoie les noms des types triés, pour un parcours stable.
+func sortedTypeNames(types map[string]*sourceType) []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
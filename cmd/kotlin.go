@@ -0,0 +1,446 @@
+package cmd
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"springcli/internal/utils"
+)
+
+// ===================== LANGAGE DU PROJET ======================
+const (
+	langJava   = "java"
+	langKotlin = "kotlin"
+	langGroovy = "groovy"
+)
+
+func allLanguages() []string {
+	return []string{langJava, langKotlin, langGroovy}
+}
+
+var detectedLanguage string
+
+// projectLanguage détecte le langage du projet courant: Kotlin si src/main/kotlin existe
+// ou si le plugin Kotlin est déclaré dans le fichier de build, Java sinon.
+func projectLanguage() string {
+	if detectedLanguage != "" {
+		return detectedLanguage
+	}
+
+	detectedLanguage = langJava
+	if utils.Exists("src/main/kotlin") {
+		detectedLanguage = langKotlin
+		return detectedLanguage
+	}
+	for _, buildFile := range []string{"pom.xml", "build.gradle.kts", "build.gradle"} {
+		data, err := os.ReadFile(buildFile)
+		if err != nil {
+			continue
+		}
+		content := string(data)
+		if strings.Contains(content, "kotlin-maven-plugin") ||
+			strings.Contains(content, "org.jetbrains.kotlin") ||
+			strings.Contains(content, `kotlin("jvm")`) {
+			detectedLanguage = langKotlin
+		}
+		break
+	}
+	return detectedLanguage
+}
+
+func isKotlin() bool {
+	return projectLanguage() == langKotlin
+}
+
+// sourceRoot renvoie le dossier racine des sources selon le langage du projet.
+func sourceRoot() string {
+	return "src/main/" + projectLanguage()
+}
+
+// sourceFile renvoie le nom du fichier source pour une classe donnée.
+func sourceFile(className string) string {
+	if isKotlin() {
+		return className + ".kt"
+	}
+	return className + ".java"
+}
+
+// languageTemplate choisit la variante Kotlin d'un template lorsque le projet est en Kotlin.
+func languageTemplate(javaTemplate, kotlinTemplate string) string {
+	if isKotlin() {
+		return kotlinTemplate
+	}
+	return javaTemplate
+}
+
+// gradleGroup lit le groupe déclaré dans build.gradle(.kts).
+func gradleGroup() string {
+	groupRegexp := regexp.MustCompile(`(?m)^\s*group\s*=\s*["']([^"']+)["']`)
+	for _, buildFile := range []string{"build.gradle.kts", "build.gradle"} {
+		data, err := os.ReadFile(buildFile)
+		if err != nil {
+			continue
+		}
+		if m := groupRegexp.FindStringSubmatch(string(data)); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// kotlinType convertit un type Java en type Kotlin, y compris les arguments d'un type
+// générique (Map<String, Integer> -> Map<String, Int>).
+func kotlinType(t string) string {
+	if open := strings.Index(t, "<"); open > 0 && strings.HasSuffix(t, ">") {
+		args := splitTypeArguments(t[open+1 : len(t)-1])
+		for i, arg := range args {
+			args[i] = kotlinType(arg)
+		}
+		return t[:open] + "<" + strings.Join(args, ", ") + ">"
+	}
+	switch t {
+	case "byte[]":
+		return "ByteArray"
+	case "int", "Integer":
+		return "Int"
+	case "boolean", "Boolean":
+		return "Boolean"
+	case "double", "Double":
+		return "Double"
+	case "long":
+		return "Long"
+	case "float":
+		return "Float"
+	case "short":
+		return "Short"
+	default:
+		return t
+	}
+}
+
+// splitTypeArguments découpe les arguments d'un type générique sur les virgules de
+// premier niveau (String, List<Long> -> [String, List<Long>]).
+func splitTypeArguments(s string) []string {
+	var args []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(args, strings.TrimSpace(s[start:]))
+}
+
+// javaTypeFromKotlin est l'inverse de kotlinType, pour relire une entité Kotlin existante.
+func javaTypeFromKotlin(t string) string {
+	switch t {
+	case "Int":
+		return "int"
+	case "Boolean":
+		return "boolean"
+	case "Double":
+		return "double"
+	default:
+		return t
+	}
+}
+
+// kotlinProperty renvoie la déclaration d'une propriété Kotlin avec sa valeur par défaut,
+// afin que le plugin kotlin-jpa dispose toujours d'un constructeur sans argument.
+func kotlinProperty(f Field) string {
+	t := kotlinType(f.Type)
+	switch t {
+	case "Int":
+		return f.Name + ": Int = 0"
+	case "Boolean":
+		return f.Name + ": Boolean = false"
+	case "Double":
+		return f.Name + ": Double = 0.0"
+	default:
+		return f.Name + ": " + t + "? = null"
+	}
+}
+
+// kotlinRelationProperty renvoie la déclaration Kotlin d'une relation.
+func kotlinRelationProperty(r Relation) string {
+	if isCollection(r) {
+		return r.Name + ": MutableList<" + r.Target + "> = mutableListOf()"
+	}
+	return r.Name + ": " + r.Target + "? = null"
+}
+
+func extractKotlinFields(kotlinContent string, relations []Relation) []Field {
+	fieldRegexp := regexp.MustCompile(`(?m)((?:^[ \t]*@[^\n]*\n)*)^[ \t]*va[rl]\s+(\w+)\s*:\s*(\w+)\??\s*(?:=[^,\n]*)?,?[ \t]*$`)
+	isRelation := make(map[string]bool)
+	for _, r := range relations {
+		isRelation[r.Name] = true
+	}
+	var fields []Field
+	for _, m := range fieldRegexp.FindAllStringSubmatch(kotlinContent, -1) {
+		if isRelation[m[2]] || strings.ToLower(m[2]) == "id" {
+			continue
+		}
+		fields = append(fields, Field{
+			Name:        m[2],
+			Type:        javaTypeFromKotlin(m[3]),
+			JSONName:    m[2],
+			Constraints: constraintsFromAnnotations(m[1]),
+			Column:      fieldColumnName(m[1]),
+		})
+	}
+	return fields
+}
+
+func extractKotlinRelations(kotlinContent string) []Relation {
+	relationRegexp := regexp.MustCompile(`@(OneToOne|OneToMany|ManyToOne|ManyToMany)((?:` + annotationArgs + `)?\s+(?:@[\w.]+(?:` + annotationArgs + `)?\s+)*)va[rl]\s+(\w+)\s*:\s*(?:(?:MutableList|List|MutableSet|Set)<(\w+)>|(\w+))`)
+	var relations []Relation
+	for _, m := range relationRegexp.FindAllStringSubmatch(kotlinContent, -1) {
+		target := m[4]
+		if target == "" {
+			target = m[5]
+		}
+		relations = append(relations, readRelationMapping(Relation{
+			Type:   "@" + m[1],
+			Target: target,
+			Name:   m[3],
+		}, m[2]))
+	}
+	return relations
+}
+
+// ===================== TEMPLATES KOTLIN =======================
+const kotlinControllerTemplate = `package {{.packageName}}.controller
+{{if .useDto}}
+import {{.packageName}}.dto.{{.createType}}
+import {{.packageName}}.dto.{{.responseType}}
+import {{.packageName}}.dto.{{.updateType}}
+{{- else}}
+import {{.packageName}}.entity.{{.entityName}}
+{{- end}}
+import {{.packageName}}.service.{{.serviceName}}
+import jakarta.validation.Valid
+import org.springframework.data.domain.Pageable
+import org.springframework.data.domain.Sort
+import org.springframework.data.web.PageableDefault
+import org.springframework.data.web.PagedModel
+import org.springframework.http.HttpStatus
+import org.springframework.web.bind.annotation.DeleteMapping
+import org.springframework.web.bind.annotation.GetMapping
+import org.springframework.web.bind.annotation.PathVariable
+import org.springframework.web.bind.annotation.PostMapping
+import org.springframework.web.bind.annotation.PutMapping
+import org.springframework.web.bind.annotation.RequestBody
+import org.springframework.web.bind.annotation.RequestMapping
+{{- if .filterable}}
+import org.springframework.web.bind.annotation.RequestParam
+{{- end}}
+import org.springframework.web.bind.annotation.ResponseStatus
+import org.springframework.web.bind.annotation.RestController
+import org.springframework.web.server.ResponseStatusException
+
+@RestController
+@RequestMapping("{{.resourcePath}}")
+class {{.controllerName}}(private val {{.serviceVar}}: {{.serviceName}}) {
+
+    @GetMapping
+    fun findAll(
+{{- if .filterable}}
+        @RequestParam filters: Map<String, String>,
+{{- end}}
+        @PageableDefault(size = 20, sort = ["id"]) pageable: Pageable,
+    ): PagedModel<{{.responseType}}> {
+        checkSort(pageable.sort)
+        return PagedModel({{.serviceVar}}.findAll({{if .filterable}}filters, {{end}}pageable))
+    }
+
+    @GetMapping("/{id}")
+    fun findById(@PathVariable id: Long): {{.responseType}} = {{.serviceVar}}.findById(id)
+
+    @PostMapping
+    @ResponseStatus(HttpStatus.CREATED)
+    fun create(@Valid @RequestBody request: {{.createType}}): {{.responseType}} = {{.serviceVar}}.create(request)
+
+    @PutMapping("/{id}")
+    fun update(@PathVariable id: Long, @Valid @RequestBody request: {{.updateType}}): {{.responseType}} =
+        {{.serviceVar}}.update(id, request)
+
+    @DeleteMapping("/{id}")
+    @ResponseStatus(HttpStatus.NO_CONTENT)
+    fun delete(@PathVariable id: Long) = {{.serviceVar}}.delete(id)
+
+    private fun checkSort(sort: Sort) {
+        sort.firstOrNull { it.property !in SORTABLE_FIELDS }?.let {
+            throw ResponseStatusException(HttpStatus.BAD_REQUEST, "Tri non autorisé: ${it.property}")
+        }
+    }
+
+    companion object {
+        // Propriétés autorisées dans le paramètre sort
+        private val SORTABLE_FIELDS = setOf({{range $i, $f := .sortableFields}}{{if $i}}, {{end}}"{{$f}}"{{end}})
+    }
+}
+`
+
+const kotlinServiceTemplate = `package {{.packageName}}.service
+{{if .useDto}}
+import {{.packageName}}.dto.{{.createType}}
+import {{.packageName}}.dto.{{.responseType}}
+import {{.packageName}}.dto.{{.updateType}}
+{{- end}}
+{{- range .serviceEntities}}
+import {{$.packageName}}.entity.{{.}}
+{{- end}}
+{{- if .useDto}}
+import {{.packageName}}.mapper.{{.mapperName}}
+{{- end}}
+{{- range .serviceRepositories}}
+import {{$.packageName}}.repository.{{.}}
+{{- end}}
+{{- if .filterable}}
+import {{.packageName}}.specification.{{.specificationName}}
+{{- end}}
+import jakarta.persistence.EntityNotFoundException
+{{- range .keyImports}}
+import {{.}}
+{{- end}}
+import org.springframework.data.domain.Page
+import org.springframework.data.domain.Pageable
+import org.springframework.stereotype.Service
+import org.springframework.transaction.annotation.Transactional
+
+@Service
+@Transactional
+class {{.serviceName}}(
+    private val {{.repositoryVar}}: {{.repositoryName}},
+{{- range .relationRepositories}}{{if .Injected}}
+    private val {{.RepositoryVar}}: {{.RepositoryName}},
+{{- end}}{{end}}
+{{- if .useDto}}
+    private val {{.mapperVar}}: {{.mapperName}},
+{{- end}}
+) {
+
+    @Transactional(readOnly = true)
+{{- if .filterable}}
+    fun findAll(filters: Map<String, String>, pageable: Pageable): Page<{{.responseType}}> =
+        {{.repositoryVar}}.findAll({{.specificationName}}.fromParams(filters), pageable){{if .useDto}}.map({{.mapperVar}}::toResponse){{end}}
+{{- else}}
+    fun findAll(pageable: Pageable): Page<{{.responseType}}> =
+        {{.repositoryVar}}.findAll(pageable){{if .useDto}}.map({{.mapperVar}}::toResponse){{end}}
+{{- end}}
+
+    @Transactional(readOnly = true)
+{{- if .useDto}}
+    fun findById(id: Long): {{.responseType}} = {{.mapperVar}}.toResponse(getEntity(id))
+{{- else}}
+    fun findById(id: Long): {{.responseType}} = getEntity(id)
+{{- end}}
+
+{{- if .useDto}}
+
+{{- if .relations}}
+
+    fun create(request: {{.createType}}): {{.responseType}} {
+        val {{.entityVar}} = {{.mapperVar}}.toEntity(request)
+{{- range .relations}}{{if .CreateRead}}
+        {{$.entityVar}}.{{.Name}} = find{{.Target}}({{.CreateRead}})
+{{- end}}{{end}}
+        return {{.mapperVar}}.toResponse({{.repositoryVar}}.save({{.entityVar}}))
+    }
+{{- else}}
+
+    fun create(request: {{.createType}}): {{.responseType}} =
+        {{.mapperVar}}.toResponse({{.repositoryVar}}.save({{.mapperVar}}.toEntity(request)))
+{{- end}}
+
+    fun update(id: Long, request: {{.updateType}}): {{.responseType}} {
+        val {{.entityVar}} = getEntity(id)
+        {{.mapperVar}}.updateEntity(request, {{.entityVar}})
+{{- range .relations}}{{if .UpdateRead}}
+        {{$.entityVar}}.{{.Name}} = find{{.Target}}({{.UpdateRead}})
+{{- end}}{{end}}
+        return {{.mapperVar}}.toResponse({{.repositoryVar}}.save({{.entityVar}}))
+    }
+{{- else}}
+
+    fun create(request: {{.createType}}): {{.responseType}} = {{.repositoryVar}}.save(request)
+
+    fun update(id: Long, request: {{.updateType}}): {{.responseType}} {
+        getEntity(id)
+        request.id = id
+        return {{.repositoryVar}}.save(request)
+    }
+{{- end}}
+
+    fun delete(id: Long) = {{.repositoryVar}}.delete(getEntity(id))
+
+    private fun getEntity(id: Long): {{.entityName}} =
+        {{.repositoryVar}}.findById(id).orElseThrow { EntityNotFoundException("{{.entityName}} $id introuvable") }
+{{- range .relationRepositories}}
+
+    private fun find{{.Target}}(id: {{kotlinType .KeyType}}?): {{.Target}}? =
+        id?.let { {{.RepositoryVar}}.findById(it).orElseThrow { EntityNotFoundException("{{.Target}} $it introuvable") } }
+{{- end}}
+}
+`
+
+const kotlinRepositoryTemplate = `package {{.packageName}}.repository
+
+import {{.packageName}}.entity.{{.entityName}}
+{{- if .keyImport}}
+import {{.keyImport}}
+{{- end}}
+import org.springframework.data.jpa.repository.JpaRepository
+import org.springframework.stereotype.Repository
+
+@Repository
+interface {{.repositoryName}} : JpaRepository<{{.entityName}}, {{kotlinType .keyType}}>
+`
+
+const kotlinEntityTemplate = `package {{.packageName}}.entity
+{{range .imports}}
+import {{.}}
+{{- end}}
+
+@Entity
+@Table(name = "{{.tableName}}")
+class {{.entityName}}(
+{{- range .fields}}
+{{- range entityFieldAnnotations .}}
+    {{.}}
+{{- end}}
+    var {{kotlinProperty .}},
+{{- end}}
+{{- range .relations}}
+{{- range relationAnnotations .}}
+    {{.}}
+{{- end}}
+    var {{kotlinRelationProperty .}},
+{{- end}}
+{{- range .keyAnnotations}}
+    {{.}}
+{{- end}}
+    var id: {{kotlinType .keyType}}? = null,
+) {
+    override fun equals(other: Any?): Boolean {
+        if (this === other) return true
+        if (other !is {{.entityName}}) return false
+        return id != null && id == other.id
+    }
+
+    // Constant pour rester stable avant et après la persistance
+    override fun hashCode(): Int = javaClass.hashCode()
+
+    override fun toString(): String =
+        "{{.entityName}}(id=$id{{range .fields}}, {{.Name}}=${{.Name}}{{end}})"
+}
+`
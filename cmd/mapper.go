@@ -0,0 +1,439 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"springcli/internal/buildfile"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	generateMapperCmd.Flags().String("type", "", "Type de mapper: mapstruct ou manual (défaut: mapstruct si présent dans le build)")
+	generateCmd.AddCommand(generateMapperCmd)
+}
+
+// ==================== GENERATE MAPPER ====================
+const (
+	mapperMapStruct = "mapstruct"
+	mapperManual    = "manual"
+
+	mapstructVersion         = "1.6.3"
+	lombokMapstructBinding   = "0.2.0"
+	mapstructVersionProperty = "mapstruct.version"
+)
+
+var generateMapperCmd = &cobra.Command{
+	Use:   "mapper [entity-name]",
+	Short: "Génère le mapper entre une entité et ses DTO.",
+	Long: `Cette commande génère un mapper entre une entité et les DTO existants
+(création, mise à jour, réponse), soit sous forme d'interface MapStruct, soit
+sous forme de classe écrite à la main. Lorsque MapStruct est choisi, la dépendance
+et le processeur d'annotations sont ajoutés au fichier de build.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("🔁 GÉNÉRATEUR DE MAPPER SPRING BOOT")
+
+		entityName := args[0]
+		mapperType, _ := cmd.Flags().GetString("type")
+		if mapperType == "" {
+			mapperType = mapperManual
+			if hasBuildDependency("org.mapstruct", "mapstruct") {
+				mapperType = mapperMapStruct
+			}
+		}
+		if mapperType != mapperMapStruct && mapperType != mapperManual {
+			utils.PrintError(fmt.Sprintf("Type de mapper inconnu: %s (valeurs possibles: mapstruct, manual)", mapperType))
+			os.Exit(1)
+		}
+		if mapperType == mapperMapStruct && isKotlin() {
+			utils.PrintWarning("MapStruct n'est pas supporté pour Kotlin: génération d'un mapper manuel")
+			mapperType = mapperManual
+		}
+
+		_, fields, relations, err := readEntity(entityName)
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire l'entité %s: %v", entityName, err))
+			os.Exit(1)
+		}
+
+		dtos := readEntityDtos(entityName)
+		if len(dtos) == 0 {
+			utils.PrintError(fmt.Sprintf("Aucun DTO trouvé pour %s: lancez d'abord 'springcli generate dto %s'", entityName, entityName))
+			os.Exit(1)
+		}
+
+		if mapperType == mapperMapStruct && !hasBuildDependency("org.mapstruct", "mapstruct") {
+			addMapStruct()
+		}
+
+		utils.PrintInfo(fmt.Sprintf("Génération du mapper: %sMapper (%s)", entityName, mapperType))
+		generateMapper(entityName, mapperType, fields, relations, dtos)
+	},
+}
+
+const mapstructMapperTemplate = `package {{.packageName}}.mapper;
+{{range .dtos}}
+import {{$.packageName}}.dto.{{.ClassName}};
+{{- end}}
+import {{.packageName}}.entity.{{.entityName}};
+import org.mapstruct.Mapper;
+import org.mapstruct.Mapping;
+{{- if .update}}
+import org.mapstruct.MappingTarget;
+{{- end}}
+
+@Mapper(componentModel = "spring")
+public interface {{.entityName}}Mapper {
+{{- with .response}}
+
+{{- range $.relationSources}}
+    @Mapping(source = "{{.}}.id", target = "{{.}}Id")
+{{- end}}
+    {{.ClassName}} toResponse({{$.entityName}} {{$.entityVar}});
+{{- end}}
+{{- with .create}}
+
+    @Mapping(target = "id", ignore = true)
+{{- range $.relations}}
+    @Mapping(target = "{{.Name}}", ignore = true)
+{{- end}}
+    {{$.entityName}} toEntity({{.ClassName}} request);
+{{- end}}
+{{- with .update}}
+
+    @Mapping(target = "id", ignore = true)
+{{- range $.relations}}
+    @Mapping(target = "{{.Name}}", ignore = true)
+{{- end}}
+    void updateEntity({{.ClassName}} request, @MappingTarget {{$.entityName}} {{$.entityVar}});
+{{- end}}
+}
+`
+
+const manualMapperTemplate = `package {{.packageName}}.mapper;
+{{range .dtos}}
+import {{$.packageName}}.dto.{{.ClassName}};
+{{- end}}
+import {{.packageName}}.entity.{{.entityName}};
+import org.springframework.stereotype.Component;
+
+@Component
+public class {{.entityName}}Mapper {
+{{- with .response}}
+    public {{.ClassName}} toResponse({{$.entityName}} {{$.entityVar}}) {
+        if ({{$.entityVar}} == null) {
+            return null;
+        }
+{{- if .Record}}
+        return new {{.ClassName}}(
+{{- range $i, $l := $.responseValues}}{{if $i}},{{end}}
+                {{$l}}
+{{- end}}
+        );
+{{- else}}
+        {{.ClassName}} response = new {{.ClassName}}();
+{{- range $.responseSetters}}
+        {{.}}
+{{- end}}
+        return response;
+{{- end}}
+    }
+{{- end}}
+{{- with .create}}
+
+    public {{$.entityName}} toEntity({{.ClassName}} request) {
+        {{$.entityName}} {{$.entityVar}} = new {{$.entityName}}();
+{{- range $.createSetters}}
+        {{.}}
+{{- end}}
+        return {{$.entityVar}};
+    }
+{{- end}}
+{{- with .update}}
+
+    public void updateEntity({{.ClassName}} request, {{$.entityName}} {{$.entityVar}}) {
+{{- range $.updateSetters}}
+        {{.}}
+{{- end}}
+    }
+{{- end}}
+}
+`
+
+const kotlinMapperTemplate = `package {{.packageName}}.mapper
+{{range .dtos}}
+import {{$.packageName}}.dto.{{.ClassName}}
+{{- end}}
+import {{.packageName}}.entity.{{.entityName}}
+import org.springframework.stereotype.Component
+
+@Component
+class {{.entityName}}Mapper {
+{{- with .response}}
+    fun toResponse({{$.entityVar}}: {{$.entityName}}) = {{.ClassName}}(
+{{- range $.responseValues}}
+        {{.}},
+{{- end}}
+    )
+{{- end}}
+{{- with .create}}
+
+    fun toEntity(request: {{.ClassName}}) = {{$.entityName}}(
+{{- range $.createSetters}}
+        {{.}},
+{{- end}}
+    )
+{{- end}}
+{{- with .update}}
+
+    fun updateEntity(request: {{.ClassName}}, {{$.entityVar}}: {{$.entityName}}) {
+{{- range $.updateSetters}}
+        {{.}}
+{{- end}}
+    }
+{{- end}}
+}
+`
+
+// dtoSource décrit un DTO existant, relu depuis son code source.
+type dtoSource struct {
+	Kind      string
+	ClassName string
+	Record    bool
+	Fields    []Field
+}
+
+// readEntityDtos relit les DTO déjà générés pour une entité.
+func readEntityDtos(entityName string) []dtoSource {
+	var dtos []dtoSource
+	for _, kind := range []string{dtoCreate, dtoUpdate, dtoResponse} {
+		className := dtoClassName(entityName, kind)
+		data, err := os.ReadFile(getSourcePath() + "/dto/" + sourceFile(className))
+		if err != nil {
+			continue
+		}
+		dto := dtoSource{Kind: kind, ClassName: className}
+		dto.Record, dto.Fields = parseDtoFields(string(data))
+		dtos = append(dtos, dto)
+	}
+	sort.Slice(dtos, func(i, j int) bool { return dtos[i].ClassName < dtos[j].ClassName })
+	return dtos
+}
+
+// parseDtoFields extrait les champs d'un DTO Java (classe ou record) ou Kotlin (data class).
+func parseDtoFields(content string) (bool, []Field) {
+	var fieldRegexp *regexp.Regexp
+	record := false
+	switch {
+	case isKotlin():
+		fieldRegexp = regexp.MustCompile(`(?m)^\s*(?:@\S+\s+)*val\s+(\w+)\s*:\s*(\w+)`)
+	case strings.Contains(content, "public record "):
+		record = true
+		fieldRegexp = regexp.MustCompile(`(?m)^\s*(?:@\w+(?:\([^)]*\))?\s+)*([\w<>]+)\s+(\w+)\s*,?$`)
+	default:
+		fieldRegexp = regexp.MustCompile(`(?m)^\s*private\s+([\w<>]+)\s+(\w+);`)
+	}
+
+	var fields []Field
+	for _, m := range fieldRegexp.FindAllStringSubmatch(content, -1) {
+		if isKotlin() {
+			fields = append(fields, Field{Name: m[1], Type: javaTypeFromKotlin(m[2])})
+			continue
+		}
+		fields = append(fields, Field{Name: m[2], Type: m[1]})
+	}
+	return record, fields
+}
+
+func generateMapper(entityName, mapperType string, fields []Field, relations []Relation, dtos []dtoSource) {
+	entityVar := uncapitalize(entityName)
+	params := map[string]interface{}{
+		"entityName":  entityName,
+		"entityVar":   entityVar,
+		"dtos":        dtos,
+		"relations":   relations,
+		"packageName": basePackage(),
+	}
+
+	entityFields := make(map[string]Field)
+	for _, f := range fields {
+		entityFields[f.Name] = f
+	}
+	singleRelations := make(map[string]bool)
+	for _, r := range relations {
+		if !isCollection(r) {
+			singleRelations[r.Name] = true
+		}
+	}
+
+	for _, dto := range dtos {
+		dto := dto
+		params[dto.Kind] = &dto
+		switch dto.Kind {
+		case dtoResponse:
+			values, setters, sources := responseMapping(dto, entityVar, entityFields, singleRelations)
+			params["responseValues"] = values
+			params["responseSetters"] = setters
+			params["relationSources"] = sources
+		case dtoCreate:
+			params["createSetters"] = requestMapping(dto, entityVar, entityFields, true)
+		case dtoUpdate:
+			params["updateSetters"] = requestMapping(dto, entityVar, entityFields, false)
+		}
+	}
+
+	mapperTemplate := manualMapperTemplate
+	if mapperType == mapperMapStruct {
+		mapperTemplate = mapstructMapperTemplate
+	}
+
+	tmpl, err := template.New("mapper").Funcs(templateFuncs).Parse(languageTemplate(mapperTemplate, kotlinMapperTemplate))
+	if err != nil {
+		utils.PrintError(fmt.Sprintf("Erreur lors du parsing du template: %v", err))
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		utils.PrintError(fmt.Sprintf("Erreur lors de l'exécution du template: %v", err))
+		os.Exit(1)
+	}
+
+	path := getSourcePath() + "/mapper"
+	filename := sourceFile(entityName + "Mapper")
+	fullPath := path + "/" + filename
+
+	// Crée le dossier s'il n'existe pas
+	if !utils.Exists(path) {
+		err := utils.CreateFolder(path)
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Erreur lors de la création du dossier: %v", err))
+			os.Exit(1)
+		}
+	}
+
+	// Vérifie si le fichier existe déjà
+	if utils.Exists(fullPath) {
+		utils.PrintWarning(fmt.Sprintf("Le fichier %s existe déjà", filename))
+		return
+	}
+
+	generateFile(path, filename, buf.Bytes())
+}
+
+// responseMapping calcule, pour chaque champ du DTO de réponse, l'expression lue sur l'entité.
+// Elle renvoie les arguments du constructeur (records, Kotlin), les appels aux setters (classes)
+// et les relations dont l'identifiant est exposé (MapStruct).
+func responseMapping(dto dtoSource, entityVar string, entityFields map[string]Field, singleRelations map[string]bool) ([]string, []string, []string) {
+	var values, setters, sources []string
+	for _, f := range dto.Fields {
+		var expr string
+		switch {
+		case f.Name == "id":
+			expr = entityVar + ".getId()"
+			if isKotlin() {
+				expr = entityVar + ".id"
+			}
+		case singleRelations[strings.TrimSuffix(f.Name, "Id")] && strings.HasSuffix(f.Name, "Id"):
+			relation := strings.TrimSuffix(f.Name, "Id")
+			sources = append(sources, relation)
+			getter := entityVar + ".get" + capitalize(relation) + "()"
+			expr = getter + " != null ? " + getter + ".getId() : null"
+			if isKotlin() {
+				expr = entityVar + "." + relation + "?.id"
+			}
+		default:
+			ef, ok := entityFields[f.Name]
+			if !ok {
+				continue
+			}
+			expr = entityVar + "." + getterName(ef.Name, ef.Type) + "()"
+			if isKotlin() {
+				expr = entityVar + "." + ef.Name
+			}
+		}
+
+		if isKotlin() {
+			values = append(values, f.Name+" = "+expr)
+			continue
+		}
+		values = append(values, expr)
+		setters = append(setters, "response.set"+capitalize(f.Name)+"("+expr+");")
+	}
+	return values, setters, sources
+}
+
+// requestMapping calcule les affectations de l'entité à partir d'un DTO de requête.
+// Les identifiants de relation sont laissés au service, qui charge les entités liées
+// (voir requestRelations).
+func requestMapping(dto dtoSource, entityVar string, entityFields map[string]Field, constructor bool) []string {
+	var lines []string
+	for _, f := range dto.Fields {
+		ef, ok := entityFields[f.Name]
+		if !ok {
+			continue
+		}
+		read := "request." + getterName(f.Name, f.Type) + "()"
+		if dto.Record {
+			read = "request." + f.Name + "()"
+		}
+
+		switch {
+		case isKotlin() && constructor:
+			lines = append(lines, ef.Name+" = request."+f.Name)
+		case isKotlin():
+			lines = append(lines, entityVar+"."+ef.Name+" = request."+f.Name)
+		default:
+			lines = append(lines, entityVar+".set"+capitalize(ef.Name)+"("+read+");")
+		}
+	}
+	return lines
+}
+
+// addMapStruct ajoute MapStruct et son processeur d'annotations au fichier de build.
+// Avec Lombok, le binding lombok-mapstruct est aussi déclaré pour que les deux
+// processeurs s'exécutent dans le bon ordre.
+func addMapStruct() {
+	version := mapstructVersion
+	if gradleBuildFile() == "" {
+		if err := setBuildProperty(mapstructVersionProperty, mapstructVersion); err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de modifier le pom.xml: %v", err))
+			os.Exit(1)
+		}
+		version = "${" + mapstructVersionProperty + "}"
+	}
+
+	err := addBuildDependency(buildfile.Dependency{GroupID: "org.mapstruct", ArtifactID: "mapstruct", Version: version}, "implementation")
+	if err == nil {
+		err = addAnnotationProcessor(buildfile.Dependency{GroupID: "org.mapstruct", ArtifactID: "mapstruct-processor", Version: version})
+	}
+	if err == nil && hasLombok() {
+		err = addAnnotationProcessor(buildfile.Dependency{GroupID: "org.projectlombok", ArtifactID: "lombok", Version: lombokProcessorVersion()})
+		if err == nil {
+			err = addAnnotationProcessor(buildfile.Dependency{GroupID: "org.projectlombok", ArtifactID: "lombok-mapstruct-binding", Version: lombokMapstructBinding})
+		}
+	}
+	if err != nil {
+		utils.PrintError(fmt.Sprintf("Impossible d'ajouter MapStruct au fichier de build: %v", err))
+		os.Exit(1)
+	}
+	utils.PrintSuccess("MapStruct ajouté au fichier de build")
+}
+
+// lombokProcessorVersion renvoie la version de Lombok à déclarer comme processeur:
+// celle gérée par Spring Boot pour Maven, aucune pour Gradle (déjà déclaré).
+func lombokProcessorVersion() string {
+	if gradleBuildFile() != "" {
+		return ""
+	}
+	return "${lombok.version}"
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ==================== MAPPING DES ENTITÉS ====================
+
+// entityMapping décrit la table et la clé primaire d'une entité. Les entités créées par
+// generate entity suivent les conventions (table au nom de l'entité, clé Long générée par
+// la base); celles issues d'un schéma existant peuvent s'en écarter.
+type entityMapping struct {
+	Table     string
+	KeyType   string
+	KeyColumn string
+	Generated bool
+	// KeyFields sont les champs d'une clé composite (@EmbeddedId <Entité>Id)
+	KeyFields []Field
+}
+
+func defaultEntityMapping(entityName string) entityMapping {
+	return entityMapping{Table: strings.ToLower(entityName), KeyType: "Long", Generated: true}
+}
+
+// composite indique si l'entité a une clé primaire composite.
+func (m entityMapping) composite() bool {
+	return len(m.KeyFields) > 0
+}
+
+// keyAnnotations renvoie les annotations du champ id.
+func (m entityMapping) keyAnnotations() []string {
+	if m.composite() {
+		return []string{"@EmbeddedId"}
+	}
+	annotations := []string{"@Id"}
+	if m.Generated {
+		annotations = append(annotations, "@GeneratedValue(strategy = GenerationType.IDENTITY)")
+	}
+	if m.KeyColumn != "" {
+		annotations = append(annotations, fmt.Sprintf(`@Column(name = "%s")`, m.KeyColumn))
+	}
+	return annotations
+}
+
+// keyImports renvoie les imports nécessaires au champ id.
+func (m entityMapping) keyImports() []string {
+	if m.composite() {
+		return []string{"jakarta.persistence.EmbeddedId"}
+	}
+	imports := []string{"jakarta.persistence.Id"}
+	if m.Generated {
+		imports = append(imports, "jakarta.persistence.GeneratedValue", "jakarta.persistence.GenerationType")
+	}
+	if m.KeyColumn != "" {
+		imports = append(imports, "jakarta.persistence.Column")
+	}
+	if imp := typeImport(m.KeyType); imp != "" {
+		imports = append(imports, imp)
+	}
+	return imports
+}
+
+// annotationArgs reconnaît les arguments d'une annotation, y compris des annotations
+// imbriquées (@JoinTable(joinColumns = @JoinColumn(...))).
+const annotationArgs = `\((?:[^()]|\([^()]*\))*\)`
+
+var (
+	tableAnnotationRegexp   = regexp.MustCompile(`@Table\(name\s*=\s*"(\w+)"`)
+	columnNameRegexp        = regexp.MustCompile(`@(?:field:)?Column\([^)]*\bname\s*=\s*"(\w+)"`)
+	javaKeyRegexp           = regexp.MustCompile(`(?m)((?:^[ \t]*@[^\n]*\n)*)^[ \t]*private\s+(\w+)\s+id;`)
+	kotlinKeyRegexp         = regexp.MustCompile(`(?m)((?:^[ \t]*@[^\n]*\n)*)^[ \t]*va[rl]\s+id\s*:\s*(\w+)`)
+	keyFieldRegexp          = regexp.MustCompile(`@Column\(name = "(\w+)"\)\s+(?:val\s+(\w+)\s*:\s*(\w+)|([\w<>]+)\s+(\w+))`)
+	mappedByRegexp          = regexp.MustCompile(`mappedBy\s*=\s*"(\w+)"`)
+	joinTableRegexp         = regexp.MustCompile(`@JoinTable\(name\s*=\s*"(\w+)"`)
+	joinColumnsRegexp       = regexp.MustCompile(`\bjoinColumns\s*=\s*\[?\s*@?JoinColumn\(name\s*=\s*"(\w+)"`)
+	inverseJoinColumnRegexp = regexp.MustCompile(`inverseJoinColumns\s*=\s*\[?\s*@?JoinColumn\(name\s*=\s*"(\w+)"`)
+	joinColumnRegexp        = regexp.MustCompile(`(?m)^\s*@JoinColumn\(name\s*=\s*"(\w+)"`)
+	requiredRelationRegexp  = regexp.MustCompile(`\boptional\s*=\s*false|@JoinColumn\([^)]*\bnullable\s*=\s*false`)
+	mapsIDRegexp            = regexp.MustCompile(`@MapsId\("(\w+)"\)`)
+)
+
+// readEntityMapping relit le mapping d'une entité existante; les conventions s'appliquent
+// si l'entité n'existe pas encore.
+func readEntityMapping(entityName string) entityMapping {
+	m := defaultEntityMapping(entityName)
+	data, err := os.ReadFile(getSourcePath() + "/entity/" + sourceFile(entityName))
+	if err != nil {
+		return m
+	}
+	content := string(data)
+	if t := tableAnnotationRegexp.FindStringSubmatch(content); t != nil {
+		m.Table = t[1]
+	}
+
+	if strings.Contains(content, "@EmbeddedId") {
+		m.KeyType = entityName + "Id"
+		m.Generated = false
+		m.KeyFields = readKeyFields(entityName + "Id")
+		return m
+	}
+
+	key := javaKeyRegexp.FindStringSubmatch(content)
+	if isKotlin() {
+		key = kotlinKeyRegexp.FindStringSubmatch(content)
+	}
+	if key == nil {
+		return m
+	}
+	m.KeyType = boxedType(javaTypeFromKotlin(key[2]))
+	if isKotlin() && key[2] == "Int" {
+		m.KeyType = "Integer"
+	}
+	m.Generated = strings.Contains(key[1], "@GeneratedValue")
+	if column := columnNameRegexp.FindStringSubmatch(key[1]); column != nil {
+		m.KeyColumn = column[1]
+	}
+	return m
+}
+
+// readKeyFields relit les champs de la classe de clé composite d'une entité.
+func readKeyFields(className string) []Field {
+	data, err := os.ReadFile(getSourcePath() + "/entity/" + sourceFile(className))
+	if err != nil {
+		return nil
+	}
+	var fields []Field
+	for _, m := range keyFieldRegexp.FindAllStringSubmatch(string(data), -1) {
+		f := Field{Name: m[5], Type: m[4], Column: m[1]}
+		if m[2] != "" {
+			f = Field{Name: m[2], Type: javaTypeFromKotlin(m[3]), Column: m[1]}
+		}
+		f.JSONName = f.Name
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// isEntityKeyType indique si le type est une classe de clé composite (@Embeddable) du
+// package entity.
+func isEntityKeyType(t string) bool {
+	if !strings.HasSuffix(t, "Id") {
+		return false
+	}
+	data, err := os.ReadFile(getSourcePath() + "/entity/" + sourceFile(t))
+	return err == nil && strings.Contains(string(data), "@Embeddable")
+}
+
+// fieldColumnName relit le nom de colonne explicite d'un champ dans ses annotations.
+func fieldColumnName(annotations string) string {
+	if m := columnNameRegexp.FindStringSubmatch(annotations); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// readRelationMapping complète une relation avec le mapping lu dans ses annotations.
+func readRelationMapping(r Relation, annotations string) Relation {
+	if m := mappedByRegexp.FindStringSubmatch(annotations); m != nil {
+		r.MappedBy = m[1]
+	}
+	if m := joinTableRegexp.FindStringSubmatch(annotations); m != nil {
+		r.JoinTable = m[1]
+		if c := joinColumnsRegexp.FindStringSubmatch(annotations); c != nil {
+			r.JoinColumn = c[1]
+		}
+		if c := inverseJoinColumnRegexp.FindStringSubmatch(annotations); c != nil {
+			r.InverseJoinColumn = c[1]
+		}
+	} else if m := joinColumnRegexp.FindStringSubmatch(annotations); m != nil {
+		r.JoinColumn = m[1]
+	}
+	r.Required = requiredRelationRegexp.MatchString(annotations)
+	if m := mapsIDRegexp.FindStringSubmatch(annotations); m != nil {
+		r.MapsID = m[1]
+	}
+	return r
+}
+
+// relationAnnotations renvoie les annotations JPA d'une relation.
+func relationAnnotations(r Relation) []string {
+	annotation := r.Type
+	switch {
+	case r.MappedBy != "":
+		annotation += fmt.Sprintf(`(mappedBy = "%s")`, r.MappedBy)
+	case r.Required:
+		annotation += "(optional = false)"
+	}
+	annotations := []string{annotation}
+	if r.MapsID != "" {
+		annotations = append(annotations, fmt.Sprintf(`@MapsId("%s")`, r.MapsID))
+	}
+
+	joinColumn := func(name string) string {
+		if isKotlin() {
+			return fmt.Sprintf(`[JoinColumn(name = "%s")]`, name)
+		}
+		return fmt.Sprintf(`@JoinColumn(name = "%s")`, name)
+	}
+	switch {
+	case r.JoinTable != "":
+		annotations = append(annotations, fmt.Sprintf(`@JoinTable(name = "%s", joinColumns = %s, inverseJoinColumns = %s)`,
+			r.JoinTable, joinColumn(r.JoinColumn), joinColumn(r.InverseJoinColumn)))
+	case r.JoinColumn != "" && r.Required:
+		annotations = append(annotations, fmt.Sprintf(`@JoinColumn(name = "%s", nullable = false)`, r.JoinColumn))
+	case r.JoinColumn != "":
+		annotations = append(annotations, fmt.Sprintf(`@JoinColumn(name = "%s")`, r.JoinColumn))
+	}
+	return annotations
+}
+
+// relationImports renvoie les imports JPA d'une relation.
+func relationImports(r Relation) []string {
+	imports := []string{"jakarta.persistence." + strings.TrimPrefix(r.Type, "@")}
+	if r.JoinTable != "" {
+		imports = append(imports, "jakarta.persistence.JoinTable")
+	}
+	if r.JoinColumn != "" {
+		imports = append(imports, "jakarta.persistence.JoinColumn")
+	}
+	if r.MapsID != "" {
+		imports = append(imports, "jakarta.persistence.MapsId")
+	}
+	return imports
+}
+
+// snakeToCamel convertit un nom de colonne ou de table en camelCase (first_name -> firstName).
+func snakeToCamel(name string) string {
+	if strings.ToUpper(name) == name {
+		name = strings.ToLower(name)
+	}
+	var b strings.Builder
+	upper := false
+	for i, r := range name {
+		switch {
+		case r == '_' || r == '-' || r == ' ':
+			upper = b.Len() > 0
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		case i == 0 || b.Len() == 0:
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// singularize renvoie le singulier (anglais) d'un nom: orders -> order, categories -> category.
+func singularize(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(lower, "sses"), strings.HasSuffix(lower, "xes"), strings.HasSuffix(lower, "ches"), strings.HasSuffix(lower, "shes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(lower, "ss"), strings.HasSuffix(lower, "us"), strings.HasSuffix(lower, "is"):
+		return name
+	case strings.HasSuffix(lower, "s") && len(name) > 1:
+		return name[:len(name)-1]
+	}
+	return name
+}
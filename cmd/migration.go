@@ -0,0 +1,457 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"springcli/internal/migration"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	generateEntityCmd.Flags().String("migration", "", "Outil de migration: flyway, liquibase ou none (défaut: détecté dans le fichier de build)")
+	generateEntityCmd.Flags().String("dialect", "", "Base de données des migrations: postgres, mysql, mariadb ou h2 (défaut: détectée)")
+}
+
+// ==================== MIGRATIONS DE SCHÉMA ====================
+const (
+	migrationFlyway    = "flyway"
+	migrationLiquibase = "liquibase"
+	migrationNone      = "none"
+
+	flywayPath         = "src/main/resources/db/migration"
+	liquibasePath      = "src/main/resources/db/changelog"
+	liquibaseMaster    = liquibasePath + "/db.changelog-master.yaml"
+	liquibaseChanges   = liquibasePath + "/changes"
+	migrationTimestamp = "20060102150405"
+)
+
+type migrationSettings struct {
+	Tool    string
+	Dialect migration.Dialect
+}
+
+// resolveMigration détermine l'outil de migration et le dialecte à partir des options
+// --migration et --dialect, ou à défaut des dépendances du fichier de build.
+func resolveMigration(cmd *cobra.Command) migrationSettings {
+	tool, _ := cmd.Flags().GetString("migration")
+	dialectName, _ := cmd.Flags().GetString("dialect")
+
+	switch tool {
+	case "":
+		tool = detectMigrationTool()
+	case migrationFlyway, migrationLiquibase, migrationNone:
+	default:
+		utils.PrintError(fmt.Sprintf("Outil de migration inconnu: %s (valeurs possibles: flyway, liquibase, none)", tool))
+		os.Exit(1)
+	}
+	if tool == migrationNone {
+		return migrationSettings{Tool: tool}
+	}
+
+	if dialectName == "" {
+		return migrationSettings{Tool: tool, Dialect: detectDialect()}
+	}
+	dialect, err := migration.ParseDialect(dialectName)
+	if err != nil {
+		utils.PrintError(err.Error())
+		os.Exit(1)
+	}
+	return migrationSettings{Tool: tool, Dialect: dialect}
+}
+
+// detectMigrationTool renvoie flyway ou liquibase selon la dépendance déclarée, none sinon.
+func detectMigrationTool() string {
+	switch {
+	case hasBuildDependency("org.flywaydb", "flyway-core"):
+		return migrationFlyway
+	case hasBuildDependency("org.liquibase", "liquibase-core"):
+		return migrationLiquibase
+	default:
+		return migrationNone
+	}
+}
+
+// jdbcDrivers associe les drivers JDBC à leur base de données. Le premier driver d'une base
+// est celui que springcli déclare lorsqu'il doit l'ajouter.
+var jdbcDrivers = []struct {
+	groupID, artifactID string
+	dialect             migration.Dialect
+}{
+	{"org.postgresql", "postgresql", migration.Postgres},
+	{"com.mysql", "mysql-connector-j", migration.MySQL},
+	{"mysql", "mysql-connector-java", migration.MySQL},
+	{"org.mariadb.jdbc", "mariadb-java-client", migration.MariaDB},
+	{"com.h2database", "h2", migration.H2},
+}
+
+// detectDialect déduit la base de données du driver JDBC déclaré (PostgreSQL par défaut).
+func detectDialect() migration.Dialect {
+	for _, d := range jdbcDrivers {
+		if hasBuildDependency(d.groupID, d.artifactID) {
+			return d.dialect
+		}
+	}
+	utils.PrintWarning("Aucun driver JDBC détecté, migrations générées pour PostgreSQL (voir --dialect)")
+	return migration.Postgres
+}
+
+// tableName renvoie le nom de la table d'une entité (celui de @Table si elle existe).
+func tableName(entityName string) string {
+	return readEntityMapping(entityName).Table
+}
+
+// columnName applique la convention de nommage physique de Spring Boot (firstName -> first_name).
+func columnName(property string) string {
+	return splitCamelCase(property, "_")
+}
+
+// fieldColumn convertit un champ d'entité en colonne.
+func fieldColumn(d migration.Dialect, f Field) (migration.Column, error) {
+	length, _ := f.constraintValue("max")
+	javaType := f.Type
+	if isEnumType(f.Type) {
+		// Les énumérations sont stockées par leur nom (@Enumerated(EnumType.STRING))
+		javaType = "String"
+	}
+	sqlType, err := migration.SQLType(d, javaType, length)
+	if err != nil {
+		return migration.Column{}, fmt.Errorf("champ %s: %w", f.Name, err)
+	}
+	name := f.Column
+	if name == "" {
+		name = columnName(f.Name)
+	}
+	return migration.Column{
+		Name:     name,
+		Type:     sqlType,
+		Nullable: !f.hasConstraint("required") && !isPrimitive(f.Type),
+		Unique:   f.hasConstraint("unique"),
+	}, nil
+}
+
+// isEnumType indique si le type est une énumération du package entity.
+func isEnumType(t string) bool {
+	if _, err := migration.SQLType(migration.Postgres, t, 0); err == nil || !regexp.MustCompile(`^[A-Z]\w*$`).MatchString(t) {
+		return false
+	}
+	data, err := os.ReadFile(getSourcePath() + "/entity/" + sourceFile(t))
+	return err == nil && regexp.MustCompile(`\benum\s+(?:class\s+)?`+t+`\b`).Match(data)
+}
+
+// keyColumns renvoie les colonnes de clé primaire d'une entité.
+func keyColumns(d migration.Dialect, m entityMapping) ([]migration.Column, error) {
+	if m.composite() {
+		var columns []migration.Column
+		for _, f := range m.KeyFields {
+			column, err := fieldColumn(d, f)
+			if err != nil {
+				return nil, err
+			}
+			column.Nullable = false
+			columns = append(columns, column)
+		}
+		return columns, nil
+	}
+	sqlType, err := migration.SQLType(d, m.KeyType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("clé primaire: %w", err)
+	}
+	return []migration.Column{{Name: keyColumnName(m), Type: sqlType, AutoIncrement: m.Generated}}, nil
+}
+
+// keyColumnName renvoie la colonne de clé primaire (simple) d'une entité.
+func keyColumnName(m entityMapping) string {
+	if m.KeyColumn != "" {
+		return m.KeyColumn
+	}
+	return "id"
+}
+
+// referenceType renvoie le type SQL d'une clé étrangère vers une entité: celui de sa clé
+// primaire simple, BIGINT par défaut.
+func referenceType(d migration.Dialect, entityName string) string {
+	m := readEntityMapping(entityName)
+	if sqlType, err := migration.SQLType(d, m.KeyType, 0); err == nil && !m.composite() {
+		return sqlType
+	}
+	return "BIGINT"
+}
+
+// joinColumn renvoie la colonne de clé étrangère d'une relation simple (role -> role_id).
+func joinColumn(r Relation) string {
+	if r.JoinColumn != "" {
+		return r.JoinColumn
+	}
+	return columnName(r.Name) + "_id"
+}
+
+// joinTable renvoie la table de jointure d'une relation multiple, nommée comme le fait
+// la stratégie de nommage de Spring Boot: <table>_<relation>.
+func joinTable(d migration.Dialect, entityName string, r Relation) migration.Table {
+	owner := tableName(entityName)
+	name := owner + "_" + columnName(r.Name)
+	ownerColumn := owner + "_id"
+	targetColumn := columnName(r.Name) + "_id"
+	if r.JoinTable != "" {
+		name, ownerColumn, targetColumn = r.JoinTable, r.JoinColumn, r.InverseJoinColumn
+	}
+
+	t := migration.Table{
+		Name: name,
+		Columns: []migration.Column{
+			{Name: ownerColumn, Type: referenceType(d, entityName)},
+			{Name: targetColumn, Type: referenceType(d, r.Target)},
+		},
+		ForeignKeys: []migration.ForeignKey{
+			{Name: migration.ForeignKeyName(name, ownerColumn), Column: ownerColumn, RefTable: owner, RefColumn: keyColumnName(readEntityMapping(entityName))},
+			{Name: migration.ForeignKeyName(name, targetColumn), Column: targetColumn, RefTable: tableName(r.Target), RefColumn: keyColumnName(readEntityMapping(r.Target))},
+		},
+		Indexes: []migration.Index{
+			{Name: migration.IndexName(name, targetColumn), Table: name, Columns: []string{targetColumn}},
+		},
+	}
+	if r.Type == "@ManyToMany" {
+		t.PrimaryKey = []string{ownerColumn, targetColumn}
+	} else {
+		// Un élément ne peut appartenir qu'à une seule collection @OneToMany
+		t.Columns[1].Unique = true
+		t.Indexes = nil
+	}
+	return t
+}
+
+// entityTables construit la table d'une entité et ses tables de jointure.
+func entityTables(d migration.Dialect, entityName string, fields []Field, relations []Relation) ([]migration.Table, error) {
+	mapping := readEntityMapping(entityName)
+	keys, err := keyColumns(d, mapping)
+	if err != nil {
+		return nil, err
+	}
+	table := migration.Table{Name: mapping.Table, Columns: keys}
+	for _, k := range keys {
+		table.PrimaryKey = append(table.PrimaryKey, k.Name)
+	}
+	for _, f := range fields {
+		column, err := fieldColumn(d, f)
+		if err != nil {
+			return nil, err
+		}
+		table.Columns = append(table.Columns, column)
+	}
+
+	tables := []migration.Table{table}
+	for _, r := range relations {
+		if r.MappedBy != "" {
+			// Côté inverse: la clé étrangère est portée par l'autre entité
+			continue
+		}
+		if isCollection(r) {
+			tables = append(tables, joinTable(d, entityName, r))
+			continue
+		}
+		column := joinColumn(r)
+		if r.MapsID == "" {
+			// Avec @MapsId, la colonne est déjà celle de la clé composite
+			tables[0].Columns = append(tables[0].Columns, migration.Column{
+				Name:     column,
+				Type:     referenceType(d, r.Target),
+				Nullable: !r.Required,
+				Unique:   r.Type == "@OneToOne",
+			})
+		}
+		tables[0].ForeignKeys = append(tables[0].ForeignKeys, migration.ForeignKey{
+			Name:      migration.ForeignKeyName(table.Name, column),
+			Column:    column,
+			RefTable:  tableName(r.Target),
+			RefColumn: keyColumnName(readEntityMapping(r.Target)),
+		})
+		if r.Type != "@OneToOne" {
+			tables[0].Indexes = append(tables[0].Indexes, migration.Index{
+				Name:    migration.IndexName(table.Name, column),
+				Table:   table.Name,
+				Columns: []string{column},
+			})
+		}
+	}
+	return tables, nil
+}
+
+// generateCreateMigration écrit la migration de création de la table d'une nouvelle entité.
+func generateCreateMigration(s migrationSettings, entityName string, fields []Field, relations []Relation) {
+	if s.Tool == migrationNone {
+		return
+	}
+	tables, err := entityTables(s.Dialect, entityName, fields, relations)
+	if err != nil {
+		utils.PrintWarning(fmt.Sprintf("Migration non générée: %v", err))
+		return
+	}
+
+	var changes []migration.Change
+	for _, t := range tables {
+		changes = append(changes, migration.CreateTable{Table: t})
+	}
+	warnReservedTable(entityName)
+	writeMigration(s, "create_"+tableName(entityName)+"_table", changes)
+}
+
+// generateAlterMigration écrit la migration ajoutant à une table existante les champs et
+// relations ajoutés à son entité.
+func generateAlterMigration(s migrationSettings, entityName string, fields []Field, relations []Relation) {
+	if s.Tool == migrationNone || len(fields)+len(relations) == 0 {
+		return
+	}
+	table := tableName(entityName)
+
+	var changes []migration.Change
+	for _, f := range fields {
+		column, err := fieldColumn(s.Dialect, f)
+		if err != nil {
+			utils.PrintWarning(fmt.Sprintf("Migration non générée: %v", err))
+			return
+		}
+		column.Default = primitiveDefault(f)
+		changes = append(changes, migration.AddColumn{Table: table, Column: column})
+	}
+
+	relationTables, err := entityTables(s.Dialect, entityName, nil, relations)
+	if err != nil {
+		utils.PrintWarning(fmt.Sprintf("Migration non générée: %v", err))
+		return
+	}
+	for _, t := range relationTables[1:] {
+		changes = append(changes, migration.CreateTable{Table: t})
+	}
+	owner := relationTables[0]
+	for i, column := range owner.Columns[len(owner.PrimaryKey):] {
+		fk := owner.ForeignKeys[i]
+		changes = append(changes, migration.AddColumn{Table: table, Column: column, ForeignKey: &fk})
+	}
+	for _, idx := range owner.Indexes {
+		changes = append(changes, migration.CreateIndex{Index: idx})
+	}
+
+	writeMigration(s, "alter_"+table+"_table", changes)
+}
+
+// primitiveDefault renvoie la valeur par défaut donnée aux lignes existantes lors de
+// l'ajout d'une colonne de type primitif (non nullable).
+func primitiveDefault(f Field) string {
+	switch {
+	case f.Type == "boolean":
+		return "FALSE"
+	case isPrimitive(f.Type):
+		return "0"
+	}
+	return ""
+}
+
+// writeMigration enregistre les changements sous forme de script Flyway ou de changelog Liquibase.
+func writeMigration(s migrationSettings, description string, changes []migration.Change) {
+	version := nextMigrationVersion()
+
+	if s.Tool == migrationFlyway {
+		filename := fmt.Sprintf("V%s__%s.sql", version, description)
+		content := fmt.Sprintf("-- %s (%s)\n\n%s", strings.ReplaceAll(description, "_", " "), s.Dialect, migration.SQL(s.Dialect, changes))
+		writeNewFile(flywayPath, filename, []byte(content))
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.yaml", version, strings.ReplaceAll(description, "_", "-"))
+	content := migration.LiquibaseChangelog(version+"-"+strings.ReplaceAll(description, "_", "-"), "springcli", changes)
+	writeNewFile(liquibaseChanges, filename, []byte(content))
+	if err := includeInMasterChangelog("db/changelog/changes/" + filename); err != nil {
+		utils.PrintWarning(fmt.Sprintf("Impossible de mettre à jour %s: %v", liquibaseMaster, err))
+	}
+}
+
+// nextMigrationVersion renvoie un horodatage strictement supérieur aux versions existantes,
+// afin que plusieurs migrations générées dans la même seconde restent ordonnées.
+func nextMigrationVersion() string {
+	version, _ := strconv.ParseInt(time.Now().Format(migrationTimestamp), 10, 64)
+
+	versionRegexp := regexp.MustCompile(`^V?(\d{14})`)
+	for _, dir := range []string{flywayPath, liquibaseChanges} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if m := versionRegexp.FindStringSubmatch(e.Name()); m != nil {
+				if existing, _ := strconv.ParseInt(m[1], 10, 64); existing >= version {
+					version = existing + 1
+				}
+			}
+		}
+	}
+	return strconv.FormatInt(version, 10)
+}
+
+// includeInMasterChangelog ajoute un fichier au changelog principal de Liquibase, créé au besoin.
+func includeInMasterChangelog(file string) error {
+	content := "databaseChangeLog:\n"
+	if data, err := os.ReadFile(liquibaseMaster); err == nil {
+		content = string(data)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if strings.Contains(content, file) {
+		return nil
+	}
+
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += fmt.Sprintf("  - include:\n      file: %s\n", file)
+	if err := os.MkdirAll(filepath.Dir(liquibaseMaster), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(liquibaseMaster, []byte(content), 0o644)
+}
+
+// warnReservedTable signale une table portant un nom réservé (user, order...), qu'Hibernate
+// doit alors protéger lui aussi.
+func warnReservedTable(entityName string) {
+	if migration.IsReserved(tableName(entityName)) {
+		utils.PrintWarning(fmt.Sprintf("La table %s porte un nom réservé: ajoutez spring.jpa.properties.hibernate.auto_quote_keyword=true", tableName(entityName)))
+	}
+}
+
+// addedFields renvoie les champs absents de l'entité existante.
+func addedFields(existing, fields []Field) []Field {
+	var added []Field
+	for _, f := range fields {
+		found := false
+		for _, e := range existing {
+			found = found || e.Name == f.Name
+		}
+		if !found {
+			added = append(added, f)
+		}
+	}
+	return added
+}
+
+// addedRelations renvoie les relations absentes de l'entité existante.
+func addedRelations(existing, relations []Relation) []Relation {
+	var added []Relation
+	for _, r := range relations {
+		found := false
+		for _, e := range existing {
+			found = found || e.Name == r.Name
+		}
+		if !found {
+			added = append(added, r)
+		}
+	}
+	return added
+}
@@ -0,0 +1,728 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"springcli/internal/openapi"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	openapiExportCmd.Flags().StringP("output", "o", "openapi.yaml", "Fichier de spécification à écrire")
+	openapiExportCmd.Flags().String("title", "", "Titre de l'API (défaut: nom du projet)")
+	openapiExportCmd.Flags().String("api-version", "", "Version de l'API (défaut: version du projet)")
+	openapiExportCmd.Flags().String("server", "", "URL du serveur déclarée dans la spécification (ex: http://localhost:8080)")
+	openapiCmd.AddCommand(openapiExportCmd)
+	rootCmd.AddCommand(openapiCmd)
+}
+
+var openapiCmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Outils pour la spécification OpenAPI",
+	Long:  `Cette commande regroupe les outils liés à la spécification OpenAPI du projet.`,
+}
+
+// ==================== OPENAPI EXPORT ====================
+var openapiExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Génère la spécification OpenAPI 3.1 à partir des contrôleurs.",
+	Long: `Cette commande analyse statiquement les classes @RestController du package
+principal, sans démarrer l'application, et écrit une spécification OpenAPI 3.1:
+  - chemins et méthodes HTTP des @RequestMapping, @GetMapping, @PostMapping, ...
+    (y compris ceux déclarés sur une interface implémentée par le contrôleur)
+  - paramètres @PathVariable, @RequestParam, @RequestHeader, @CookieValue et Pageable
+  - corps @RequestBody et type de retour, avec @ResponseStatus pour le code HTTP
+  - schémas des DTO, records, entités et énumérations du projet, avec les contraintes
+    Bean Validation (@NotNull, @Size, @Min, @Max, @Email, @Pattern, @Positive)`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("📜 EXPORT DE LA SPÉCIFICATION OPENAPI")
+
+		types, err := scanSourceTypes(getSourcePath())
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire les sources de %s: %v", getSourcePath(), err))
+			os.Exit(1)
+		}
+
+		name, version := projectInfo()
+		title, _ := cmd.Flags().GetString("title")
+		if title == "" {
+			title = name
+		}
+		if v, _ := cmd.Flags().GetString("api-version"); v != "" {
+			version = v
+		}
+		doc := &openapi.Document{OpenAPI: "3.1.0", Info: openapi.Info{Title: title, Version: version}}
+		if server, _ := cmd.Flags().GetString("server"); server != "" {
+			doc.Servers = []openapi.Server{{URL: server}}
+		}
+
+		e := &apiExporter{doc: doc, types: types, operationIDs: map[string]bool{}}
+		controllers := e.export()
+		if controllers == 0 {
+			utils.PrintError("Aucune classe @RestController trouvée dans " + getSourcePath())
+			os.Exit(1)
+		}
+
+		data, err := openapi.Marshal(doc)
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de sérialiser la spécification: %v", err))
+			os.Exit(1)
+		}
+		output, _ := cmd.Flags().GetString("output")
+		if dir := filepath.Dir(output); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				utils.PrintError(fmt.Sprintf("Impossible de créer le dossier %s: %v", dir, err))
+				os.Exit(1)
+			}
+		}
+		if err := os.WriteFile(output, data, 0644); err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible d'écrire %s: %v", output, err))
+			os.Exit(1)
+		}
+
+		var rows [][]string
+		for _, p := range doc.Paths {
+			for _, o := range p.Item.Operations() {
+				rows = append(rows, []string{o.Method, p.Path, o.Operation.OperationID})
+			}
+		}
+		fmt.Println(formatQueryTable([]string{"Méthode", "Chemin", "Opération"}, rows))
+		utils.PrintSuccess(fmt.Sprintf("%s écrit: %d opération(s) de %d contrôleur(s), %d schéma(s)",
+			output, len(rows), controllers, len(doc.Components.Schemas)))
+	},
+}
+
+// projectInfo renvoie le nom et la version du projet lus dans le pom.xml ou le script
+// Gradle, avec le nom du dossier et 1.0.0 à défaut.
+func projectInfo() (string, string) {
+	name, version := "", ""
+	if data, err := os.ReadFile(pomPath); err == nil {
+		// Les valeurs du <parent> ne décrivent pas le projet
+		content := regexp.MustCompile(`(?s)<parent>.*?</parent>`).ReplaceAllString(string(data), "")
+		content = regexp.MustCompile(`(?s)<(dependencies|dependencyManagement|build|properties)>.*?</(dependencies|dependencyManagement|build|properties)>`).ReplaceAllString(content, "")
+		for _, tag := range []string{"artifactId", "name"} {
+			if m := regexp.MustCompile(`<` + tag + `>\s*([^<]+?)\s*</` + tag + `>`).FindStringSubmatch(content); m != nil {
+				name = m[1]
+			}
+		}
+		if m := regexp.MustCompile(`<version>\s*([^<$]+?)\s*</version>`).FindStringSubmatch(content); m != nil {
+			version = m[1]
+		}
+	} else if buildFile := gradleBuildFile(); buildFile != "" {
+		if data, err := os.ReadFile(buildFile); err == nil {
+			if m := regexp.MustCompile(`(?m)^\s*version\s*=\s*["']([^"']+)["']`).FindStringSubmatch(string(data)); m != nil {
+				version = m[1]
+			}
+		}
+		for _, settings := range []string{"settings.gradle.kts", "settings.gradle"} {
+			if data, err := os.ReadFile(settings); err == nil {
+				if m := regexp.MustCompile(`rootProject\.name\s*=\s*["']([^"']+)["']`).FindStringSubmatch(string(data)); m != nil {
+					name = m[1]
+				}
+			}
+		}
+	}
+	if name == "" {
+		if dir, err := os.Getwd(); err == nil {
+			name = filepath.Base(dir)
+		}
+	}
+	if version == "" {
+		version = "1.0.0"
+	}
+	return name, version
+}
+
+// ==================== ANALYSE DES CONTRÔLEURS ====================
+
+// apiExporter construit la spécification à partir des types du projet.
+type apiExporter struct {
+	doc          *openapi.Document
+	types        map[string]*sourceType
+	operationIDs map[string]bool
+}
+
+// export ajoute les opérations de chaque contrôleur et renvoie le nombre de contrôleurs.
+func (e *apiExporter) export() int {
+	controllers := 0
+	for _, name := range sortedTypeNames(e.types) {
+		t := e.types[name]
+		if t.Kind != "class" || !regexp.MustCompile(`@RestController\b`).MatchString(t.Header) {
+			continue
+		}
+		controllers++
+
+		// Les mappings peuvent être portés par une interface implémentée (générée par from-openapi)
+		declarations := []*sourceType{t}
+		for _, s := range t.Supertypes {
+			if i, ok := e.types[s]; ok && i.Kind == "interface" {
+				declarations = append(declarations, i)
+			}
+		}
+		basePath := ""
+		for _, d := range declarations {
+			if paths := mappingPaths(classAnnotations(d)); len(paths) > 0 {
+				basePath = paths[0]
+				break
+			}
+		}
+
+		tag := strings.TrimSuffix(strings.TrimSuffix(name, "Controller"), "Api")
+		seen := map[string]bool{}
+		for _, d := range declarations {
+			for _, m := range d.mappedMethods() {
+				if seen[m.Name] {
+					continue
+				}
+				seen[m.Name] = true
+				e.addOperation(tag, basePath, m)
+			}
+		}
+	}
+	return controllers
+}
+
+// classAnnotations renvoie les annotations placées juste avant la déclaration du type.
+func classAnnotations(t *sourceType) []sourceAnnotation {
+	var annotations []sourceAnnotation
+	for _, loc := range regexp.MustCompile(`@\w+`).FindAllStringIndex(t.Header, -1) {
+		// Les lignes d'import ne sont pas des annotations
+		if lineStart := strings.LastIndex(t.Header[:loc[0]], "\n") + 1; strings.TrimSpace(t.Header[lineStart:loc[0]]) != "" {
+			continue
+		}
+		a, _ := parseAnnotations(t.Header[loc[0]:])
+		if len(a) > 0 {
+			annotations = append(annotations, a[0])
+		}
+	}
+	return annotations
+}
+
+// mappingPaths renvoie les chemins déclarés par l'annotation @RequestMapping.
+func mappingPaths(annotations []sourceAnnotation) []string {
+	a, ok := findAnnotation(annotations, "RequestMapping")
+	if !ok {
+		return nil
+	}
+	return annotationPaths(a)
+}
+
+// annotationPaths renvoie les chemins d'une annotation de mapping (value ou path, simple ou tableau).
+func annotationPaths(a sourceAnnotation) []string {
+	for _, arg := range splitTopLevel(a.Args) {
+		key, value, named := strings.Cut(arg, "=")
+		if named && strings.TrimSpace(key) != "value" && strings.TrimSpace(key) != "path" {
+			continue
+		}
+		if !named {
+			value = arg
+		}
+		var paths []string
+		for _, m := range stringLiteralRegexp.FindAllStringSubmatch(value, -1) {
+			paths = append(paths, unquoteLiteral(m[1]))
+		}
+		return paths
+	}
+	return nil
+}
+
+var httpMethodRegexp = regexp.MustCompile(`RequestMethod\.(\w+)`)
+
+// pathVariableRegexp retire l'expression régulière d'une variable de chemin ({id:\d+} -> {id}).
+var pathVariableRegexp = regexp.MustCompile(`\{(\w+):[^}]*\}`)
+
+// addOperation ajoute une méthode de contrôleur à la spécification.
+func (e *apiExporter) addOperation(tag, basePath string, m sourceMember) {
+	var mapping sourceAnnotation
+	for _, a := range m.Annotations {
+		if strings.HasSuffix(a.Name, "Mapping") && a.Name != "PageableDefault" {
+			mapping = a
+			break
+		}
+	}
+	methods := []string{strings.ToUpper(strings.TrimSuffix(mapping.Name, "Mapping"))}
+	if mapping.Name == "RequestMapping" {
+		methods = nil
+		for _, v := range httpMethodRegexp.FindAllStringSubmatch(mapping.Args, -1) {
+			methods = append(methods, v[1])
+		}
+		if len(methods) == 0 {
+			methods = []string{"GET"}
+		}
+	}
+	paths := annotationPaths(mapping)
+	if len(paths) == 0 {
+		paths = []string{""}
+	}
+	produces, _ := mapping.value("produces")
+	if produces == "" || strings.Contains(produces, "MediaType") {
+		produces = "application/json"
+	}
+	consumes, _ := mapping.value("consumes")
+	if consumes == "" || strings.Contains(consumes, "MediaType") {
+		consumes = "application/json"
+	}
+
+	for _, path := range paths {
+		path = pathVariableRegexp.ReplaceAllString(joinPaths(basePath, path), "{$1}")
+		for _, method := range methods {
+			op := &openapi.Operation{
+				OperationID: e.operationID(m.Name, tag),
+				Summary:     m.Comment,
+				Tags:        []string{tag},
+			}
+			e.addParameters(op, m, consumes)
+			e.addResponse(op, m, produces)
+			e.setOperation(path, method, op)
+		}
+	}
+}
+
+// joinPaths concatène le chemin du contrôleur et celui de la méthode.
+func joinPaths(base, path string) string {
+	joined := "/" + strings.Trim(base, "/")
+	if p := strings.Trim(path, "/"); p != "" {
+		joined = strings.TrimSuffix(joined, "/") + "/" + p
+	}
+	return joined
+}
+
+// operationID renvoie le nom de la méthode, suffixé du tag s'il est déjà utilisé.
+func (e *apiExporter) operationID(name, tag string) string {
+	id := name
+	if e.operationIDs[id] {
+		id = name + tag
+	}
+	for i := 2; e.operationIDs[id]; i++ {
+		id = fmt.Sprintf("%s%s%d", name, tag, i)
+	}
+	e.operationIDs[id] = true
+	return id
+}
+
+func (e *apiExporter) setOperation(path, method string, op *openapi.Operation) {
+	var item *openapi.PathItem
+	for _, p := range e.doc.Paths {
+		if p.Path == path {
+			item = p.Item
+		}
+	}
+	if item == nil {
+		item = &openapi.PathItem{}
+		e.doc.Paths = append(e.doc.Paths, openapi.NamedPath{Path: path, Item: item})
+	}
+	switch method {
+	case "GET":
+		item.Get = op
+	case "POST":
+		item.Post = op
+	case "PUT":
+		item.Put = op
+	case "PATCH":
+		item.Patch = op
+	case "DELETE":
+		item.Delete = op
+	}
+}
+
+// ignoredParameterTypes sont résolus par Spring et n'apparaissent pas dans l'API.
+var ignoredParameterTypes = map[string]bool{
+	"HttpServletRequest": true, "HttpServletResponse": true, "HttpSession": true, "Principal": true,
+	"Authentication": true, "BindingResult": true, "Model": true, "Locale": true, "WebRequest": true,
+	"UriComponentsBuilder": true, "Jwt": true, "UserDetails": true,
+}
+
+// addParameters ajoute les paramètres et le corps de requête d'une méthode.
+func (e *apiExporter) addParameters(op *openapi.Operation, m sourceMember, consumes string) {
+	for _, p := range m.Params {
+		typ := simpleTypeName(p.Type)
+		optional := p.Nullable || p.HasDefault || typ == "Optional"
+
+		if a, ok := p.annotation("RequestBody"); ok {
+			required := !optional && !strings.Contains(regexp.MustCompile(`\s`).ReplaceAllString(a.Args, ""), "required=false")
+			op.RequestBody = &openapi.RequestBody{
+				Required: required,
+				Content:  openapi.Content{{Type: consumes, MediaType: &openapi.MediaType{Schema: e.schema(p.Type)}}},
+			}
+			continue
+		}
+
+		location := ""
+		var annotation sourceAnnotation
+		for _, candidate := range []struct{ name, in string }{
+			{"PathVariable", "path"}, {"RequestParam", "query"}, {"RequestHeader", "header"}, {"CookieValue", "cookie"},
+		} {
+			if a, ok := p.annotation(candidate.name); ok {
+				location, annotation = candidate.in, a
+				break
+			}
+		}
+
+		if typ == "Pageable" {
+			op.Parameters = append(op.Parameters, pageableParameters(p)...)
+			continue
+		}
+		if location == "" {
+			// Sans annotation, Spring lie les types simples aux paramètres de requête
+			if ignoredParameterTypes[typ] || scalarSchema(typ) == nil {
+				continue
+			}
+			location = "query"
+		}
+
+		name := p.Name
+		if v, ok := annotation.value("value", "name"); ok && v != "" {
+			name = v
+		}
+		param := &openapi.Parameter{Name: name, In: location, Required: true, Schema: e.schema(p.Type)}
+		if location != "path" {
+			if v, ok := annotation.value("required"); (ok && v == "false") || optional {
+				param.Required = false
+			}
+			if v, ok := annotation.value("defaultValue"); ok {
+				param.Required = false
+				param.Schema.Default = defaultValue(v, param.Schema)
+			}
+		}
+		// @RequestParam Map<String, String> reçoit tous les paramètres de la requête
+		if location == "query" && (typ == "Map" || typ == "MultiValueMap") {
+			explode := true
+			param.Name, param.Style, param.Explode = p.Name, "form", &explode
+			param.Required = false
+		}
+		applyConstraints(param.Schema, constraintsFromAnnotations(p.AnnotationText))
+		op.Parameters = append(op.Parameters, param)
+	}
+}
+
+// pageableParameters décrit les paramètres page, size et sort d'un Pageable.
+func pageableParameters(p sourceMember) []*openapi.Parameter {
+	size := 20
+	if a, ok := p.annotation("PageableDefault"); ok {
+		for _, key := range []string{"size", "value"} {
+			if v, ok := a.value(key); ok {
+				if n, err := strconv.Atoi(v); err == nil {
+					size = n
+				}
+			}
+		}
+	}
+	zero, one := 0.0, 1.0
+	return []*openapi.Parameter{
+		{Name: "page", In: "query", Description: "Numéro de page (à partir de 0)",
+			Schema: &openapi.Schema{Type: openapi.Types{"integer"}, Format: "int32", Minimum: &zero, Default: 0}},
+		{Name: "size", In: "query", Description: "Taille de la page",
+			Schema: &openapi.Schema{Type: openapi.Types{"integer"}, Format: "int32", Minimum: &one, Default: size}},
+		{Name: "sort", In: "query", Description: "Critères de tri: propriété,(asc|desc)",
+			Schema: &openapi.Schema{Type: openapi.Types{"array"}, Items: &openapi.Schema{Type: openapi.Types{"string"}}}},
+	}
+}
+
+// defaultValue convertit la valeur par défaut d'un @RequestParam selon le type du paramètre.
+func defaultValue(v string, s *openapi.Schema) interface{} {
+	switch {
+	case s.Is("integer"):
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	case s.Is("number"):
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	case s.Is("boolean"):
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return v
+}
+
+// successStatusCodes associe les constantes HttpStatus de succès à leur code.
+var successStatusCodes = map[string]string{"OK": "200"}
+
+func init() {
+	for code, name := range successStatusNames {
+		successStatusCodes[name] = code
+	}
+}
+
+// addResponse ajoute la réponse de succès d'une méthode, selon son type de retour et
+// son éventuelle annotation @ResponseStatus.
+func (e *apiExporter) addResponse(op *openapi.Operation, m sourceMember, produces string) {
+	status, name := "200", "OK"
+	if a, ok := m.annotation("ResponseStatus"); ok {
+		if v, ok := a.value("value", "code"); ok {
+			name = strings.TrimPrefix(v, "HttpStatus.")
+			if code, ok := successStatusCodes[name]; ok {
+				status = code
+			} else {
+				for code, n := range httpStatusNames {
+					if n == name {
+						status = strconv.Itoa(code)
+					}
+				}
+			}
+		}
+	}
+
+	response := &openapi.Response{Description: statusDescription(name)}
+	if status != "204" {
+		if schema := e.schema(m.Type); schema != nil {
+			response.Content = openapi.Content{{Type: produces, MediaType: &openapi.MediaType{Schema: schema}}}
+		}
+	}
+	op.Responses = openapi.Responses{{Status: status, Response: response}}
+}
+
+// statusDescription convertit NO_CONTENT en "No Content".
+func statusDescription(name string) string {
+	if name == "OK" {
+		return name
+	}
+	words := strings.Split(strings.ToLower(name), "_")
+	for i, w := range words {
+		words[i] = capitalize(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// ==================== SCHÉMAS ====================
+
+// simpleTypeName renvoie le nom d'un type sans package, arguments génériques ni nullabilité.
+func simpleTypeName(t string) string {
+	t = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(t), "?"))
+	if open := strings.Index(t, "<"); open >= 0 {
+		t = t[:open]
+	}
+	return t[strings.LastIndex(t, ".")+1:]
+}
+
+// typeArguments renvoie les arguments génériques d'un type (Map<String, Long> -> String, Long).
+func typeArguments(t string) []string {
+	t = strings.TrimSuffix(strings.TrimSpace(t), "?")
+	open := strings.Index(t, "<")
+	if open < 0 || !strings.HasSuffix(t, ">") {
+		return nil
+	}
+	return splitTypeArguments(t[open+1 : len(t)-1])
+}
+
+// scalarSchema renvoie le schéma d'un type simple Java ou Kotlin, nil sinon.
+func scalarSchema(t string) *openapi.Schema {
+	typed := func(typ, format string) *openapi.Schema {
+		return &openapi.Schema{Type: openapi.Types{typ}, Format: format}
+	}
+	switch t {
+	case "String", "char", "Character", "Char", "CharSequence":
+		return typed("string", "")
+	case "int", "Integer", "Int", "short", "Short", "byte", "Byte":
+		return typed("integer", "int32")
+	case "long", "Long", "BigInteger":
+		return typed("integer", "int64")
+	case "double", "Double":
+		return typed("number", "double")
+	case "float", "Float":
+		return typed("number", "float")
+	case "BigDecimal":
+		return typed("number", "")
+	case "boolean", "Boolean":
+		return typed("boolean", "")
+	case "LocalDate":
+		return typed("string", "date")
+	case "LocalDateTime", "OffsetDateTime", "ZonedDateTime", "Instant", "Date":
+		return typed("string", "date-time")
+	case "LocalTime":
+		return typed("string", "time")
+	case "Duration":
+		return typed("string", "duration")
+	case "UUID":
+		return typed("string", "uuid")
+	case "URI", "URL":
+		return typed("string", "uri")
+	case "MultipartFile", "Resource", "ByteArray":
+		return typed("string", "binary")
+	}
+	return nil
+}
+
+// schema renvoie le schéma d'un type Java ou Kotlin, en déclarant les composants des types
+// du projet. Il renvoie nil pour void, Void et Unit.
+func (e *apiExporter) schema(t string) *openapi.Schema {
+	t = strings.TrimSuffix(strings.TrimSpace(t), "?")
+	if strings.HasSuffix(t, "[]") {
+		item := strings.TrimSuffix(t, "[]")
+		if item == "byte" {
+			return &openapi.Schema{Type: openapi.Types{"string"}, Format: "byte"}
+		}
+		return &openapi.Schema{Type: openapi.Types{"array"}, Items: e.schemaOrAny(item)}
+	}
+
+	name, args := simpleTypeName(t), typeArguments(t)
+	arg := func(i int) string {
+		if i < len(args) {
+			return args[i]
+		}
+		return "Object"
+	}
+	switch name {
+	case "", "void", "Void", "Unit":
+		return nil
+	case "ResponseEntity", "Optional", "Mono", "CompletableFuture", "EntityModel":
+		return e.schema(arg(0))
+	case "List", "Set", "Collection", "Iterable", "MutableList", "MutableSet", "Array", "Flux", "CollectionModel":
+		return &openapi.Schema{Type: openapi.Types{"array"}, Items: e.schemaOrAny(arg(0))}
+	case "Map", "MutableMap", "HashMap", "MultiValueMap":
+		return &openapi.Schema{Type: openapi.Types{"object"}, AdditionalProperties: e.schemaOrAny(arg(len(args) - 1))}
+	case "Page", "PagedModel", "Slice":
+		return e.pagedSchema(arg(0))
+	case "Object", "Any", "JsonNode", "ObjectNode":
+		return &openapi.Schema{}
+	}
+	if s := scalarSchema(name); s != nil {
+		return s
+	}
+
+	source, ok := e.types[name]
+	if !ok || source.Kind == "interface" {
+		return &openapi.Schema{Type: openapi.Types{"object"}}
+	}
+	if e.doc.Components.Schemas.Get(name) == nil {
+		// Le composant est déclaré avant ses propriétés pour les types récursifs
+		component := &openapi.Schema{}
+		e.doc.Components.Schemas = append(e.doc.Components.Schemas, openapi.NamedSchema{Name: name, Schema: component})
+		if source.Kind == "enum" {
+			component.Type, component.Enum = openapi.Types{"string"}, source.enumConstants()
+		} else {
+			e.objectSchema(component, source)
+		}
+	}
+	return &openapi.Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (e *apiExporter) schemaOrAny(t string) *openapi.Schema {
+	if s := e.schema(t); s != nil {
+		return s
+	}
+	return &openapi.Schema{}
+}
+
+// pagedSchema déclare le composant Paged<T> correspondant à la sérialisation d'un PagedModel.
+func (e *apiExporter) pagedSchema(item string) *openapi.Schema {
+	name := "Paged" + simpleTypeName(item)
+	if e.doc.Components.Schemas.Get("PageMetadata") == nil {
+		integer := func() *openapi.Schema { return &openapi.Schema{Type: openapi.Types{"integer"}, Format: "int64"} }
+		e.doc.Components.Schemas = append(e.doc.Components.Schemas, openapi.NamedSchema{Name: "PageMetadata", Schema: &openapi.Schema{
+			Type: openapi.Types{"object"},
+			Properties: openapi.Schemas{
+				{Name: "size", Schema: integer()},
+				{Name: "number", Schema: integer()},
+				{Name: "totalElements", Schema: integer()},
+				{Name: "totalPages", Schema: integer()},
+			},
+		}})
+	}
+	if e.doc.Components.Schemas.Get(name) == nil {
+		e.doc.Components.Schemas = append(e.doc.Components.Schemas, openapi.NamedSchema{Name: name, Schema: &openapi.Schema{
+			Type: openapi.Types{"object"},
+			Properties: openapi.Schemas{
+				{Name: "content", Schema: &openapi.Schema{Type: openapi.Types{"array"}, Items: e.schemaOrAny(item)}},
+				{Name: "page", Schema: &openapi.Schema{Ref: "#/components/schemas/PageMetadata"}},
+			},
+		}})
+	}
+	return &openapi.Schema{Ref: "#/components/schemas/" + name}
+}
+
+// objectSchema renseigne les propriétés d'une classe, d'un record ou d'une classe Kotlin.
+func (e *apiExporter) objectSchema(component *openapi.Schema, source *sourceType) {
+	component.Type = openapi.Types{"object"}
+	for _, p := range source.properties() {
+		if _, ok := p.annotation("JsonIgnore"); ok {
+			continue
+		}
+		name := p.Name
+		if a, ok := p.annotation("JsonProperty"); ok {
+			if v, ok := a.value("value"); ok && v != "" {
+				name = v
+			}
+		}
+
+		schema := e.schemaOrAny(p.Type)
+		constraints := constraintsFromAnnotations(p.AnnotationText)
+		if _, ok := p.annotation("Id"); ok {
+			schema.ReadOnly = true
+		}
+		// Jackson Kotlin exige les propriétés non nulles sans valeur par défaut
+		if source.Kotlin && !p.Nullable && !p.HasDefault {
+			constraints = append(constraints, "required")
+		}
+		for _, c := range constraints {
+			if c == "required" && !schema.ReadOnly && !component.IsRequired(name) {
+				component.Required = append(component.Required, name)
+			}
+		}
+		applyConstraints(schema, constraints)
+		component.Properties = append(component.Properties, openapi.NamedSchema{Name: name, Schema: schema})
+	}
+}
+
+// applyConstraints traduit les contraintes Bean Validation en mots-clés JSON Schema
+// (inverse de apiGenerator.field).
+func applyConstraints(s *openapi.Schema, constraints []string) {
+	if s.Ref != "" {
+		return
+	}
+	for _, c := range constraints {
+		name, value, _ := strings.Cut(c, "=")
+		n, _ := strconv.Atoi(value)
+		bound := float64(n)
+		switch {
+		case name == "email":
+			s.Format = "email"
+		case name == "pattern":
+			s.Pattern = value
+		case name == "positive":
+			s.ExclusiveMinimum = 0
+		case (name == "min" || name == "max") && s.Is("string"):
+			if name == "min" {
+				s.MinLength = &n
+			} else {
+				s.MaxLength = &n
+			}
+		case (name == "min" || name == "max") && s.Is("array"):
+			if name == "min" {
+				s.MinItems = &n
+			} else {
+				s.MaxItems = &n
+			}
+		case name == "min" && (s.Is("integer") || s.Is("number")):
+			s.Minimum = &bound
+		case name == "max" && (s.Is("integer") || s.Is("number")):
+			s.Maximum = &bound
+		case name == "decimalmin" || name == "decimalmax" || name == "exclusivemin" || name == "exclusivemax":
+			decimal, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			switch name {
+			case "decimalmin":
+				s.Minimum = &decimal
+			case "decimalmax":
+				s.Maximum = &decimal
+			case "exclusivemin":
+				s.ExclusiveMinimum = decimal
+			default:
+				s.ExclusiveMaximum = decimal
+			}
+		}
+	}
+}
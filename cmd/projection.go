@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	generateProjectionCmd.Flags().String("from", "", "Entité projetée (obligatoire)")
+	generateProjectionCmd.Flags().StringSlice("fields", nil, "Champs de l'entité exposés par la projection")
+	generateProjectionCmd.Flags().String("type", projectionInterface, "Type de projection: interface ou record")
+	generateProjectionCmd.Flags().StringArray("nested", nil, "Projection imbriquée d'une relation, sous la forme relation:champ1,champ2 (répétable)")
+	generateProjectionCmd.Flags().String("method", "", "Méthode de repository renvoyant la projection (défaut: find<Projection>By)")
+	generateProjectionCmd.Flags().String("return", "", "Type de retour de la méthode: optional, list, page ou stream (déduit par défaut)")
+	_ = generateProjectionCmd.MarkFlagRequired("from")
+	generateCmd.AddCommand(generateProjectionCmd)
+}
+
+// ==================== GENERATE PROJECTION ====================
+const (
+	projectionInterface = "interface"
+	projectionRecord    = "record"
+)
+
+var generateProjectionCmd = &cobra.Command{
+	Use:   "projection [projection-name]",
+	Short: "Génère une projection Spring Data et la méthode de repository associée.",
+	Long: `Cette commande génère une projection (interface ou record) exposant une partie
+des champs d'une entité, éventuellement avec des projections imbriquées sur ses
+relations, puis ajoute au repository de l'entité une méthode typée la renvoyant.
+La méthode peut être une requête dérivée (--method findByAgeGreaterThan).`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("🪞 GÉNÉRATEUR DE PROJECTION SPRING DATA")
+
+		projectionName := capitalize(args[0])
+		entityName, _ := cmd.Flags().GetString("from")
+		fieldNames, _ := cmd.Flags().GetStringSlice("fields")
+		kind, _ := cmd.Flags().GetString("type")
+		nestedSpecs, _ := cmd.Flags().GetStringArray("nested")
+		methodName, _ := cmd.Flags().GetString("method")
+		returnKind, _ := cmd.Flags().GetString("return")
+
+		if kind != projectionInterface && kind != projectionRecord {
+			utils.PrintError(fmt.Sprintf("Type de projection inconnu: %s (valeurs possibles: interface, record)", kind))
+			os.Exit(1)
+		}
+		if kind == projectionRecord && len(nestedSpecs) > 0 {
+			utils.PrintError("Les projections imbriquées ne sont possibles qu'avec --type interface")
+			os.Exit(1)
+		}
+
+		model, err := readQueryModel(entityName)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		projection, err := buildProjection(model, fieldNames, nestedSpecs)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		utils.PrintInfo(fmt.Sprintf("Génération de la projection: %s", projectionName))
+		params := map[string]interface{}{
+			"className":   projectionName,
+			"fields":      projection.Fields,
+			"nested":      projection.Nested,
+			"imports":     projection.imports(),
+			"packageName": basePackage(),
+		}
+		tmpl := languageTemplate(interfaceProjectionTemplate, kotlinInterfaceProjectionTemplate)
+		if kind == projectionRecord {
+			tmpl = languageTemplate(recordProjectionTemplate, kotlinRecordProjectionTemplate)
+		}
+		writeNewFile(getSourcePath()+"/projection", sourceFile(projectionName), renderTemplate("projection", tmpl, params))
+
+		if methodName == "" {
+			methodName = "find" + projectionName + "By"
+		}
+		addProjectionMethod(model, projectionName, methodName, returnKind)
+	},
+}
+
+const interfaceProjectionTemplate = `package {{.packageName}}.projection;
+{{- if .imports}}
+{{range .imports}}
+import {{.}};
+{{- end}}
+{{- end}}
+
+public interface {{.className}} {
+{{- range .fields}}
+    {{.Type}} {{getter .Name .Type}}();
+{{- end}}
+{{- range .nested}}
+    {{.Type}} {{getter .Name .Type}}();
+{{- end}}
+{{- range .nested}}
+
+    interface {{.View}} {
+{{- range .Fields}}
+        {{.Type}} {{getter .Name .Type}}();
+{{- end}}
+    }
+{{- end}}
+}
+`
+
+const recordProjectionTemplate = `package {{.packageName}}.projection;
+{{- if .imports}}
+{{range .imports}}
+import {{.}};
+{{- end}}
+{{- end}}
+
+public record {{.className}}(
+{{- range $i, $f := .fields}}{{if $i}},{{end}}
+        {{$f.Type}} {{$f.Name}}
+{{- end}}
+) {
+}
+`
+
+const kotlinInterfaceProjectionTemplate = `package {{.packageName}}.projection
+{{- if .imports}}
+{{range .imports}}
+import {{.}}
+{{- end}}
+{{- end}}
+
+interface {{.className}} {
+{{- range .fields}}
+    val {{.Name}}: {{kotlinNullableType .Type}}
+{{- end}}
+{{- range .nested}}
+    val {{.Name}}: {{kotlinNullableType .Type}}
+{{- end}}
+{{- range .nested}}
+
+    interface {{.View}} {
+{{- range .Fields}}
+        val {{.Name}}: {{kotlinNullableType .Type}}
+{{- end}}
+    }
+{{- end}}
+}
+`
+
+const kotlinRecordProjectionTemplate = `package {{.packageName}}.projection
+{{- if .imports}}
+{{range .imports}}
+import {{.}}
+{{- end}}
+{{- end}}
+
+data class {{.className}}(
+{{- range .fields}}
+    val {{.Name}}: {{kotlinNullableType .Type}},
+{{- end}}
+)
+`
+
+// nestedProjection est la projection imbriquée d'une relation (role -> RoleView).
+type nestedProjection struct {
+	Name   string
+	Type   string
+	View   string
+	Fields []Field
+}
+
+type projection struct {
+	Fields []Field
+	Nested []nestedProjection
+}
+
+// buildProjection vérifie les champs demandés dans l'entité et résout leurs types.
+func buildProjection(model queryModel, fieldNames, nestedSpecs []string) (projection, error) {
+	var p projection
+	if len(fieldNames) == 0 && len(nestedSpecs) == 0 {
+		return p, fmt.Errorf("indiquez les champs de la projection avec --fields")
+	}
+
+	fields, err := projectionFields(model, fieldNames)
+	if err != nil {
+		return p, err
+	}
+	p.Fields = fields
+
+	for _, spec := range nestedSpecs {
+		relationName, nestedFields, ok := strings.Cut(spec, ":")
+		if !ok {
+			return p, fmt.Errorf("projection imbriquée invalide: %s (format attendu: relation:champ1,champ2)", spec)
+		}
+
+		var relation *Relation
+		for i, r := range model.Relations {
+			if r.Name == relationName {
+				relation = &model.Relations[i]
+			}
+		}
+		if relation == nil {
+			return p, fmt.Errorf("la relation %s n'existe pas dans l'entité %s", relationName, model.Entity)
+		}
+
+		target, err := readQueryModel(relation.Target)
+		if err != nil {
+			return p, err
+		}
+		fields, err := projectionFields(target, strings.Split(nestedFields, ","))
+		if err != nil {
+			return p, err
+		}
+
+		view := relation.Target + "View"
+		typ := view
+		if isCollection(*relation) {
+			typ = "List<" + view + ">"
+		}
+		p.Nested = append(p.Nested, nestedProjection{Name: relation.Name, Type: typ, View: view, Fields: fields})
+	}
+	return p, nil
+}
+
+func projectionFields(model queryModel, names []string) ([]Field, error) {
+	var fields []Field
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		_, f, ok := model.property(capitalize(name))
+		if !ok || f.Name != name {
+			return nil, fmt.Errorf("le champ %s n'existe pas dans l'entité %s (champs: %s)",
+				name, model.Entity, strings.Join(model.propertyNames(), ", "))
+		}
+		if !isKnownType(f.Type) {
+			return nil, fmt.Errorf("%s est une relation: utilisez --nested %s:champ1,champ2", name, name)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func (p projection) imports() []string {
+	imports := map[string]bool{}
+	for _, f := range p.Fields {
+		if imp := typeImport(f.Type); imp != "" {
+			imports[imp] = true
+		}
+	}
+	for _, n := range p.Nested {
+		if strings.HasPrefix(n.Type, "List<") && !isKotlin() {
+			imports["java.util.List"] = true
+		}
+		for _, f := range n.Fields {
+			if imp := typeImport(f.Type); imp != "" {
+				imports[imp] = true
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(imports))
+	for imp := range imports {
+		sorted = append(sorted, imp)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// addProjectionMethod ajoute au repository une méthode dérivée renvoyant la projection.
+func addProjectionMethod(model queryModel, projectionName, methodName, returnKind string) {
+	if m := derivedQueryRegexp.FindStringSubmatch(methodName); m != nil {
+		switch m[1] {
+		case "exists", "count", "delete", "remove":
+			utils.PrintError(fmt.Sprintf("La méthode %s doit être une recherche (find..., read..., get...)", methodName))
+			os.Exit(1)
+		}
+	}
+
+	method, err := derivedQueryMethod(model, methodName, returnKind)
+	if err != nil {
+		utils.PrintError(err.Error())
+		os.Exit(1)
+	}
+	method.Element = projectionName
+	method.Extra = append(method.Extra, basePackage()+".projection."+projectionName)
+
+	if !utils.Exists(repositoryPath(model.Entity)) {
+		generateRepository(model.Entity)
+	}
+	added, err := addRepositoryMethod(model.Entity, method.Name, method.declaration(), method.imports())
+	if err != nil {
+		utils.PrintWarning(fmt.Sprintf("Méthode %s non ajoutée: %v", method.Name, err))
+		return
+	}
+	if !added {
+		utils.PrintWarning(fmt.Sprintf("La méthode %s existe déjà dans %sRepository", method.Name, model.Entity))
+		return
+	}
+	utils.PrintSuccess(fmt.Sprintf("Méthode ajoutée à %sRepository:", model.Entity))
+	fmt.Println(utils.BoxStyle.Render(method.declaration()))
+}
+
+// kotlinNullableType renvoie le type Kotlin nullable d'une propriété de projection.
+func kotlinNullableType(t string) string {
+	if inner, ok := strings.CutPrefix(t, "List<"); ok {
+		return "List<" + strings.TrimSuffix(inner, ">") + ">"
+	}
+	return kotlinType(t) + "?"
+}
@@ -0,0 +1,737 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	generateQueryCmd.Flags().String("return", "", "Type de retour d'une recherche: optional, list, page ou stream (déduit par défaut)")
+	generateQueryCmd.Flags().String("query", "", "Requête JPQL (ou SQL avec --native) à placer dans @Query")
+	generateQueryCmd.Flags().Bool("native", false, "La requête passée à --query est du SQL natif")
+	generateCmd.AddCommand(generateQueryCmd)
+}
+
+// ==================== GENERATE QUERY ====================
+var generateQueryCmd = &cobra.Command{
+	Use:   "query [entity-name] [method-name]",
+	Short: "Ajoute une méthode de requête au repository d'une entité.",
+	Long: `Cette commande ajoute une méthode de requête dérivée (ex: findByEmailAndStatus)
+au repository d'une entité. Les propriétés sont vérifiées dans le code source de
+l'entité et les types des paramètres en sont déduits. Sans nom de méthode, un
+assistant interactif construit la requête à partir des champs de l'entité.
+Avec --query, la méthode porte une requête @Query JPQL (ou native avec --native).`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("🔎 GÉNÉRATEUR DE REQUÊTES SPRING DATA")
+
+		entityName := args[0]
+		returnKind, _ := cmd.Flags().GetString("return")
+		query, _ := cmd.Flags().GetString("query")
+		native, _ := cmd.Flags().GetBool("native")
+
+		model, err := readQueryModel(entityName)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		var methodName string
+		if len(args) == 2 {
+			methodName = args[1]
+		} else if query != "" {
+			utils.PrintError("Le nom de la méthode est requis avec --query")
+			os.Exit(1)
+		} else {
+			methodName, returnKind = askDerivedQuery(model)
+		}
+
+		var method repositoryMethod
+		if query != "" {
+			method, err = queryMethod(model, methodName, query, native, returnKind)
+		} else {
+			method, err = derivedQueryMethod(model, methodName, returnKind)
+		}
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+
+		if !utils.Exists(repositoryPath(entityName)) {
+			generateRepository(entityName)
+		}
+		added, err := addRepositoryMethod(entityName, method.Name, method.declaration(), method.imports())
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+		if !added {
+			utils.PrintWarning(fmt.Sprintf("La méthode %s existe déjà dans %sRepository", method.Name, entityName))
+			return
+		}
+		utils.PrintSuccess(fmt.Sprintf("Méthode ajoutée à %sRepository:", entityName))
+		fmt.Println(utils.BoxStyle.Render(method.declaration()))
+	},
+}
+
+// ==================== MÉTHODES DE REPOSITORY ====================
+
+type methodParam struct {
+	Name        string
+	Type        string
+	Collection  bool
+	Annotations []string
+}
+
+// repositoryMethod décrit une méthode de repository, rendue en Java ou en Kotlin.
+type repositoryMethod struct {
+	Annotations []string
+	// Return est le type de retour: un type simple (boolean, long, User) ou un type
+	// générique décrit par Wrapper (Optional, List, Page, Stream) et Element.
+	Return  string
+	Wrapper string
+	Element string
+	Name    string
+	Params  []methodParam
+	// Extra contient les imports propres aux types utilisés par la méthode.
+	Extra []string
+}
+
+func (m repositoryMethod) declaration() string {
+	var lines []string
+	lines = append(lines, m.Annotations...)
+
+	var params []string
+	for _, p := range m.Params {
+		annotations := strings.Join(p.Annotations, " ")
+		if annotations != "" {
+			annotations += " "
+		}
+		if isKotlin() {
+			t := kotlinType(p.Type)
+			if p.Collection {
+				t = "Collection<" + t + ">"
+			}
+			params = append(params, annotations+p.Name+": "+t)
+		} else {
+			t := p.Type
+			if p.Collection {
+				t = "Collection<" + boxedType(t) + ">"
+			}
+			params = append(params, annotations+t+" "+p.Name)
+		}
+	}
+
+	if isKotlin() {
+		lines = append(lines, fmt.Sprintf("fun %s(%s): %s", m.Name, strings.Join(params, ", "), m.kotlinReturnType()))
+	} else {
+		lines = append(lines, fmt.Sprintf("%s %s(%s);", m.javaReturnType(), m.Name, strings.Join(params, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m repositoryMethod) javaReturnType() string {
+	if m.Wrapper != "" {
+		return m.Wrapper + "<" + m.Element + ">"
+	}
+	return m.Return
+}
+
+func (m repositoryMethod) kotlinReturnType() string {
+	switch m.Wrapper {
+	case "":
+		return kotlinType(capitalize(m.Return))
+	case "Optional":
+		// En Kotlin, Spring Data sait renvoyer directement un type nullable
+		return m.Element + "?"
+	default:
+		return m.Wrapper + "<" + m.Element + ">"
+	}
+}
+
+func (m repositoryMethod) imports() []string {
+	imports := map[string]bool{}
+	for _, imp := range m.Extra {
+		imports[imp] = true
+	}
+	for _, a := range m.Annotations {
+		switch {
+		case strings.HasPrefix(a, "@Query"):
+			imports["org.springframework.data.jpa.repository.Query"] = true
+		case strings.HasPrefix(a, "@Modifying"):
+			imports["org.springframework.data.jpa.repository.Modifying"] = true
+		case strings.HasPrefix(a, "@Transactional"):
+			imports["org.springframework.transaction.annotation.Transactional"] = true
+		}
+	}
+	for _, p := range m.Params {
+		if len(p.Annotations) > 0 {
+			imports["org.springframework.data.repository.query.Param"] = true
+		}
+		if p.Collection && !isKotlin() {
+			imports["java.util.Collection"] = true
+		}
+		if p.Type == "Pageable" {
+			imports["org.springframework.data.domain.Pageable"] = true
+		}
+		if imp := typeImport(p.Type); imp != "" {
+			imports[imp] = true
+		}
+	}
+	switch m.Wrapper {
+	case "Optional":
+		if !isKotlin() {
+			imports["java.util.Optional"] = true
+		}
+	case "List":
+		if !isKotlin() {
+			imports["java.util.List"] = true
+		}
+	case "Page":
+		imports["org.springframework.data.domain.Page"] = true
+	case "Stream":
+		imports["java.util.stream.Stream"] = true
+	}
+
+	sorted := make([]string, 0, len(imports))
+	for imp := range imports {
+		sorted = append(sorted, imp)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// ==================== MODÈLE DE L'ENTITÉ ====================
+
+// queryModel regroupe les propriétés d'une entité utilisables dans une requête.
+type queryModel struct {
+	Entity    string
+	Fields    []Field
+	Relations []Relation
+}
+
+func readQueryModel(entityName string) (queryModel, error) {
+	_, fields, relations, err := readEntity(entityName)
+	if err != nil {
+		return queryModel{}, fmt.Errorf("impossible de lire l'entité %s: %v", entityName, err)
+	}
+	fields = append([]Field{{Name: "id", Type: "Long", Constraints: []string{"unique"}}}, fields...)
+	return queryModel{Entity: entityName, Fields: fields, Relations: relations}, nil
+}
+
+// property résout une propriété (Email, RoleName, Role_Name) et renvoie son chemin et son type.
+func (m queryModel) property(name string) (path string, field Field, ok bool) {
+	name = strings.TrimPrefix(name, "_")
+	for _, f := range m.Fields {
+		if capitalize(f.Name) == name {
+			return f.Name, f, true
+		}
+	}
+	for _, r := range m.Relations {
+		if capitalize(r.Name) == name && !isCollection(r) {
+			return r.Name, Field{Name: r.Name, Type: r.Target}, true
+		}
+		// Propriété imbriquée: RoleName -> role.name
+		rest, found := strings.CutPrefix(name, capitalize(r.Name))
+		if !found || rest == "" {
+			continue
+		}
+		target, err := readQueryModel(r.Target)
+		if err != nil {
+			continue
+		}
+		if nested, f, ok := target.property(rest); ok {
+			return r.Name + "." + nested, f, true
+		}
+	}
+	return "", Field{}, false
+}
+
+// ==================== REQUÊTES DÉRIVÉES ====================
+
+type queryOperator struct {
+	Keyword     string
+	Params      int
+	Collection  bool
+	Description string
+}
+
+// queryOperators liste les mots-clés Spring Data reconnus, du plus long au plus court
+// pour que NotNull soit reconnu avant Null.
+var queryOperators = []queryOperator{
+	{"IsGreaterThanEqual", 1, false, ""},
+	{"GreaterThanEqual", 1, false, "Supérieur ou égal"},
+	{"IsLessThanEqual", 1, false, ""},
+	{"LessThanEqual", 1, false, "Inférieur ou égal"},
+	{"IsNotContaining", 1, false, ""},
+	{"NotContaining", 1, false, "Ne contient pas"},
+	{"IsStartingWith", 1, false, ""},
+	{"IsGreaterThan", 1, false, ""},
+	{"GreaterThan", 1, false, "Strictement supérieur"},
+	{"StartingWith", 1, false, "Commence par"},
+	{"IsEndingWith", 1, false, ""},
+	{"IsContaining", 1, false, ""},
+	{"IsLessThan", 1, false, ""},
+	{"IsNotEmpty", 0, false, ""},
+	{"EndingWith", 1, false, "Se termine par"},
+	{"Containing", 1, false, "Contient"},
+	{"IsNotNull", 0, false, ""},
+	{"IsBetween", 2, false, ""},
+	{"IsNotLike", 1, false, ""},
+	{"StartsWith", 1, false, ""},
+	{"LessThan", 1, false, "Strictement inférieur"},
+	{"NotEmpty", 0, false, "Collection non vide"},
+	{"IsBefore", 1, false, ""},
+	{"IsEmpty", 0, false, ""},
+	{"IsFalse", 0, false, ""},
+	{"NotNull", 0, false, "Non nul"},
+	{"Between", 2, false, "Entre deux valeurs"},
+	{"NotLike", 1, false, "Ne correspond pas au motif"},
+	{"EndsWith", 1, false, ""},
+	{"Contains", 1, false, ""},
+	{"IsAfter", 1, false, ""},
+	{"IsNotIn", 1, true, ""},
+	{"IsNull", 0, false, ""},
+	{"IsTrue", 0, false, ""},
+	{"IsLike", 1, false, ""},
+	{"Equals", 1, false, ""},
+	{"Before", 1, false, "Avant (dates)"},
+	{"IsNot", 1, false, ""},
+	{"After", 1, false, "Après (dates)"},
+	{"Empty", 0, false, "Collection vide"},
+	{"False", 0, false, "Faux"},
+	{"NotIn", 1, true, "Absent de la liste"},
+	{"Null", 0, false, "Nul"},
+	{"True", 0, false, "Vrai"},
+	{"Like", 1, false, "Correspond au motif"},
+	{"IsIn", 1, true, ""},
+	{"Not", 1, false, "Différent"},
+	{"Is", 1, false, ""},
+	{"In", 1, true, "Dans la liste"},
+}
+
+// queryPart est un critère d'une requête dérivée (ex: AgeGreaterThan).
+type queryPart struct {
+	Path     string
+	Field    Field
+	Operator queryOperator
+}
+
+var derivedQueryRegexp = regexp.MustCompile(`^(find|read|get|query|search|stream|exists|count|delete|remove)(\w*?)By(\w*)$`)
+
+// derivedQueryMethod analyse un nom de méthode dérivée et construit sa signature.
+func derivedQueryMethod(model queryModel, methodName, returnKind string) (repositoryMethod, error) {
+	m := derivedQueryRegexp.FindStringSubmatch(methodName)
+	if m == nil {
+		return repositoryMethod{}, fmt.Errorf("nom de méthode invalide: %s (attendu: findBy..., existsBy..., countBy... ou deleteBy...)", methodName)
+	}
+	subject, modifiers, predicate := m[1], m[2], m[3]
+
+	// Le tri (OrderByNameAsc) ne produit pas de paramètre mais ses propriétés doivent exister
+	if i := strings.Index(predicate, "OrderBy"); i >= 0 {
+		if err := checkOrderBy(model, predicate[i+len("OrderBy"):]); err != nil {
+			return repositoryMethod{}, err
+		}
+		predicate = predicate[:i]
+	}
+	predicate = strings.TrimSuffix(predicate, "AllIgnoreCase")
+
+	var parts []queryPart
+	for _, expression := range splitPredicate(predicate) {
+		part, err := parseQueryPart(model, strings.TrimSuffix(strings.TrimSuffix(expression, "IgnoringCase"), "IgnoreCase"))
+		if err != nil {
+			return repositoryMethod{}, err
+		}
+		parts = append(parts, part)
+	}
+
+	method := repositoryMethod{Name: methodName, Params: queryParams(parts)}
+	for _, p := range method.Params {
+		if !isKnownType(p.Type) {
+			method.Extra = append(method.Extra, basePackage()+".entity."+p.Type)
+		}
+	}
+
+	switch subject {
+	case "exists":
+		method.Return = "boolean"
+	case "count":
+		method.Return = "long"
+	case "delete", "remove":
+		method.Annotations = []string{"@Transactional"}
+		method.Return = "long"
+	default:
+		if returnKind == "" {
+			returnKind = defaultReturnKind(modifiers, parts)
+		}
+		if err := method.setFindReturn(returnKind, model.Entity); err != nil {
+			return repositoryMethod{}, err
+		}
+	}
+	return method, nil
+}
+
+// setFindReturn applique le type de retour d'une recherche (optional, list, page, stream).
+func (m *repositoryMethod) setFindReturn(returnKind, element string) error {
+	m.Element = element
+	switch returnKind {
+	case "optional":
+		m.Wrapper = "Optional"
+	case "list":
+		m.Wrapper = "List"
+	case "page":
+		m.Wrapper = "Page"
+		m.Params = append(m.Params, methodParam{Name: "pageable", Type: "Pageable"})
+	case "stream":
+		m.Wrapper = "Stream"
+	default:
+		return fmt.Errorf("type de retour inconnu: %s (valeurs possibles: optional, list, page, stream)", returnKind)
+	}
+	return nil
+}
+
+// defaultReturnKind renvoie optional pour findFirst/findTop ou une égalité sur des champs
+// uniques, list sinon.
+func defaultReturnKind(modifiers string, parts []queryPart) string {
+	if regexp.MustCompile(`^(?:Distinct)?(?:First|Top)1?$`).MatchString(modifiers) {
+		return "optional"
+	}
+	if len(parts) == 0 {
+		return "list"
+	}
+	for _, p := range parts {
+		equality := p.Operator.Keyword == "Equals" || p.Operator.Keyword == "Is"
+		if !equality || !p.Field.hasConstraint("unique") || strings.Contains(p.Path, ".") {
+			return "list"
+		}
+	}
+	return "optional"
+}
+
+// splitPredicate découpe EmailAndStatusOrAge en [Email, Status, Age] selon les mots And / Or.
+func splitPredicate(predicate string) []string {
+	var parts []string
+	var current strings.Builder
+	for _, word := range camelWords(predicate) {
+		if (word == "And" || word == "Or") && current.Len() > 0 {
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteString(word)
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
+
+// camelWords découpe un identifiant CamelCase en mots (les _ sont conservés en tête de mot).
+func camelWords(s string) []string {
+	var words []string
+	start := 0
+	for i, r := range s {
+		if i > 0 && (unicode.IsUpper(r) || r == '_') && s[i-1] != '_' {
+			words = append(words, s[start:i])
+			start = i
+		}
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+// parseQueryPart sépare la propriété et l'opérateur d'un critère (AgeGreaterThan).
+func parseQueryPart(model queryModel, expression string) (queryPart, error) {
+	for _, op := range queryOperators {
+		name, found := strings.CutSuffix(expression, op.Keyword)
+		if !found || name == "" {
+			continue
+		}
+		if path, field, ok := model.property(name); ok {
+			return queryPart{Path: path, Field: field, Operator: op}, nil
+		}
+	}
+	if path, field, ok := model.property(expression); ok {
+		return queryPart{Path: path, Field: field, Operator: queryOperator{Keyword: "Equals", Params: 1}}, nil
+	}
+	return queryPart{}, fmt.Errorf("la propriété %s n'existe pas dans l'entité %s (champs: %s)",
+		uncapitalize(expression), model.Entity, strings.Join(model.propertyNames(), ", "))
+}
+
+func checkOrderBy(model queryModel, orderBy string) error {
+	for _, order := range regexp.MustCompile(`(Asc|Desc)`).Split(orderBy, -1) {
+		if order == "" {
+			continue
+		}
+		if _, _, ok := model.property(order); !ok {
+			return fmt.Errorf("la propriété de tri %s n'existe pas dans l'entité %s", uncapitalize(order), model.Entity)
+		}
+	}
+	return nil
+}
+
+func (m queryModel) propertyNames() []string {
+	var names []string
+	for _, f := range m.Fields {
+		names = append(names, f.Name)
+	}
+	for _, r := range m.Relations {
+		if !isCollection(r) {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
+
+// queryParams déduit les paramètres de la méthode à partir des critères.
+func queryParams(parts []queryPart) []methodParam {
+	var params []methodParam
+	used := map[string]int{}
+	name := func(base string) string {
+		used[base]++
+		if used[base] > 1 {
+			return base + strconv.Itoa(used[base])
+		}
+		return base
+	}
+
+	for _, p := range parts {
+		base := p.Path
+		if i := strings.LastIndex(base, "."); i >= 0 {
+			base = p.Path[:i] + capitalize(p.Path[i+1:])
+			base = strings.ReplaceAll(base, ".", "")
+		}
+		typ := p.Field.Type
+		switch p.Operator.Keyword {
+		case "Like", "IsLike", "NotLike", "IsNotLike", "Containing", "IsContaining", "Contains",
+			"NotContaining", "IsNotContaining", "StartingWith", "IsStartingWith", "StartsWith",
+			"EndingWith", "IsEndingWith", "EndsWith":
+			typ = "String"
+		}
+
+		switch {
+		case p.Operator.Params == 2:
+			params = append(params,
+				methodParam{Name: name(base + "From"), Type: typ},
+				methodParam{Name: name(base + "To"), Type: typ})
+		case p.Operator.Collection:
+			params = append(params, methodParam{Name: name(pluralize(base)), Type: typ, Collection: true})
+		case p.Operator.Params == 1:
+			params = append(params, methodParam{Name: name(base), Type: typ})
+		}
+	}
+	return params
+}
+
+// isKnownType indique si un type est un type Java standard (et non une entité du projet).
+func isKnownType(t string) bool {
+	if isPrimitive(t) || isNumericType(t) || typeImport(t) != "" {
+		return true
+	}
+	switch t {
+	case "String", "Boolean", "Character", "Byte", "Object", "Pageable":
+		return true
+	}
+	return false
+}
+
+// ==================== REQUÊTES @Query ====================
+
+// queryMethod construit une méthode annotée @Query; ses paramètres sont les paramètres
+// nommés (:email) de la requête, typés d'après les champs de l'entité.
+func queryMethod(model queryModel, methodName, query string, native bool, returnKind string) (repositoryMethod, error) {
+	if !regexp.MustCompile(`^[a-z]\w*$`).MatchString(methodName) {
+		return repositoryMethod{}, fmt.Errorf("nom de méthode invalide: %s", methodName)
+	}
+	if regexp.MustCompile(`\?\d`).MatchString(query) {
+		return repositoryMethod{}, fmt.Errorf("utilisez des paramètres nommés (:email) plutôt que positionnels (?1)")
+	}
+
+	literal := strconv.Quote(query)
+	annotation := "@Query(" + literal + ")"
+	if native {
+		annotation = "@Query(value = " + literal + ", nativeQuery = true)"
+	}
+	method := repositoryMethod{Name: methodName, Annotations: []string{annotation}}
+
+	seen := map[string]bool{}
+	for _, m := range regexp.MustCompile(`:(\w+)`).FindAllStringSubmatch(query, -1) {
+		if seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		typ := "String"
+		if _, f, ok := model.property(capitalize(m[1])); ok {
+			typ = f.Type
+		}
+		method.Params = append(method.Params, methodParam{
+			Name:        m[1],
+			Type:        typ,
+			Annotations: []string{fmt.Sprintf(`@Param("%s")`, m[1])},
+		})
+	}
+
+	statement := strings.ToLower(strings.Fields(query + " ")[0])
+	switch {
+	case statement == "update" || statement == "delete":
+		method.Annotations = append([]string{"@Transactional", "@Modifying"}, method.Annotations...)
+		method.Return = "int"
+	case regexp.MustCompile(`(?i)^select\s+count\s*\(`).MatchString(query):
+		method.Return = "long"
+	default:
+		if returnKind == "" {
+			returnKind = "list"
+		}
+		if err := method.setFindReturn(returnKind, model.Entity); err != nil {
+			return repositoryMethod{}, err
+		}
+	}
+	return method, nil
+}
+
+// ==================== ASSISTANT INTERACTIF ====================
+
+// askDerivedQuery construit un nom de méthode dérivée à partir des champs de l'entité.
+func askDerivedQuery(model queryModel) (string, string) {
+	utils.PrintSubtitle(fmt.Sprintf("Propriétés de l'entité %s", model.Entity))
+	fmt.Println(formatQueryTable([]string{"Propriété", "Type"}, model.propertyRows()))
+
+	var subject string
+	utils.PrintPrompt("Type de requête (find, exists, count, delete) [find]: ")
+	fmt.Scanln(&subject)
+	if subject == "" {
+		subject = "find"
+	}
+
+	// Seuls les opérateurs décrits sont proposés, l'égalité en premier
+	operators := []queryOperator{{Keyword: "Equals", Params: 1, Description: "Égal"}}
+	for _, op := range queryOperators {
+		if op.Description != "" && op.Keyword != "Equals" {
+			operators = append(operators, op)
+		}
+	}
+	var operatorRows [][]string
+	for i, op := range operators {
+		operatorRows = append(operatorRows, []string{strconv.Itoa(i + 1), op.Keyword, op.Description})
+	}
+
+	var predicate strings.Builder
+	for {
+		var property string
+		utils.PrintPrompt("Propriété (laisser vide pour finir): ")
+		fmt.Scanln(&property)
+		if property == "" {
+			if predicate.Len() == 0 {
+				utils.PrintWarning("Au moins une propriété est requise")
+				continue
+			}
+			break
+		}
+		if _, _, ok := model.property(capitalize(property)); !ok {
+			utils.PrintWarning(fmt.Sprintf("Propriété inconnue: %s", property))
+			continue
+		}
+
+		if predicate.Len() > 0 {
+			var combinator string
+			utils.PrintPrompt("Combinaison avec le critère précédent (and/or) [and]: ")
+			fmt.Scanln(&combinator)
+			if strings.EqualFold(combinator, "or") {
+				predicate.WriteString("Or")
+			} else {
+				predicate.WriteString("And")
+			}
+		}
+
+		fmt.Println(formatQueryTable([]string{"#", "Opérateur", "Description"}, operatorRows))
+		var choice string
+		utils.PrintPrompt("Opérateur [1]: ")
+		fmt.Scanln(&choice)
+		index, err := strconv.Atoi(choice)
+		if err != nil || index < 1 || index > len(operators) {
+			index = 1
+		}
+
+		predicate.WriteString(capitalize(property))
+		if keyword := operators[index-1].Keyword; keyword != "Equals" {
+			predicate.WriteString(keyword)
+		}
+	}
+
+	var returnKind string
+	if subject == "find" {
+		utils.PrintPrompt("Type de retour (optional, list, page, stream) [déduit]: ")
+		fmt.Scanln(&returnKind)
+	}
+
+	methodName := subject + "By" + predicate.String()
+	utils.PrintInfo(fmt.Sprintf("Méthode: %s", methodName))
+	return methodName, returnKind
+}
+
+func (m queryModel) propertyRows() [][]string {
+	var rows [][]string
+	for _, f := range m.Fields {
+		rows = append(rows, []string{f.Name, f.Type})
+	}
+	for _, r := range m.Relations {
+		if !isCollection(r) {
+			rows = append(rows, []string{r.Name, r.Target})
+		}
+	}
+	return rows
+}
+
+// formatQueryTable met en forme un tableau, en colonnes de 22 caractères par défaut. Avec
+// widths, chaque colonne a sa largeur et les cellules trop longues sont tronquées.
+func formatQueryTable(headers []string, rows [][]string, widths ...int) string {
+	width := func(i int) int {
+		if i < len(widths) {
+			return widths[i]
+		}
+		return 22
+	}
+
+	var table strings.Builder
+
+	// En-têtes, élargis de la bordure qui sépare les cellules
+	headerRow := ""
+	for i, header := range headers {
+		w := width(i)
+		if i < len(headers)-1 {
+			w++
+		}
+		headerRow += utils.TableHeaderStyle.Width(w).Render(header)
+	}
+	table.WriteString(headerRow + "\n")
+
+	// Lignes: la dernière cellule n'a pas de bordure, le cadre ferme la ligne
+	for _, row := range rows {
+		rowStr := ""
+		for i, cell := range row {
+			if i < len(widths) {
+				cell = truncate(cell, widths[i]-2)
+			}
+			style := utils.TableCellStyle.Width(width(i))
+			if i == len(row)-1 {
+				style = style.BorderRight(false)
+			}
+			rowStr += style.Render(cell)
+		}
+		table.WriteString(rowStr + "\n")
+	}
+
+	return utils.BoxStyle.Render(table.String())
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ==================== MÉTHODES DE REPOSITORY ====================
+
+// repositoryPath renvoie le chemin du repository d'une entité.
+func repositoryPath(entityName string) string {
+	return getSourcePath() + "/repository/" + sourceFile(entityName+"Repository")
+}
+
+// addRepositoryMethod ajoute une méthode (annotations comprises) et ses imports au repository
+// d'une entité. La méthode n'est pas ajoutée si une méthode du même nom existe déjà.
+func addRepositoryMethod(entityName, methodName, declaration string, imports []string) (bool, error) {
+	path := repositoryPath(entityName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("repository %sRepository introuvable: %w", entityName, err)
+	}
+	content := string(data)
+
+	if regexp.MustCompile(`\b` + regexp.QuoteMeta(methodName) + `\s*\(`).MatchString(content) {
+		return false, nil
+	}
+
+	content = addImports(content, imports)
+
+	// Une interface Kotlin sans corps (interface X : JpaRepository<...>) en reçoit un
+	if isKotlin() && !strings.Contains(content, "{") {
+		content = strings.TrimRight(content, "\n") + " {\n}\n"
+	}
+
+	end := strings.LastIndex(content, "}")
+	if end < 0 {
+		return false, fmt.Errorf("impossible de trouver la fin de l'interface %sRepository", entityName)
+	}
+	before := strings.TrimRight(content[:end], " \t\n")
+	separator := "\n\n"
+	if strings.HasSuffix(before, "{") {
+		separator = "\n"
+	}
+	content = before + separator + "    " + strings.ReplaceAll(declaration, "\n", "\n    ") + "\n" + content[end:]
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// addImports ajoute les imports manquants en respectant l'ordre alphabétique du bloc
+// d'imports existant (ou après la déclaration de package s'il n'y en a pas).
+func addImports(content string, imports []string) string {
+	terminator := ";"
+	if isKotlin() {
+		terminator = ""
+	}
+
+	for _, imp := range imports {
+		line := "import " + imp + terminator
+		existing := regexp.MustCompile(`(?m)^import [^\n]*\n`).FindAllStringIndex(content, -1)
+		if regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(line) + `\s*$`).MatchString(content) {
+			continue
+		}
+
+		if len(existing) == 0 {
+			pkg := regexp.MustCompile(`(?m)^package [^\n]*\n`).FindStringIndex(content)
+			if pkg == nil {
+				content = line + "\n\n" + content
+				continue
+			}
+			content = content[:pkg[1]] + "\n" + line + "\n" + content[pkg[1]:]
+			continue
+		}
+
+		pos := existing[len(existing)-1][1]
+		for _, loc := range existing {
+			if strings.TrimSpace(content[loc[0]:loc[1]]) > line {
+				pos = loc[0]
+				break
+			}
+		}
+		content = content[:pos] + line + "\n" + content[pos:]
+	}
+	return content
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"runtime/debug"
 	"strings"
 
 	/* "github.com/charmbracelet/lipgloss" */
@@ -11,7 +12,36 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var version = "0.1.0"
+// Informations de version, injectées à la compilation:
+//
+//	go build -ldflags "-X springcli/cmd.version=1.2.0 -X springcli/cmd.commit=$(git rev-parse --short HEAD) -X springcli/cmd.date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
+
+func init() {
+	// Sans -ldflags, go install et go build renseignent la version du module et le commit
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	if version == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		version = strings.TrimPrefix(info.Main.Version, "v")
+	}
+	for _, setting := range info.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && commit == "":
+			commit = setting.Value
+			if len(commit) > 7 {
+				commit = commit[:7]
+			}
+		case setting.Key == "vcs.time" && date == "":
+			date = setting.Value
+		}
+	}
+}
 
 var rootCmd = &cobra.Command{
 	Use:   "springcli",
@@ -50,12 +80,28 @@ func displayVersion() {
 	versionBox.WriteString(utils.IconStyle.Render("🍃 ") + utils.MainTitleStyle.Render("SpringCLI"))
 	versionBox.WriteString("\n")
 	versionBox.WriteString(utils.VersionStyle.Render("Version " + version))
+	if details := buildDetails(); details != "" {
+		versionBox.WriteString("\n")
+		versionBox.WriteString(utils.CommandDescStyle.Render(details))
+	}
 	versionBox.WriteString("\n\n")
 	versionBox.WriteString(utils.CommandDescStyle.Render("Un outil moderne pour gérer vos projets Spring Boot"))
 
 	fmt.Println(utils.HelpBoxStyle.Render(versionBox.String()))
 }
 
+// buildDetails renvoie le commit et la date de compilation, vides s'ils sont inconnus.
+func buildDetails() string {
+	var details []string
+	if commit != "" {
+		details = append(details, "commit "+commit)
+	}
+	if date != "" {
+		details = append(details, "compilé le "+date)
+	}
+	return strings.Join(details, ", ")
+}
+
 func displayWelcomeScreen() {
 	fmt.Println()
 
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"springcli/internal/buildfile"
+	"springcli/internal/maven"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	searchDependencyCmd.Flags().IntP("limit", "n", 10, "Nombre maximal de résultats")
+	searchDependencyCmd.Flags().String("scope", "compile", "Scope Maven de la dépendance choisie: compile, runtime, test ou provided")
+	searchCmd.AddCommand(searchDependencyCmd)
+	rootCmd.AddCommand(searchCmd)
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Recherche dans les dépôts Maven",
+	Long:  `Cette commande regroupe les recherches dans les dépôts Maven.`,
+}
+
+// ==================== SEARCH DEPENDENCY ====================
+var searchDependencyCmd = &cobra.Command{
+	Use:   "dependency <terme>",
+	Short: "Recherche une dépendance et l'ajoute au fichier de build.",
+	Long: `Cette commande interroge l'API de recherche de Maven Central, ou celle configurée avec
+` + "`maven.search`" + ` dans .springcli.yaml (Nexus 3 ou Artifactory), et affiche les artefacts
+trouvés avec leur dernière version et leur description.
+
+La dépendance choisie est ensuite ajoutée au fichier de build comme avec 'springcli add
+dependency': sans version si le BOM de Spring Boot la gère.`,
+	Example: `  springcli search dependency jackson
+  springcli search dependency testcontainers --scope test --limit 20`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, _ := cmd.Flags().GetInt("limit")
+		scope, _ := cmd.Flags().GetString("scope")
+		if _, ok := gradleConfigurations[scope]; !ok {
+			utils.PrintError(fmt.Sprintf("Scope %s invalide (compile, runtime, test ou provided)", scope))
+			os.Exit(1)
+		}
+		if limit < 1 {
+			utils.PrintError("--limit doit être positif")
+			os.Exit(1)
+		}
+
+		utils.PrintTitle("🔎 RECHERCHE DE DÉPENDANCES")
+		artifacts, err := maven.Search(projectConfig().Maven.Search, args[0], limit)
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Recherche impossible: %v", err))
+			os.Exit(1)
+		}
+		if len(artifacts) == 0 {
+			utils.PrintInfo(fmt.Sprintf("Aucune dépendance trouvée pour %s", args[0]))
+			return
+		}
+		describeArtifacts(mavenRepository(), artifacts)
+		fmt.Println(formatArtifactTable(artifacts))
+
+		var choice string
+		utils.PrintPrompt(fmt.Sprintf("Dépendance à ajouter (1-%d, vide pour quitter): ", len(artifacts)))
+		fmt.Scanln(&choice)
+		if choice = strings.TrimSpace(choice); choice == "" {
+			return
+		}
+		n, err := strconv.Atoi(choice)
+		if err != nil || n < 1 || n > len(artifacts) {
+			utils.PrintError(fmt.Sprintf("Choix invalide: %s", choice))
+			os.Exit(1)
+		}
+
+		selected := artifacts[n-1]
+		dep := buildfile.Dependency{GroupID: selected.GroupID, ArtifactID: selected.ArtifactID, Scope: scope}
+		if err := addDependency(dep); err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+// describeArtifacts complète les résultats avec la description de leur pom, lue dans le
+// dépôt Maven en parallèle.
+func describeArtifacts(repository *maven.Repository, artifacts []maven.Artifact) {
+	var wg sync.WaitGroup
+	for i := range artifacts {
+		if artifacts[i].Description != "" || artifacts[i].Version == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(a *maven.Artifact) {
+			defer wg.Done()
+			a.Description = repository.Description(a.GroupID, a.ArtifactID, a.Version)
+		}(&artifacts[i])
+	}
+	wg.Wait()
+}
+
+func formatArtifactTable(artifacts []maven.Artifact) string {
+	headers := []string{"#", "GroupId", "ArtifactId", "Version", "Description"}
+	widths := []int{4, 30, 30, 14, 44}
+
+	var table strings.Builder
+
+	// En-têtes
+	headerRow := ""
+	for i, header := range headers {
+		headerRow += utils.TableHeaderStyle.Width(widths[i]).Render(header)
+	}
+	table.WriteString(headerRow + "\n")
+
+	// Lignes
+	for n, a := range artifacts {
+		rowStr := ""
+		cells := []string{strconv.Itoa(n + 1), a.GroupID, a.ArtifactID, a.Version, a.Description}
+		for i, cell := range cells {
+			rowStr += utils.TableCellStyle.Width(widths[i]).Render(truncate(cell, widths[i]-2))
+		}
+		table.WriteString(rowStr + "\n")
+	}
+
+	return utils.BoxStyle.Render(table.String())
+}
+
+// truncate raccourcit le texte à max caractères, pour garder une ligne par résultat.
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-1]) + "…"
+}
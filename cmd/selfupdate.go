@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"springcli/internal/release"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	selfUpdateCmd.Flags().Bool("force", false, "Réinstalle la dernière release même si la version installée est à jour")
+	selfUpdateCmd.Flags().BoolP("yes", "y", false, "Met à jour sans demander de confirmation")
+	rootCmd.AddCommand(selfUpdateCmd)
+}
+
+// ==================== SELF-UPDATE ====================
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Remplace SpringCLI par la dernière release publiée.",
+	Long: `Cette commande télécharge, depuis la dernière release GitHub, le binaire construit pour le
+système et l'architecture courants (archive tar.gz ou zip, ou binaire nu), vérifie sa somme
+SHA-256 avec le fichier de sommes de contrôle de la release, puis remplace le binaire installé.
+
+Le nouveau binaire est écrit à côté de l'ancien puis renommé par-dessus: une mise à jour
+interrompue laisse la version installée intacte. Une release sans fichier de sommes de
+contrôle est refusée.`,
+	Example: `  springcli self-update
+  springcli self-update --yes`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		utils.PrintTitle("⬆️  MISE À JOUR DE SPRINGCLI")
+		client := latestRelease()
+		latest, err := client.Latest()
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire la dernière release: %v", err))
+			os.Exit(1)
+		}
+		if !isNewerRelease(latest) && !force {
+			utils.PrintSuccess(fmt.Sprintf("SpringCLI %s est déjà la dernière version", version))
+			return
+		}
+
+		asset, err := latest.AssetFor(runtime.GOOS, runtime.GOARCH)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+		checksums, ok := latest.ChecksumsAsset()
+		if !ok {
+			utils.PrintError(fmt.Sprintf("La release %s ne publie pas de sommes de contrôle: mise à jour refusée", latest.TagName))
+			os.Exit(1)
+		}
+		executable, err := os.Executable()
+		if err == nil {
+			executable, err = filepath.EvalSymlinks(executable)
+		}
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Binaire installé introuvable: %v", err))
+			os.Exit(1)
+		}
+
+		utils.PrintInfo(fmt.Sprintf("SpringCLI %s → %s (%s)", version, latest.Version(), asset.Name))
+		utils.PrintInfo("Binaire remplacé: " + executable)
+		if !yes && !AskYesNo() {
+			utils.PrintInfo("Mise à jour annulée")
+			return
+		}
+
+		binary, err := downloadRelease(client, asset, checksums)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+		if err := release.Replace(executable, binary); err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de remplacer %s: %v", executable, err))
+			os.Exit(1)
+		}
+		utils.PrintSuccess(fmt.Sprintf("SpringCLI mis à jour en version %s", latest.Version()))
+	},
+}
+
+// downloadRelease télécharge le fichier de la release, vérifie sa somme de contrôle et en
+// extrait le binaire.
+func downloadRelease(client *release.Client, asset, checksums release.Asset) ([]byte, error) {
+	sums, err := client.Download(checksums)
+	if err != nil {
+		return nil, fmt.Errorf("impossible de télécharger %s: %v", checksums.Name, err)
+	}
+	data, err := client.Download(asset)
+	if err != nil {
+		return nil, fmt.Errorf("impossible de télécharger %s: %v", asset.Name, err)
+	}
+	if err := release.VerifyChecksum(sums, asset.Name, data); err != nil {
+		return nil, err
+	}
+	utils.PrintSuccess("Somme de contrôle vérifiée")
+	return release.Extract(asset.Name, data)
+}
@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"springcli/internal/utils"
+)
+
+// ==================== SPECIFICATIONS JPA ====================
+// Les filtres de liste sont passés en paramètres de requête:
+//
+//	GET /api/users?name=foo&age.gte=18&status.in=ACTIVE,LOCKED&sort=name,asc
+//
+// champ=valeur teste l'égalité, champ.like une sous-chaîne (insensible à la casse),
+// champ.gt|gte|lt|lte une borne et champ.in une liste de valeurs.
+
+type specificationFilter struct {
+	Name   string
+	Parser string
+}
+
+const specificationTemplate = `package {{.packageName}}.specification;
+{{range .imports}}
+import {{.}};
+{{- end}}
+
+/**
+ * Filtres dynamiques de {{.entityName}} construits à partir des paramètres de requête:
+ * champ=valeur, champ.like=texte, champ.gt|gte|lt|lte=valeur et champ.in=a,b,c.
+ */
+public final class {{.className}} {
+    private {{.className}}() {
+    }
+
+    public static Specification<{{.entityName}}> fromParams(Map<String, String> params) {
+        Specification<{{.entityName}}> specification = (root, query, cb) -> cb.conjunction();
+        for (Map.Entry<String, String> param : params.entrySet()) {
+            String[] key = param.getKey().split("\\.", 2);
+            String operator = key.length > 1 ? key[1] : "eq";
+            Specification<{{.entityName}}> condition = switch (key[0]) {
+{{- range .filters}}
+                case "{{.Name}}" -> filter(key[0], operator, param.getValue(), {{.Parser}});
+{{- end}}
+                default -> null;
+            };
+            if (condition != null) {
+                specification = specification.and(condition);
+            }
+        }
+        return specification;
+    }
+
+    private static <T extends Comparable<? super T>> Specification<{{.entityName}}> filter(
+            String field, String operator, String value, Function<String, T> parser) {
+        return (root, query, cb) -> switch (operator) {
+            case "eq" -> cb.equal(root.get(field), parse(field, value, parser));
+            case "like" -> cb.like(cb.lower(root.get(field).as(String.class)), "%" + value.toLowerCase() + "%");
+            case "gt" -> cb.greaterThan(root.<T>get(field), parse(field, value, parser));
+            case "gte" -> cb.greaterThanOrEqualTo(root.<T>get(field), parse(field, value, parser));
+            case "lt" -> cb.lessThan(root.<T>get(field), parse(field, value, parser));
+            case "lte" -> cb.lessThanOrEqualTo(root.<T>get(field), parse(field, value, parser));
+            case "in" -> root.get(field).in(Arrays.stream(value.split(","))
+                    .map(v -> parse(field, v.trim(), parser))
+                    .toList());
+            default -> throw new ResponseStatusException(HttpStatus.BAD_REQUEST, "Opérateur de filtre inconnu: " + operator);
+        };
+    }
+
+    private static <T> T parse(String field, String value, Function<String, T> parser) {
+        try {
+            return parser.apply(value);
+        } catch (RuntimeException e) {
+            throw new ResponseStatusException(HttpStatus.BAD_REQUEST, "Valeur invalide pour le filtre " + field + ": " + value);
+        }
+    }
+}
+`
+
+const kotlinSpecificationTemplate = `package {{.packageName}}.specification
+{{range .imports}}
+import {{.}}
+{{- end}}
+
+/**
+ * Filtres dynamiques de {{.entityName}} construits à partir des paramètres de requête:
+ * champ=valeur, champ.like=texte, champ.gt|gte|lt|lte=valeur et champ.in=a,b,c.
+ */
+object {{.className}} {
+
+    fun fromParams(params: Map<String, String>): Specification<{{.entityName}}> =
+        params.entries.fold(Specification<{{.entityName}}> { _, _, cb -> cb.conjunction() }) { specification, (key, value) ->
+            val field = key.substringBefore('.')
+            val operator = key.substringAfter('.', "eq")
+            val condition = when (field) {
+{{- range .filters}}
+                "{{.Name}}" -> filter(field, operator, value, {{.Parser}})
+{{- end}}
+                else -> null
+            }
+            if (condition != null) specification.and(condition) else specification
+        }
+
+    private fun <T : Comparable<T>> filter(
+        field: String,
+        operator: String,
+        value: String,
+        parser: (String) -> T,
+    ): Specification<{{.entityName}}> = Specification { root, _, cb ->
+        when (operator) {
+            "eq" -> cb.equal(root.get<T>(field), parse(field, value, parser))
+            "like" -> cb.like(cb.lower(root.get<Any>(field).` + "`as`" + `(String::class.java)), "%${value.lowercase()}%")
+            "gt" -> cb.greaterThan(root.get<T>(field), parse(field, value, parser))
+            "gte" -> cb.greaterThanOrEqualTo(root.get<T>(field), parse(field, value, parser))
+            "lt" -> cb.lessThan(root.get<T>(field), parse(field, value, parser))
+            "lte" -> cb.lessThanOrEqualTo(root.get<T>(field), parse(field, value, parser))
+            "in" -> root.get<T>(field).` + "`in`" + `(value.split(",").map { parse(field, it.trim(), parser) })
+            else -> throw ResponseStatusException(HttpStatus.BAD_REQUEST, "Opérateur de filtre inconnu: $operator")
+        }
+    }
+
+    private fun <T> parse(field: String, value: String, parser: (String) -> T): T =
+        try {
+            parser(value)
+        } catch (e: RuntimeException) {
+            throw ResponseStatusException(HttpStatus.BAD_REQUEST, "Valeur invalide pour le filtre $field: $value")
+        }
+}
+`
+
+// specificationPath renvoie le chemin des Specifications d'une entité.
+func specificationPath(entityName string) string {
+	return getSourcePath() + "/specification/" + sourceFile(entityName+"Specifications")
+}
+
+// serviceFiltersRegexp reconnaît la méthode findAll filtrable d'un service, en Java
+// (findAll(Map<String, String> filters, ...)) comme en Kotlin (findAll(filters: Map<...>, ...)).
+var serviceFiltersRegexp = regexp.MustCompile(`findAll\(\s*(?:filters\s*:\s*)?Map<`)
+
+// checkServiceFilters renvoie une erreur lorsque le service de l'entité existe déjà sans
+// recevoir les filtres: le contrôleur filtrable appellerait findAll(filters, pageable), que
+// le service ne déclare pas. Un service absent sera généré avec les filtres.
+func checkServiceFilters(entityName string) error {
+	path := getSourcePath() + "/service/" + sourceFile(entityName+"Service")
+	data, err := os.ReadFile(path)
+	if err != nil || serviceFiltersRegexp.Match(data) {
+		return nil
+	}
+	return fmt.Errorf("le service %sService existe déjà sans filtres: supprimez-le puis régénérez-le avec 'springcli generate service %s --filterable ...' avant le contrôleur", entityName, entityName)
+}
+
+// generateSpecification génère les Specifications JPA d'une entité pour les champs filtrables
+// et fait hériter son repository de JpaSpecificationExecutor.
+func generateSpecification(entityName string, filterable []string) {
+	_, fields, _, err := readEntity(entityName)
+	if err != nil {
+		utils.PrintError(fmt.Sprintf("Impossible de lire l'entité %s: %v", entityName, err))
+		os.Exit(1)
+	}
+	fieldTypes := map[string]string{"id": "Long"}
+	for _, f := range fields {
+		fieldTypes[f.Name] = f.Type
+	}
+
+	imports := map[string]bool{}
+	var filters []specificationFilter
+	for _, name := range filterable {
+		name = strings.TrimSpace(name)
+		typ, ok := fieldTypes[name]
+		if !ok {
+			utils.PrintError(fmt.Sprintf("Le champ %s n'existe pas dans l'entité %s", name, entityName))
+			os.Exit(1)
+		}
+		parser := filterParser(typ)
+		if parser == "" {
+			utils.PrintError(fmt.Sprintf("Le champ %s (%s) ne peut pas être filtré", name, typ))
+			os.Exit(1)
+		}
+		if imp := typeImport(typ); imp != "" {
+			imports[imp] = true
+		}
+		filters = append(filters, specificationFilter{Name: name, Parser: parser})
+	}
+
+	imports[basePackage()+".entity."+entityName] = true
+	imports["org.springframework.data.jpa.domain.Specification"] = true
+	imports["org.springframework.http.HttpStatus"] = true
+	imports["org.springframework.web.server.ResponseStatusException"] = true
+	if !isKotlin() {
+		imports["java.util.Arrays"] = true
+		imports["java.util.Map"] = true
+		imports["java.util.function.Function"] = true
+	}
+	sortedImports := make([]string, 0, len(imports))
+	for imp := range imports {
+		sortedImports = append(sortedImports, imp)
+	}
+	sort.Strings(sortedImports)
+
+	params := map[string]interface{}{
+		"className":   entityName + "Specifications",
+		"entityName":  entityName,
+		"filters":     filters,
+		"imports":     sortedImports,
+		"packageName": basePackage(),
+	}
+
+	utils.PrintInfo(fmt.Sprintf("Génération des filtres: %sSpecifications", entityName))
+	buf := renderTemplate("specification", languageTemplate(specificationTemplate, kotlinSpecificationTemplate), params)
+	writeNewFile(getSourcePath()+"/specification", sourceFile(entityName+"Specifications"), buf)
+
+	if !utils.Exists(repositoryPath(entityName)) {
+		generateRepository(entityName)
+	}
+	if err := addSpecificationExecutor(entityName); err != nil {
+		utils.PrintWarning(fmt.Sprintf("Impossible de modifier %sRepository: %v", entityName, err))
+	}
+}
+
+// filterParser renvoie la conversion d'un paramètre de requête vers le type du champ.
+func filterParser(t string) string {
+	if isKotlin() {
+		switch kotlinType(t) {
+		case "String":
+			return "{ it }"
+		case "Int", "Long", "Double", "Float", "BigDecimal":
+			return "String::to" + kotlinType(t)
+		case "Boolean":
+			return "String::toBooleanStrict"
+		case "LocalDate", "LocalDateTime", "LocalTime", "Instant":
+			return t + "::parse"
+		case "UUID":
+			return "UUID::fromString"
+		}
+		return ""
+	}
+
+	switch t {
+	case "String":
+		return "Function.identity()"
+	case "int", "long", "double", "float", "boolean":
+		return boxedType(t) + "::valueOf"
+	case "Integer", "Long", "Double", "Float", "Boolean":
+		return t + "::valueOf"
+	case "LocalDate", "LocalDateTime", "LocalTime", "Instant":
+		return t + "::parse"
+	case "BigDecimal":
+		return "BigDecimal::new"
+	case "UUID":
+		return "UUID::fromString"
+	}
+	return ""
+}
+
+// addSpecificationExecutor fait hériter le repository d'une entité de JpaSpecificationExecutor.
+func addSpecificationExecutor(entityName string) error {
+	path := repositoryPath(entityName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	content := string(data)
+	if strings.Contains(content, "JpaSpecificationExecutor") {
+		return nil
+	}
+
+	parent := regexp.MustCompile(`JpaRepository<\s*\w+\s*,\s*\w+\s*>`)
+	loc := parent.FindStringIndex(content)
+	if loc == nil {
+		return fmt.Errorf("déclaration JpaRepository introuvable")
+	}
+	content = content[:loc[1]] + ", JpaSpecificationExecutor<" + entityName + ">" + content[loc[1]:]
+	content = addImports(content, []string{"org.springframework.data.jpa.repository.JpaSpecificationExecutor"})
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return err
+	}
+	utils.PrintSuccess(fmt.Sprintf("%sRepository hérite désormais de JpaSpecificationExecutor", entityName))
+	return nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCheckServiceFilters génère le service avant le contrôleur filtrable: un service
+// généré sans filtres doit bloquer le contrôleur, qui appellerait findAll(filters, pageable).
+func TestCheckServiceFilters(t *testing.T) {
+	for _, name := range []string{"java-postgres", "kotlin-mysql"} {
+		t.Run(name, func(t *testing.T) {
+			project, err := filepath.Abs(filepath.Join("testdata", "integration-tests", name, "project"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			dir := t.TempDir()
+			copyTree(t, project, dir)
+			chdir(t, dir)
+
+			if err := checkServiceFilters("Order"); err != nil {
+				t.Errorf("sans service: %v", err)
+			}
+
+			generateService("Order")
+			if err := checkServiceFilters("Order"); err == nil {
+				t.Error("service sans filtres accepté")
+			}
+
+			if err := os.RemoveAll(getSourcePath() + "/service"); err != nil {
+				t.Fatal(err)
+			}
+			generateSpecification("Order", []string{"reference"})
+			generateService("Order")
+			if err := checkServiceFilters("Order"); err != nil {
+				t.Errorf("service filtrable: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package com.example.shop.controller;
+
+import com.example.shop.dto.OrderCreateRequest;
+import com.example.shop.dto.OrderResponse;
+import com.example.shop.dto.OrderUpdateRequest;
+import com.example.shop.service.OrderService;
+import jakarta.validation.Valid;
+import java.util.Set;
+import org.springframework.beans.factory.annotation.Autowired;
+import org.springframework.data.domain.Pageable;
+import org.springframework.data.domain.Sort;
+import org.springframework.data.web.PageableDefault;
+import org.springframework.data.web.PagedModel;
+import org.springframework.http.HttpStatus;
+import org.springframework.web.bind.annotation.DeleteMapping;
+import org.springframework.web.bind.annotation.GetMapping;
+import org.springframework.web.bind.annotation.PathVariable;
+import org.springframework.web.bind.annotation.PostMapping;
+import org.springframework.web.bind.annotation.PutMapping;
+import org.springframework.web.bind.annotation.RequestBody;
+import org.springframework.web.bind.annotation.RequestMapping;
+import org.springframework.web.bind.annotation.ResponseStatus;
+import org.springframework.web.bind.annotation.RestController;
+import org.springframework.web.server.ResponseStatusException;
+
+@RestController
+@RequestMapping("/api/orders")
+public class OrderController {
+    // Propriétés autorisées dans le paramètre sort
+    private static final Set<String> SORTABLE_FIELDS = Set.of("id", "reference", "status", "total");
+
+    @Autowired
+    private OrderService orderService;
+
+    @GetMapping
+    public PagedModel<OrderResponse> findAll(
+            @PageableDefault(size = 20, sort = "id") Pageable pageable) {
+        checkSort(pageable.getSort());
+        return new PagedModel<>(orderService.findAll(pageable));
+    }
+
+    @GetMapping("/{id}")
+    public OrderResponse findById(@PathVariable Long id) {
+        return orderService.findById(id);
+    }
+
+    @PostMapping
+    @ResponseStatus(HttpStatus.CREATED)
+    public OrderResponse create(@Valid @RequestBody OrderCreateRequest request) {
+        return orderService.create(request);
+    }
+
+    @PutMapping("/{id}")
+    public OrderResponse update(@PathVariable Long id, @Valid @RequestBody OrderUpdateRequest request) {
+        return orderService.update(id, request);
+    }
+
+    @DeleteMapping("/{id}")
+    @ResponseStatus(HttpStatus.NO_CONTENT)
+    public void delete(@PathVariable Long id) {
+        orderService.delete(id);
+    }
+
+    private void checkSort(Sort sort) {
+        for (Sort.Order order : sort) {
+            if (!SORTABLE_FIELDS.contains(order.getProperty())) {
+                throw new ResponseStatusException(HttpStatus.BAD_REQUEST, "Tri non autorisé: " + order.getProperty());
+            }
+        }
+    }
+}
@@ -0,0 +1,59 @@
+package com.example.shop.dto;
+
+import com.example.shop.entity.OrderStatus;
+import java.math.BigDecimal;
+
+public class OrderResponse {
+    private Long id;
+
+    private String reference;
+
+    private OrderStatus status;
+
+    private BigDecimal total;
+
+    private Long customerId;
+
+    public OrderResponse() {
+    }
+
+    public Long getId() {
+        return id;
+    }
+
+    public void setId(Long id) {
+        this.id = id;
+    }
+
+    public String getReference() {
+        return reference;
+    }
+
+    public void setReference(String reference) {
+        this.reference = reference;
+    }
+
+    public OrderStatus getStatus() {
+        return status;
+    }
+
+    public void setStatus(OrderStatus status) {
+        this.status = status;
+    }
+
+    public BigDecimal getTotal() {
+        return total;
+    }
+
+    public void setTotal(BigDecimal total) {
+        this.total = total;
+    }
+
+    public Long getCustomerId() {
+        return customerId;
+    }
+
+    public void setCustomerId(Long customerId) {
+        this.customerId = customerId;
+    }
+}
@@ -0,0 +1,8 @@
+package com.example.shop.entity;
+
+public enum OrderStatus {
+    PENDING,
+    PAID,
+    SHIPPED,
+    CANCELLED
+}
@@ -0,0 +1,19 @@
+package com.example.shop.exception;
+
+import org.springframework.http.HttpStatus;
+
+/**
+ * Exception métier portant le statut HTTP à renvoyer au client.
+ */
+public abstract class DomainException extends RuntimeException {
+    private final HttpStatus status;
+
+    protected DomainException(HttpStatus status, String message) {
+        super(message);
+        this.status = status;
+    }
+
+    public HttpStatus getStatus() {
+        return status;
+    }
+}
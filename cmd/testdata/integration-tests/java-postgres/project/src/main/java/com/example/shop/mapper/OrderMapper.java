@@ -0,0 +1,37 @@
+package com.example.shop.mapper;
+
+import com.example.shop.dto.OrderCreateRequest;
+import com.example.shop.dto.OrderResponse;
+import com.example.shop.dto.OrderUpdateRequest;
+import com.example.shop.entity.Order;
+import org.springframework.stereotype.Component;
+
+@Component
+public class OrderMapper {
+    public OrderResponse toResponse(Order order) {
+        if (order == null) {
+            return null;
+        }
+        OrderResponse response = new OrderResponse();
+        response.setId(order.getId());
+        response.setReference(order.getReference());
+        response.setStatus(order.getStatus());
+        response.setTotal(order.getTotal());
+        response.setCustomerId(order.getCustomer() != null ? order.getCustomer().getId() : null);
+        return response;
+    }
+
+    public Order toEntity(OrderCreateRequest request) {
+        Order order = new Order();
+        order.setReference(request.getReference());
+        order.setStatus(request.getStatus());
+        order.setTotal(request.getTotal());
+        return order;
+    }
+
+    public void updateEntity(OrderUpdateRequest request, Order order) {
+        order.setReference(request.getReference());
+        order.setStatus(request.getStatus());
+        order.setTotal(request.getTotal());
+    }
+}
@@ -0,0 +1,22 @@
+package com.example.shop;
+
+import org.springframework.boot.test.context.SpringBootTest;
+import org.springframework.boot.testcontainers.service.connection.ServiceConnection;
+import org.testcontainers.postgresql.PostgreSQLContainer;
+
+// Démarre l'application sur un port aléatoire, connectée à une base PostgreSQL lancée par
+// Testcontainers. Le conteneur est démarré une seule fois pour tous les tests d'intégration,
+// qui partagent ainsi le même contexte Spring; Testcontainers l'arrête à la fin des tests.
+@SpringBootTest(webEnvironment = SpringBootTest.WebEnvironment.RANDOM_PORT,
+        properties = {
+                "spring.jpa.hibernate.ddl-auto=create-drop",
+                "spring.jpa.properties.hibernate.auto_quote_keyword=true"
+        })
+public abstract class AbstractIntegrationTest {
+    @ServiceConnection
+    static final PostgreSQLContainer database = new PostgreSQLContainer("postgres:17-alpine");
+
+    static {
+        database.start();
+    }
+}
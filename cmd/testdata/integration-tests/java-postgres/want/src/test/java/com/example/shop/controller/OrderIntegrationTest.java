@@ -0,0 +1,104 @@
+package com.example.shop.controller;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+
+import com.example.shop.AbstractIntegrationTest;
+import java.util.List;
+import java.util.Map;
+import org.junit.jupiter.api.BeforeEach;
+import org.junit.jupiter.api.Test;
+import org.springframework.boot.web.server.test.LocalServerPort;
+import org.springframework.core.ParameterizedTypeReference;
+import org.springframework.http.HttpStatus;
+import org.springframework.http.MediaType;
+import org.springframework.http.ResponseEntity;
+import org.springframework.web.client.HttpClientErrorException;
+import org.springframework.web.client.RestClient;
+
+class OrderIntegrationTest extends AbstractIntegrationTest {
+    private static final ParameterizedTypeReference<Map<String, Object>> JSON_OBJECT =
+            new ParameterizedTypeReference<>() {
+            };
+
+    private static final String BODY = """
+            {
+              "reference": "Reference",
+              "status": "PENDING",
+              "total": 1
+            }
+            """;
+
+    @LocalServerPort
+    private int port;
+
+    private RestClient client;
+
+    @BeforeEach
+    void setUp() {
+        client = RestClient.create("http://localhost:" + port);
+    }
+
+    @Test
+    void createReturnsCreated() {
+        ResponseEntity<Map<String, Object>> response = client.post()
+                .uri("/api/orders")
+                .contentType(MediaType.APPLICATION_JSON)
+                .body(BODY)
+                .retrieve()
+                .toEntity(JSON_OBJECT);
+
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.CREATED);
+        assertThat(response.getBody()).containsKey("id");
+    }
+
+    @Test
+    void findByIdReturnsCreatedOrder() {
+        Object id = create();
+
+        ResponseEntity<Map<String, Object>> response = client.get()
+                .uri("/api/orders/{id}", id)
+                .retrieve()
+                .toEntity(JSON_OBJECT);
+
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getBody()).containsEntry("id", id);
+    }
+
+    @Test
+    void findAllReturnsPage() {
+        create();
+
+        ResponseEntity<Map<String, Object>> response = client.get()
+                .uri("/api/orders")
+                .retrieve()
+                .toEntity(JSON_OBJECT);
+
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat((List<?>) response.getBody().get("content")).isNotEmpty();
+    }
+
+    @Test
+    void deleteRemovesOrder() {
+        Object id = create();
+
+        ResponseEntity<Void> response = client.delete()
+                .uri("/api/orders/{id}", id)
+                .retrieve()
+                .toBodilessEntity();
+
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.NO_CONTENT);
+        assertThatThrownBy(() -> client.get().uri("/api/orders/{id}", id).retrieve().toBodilessEntity())
+                .isInstanceOf(HttpClientErrorException.NotFound.class);
+    }
+
+    private Object create() {
+        Map<String, Object> created = client.post()
+                .uri("/api/orders")
+                .contentType(MediaType.APPLICATION_JSON)
+                .body(BODY)
+                .retrieve()
+                .body(JSON_OBJECT);
+        return created.get("id");
+    }
+}
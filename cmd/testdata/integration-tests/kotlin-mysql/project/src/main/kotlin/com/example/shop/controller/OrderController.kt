@@ -0,0 +1,61 @@
+package com.example.shop.controller
+
+import com.example.shop.dto.OrderCreateRequest
+import com.example.shop.dto.OrderResponse
+import com.example.shop.dto.OrderUpdateRequest
+import com.example.shop.service.OrderService
+import jakarta.validation.Valid
+import org.springframework.data.domain.Pageable
+import org.springframework.data.domain.Sort
+import org.springframework.data.web.PageableDefault
+import org.springframework.data.web.PagedModel
+import org.springframework.http.HttpStatus
+import org.springframework.web.bind.annotation.DeleteMapping
+import org.springframework.web.bind.annotation.GetMapping
+import org.springframework.web.bind.annotation.PathVariable
+import org.springframework.web.bind.annotation.PostMapping
+import org.springframework.web.bind.annotation.PutMapping
+import org.springframework.web.bind.annotation.RequestBody
+import org.springframework.web.bind.annotation.RequestMapping
+import org.springframework.web.bind.annotation.ResponseStatus
+import org.springframework.web.bind.annotation.RestController
+import org.springframework.web.server.ResponseStatusException
+
+@RestController
+@RequestMapping("/api/orders")
+class OrderController(private val orderService: OrderService) {
+
+    @GetMapping
+    fun findAll(
+        @PageableDefault(size = 20, sort = ["id"]) pageable: Pageable,
+    ): PagedModel<OrderResponse> {
+        checkSort(pageable.sort)
+        return PagedModel(orderService.findAll(pageable))
+    }
+
+    @GetMapping("/{id}")
+    fun findById(@PathVariable id: Long): OrderResponse = orderService.findById(id)
+
+    @PostMapping
+    @ResponseStatus(HttpStatus.CREATED)
+    fun create(@Valid @RequestBody request: OrderCreateRequest): OrderResponse = orderService.create(request)
+
+    @PutMapping("/{id}")
+    fun update(@PathVariable id: Long, @Valid @RequestBody request: OrderUpdateRequest): OrderResponse =
+        orderService.update(id, request)
+
+    @DeleteMapping("/{id}")
+    @ResponseStatus(HttpStatus.NO_CONTENT)
+    fun delete(@PathVariable id: Long) = orderService.delete(id)
+
+    private fun checkSort(sort: Sort) {
+        sort.firstOrNull { it.property !in SORTABLE_FIELDS }?.let {
+            throw ResponseStatusException(HttpStatus.BAD_REQUEST, "Tri non autorisé: ${it.property}")
+        }
+    }
+
+    companion object {
+        // Propriétés autorisées dans le paramètre sort
+        private val SORTABLE_FIELDS = setOf("id", "reference", "status", "total")
+    }
+}
@@ -0,0 +1,12 @@
+package com.example.shop.dto
+
+import com.example.shop.entity.OrderStatus
+import java.math.BigDecimal
+
+data class OrderResponse(
+    val id: Long? = null,
+    val reference: String? = null,
+    val status: OrderStatus? = null,
+    val total: BigDecimal? = null,
+    val customerId: Long? = null,
+)
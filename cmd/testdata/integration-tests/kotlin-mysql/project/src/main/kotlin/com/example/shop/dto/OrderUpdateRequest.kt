@@ -0,0 +1,15 @@
+package com.example.shop.dto
+
+import com.example.shop.entity.OrderStatus
+import jakarta.validation.constraints.NotBlank
+import jakarta.validation.constraints.NotNull
+import java.math.BigDecimal
+
+data class OrderUpdateRequest(
+    @field:NotBlank
+    val reference: String? = null,
+    @field:NotNull
+    val status: OrderStatus? = null,
+    val total: BigDecimal? = null,
+    val customerId: Long? = null,
+)
@@ -0,0 +1,42 @@
+package com.example.shop.entity
+
+import jakarta.persistence.Column
+import jakarta.persistence.Entity
+import jakarta.persistence.GeneratedValue
+import jakarta.persistence.GenerationType
+import jakarta.persistence.Id
+import jakarta.persistence.OneToMany
+import jakarta.persistence.Table
+import jakarta.validation.constraints.Email
+import jakarta.validation.constraints.NotBlank
+import jakarta.validation.constraints.Size
+
+@Entity
+@Table(name = "customer")
+class Customer(
+    @field:Column(nullable = false, unique = true)
+    @field:NotBlank
+    @field:Email
+    var email: String? = null,
+    @field:Column(nullable = false, length = 80)
+    @field:NotBlank
+    @field:Size(max = 80)
+    var name: String? = null,
+    @OneToMany(mappedBy = "customer")
+    var orders: MutableList<Order> = mutableListOf(),
+    @Id
+    @GeneratedValue(strategy = GenerationType.IDENTITY)
+    var id: Long? = null,
+) {
+    override fun equals(other: Any?): Boolean {
+        if (this === other) return true
+        if (other !is Customer) return false
+        return id != null && id == other.id
+    }
+
+    // Constant pour rester stable avant et après la persistance
+    override fun hashCode(): Int = javaClass.hashCode()
+
+    override fun toString(): String =
+        "Customer(id=$id, email=$email, name=$name)"
+}
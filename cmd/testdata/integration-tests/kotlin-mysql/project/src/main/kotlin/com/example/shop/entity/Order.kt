@@ -0,0 +1,47 @@
+package com.example.shop.entity
+
+import jakarta.persistence.Column
+import jakarta.persistence.Entity
+import jakarta.persistence.EnumType
+import jakarta.persistence.Enumerated
+import jakarta.persistence.GeneratedValue
+import jakarta.persistence.GenerationType
+import jakarta.persistence.Id
+import jakarta.persistence.ManyToMany
+import jakarta.persistence.ManyToOne
+import jakarta.persistence.Table
+import jakarta.validation.constraints.NotBlank
+import jakarta.validation.constraints.NotNull
+import java.math.BigDecimal
+
+@Entity
+@Table(name = "order")
+class Order(
+    @field:Column(nullable = false)
+    @field:NotBlank
+    var reference: String? = null,
+    @field:Column(nullable = false)
+    @field:Enumerated(EnumType.STRING)
+    @field:NotNull
+    var status: OrderStatus? = null,
+    var total: BigDecimal? = null,
+    @ManyToOne
+    var customer: Customer? = null,
+    @ManyToMany
+    var tags: MutableList<Tag> = mutableListOf(),
+    @Id
+    @GeneratedValue(strategy = GenerationType.IDENTITY)
+    var id: Long? = null,
+) {
+    override fun equals(other: Any?): Boolean {
+        if (this === other) return true
+        if (other !is Order) return false
+        return id != null && id == other.id
+    }
+
+    // Constant pour rester stable avant et après la persistance
+    override fun hashCode(): Int = javaClass.hashCode()
+
+    override fun toString(): String =
+        "Order(id=$id, reference=$reference, status=$status, total=$total)"
+}
@@ -0,0 +1,7 @@
+package com.example.shop.entity
+
+enum class OrderStatus {
+    PENDING,
+    PAID,
+    SHIPPED
+}
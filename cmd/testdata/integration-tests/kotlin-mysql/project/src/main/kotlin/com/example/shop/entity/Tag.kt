@@ -0,0 +1,35 @@
+package com.example.shop.entity
+
+import jakarta.persistence.Column
+import jakarta.persistence.Entity
+import jakarta.persistence.GeneratedValue
+import jakarta.persistence.GenerationType
+import jakarta.persistence.Id
+import jakarta.persistence.ManyToMany
+import jakarta.persistence.Table
+import jakarta.validation.constraints.NotBlank
+
+@Entity
+@Table(name = "tag")
+class Tag(
+    @field:Column(nullable = false, unique = true)
+    @field:NotBlank
+    var label: String? = null,
+    @ManyToMany(mappedBy = "tags")
+    var orders: MutableList<Order> = mutableListOf(),
+    @Id
+    @GeneratedValue(strategy = GenerationType.IDENTITY)
+    var id: Long? = null,
+) {
+    override fun equals(other: Any?): Boolean {
+        if (this === other) return true
+        if (other !is Tag) return false
+        return id != null && id == other.id
+    }
+
+    // Constant pour rester stable avant et après la persistance
+    override fun hashCode(): Int = javaClass.hashCode()
+
+    override fun toString(): String =
+        "Tag(id=$id, label=$label)"
+}
@@ -0,0 +1,30 @@
+package com.example.shop.mapper
+
+import com.example.shop.dto.OrderCreateRequest
+import com.example.shop.dto.OrderResponse
+import com.example.shop.dto.OrderUpdateRequest
+import com.example.shop.entity.Order
+import org.springframework.stereotype.Component
+
+@Component
+class OrderMapper {
+    fun toResponse(order: Order) = OrderResponse(
+        id = order.id,
+        reference = order.reference,
+        status = order.status,
+        total = order.total,
+        customerId = order.customer?.id,
+    )
+
+    fun toEntity(request: OrderCreateRequest) = Order(
+        reference = request.reference,
+        status = request.status,
+        total = request.total,
+    )
+
+    fun updateEntity(request: OrderUpdateRequest, order: Order) {
+        order.reference = request.reference
+        order.status = request.status
+        order.total = request.total
+    }
+}
@@ -0,0 +1,22 @@
+package com.example.shop
+
+import org.springframework.boot.test.context.SpringBootTest
+import org.springframework.boot.testcontainers.service.connection.ServiceConnection
+import org.testcontainers.containers.MySQLContainer
+
+// Démarre l'application sur un port aléatoire, connectée à une base MySQL lancée par
+// Testcontainers. Le conteneur est démarré une seule fois pour tous les tests d'intégration,
+// qui partagent ainsi le même contexte Spring; Testcontainers l'arrête à la fin des tests.
+@SpringBootTest(webEnvironment = SpringBootTest.WebEnvironment.RANDOM_PORT,
+    properties = ["spring.jpa.properties.hibernate.auto_quote_keyword=true"])
+abstract class AbstractIntegrationTest {
+    companion object {
+        @ServiceConnection
+        @JvmStatic
+        val database = MySQLContainer<Nothing>("mysql:8.4")
+
+        init {
+            database.start()
+        }
+    }
+}
@@ -0,0 +1,93 @@
+package com.example.shop.controller
+
+import com.example.shop.AbstractIntegrationTest
+import org.assertj.core.api.Assertions.assertThat
+import org.junit.jupiter.api.BeforeEach
+import org.junit.jupiter.api.Test
+import org.springframework.boot.test.web.server.LocalServerPort
+import org.springframework.http.HttpStatus
+import org.springframework.http.MediaType
+import org.springframework.web.client.RestClient
+import org.springframework.web.client.body
+import org.springframework.web.client.toEntity
+
+class OrderIntegrationTest : AbstractIntegrationTest() {
+    @LocalServerPort
+    private var port: Int = 0
+
+    private lateinit var client: RestClient
+
+    @BeforeEach
+    fun setUp() {
+        client = RestClient.create("http://localhost:$port")
+    }
+
+    @Test
+    fun createReturnsCreated() {
+        val response = client.post()
+            .uri("/api/orders")
+            .contentType(MediaType.APPLICATION_JSON)
+            .body(BODY)
+            .retrieve()
+            .toEntity<Map<String, Any>>()
+
+        assertThat(response.statusCode).isEqualTo(HttpStatus.CREATED)
+        assertThat(response.body).containsKey("id")
+    }
+
+    @Test
+    fun findByIdReturnsCreatedOrder() {
+        val id = create()
+
+        val response = client.get()
+            .uri("/api/orders/{id}", id)
+            .retrieve()
+            .toEntity<Map<String, Any>>()
+
+        assertThat(response.statusCode).isEqualTo(HttpStatus.OK)
+        assertThat(response.body).containsEntry("id", id)
+    }
+
+    @Test
+    fun findAllReturnsPage() {
+        create()
+
+        val response = client.get()
+            .uri("/api/orders")
+            .retrieve()
+            .toEntity<Map<String, Any>>()
+
+        assertThat(response.statusCode).isEqualTo(HttpStatus.OK)
+        assertThat(response.body!!["content"] as List<*>).isNotEmpty()
+    }
+
+    @Test
+    fun deleteRemovesOrder() {
+        val id = create()
+
+        val response = client.delete()
+            .uri("/api/orders/{id}", id)
+            .retrieve()
+            .toBodilessEntity()
+
+        assertThat(response.statusCode).isEqualTo(HttpStatus.NO_CONTENT)
+    }
+
+    private fun create(): Any = client.post()
+        .uri("/api/orders")
+        .contentType(MediaType.APPLICATION_JSON)
+        .body(BODY)
+        .retrieve()
+        .body<Map<String, Any>>()!!
+        .getValue("id")
+
+    companion object {
+        private val BODY = """
+            {
+              "reference": "Reference",
+              "status": "PENDING",
+              "total": 1
+            }
+        """.trimIndent()
+    }
+}
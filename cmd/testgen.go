@@ -0,0 +1,1313 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"springcli/internal/buildfile"
+	"springcli/internal/config"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ===================== TESTS GÉNÉRÉS =========================
+// Avec --with-tests (ou generate.with-tests dans .springcli.yaml), chaque couche est
+// générée avec son test, sous src/test dans le package miroir:
+//
+//	repository -> @DataJpaTest
+//	service    -> test unitaire Mockito
+//	controller -> @WebMvcTest avec MockMvc (chaque endpoint CRUD et un échec de validation)
+//
+// Les tests construisent leurs entités avec le builder de données de test de l'entité.
+
+func init() {
+	generateCmd.PersistentFlags().Bool("with-tests", false, "Génère aussi les tests (défaut: generate.with-tests de "+config.FileName+")")
+}
+
+// withTests indique si les tests doivent être générés: le flag --with-tests l'emporte sur
+// la configuration du projet.
+func withTests(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("with-tests") {
+		enabled, _ := cmd.Flags().GetBool("with-tests")
+		return enabled
+	}
+	return projectConfig().Generate.WithTests
+}
+
+// testSourcePath renvoie le dossier des tests, miroir du package principal.
+func testSourcePath() string {
+	return "src/test/" + projectLanguage() + "/" + strings.ReplaceAll(basePackage(), ".", "/")
+}
+
+// testSliceImports renvoie les imports de @DataJpaTest et @WebMvcTest, déplacés dans des
+// modules dédiés depuis Spring Boot 4.
+func testSliceImports() (string, string) {
+	if isBoot4() {
+		return "org.springframework.boot.data.jpa.test.autoconfigure.DataJpaTest",
+			"org.springframework.boot.webmvc.test.autoconfigure.WebMvcTest"
+	}
+	return "org.springframework.boot.test.autoconfigure.orm.jpa.DataJpaTest",
+		"org.springframework.boot.test.autoconfigure.web.servlet.WebMvcTest"
+}
+
+// mockBeanImport renvoie l'annotation qui remplace un bean par un mock: @MockitoBean de
+// Spring Framework 6.2 (Spring Boot 3.4), @MockBean de Spring Boot avant.
+func mockBeanImport() string {
+	if isBootAtLeast("3.4") {
+		return "org.springframework.test.context.bean.override.mockito.MockitoBean"
+	}
+	return "org.springframework.boot.test.mock.mockito.MockBean"
+}
+
+// addTestStarter déclare le starter de test d'une tranche (Spring Boot 4), que
+// spring-boot-starter-test n'inclut plus.
+func addTestStarter(artifactID string) {
+	if !isBoot4() || hasBuildDependency("org.springframework.boot", artifactID) {
+		return
+	}
+	err := addBuildDependency(buildfile.Dependency{
+		GroupID:    "org.springframework.boot",
+		ArtifactID: artifactID,
+		Scope:      "test",
+	}, "testImplementation")
+	if err != nil && !errors.Is(err, buildfile.ErrDuplicate) {
+		utils.PrintError(fmt.Sprintf("Impossible d'ajouter %s à %s: %v", artifactID, buildFileName(), err))
+		os.Exit(1)
+	}
+	utils.PrintSuccess(fmt.Sprintf("%s ajouté à %s (scope test)", artifactID, buildFileName()))
+}
+
+// ===================== VALEURS DE TEST =======================
+
+// testValue est une valeur de champ valide, en code source et en JSON.
+type testValue struct {
+	Code string
+	JSON string
+}
+
+// sampleValue renvoie une valeur qui respecte le type et les contraintes du champ, choisie
+// d'après son nom lorsque c'est possible (email, téléphone, url).
+func sampleValue(f Field) testValue {
+	lower := strings.ToLower(f.Name)
+	switch f.Type {
+	case "String":
+		text := capitalize(splitCamelCase(f.Name, " "))
+		switch {
+		case f.hasConstraint("email") || strings.Contains(lower, "email"):
+			text = "jane.doe@example.com"
+		case strings.Contains(lower, "phone"):
+			text = "+33600000000"
+		case strings.Contains(lower, "url"):
+			text = "https://example.com"
+		}
+		if min, ok := f.constraintValue("min"); ok && len(text) < min {
+			text += strings.Repeat("x", min-len(text))
+		}
+		if max, ok := f.constraintValue("max"); ok && len(text) > max {
+			text = text[:max]
+		}
+		quoted, _ := json.Marshal(text)
+		return testValue{Code: string(quoted), JSON: string(quoted)}
+	case "int", "Integer", "long", "Long", "short", "Short", "double", "Double", "float", "Float", "BigDecimal":
+		n := 1
+		min, hasMin, max, hasMax := f.numericBounds()
+		if hasMin && min > n {
+			n = min
+		}
+		if hasMax && max < n && !f.hasConstraint("positive") {
+			n = max
+		}
+		return testValue{Code: numberLiteral(f.Type, n), JSON: strconv.Itoa(n)}
+	case "boolean", "Boolean":
+		return testValue{Code: "true", JSON: "true"}
+	case "LocalDate":
+		if f.hasConstraint("future") {
+			return testValue{Code: "LocalDate.of(2999, 1, 1)", JSON: `"2999-01-01"`}
+		}
+		return testValue{Code: "LocalDate.of(2000, 1, 1)", JSON: `"2000-01-01"`}
+	case "LocalDateTime":
+		if f.hasConstraint("future") {
+			return testValue{Code: "LocalDateTime.of(2999, 1, 1, 12, 0)", JSON: `"2999-01-01T12:00:00"`}
+		}
+		return testValue{Code: "LocalDateTime.of(2000, 1, 1, 12, 0)", JSON: `"2000-01-01T12:00:00"`}
+	case "LocalTime":
+		return testValue{Code: "LocalTime.NOON", JSON: `"12:00:00"`}
+	case "Instant", "OffsetDateTime":
+		year := "2000"
+		if f.hasConstraint("future") {
+			year = "2999"
+		}
+		return testValue{Code: fmt.Sprintf(`%s.parse("%s-01-01T12:00:00Z")`, f.Type, year), JSON: `"` + year + `-01-01T12:00:00Z"`}
+	case "UUID":
+		return testValue{Code: `UUID.fromString("00000000-0000-0000-0000-000000000001")`, JSON: `"00000000-0000-0000-0000-000000000001"`}
+	}
+	if isEnumType(f.Type) {
+		types, _ := scanSourceTypes(getSourcePath() + "/entity")
+		if t, ok := types[f.Type]; ok {
+			if constants := t.enumConstants(); len(constants) > 0 {
+				return testValue{Code: f.Type + "." + constants[0], JSON: `"` + constants[0] + `"`}
+			}
+		}
+	}
+	return testValue{JSON: "null"}
+}
+
+// numberLiteral écrit un entier dans le type numérique Java ou Kotlin donné.
+func numberLiteral(t string, n int) string {
+	switch t {
+	case "long", "Long":
+		return strconv.Itoa(n) + "L"
+	case "short", "Short":
+		if isKotlin() {
+			return strconv.Itoa(n)
+		}
+		return "(short) " + strconv.Itoa(n)
+	case "double", "Double":
+		return strconv.Itoa(n) + ".0"
+	case "float", "Float":
+		return strconv.Itoa(n) + ".0f"
+	case "BigDecimal":
+		return "BigDecimal.valueOf(" + strconv.Itoa(n) + ")"
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+// invalidJSONValue renvoie une valeur JSON qui enfreint une contrainte du champ, et faux
+// si le champ n'a aucune contrainte vérifiable par Bean Validation.
+func invalidJSONValue(f Field) (string, bool) {
+	min, hasMin := f.constraintValue("min")
+	max, hasMax := f.constraintValue("max")
+	if isNumericType(f.Type) {
+		min, hasMin, max, hasMax = f.numericBounds()
+	}
+	switch {
+	case f.hasConstraint("required") && !isPrimitive(f.Type):
+		return "null", true
+	case isTextType(f.Type) && (f.hasConstraint("email") || strings.EqualFold(f.Name, "email")):
+		return `"not-an-email"`, true
+	case isTextType(f.Type) && hasMax:
+		return `"` + strings.Repeat("x", max+1) + `"`, true
+	case isTextType(f.Type) && hasMin && min > 0:
+		return `""`, true
+	case isNumericType(f.Type) && f.hasConstraint("positive"):
+		return "0", true
+	case isNumericType(f.Type) && hasMin:
+		return strconv.Itoa(min - 1), true
+	case isNumericType(f.Type) && hasMax:
+		return strconv.Itoa(max + 1), true
+	case f.Type == "LocalDate" && f.hasConstraint("past"):
+		return `"2999-01-01"`, true
+	case f.Type == "LocalDate" && f.hasConstraint("future"):
+		return `"2000-01-01"`, true
+	}
+	return "", false
+}
+
+// ===================== DONNÉES DE TEST =======================
+
+// builderProperty est une propriété du builder de données de test.
+type builderProperty struct {
+	Name       string
+	Type       string
+	KotlinType string
+	Value      string
+}
+
+const testDataBuilderTemplate = `package {{.packageName}}.entity;
+{{- if .imports}}
+{{range .imports}}
+import {{.}};
+{{- end}}
+{{- end}}
+
+// Construit des {{.entityName}} valides pour les tests, chaque valeur pouvant être remplacée.
+public class {{.builderName}} {
+    private {{.keyType}} id;
+{{- range .properties}}
+    private {{.Type}} {{.Name}}{{if .Value}} = {{.Value}}{{end}};
+{{- end}}
+
+    public static {{.builderName}} {{.factoryName}}() {
+        return new {{.builderName}}();
+    }
+
+    public {{.builderName}} withId({{.keyType}} id) {
+        this.id = id;
+        return this;
+    }
+{{range .properties}}
+    public {{$.builderName}} with{{capitalize .Name}}({{.Type}} {{.Name}}) {
+        this.{{.Name}} = {{.Name}};
+        return this;
+    }
+{{end}}
+    public {{.entityName}} build() {
+        {{.entityName}} {{.entityVar}} = new {{.entityName}}();
+        {{.entityVar}}.setId(id);
+{{- range .properties}}
+        {{$.entityVar}}.set{{capitalize .Name}}({{.Name}});
+{{- end}}
+        return {{.entityVar}};
+    }
+}
+`
+
+const kotlinTestDataBuilderTemplate = `package {{.packageName}}.entity
+{{- if .imports}}
+{{range .imports}}
+import {{.}}
+{{- end}}
+{{- end}}
+
+// Construit des {{.entityName}} valides pour les tests, chaque valeur pouvant être remplacée.
+class {{.builderName}} {
+    private var id: {{kotlinType .keyType}}? = null
+{{- range .properties}}
+    private var {{.Name}}: {{.KotlinType}} = {{.Value}}
+{{- end}}
+
+    fun withId(id: {{kotlinType .keyType}}?) = apply { this.id = id }
+{{- range .properties}}
+
+    fun with{{capitalize .Name}}({{.Name}}: {{.KotlinType}}) = apply { this.{{.Name}} = {{.Name}} }
+{{- end}}
+
+    fun build() = {{.entityName}}(
+{{- range .properties}}
+        {{.Name}} = {{.Name}},
+{{- end}}
+        id = id,
+    )
+
+    companion object {
+        fun {{.factoryName}}() = {{.builderName}}()
+    }
+}
+`
+
+func testDataBuilderName(entityName string) string {
+	return entityName + "TestDataBuilder"
+}
+
+// testDataFactory renvoie le nom de la méthode de création du builder (aUser, anOrder).
+func testDataFactory(entityName string) string {
+	if strings.ContainsAny(entityName[:1], "AEIOU") {
+		return "an" + entityName
+	}
+	return "a" + entityName
+}
+
+// generateTestDataBuilder génère le builder de données de test d'une entité. Un builder
+// existant n'est réécrit que si overwrite est vrai (entité modifiée).
+func generateTestDataBuilder(entityName string, overwrite bool) {
+	_, fields, relations, err := readEntity(entityName)
+	if err != nil {
+		utils.PrintError(fmt.Sprintf("Impossible de lire l'entité %s: %v", entityName, err))
+		os.Exit(1)
+	}
+	mapping := readEntityMapping(entityName)
+
+	imports := map[string]bool{}
+	if imp := typeImport(mapping.KeyType); imp != "" {
+		imports[imp] = true
+	}
+	var properties []builderProperty
+	for _, f := range fields {
+		p := builderProperty{Name: f.Name, Type: f.Type, Value: sampleValue(f).Code}
+		p.KotlinType = strings.TrimPrefix(strings.SplitN(kotlinProperty(f), " = ", 2)[0], f.Name+": ")
+		if imp := typeImport(f.Type); imp != "" {
+			imports[imp] = true
+		}
+		properties = append(properties, p)
+	}
+	// Les relations simples restent vides: le test les renseigne avec with...
+	for _, r := range relations {
+		if !isCollection(r) {
+			properties = append(properties, builderProperty{Name: r.Name, Type: r.Target, KotlinType: r.Target + "?"})
+		}
+	}
+	if isKotlin() {
+		for i := range properties {
+			if properties[i].Value == "" {
+				properties[i].Value = "null"
+			}
+		}
+	}
+
+	params := map[string]interface{}{
+		"entityName":  entityName,
+		"entityVar":   uncapitalize(entityName),
+		"builderName": testDataBuilderName(entityName),
+		"factoryName": testDataFactory(entityName),
+		"keyType":     mapping.KeyType,
+		"properties":  properties,
+		"imports":     sortedKeys(imports),
+		"packageName": basePackage(),
+	}
+	buf := renderTemplate("testDataBuilder", languageTemplate(testDataBuilderTemplate, kotlinTestDataBuilderTemplate), params)
+
+	path := testSourcePath() + "/entity"
+	filename := sourceFile(testDataBuilderName(entityName))
+	if overwrite && utils.Exists(path+"/"+filename) {
+		generateFile(path, filename, buf)
+		return
+	}
+	writeNewFile(path, filename, buf)
+}
+
+// ensureTestDataBuilder génère le builder de l'entité s'il n'existe pas encore.
+func ensureTestDataBuilder(entityName string) {
+	if !utils.Exists(testSourcePath() + "/entity/" + sourceFile(testDataBuilderName(entityName))) {
+		generateTestDataBuilder(entityName, false)
+	}
+}
+
+// refreshTestDataBuilder réécrit le builder d'une entité modifiée, s'il a été généré.
+func refreshTestDataBuilder(entityName string) {
+	if utils.Exists(testSourcePath() + "/entity/" + sourceFile(testDataBuilderName(entityName))) {
+		generateTestDataBuilder(entityName, true)
+	}
+}
+
+// testParams complète les paramètres CRUD de l'entité pour les templates de test.
+func testParams(entityName string) map[string]interface{} {
+	params := crudParams(entityName)
+	params["builderName"] = testDataBuilderName(entityName)
+	params["factoryName"] = testDataFactory(entityName)
+	return params
+}
+
+// ===================== TEST DU REPOSITORY =====================
+
+const repositoryTestTemplate = `package {{.packageName}}.repository;
+
+import static {{.packageName}}.entity.{{.builderName}}.{{.factoryName}};
+import static org.assertj.core.api.Assertions.assertThat;
+
+import {{.packageName}}.entity.{{.entityName}};
+import org.junit.jupiter.api.Test;
+import org.springframework.beans.factory.annotation.Autowired;
+import {{.dataJpaTest}};
+import org.springframework.data.domain.PageRequest;
+
+@DataJpaTest
+class {{.repositoryName}}Test {
+    @Autowired
+    private {{.repositoryName}} {{.repositoryVar}};
+
+    @Test
+    void saveAssignsIdAndFindsById() {
+        {{.entityName}} saved = {{.repositoryVar}}.save({{.factoryName}}().build());
+
+        assertThat(saved.getId()).isNotNull();
+        assertThat({{.repositoryVar}}.findById(saved.getId())).isPresent();
+    }
+
+    @Test
+    void findAllReturnsPage() {
+        {{.repositoryVar}}.save({{.factoryName}}().build());
+
+        assertThat({{.repositoryVar}}.findAll(PageRequest.of(0, 20)).getTotalElements()).isEqualTo(1);
+    }
+
+    @Test
+    void deleteRemoves{{.entityName}}() {
+        {{.entityName}} saved = {{.repositoryVar}}.save({{.factoryName}}().build());
+
+        {{.repositoryVar}}.delete(saved);
+
+        assertThat({{.repositoryVar}}.findById(saved.getId())).isEmpty();
+    }
+}
+`
+
+const kotlinRepositoryTestTemplate = `package {{.packageName}}.repository
+{{range .imports}}
+import {{.}}
+{{- end}}
+
+@DataJpaTest
+class {{.repositoryName}}Test {
+    @Autowired
+    private lateinit var {{.repositoryVar}}: {{.repositoryName}}
+
+    @Test
+    fun saveAssignsIdAndFindsById() {
+        val saved = {{.repositoryVar}}.save({{.factoryName}}().build())
+
+        assertThat(saved.id).isNotNull()
+        assertThat({{.repositoryVar}}.findById(saved.id!!)).isPresent()
+    }
+
+    @Test
+    fun findAllReturnsPage() {
+        {{.repositoryVar}}.save({{.factoryName}}().build())
+
+        assertThat({{.repositoryVar}}.findAll(PageRequest.of(0, 20)).totalElements).isEqualTo(1)
+    }
+
+    @Test
+    fun deleteRemoves{{.entityName}}() {
+        val saved = {{.repositoryVar}}.save({{.factoryName}}().build())
+
+        {{.repositoryVar}}.delete(saved)
+
+        assertThat({{.repositoryVar}}.findById(saved.id!!)).isEmpty()
+    }
+}
+`
+
+// generateRepositoryTest génère le test @DataJpaTest du repository d'une entité.
+func generateRepositoryTest(entityName string) {
+	if readEntityMapping(entityName).composite() {
+		utils.PrintWarning(fmt.Sprintf("%s a une clé composite: test du repository non généré", entityName))
+		return
+	}
+	ensureTestDataBuilder(entityName)
+	addTestStarter("spring-boot-starter-data-jpa-test")
+
+	params := testParams(entityName)
+	params["dataJpaTest"], _ = testSliceImports()
+	if isKotlin() {
+		params["imports"] = sortedKeys(map[string]bool{
+			basePackage() + ".entity." + testDataBuilderName(entityName) + ".Companion." + testDataFactory(entityName): true,
+			"org.assertj.core.api.Assertions.assertThat":                                                               true,
+			"org.junit.jupiter.api.Test":                                                                               true,
+			"org.springframework.beans.factory.annotation.Autowired":                                                   true,
+			params["dataJpaTest"].(string):                                                                             true,
+			"org.springframework.data.domain.PageRequest":                                                              true,
+		})
+	}
+	buf := renderTemplate("repositoryTest", languageTemplate(repositoryTestTemplate, kotlinRepositoryTestTemplate), params)
+	writeNewFile(testSourcePath()+"/repository", sourceFile(entityName+"RepositoryTest"), buf)
+
+	if !hasEmbeddedDatabase() && !embeddedDatabaseHinted {
+		embeddedDatabaseHinted = true
+		utils.PrintInfo("@DataJpaTest utilise une base embarquée: ajoutez H2 en scope test ou lancez 'springcli generate integration-tests'")
+	}
+}
+
+// embeddedDatabaseHinted évite de répéter le conseil sur la base embarquée pour chaque entité.
+var embeddedDatabaseHinted bool
+
+// hasEmbeddedDatabase indique si une base embarquée est disponible pour les tests.
+func hasEmbeddedDatabase() bool {
+	return hasBuildDependency("com.h2database", "h2") || hasBuildDependency("org.hsqldb", "hsqldb") ||
+		hasBuildDependency("org.apache.derby", "derby")
+}
+
+// ===================== TEST DU SERVICE =======================
+
+const serviceTestTemplate = `package {{.packageName}}.service.impl;
+
+import static {{.packageName}}.entity.{{.builderName}}.{{.factoryName}};
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+{{- if .relationRepositories}}
+import static org.mockito.ArgumentMatchers.any;
+{{- end}}
+{{- if .filterable}}
+import static org.mockito.ArgumentMatchers.eq;
+{{- end}}
+{{- if .relationRepositories}}
+import static org.mockito.Mockito.mock;
+{{- end}}
+import static org.mockito.Mockito.verify;
+import static org.mockito.Mockito.when;
+{{range .imports}}
+import {{.}};
+{{- end}}
+
+@ExtendWith(MockitoExtension.class)
+class {{.serviceName}}ImplTest {
+    @Mock
+    private {{.repositoryName}} {{.repositoryVar}};
+{{- range .relationRepositories}}{{if .Injected}}
+
+    @Mock
+    private {{.RepositoryName}} {{.RepositoryVar}};
+{{- end}}{{end}}
+{{- if .useDto}}
+
+    @Mock
+    private {{.mapperName}} {{.mapperVar}};
+{{- end}}
+
+    @InjectMocks
+    private {{.serviceName}}Impl {{.serviceVar}};
+
+    @Test
+    void findAllReturnsPage() {
+        {{.entityName}} {{.entityVar}} = {{.factoryName}}().withId(1L).build();
+        Pageable pageable = PageRequest.of(0, 20);
+{{- if .filterable}}
+        when({{.repositoryVar}}.findAll(ArgumentMatchers.<Specification<{{.entityName}}>>any(), eq(pageable)))
+{{- else}}
+        when({{.repositoryVar}}.findAll(pageable))
+{{- end}}
+                .thenReturn(new PageImpl<>(List.of({{.entityVar}}), pageable, 1));
+{{- if .useDto}}
+        {{.responseType}} response = response();
+        when({{.mapperVar}}.toResponse({{.entityVar}})).thenReturn(response);
+
+        assertThat({{.serviceVar}}.findAll({{if .filterable}}Map.of(), {{end}}pageable).getContent()).containsExactly(response);
+{{- else}}
+
+        assertThat({{.serviceVar}}.findAll({{if .filterable}}Map.of(), {{end}}pageable).getContent()).containsExactly({{.entityVar}});
+{{- end}}
+    }
+
+    @Test
+    void findByIdReturns{{.entityName}}() {
+        {{.entityName}} {{.entityVar}} = {{.factoryName}}().withId(1L).build();
+        when({{.repositoryVar}}.findById(1L)).thenReturn(Optional.of({{.entityVar}}));
+{{- if .useDto}}
+        {{.responseType}} response = response();
+        when({{.mapperVar}}.toResponse({{.entityVar}})).thenReturn(response);
+
+        assertThat({{.serviceVar}}.findById(1L)).isEqualTo(response);
+{{- else}}
+
+        assertThat({{.serviceVar}}.findById(1L)).isSameAs({{.entityVar}});
+{{- end}}
+    }
+
+    @Test
+    void findByIdThrowsWhen{{.entityName}}IsMissing() {
+        when({{.repositoryVar}}.findById(1L)).thenReturn(Optional.empty());
+
+        assertThatThrownBy(() -> {{.serviceVar}}.findById(1L)).isInstanceOf(EntityNotFoundException.class);
+    }
+
+    @Test
+    void createSaves{{.entityName}}() {
+        {{.entityName}} {{.entityVar}} = {{.factoryName}}().build();
+{{- if .useDto}}
+        {{.createType}} request = createRequest();
+        {{.responseType}} response = response();
+        when({{.mapperVar}}.toEntity(request)).thenReturn({{.entityVar}});
+{{- range .relationRepositories}}{{if .Create}}
+        when({{.RepositoryVar}}.findById(any())).thenReturn(Optional.of(mock({{.Target}}.class)));
+{{- end}}{{end}}
+        when({{.repositoryVar}}.save({{.entityVar}})).thenReturn({{.entityVar}});
+        when({{.mapperVar}}.toResponse({{.entityVar}})).thenReturn(response);
+
+        assertThat({{.serviceVar}}.create(request)).isEqualTo(response);
+{{- else}}
+        when({{.repositoryVar}}.save({{.entityVar}})).thenReturn({{.entityVar}});
+
+        assertThat({{.serviceVar}}.create({{.entityVar}})).isSameAs({{.entityVar}});
+{{- end}}
+    }
+
+    @Test
+    void updateModifiesExisting{{.entityName}}() {
+{{- if .useDto}}
+        {{.entityName}} {{.entityVar}} = {{.factoryName}}().withId(1L).build();
+        {{.updateType}} request = updateRequest();
+        {{.responseType}} response = response();
+{{- range .relationRepositories}}{{if .Update}}
+        when({{.RepositoryVar}}.findById(any())).thenReturn(Optional.of(mock({{.Target}}.class)));
+{{- end}}{{end}}
+        when({{.repositoryVar}}.findById(1L)).thenReturn(Optional.of({{.entityVar}}));
+        when({{.repositoryVar}}.save({{.entityVar}})).thenReturn({{.entityVar}});
+        when({{.mapperVar}}.toResponse({{.entityVar}})).thenReturn(response);
+
+        assertThat({{.serviceVar}}.update(1L, request)).isEqualTo(response);
+        verify({{.mapperVar}}).updateEntity(request, {{.entityVar}});
+{{- else}}
+        {{.entityName}} {{.entityVar}} = {{.factoryName}}().build();
+        when({{.repositoryVar}}.findById(1L)).thenReturn(Optional.of({{.factoryName}}().withId(1L).build()));
+        when({{.repositoryVar}}.save({{.entityVar}})).thenReturn({{.entityVar}});
+
+        assertThat({{.serviceVar}}.update(1L, {{.entityVar}}).getId()).isEqualTo(1L);
+{{- end}}
+    }
+
+    @Test
+    void deleteRemoves{{.entityName}}() {
+        {{.entityName}} {{.entityVar}} = {{.factoryName}}().withId(1L).build();
+        when({{.repositoryVar}}.findById(1L)).thenReturn(Optional.of({{.entityVar}}));
+
+        {{.serviceVar}}.delete(1L);
+
+        verify({{.repositoryVar}}).delete({{.entityVar}});
+    }
+{{- range .helpers}}
+
+{{.}}
+{{- end}}
+}
+`
+
+const kotlinServiceTestTemplate = `package {{.packageName}}.service
+{{range .imports}}
+import {{.}}
+{{- end}}
+
+@ExtendWith(MockitoExtension::class)
+class {{.serviceName}}Test {
+    @Mock
+    private lateinit var {{.repositoryVar}}: {{.repositoryName}}
+{{- range .relationRepositories}}{{if .Injected}}
+
+    @Mock
+    private lateinit var {{.RepositoryVar}}: {{.RepositoryName}}
+{{- end}}{{end}}
+{{- if .useDto}}
+
+    @Mock
+    private lateinit var {{.mapperVar}}: {{.mapperName}}
+{{- end}}
+
+    @InjectMocks
+    private lateinit var {{.serviceVar}}: {{.serviceName}}
+
+    @Test
+    fun findAllReturnsPage() {
+        val {{.entityVar}} = {{.factoryName}}().withId(1L).build()
+        val pageable = PageRequest.of(0, 20)
+{{- if .filterable}}
+        ` + "`when`" + `({{.repositoryVar}}.findAll(any<Specification<{{.entityName}}>>(), eq(pageable)))
+{{- else}}
+        ` + "`when`" + `({{.repositoryVar}}.findAll(pageable))
+{{- end}}
+            .thenReturn(PageImpl(listOf({{.entityVar}}), pageable, 1))
+{{- if .useDto}}
+        val response = response()
+        ` + "`when`" + `({{.mapperVar}}.toResponse({{.entityVar}})).thenReturn(response)
+
+        assertThat({{.serviceVar}}.findAll({{if .filterable}}mapOf(), {{end}}pageable).content).containsExactly(response)
+{{- else}}
+
+        assertThat({{.serviceVar}}.findAll({{if .filterable}}mapOf(), {{end}}pageable).content).containsExactly({{.entityVar}})
+{{- end}}
+    }
+
+    @Test
+    fun findByIdReturns{{.entityName}}() {
+        val {{.entityVar}} = {{.factoryName}}().withId(1L).build()
+        ` + "`when`" + `({{.repositoryVar}}.findById(1L)).thenReturn(Optional.of({{.entityVar}}))
+{{- if .useDto}}
+        val response = response()
+        ` + "`when`" + `({{.mapperVar}}.toResponse({{.entityVar}})).thenReturn(response)
+
+        assertThat({{.serviceVar}}.findById(1L)).isEqualTo(response)
+{{- else}}
+
+        assertThat({{.serviceVar}}.findById(1L)).isSameAs({{.entityVar}})
+{{- end}}
+    }
+
+    @Test
+    fun findByIdThrowsWhen{{.entityName}}IsMissing() {
+        ` + "`when`" + `({{.repositoryVar}}.findById(1L)).thenReturn(Optional.empty())
+
+        assertThatThrownBy { {{.serviceVar}}.findById(1L) }.isInstanceOf(EntityNotFoundException::class.java)
+    }
+
+    @Test
+    fun createSaves{{.entityName}}() {
+        val {{.entityVar}} = {{.factoryName}}().build()
+{{- if .useDto}}
+        val request = createRequest()
+        val response = response()
+        ` + "`when`" + `({{.mapperVar}}.toEntity(request)).thenReturn({{.entityVar}})
+{{- range .relationRepositories}}{{if .Create}}
+        ` + "`when`" + `({{.RepositoryVar}}.findById(any())).thenReturn(Optional.of(mock({{.Target}}::class.java)))
+{{- end}}{{end}}
+        ` + "`when`" + `({{.repositoryVar}}.save({{.entityVar}})).thenReturn({{.entityVar}})
+        ` + "`when`" + `({{.mapperVar}}.toResponse({{.entityVar}})).thenReturn(response)
+
+        assertThat({{.serviceVar}}.create(request)).isEqualTo(response)
+{{- else}}
+        ` + "`when`" + `({{.repositoryVar}}.save({{.entityVar}})).thenReturn({{.entityVar}})
+
+        assertThat({{.serviceVar}}.create({{.entityVar}})).isSameAs({{.entityVar}})
+{{- end}}
+    }
+
+    @Test
+    fun updateModifiesExisting{{.entityName}}() {
+{{- if .useDto}}
+        val {{.entityVar}} = {{.factoryName}}().withId(1L).build()
+        val request = updateRequest()
+        val response = response()
+{{- range .relationRepositories}}{{if .Update}}
+        ` + "`when`" + `({{.RepositoryVar}}.findById(any())).thenReturn(Optional.of(mock({{.Target}}::class.java)))
+{{- end}}{{end}}
+        ` + "`when`" + `({{.repositoryVar}}.findById(1L)).thenReturn(Optional.of({{.entityVar}}))
+        ` + "`when`" + `({{.repositoryVar}}.save({{.entityVar}})).thenReturn({{.entityVar}})
+        ` + "`when`" + `({{.mapperVar}}.toResponse({{.entityVar}})).thenReturn(response)
+
+        assertThat({{.serviceVar}}.update(1L, request)).isEqualTo(response)
+        verify({{.mapperVar}}).updateEntity(request, {{.entityVar}})
+{{- else}}
+        val {{.entityVar}} = {{.factoryName}}().build()
+        ` + "`when`" + `({{.repositoryVar}}.findById(1L)).thenReturn(Optional.of({{.factoryName}}().withId(1L).build()))
+        ` + "`when`" + `({{.repositoryVar}}.save({{.entityVar}})).thenReturn({{.entityVar}})
+
+        assertThat({{.serviceVar}}.update(1L, {{.entityVar}}).id).isEqualTo(1L)
+{{- end}}
+    }
+
+    @Test
+    fun deleteRemoves{{.entityName}}() {
+        val {{.entityVar}} = {{.factoryName}}().withId(1L).build()
+        ` + "`when`" + `({{.repositoryVar}}.findById(1L)).thenReturn(Optional.of({{.entityVar}}))
+
+        {{.serviceVar}}.delete(1L)
+
+        verify({{.repositoryVar}}).delete({{.entityVar}})
+    }
+{{- range .helpers}}
+
+{{.}}
+{{- end}}
+}
+`
+
+// generateServiceTest génère le test unitaire Mockito du service d'une entité.
+func generateServiceTest(entityName string) {
+	ensureTestDataBuilder(entityName)
+	params := testParams(entityName)
+
+	imports := map[string]bool{
+		basePackage() + ".entity." + entityName:                    true,
+		basePackage() + ".repository." + entityName + "Repository": true,
+		"jakarta.persistence.EntityNotFoundException":              true,
+		"java.util.Optional":                                       true,
+		"org.junit.jupiter.api.Test":                               true,
+		"org.junit.jupiter.api.extension.ExtendWith":               true,
+		"org.mockito.InjectMocks":                                  true,
+		"org.mockito.Mock":                                         true,
+		"org.mockito.junit.jupiter.MockitoExtension":               true,
+		"org.springframework.data.domain.PageImpl":                 true,
+		"org.springframework.data.domain.PageRequest":              true,
+	}
+	if isKotlin() {
+		for _, imp := range []string{
+			basePackage() + ".entity." + testDataBuilderName(entityName) + ".Companion." + testDataFactory(entityName),
+			"org.assertj.core.api.Assertions.assertThat",
+			"org.assertj.core.api.Assertions.assertThatThrownBy",
+			"org.mockito.Mockito.verify",
+			"org.mockito.Mockito.`when`",
+		} {
+			imports[imp] = true
+		}
+	} else {
+		imports["java.util.List"] = true
+		imports["org.springframework.data.domain.Pageable"] = true
+	}
+	if params["filterable"].(bool) {
+		imports["org.springframework.data.jpa.domain.Specification"] = true
+		if isKotlin() {
+			imports["org.mockito.ArgumentMatchers.any"] = true
+			imports["org.mockito.ArgumentMatchers.eq"] = true
+		} else {
+			imports["java.util.Map"] = true
+			imports["org.mockito.ArgumentMatchers"] = true
+		}
+	}
+	var helpers []string
+	if params["useDto"].(bool) {
+		imports[basePackage()+".mapper."+entityName+"Mapper"] = true
+		for _, h := range []struct{ method, kind string }{
+			{"createRequest", dtoCreate}, {"updateRequest", dtoUpdate}, {"response", dtoResponse},
+		} {
+			helper, helperImports := dtoFactoryMethod(h.method, entityName, dtoClassName(entityName, h.kind))
+			helpers = append(helpers, helper)
+			for _, imp := range helperImports {
+				imports[imp] = true
+			}
+		}
+	}
+	// Les repositories des entités liées chargent les relations des DTO de requête
+	for _, r := range params["relationRepositories"].([]relationRepository) {
+		imports[basePackage()+".entity."+r.Target] = true
+		imports[basePackage()+".repository."+r.RepositoryName] = true
+		if isKotlin() {
+			imports["org.mockito.ArgumentMatchers.any"] = true
+			imports["org.mockito.Mockito.mock"] = true
+		}
+	}
+	params["imports"] = sortedKeys(imports)
+	params["helpers"] = helpers
+
+	buf := renderTemplate("serviceTest", languageTemplate(serviceTestTemplate, kotlinServiceTestTemplate), params)
+	if isKotlin() {
+		writeNewFile(testSourcePath()+"/service", entityName+"ServiceTest.kt", buf)
+		return
+	}
+	writeNewFile(testSourcePath()+"/service/impl", entityName+"ServiceImplTest.java", buf)
+}
+
+// dtoFactoryMethod renvoie une méthode de test construisant un DTO valide (constructeur
+// d'un record, setters d'une classe, arguments nommés en Kotlin) et les imports requis.
+func dtoFactoryMethod(method, entityName, className string) (string, []string) {
+	entityFields := map[string]Field{}
+	if _, fields, _, err := readEntity(entityName); err == nil {
+		for _, f := range fields {
+			entityFields[f.Name] = f
+		}
+	}
+	imports := []string{basePackage() + ".dto." + className}
+
+	var values []string
+	var properties []sourceMember
+	types, _ := scanSourceTypes(getSourcePath() + "/dto")
+	if t, ok := types[className]; ok {
+		properties = t.properties()
+	}
+	for _, p := range properties {
+		javaType := p.Type
+		if isKotlin() {
+			javaType = javaTypeFromKotlin(p.Type)
+		}
+		f, ok := entityFields[p.Name]
+		if !ok || f.Type != javaType {
+			f = Field{Name: p.Name, Type: javaType}
+		}
+		value := sampleValue(f).Code
+		if value == "" {
+			value = "null"
+		}
+		values = append(values, value)
+		imports = append(imports, fieldTypeImports(javaType)...)
+	}
+
+	var b strings.Builder
+	switch {
+	case isKotlin():
+		fmt.Fprintf(&b, "    private fun %s() = %s(\n", method, className)
+		for i, p := range properties {
+			fmt.Fprintf(&b, "        %s = %s,\n", p.Name, values[i])
+		}
+		b.WriteString("    )")
+	case types[className] != nil && types[className].Kind == "record":
+		fmt.Fprintf(&b, "    private static %s %s() {\n        return new %s(", className, method, className)
+		for i, v := range values {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n                " + v)
+		}
+		b.WriteString(");\n    }")
+	default:
+		variable := uncapitalize(method)
+		fmt.Fprintf(&b, "    private static %s %s() {\n        %s %s = new %s();\n", className, method, className, variable, className)
+		for i, p := range properties {
+			fmt.Fprintf(&b, "        %s.set%s(%s);\n", variable, capitalize(p.Name), values[i])
+		}
+		fmt.Fprintf(&b, "        return %s;\n    }", variable)
+	}
+	return b.String(), imports
+}
+
+// ===================== TEST DU CONTRÔLEUR =====================
+
+const controllerTestTemplate = `package {{.packageName}}.controller;
+{{if not .useDto}}
+import static {{.packageName}}.entity.{{.builderName}}.{{.factoryName}};
+{{- end}}
+import static org.mockito.ArgumentMatchers.any;
+{{- if .filterable}}
+import static org.mockito.ArgumentMatchers.anyMap;
+{{- end}}
+import static org.mockito.ArgumentMatchers.eq;
+{{- if .invalidField}}
+import static org.mockito.Mockito.never;
+{{- end}}
+import static org.mockito.Mockito.verify;
+import static org.mockito.Mockito.when;
+import static org.springframework.test.web.servlet.request.MockMvcRequestBuilders.delete;
+import static org.springframework.test.web.servlet.request.MockMvcRequestBuilders.get;
+import static org.springframework.test.web.servlet.request.MockMvcRequestBuilders.post;
+import static org.springframework.test.web.servlet.request.MockMvcRequestBuilders.put;
+import static org.springframework.test.web.servlet.result.MockMvcResultMatchers.jsonPath;
+import static org.springframework.test.web.servlet.result.MockMvcResultMatchers.status;
+{{range .imports}}
+import {{.}};
+{{- end}}
+
+@WebMvcTest({{.controllerName}}.class)
+class {{.controllerName}}Test {
+    private static final String VALID_BODY = """
+{{- range .validBody}}
+            {{.}}
+{{- end}}
+            """;
+{{- if .invalidField}}
+
+    // {{.invalidField}} ne respecte pas ses contraintes de validation
+    private static final String INVALID_BODY = """
+{{- range .invalidBody}}
+            {{.}}
+{{- end}}
+            """;
+{{- end}}
+
+    @Autowired
+    private MockMvc mockMvc;
+
+    @{{.mockBean}}
+    private {{.serviceName}} {{.serviceVar}};
+
+    @Test
+    void findAllReturnsPage() throws Exception {
+        when({{.serviceVar}}.findAll({{if .filterable}}anyMap(), {{end}}any(Pageable.class)))
+                .thenReturn(new PageImpl<>(List.of(response()), PageRequest.of(0, 20), 1));
+
+        mockMvc.perform(get("{{.resourcePath}}"))
+                .andExpect(status().isOk())
+                .andExpect(jsonPath("$.content.length()").value(1));
+    }
+
+    @Test
+    void findByIdReturns{{.entityName}}() throws Exception {
+        when({{.serviceVar}}.findById(1L)).thenReturn(response());
+
+        mockMvc.perform(get("{{.resourcePath}}/1"))
+                .andExpect(status().isOk())
+                .andExpect(jsonPath("$.id").value(1));
+    }
+{{- if .notFoundHandled}}
+
+    @Test
+    void findByIdReturnsNotFound() throws Exception {
+        when({{.serviceVar}}.findById(1L)).thenThrow(new EntityNotFoundException("{{.entityName}} 1 introuvable"));
+
+        mockMvc.perform(get("{{.resourcePath}}/1"))
+                .andExpect(status().isNotFound());
+    }
+{{- end}}
+
+    @Test
+    void createReturnsCreated() throws Exception {
+        when({{.serviceVar}}.create(any({{.createType}}.class))).thenReturn(response());
+
+        mockMvc.perform(post("{{.resourcePath}}").contentType(MediaType.APPLICATION_JSON).content(VALID_BODY))
+                .andExpect(status().isCreated())
+                .andExpect(jsonPath("$.id").value(1));
+    }
+{{- if .invalidField}}
+
+    @Test
+    void createRejectsInvalid{{capitalize .invalidField}}() throws Exception {
+        mockMvc.perform(post("{{.resourcePath}}").contentType(MediaType.APPLICATION_JSON).content(INVALID_BODY))
+                .andExpect(status().isBadRequest());
+
+        verify({{.serviceVar}}, never()).create(any({{.createType}}.class));
+    }
+{{- end}}
+
+    @Test
+    void updateReturns{{.entityName}}() throws Exception {
+        when({{.serviceVar}}.update(eq(1L), any({{.updateType}}.class))).thenReturn(response());
+
+        mockMvc.perform(put("{{.resourcePath}}/1").contentType(MediaType.APPLICATION_JSON).content(VALID_BODY))
+                .andExpect(status().isOk())
+                .andExpect(jsonPath("$.id").value(1));
+    }
+{{- if .invalidField}}
+
+    @Test
+    void updateRejectsInvalid{{capitalize .invalidField}}() throws Exception {
+        mockMvc.perform(put("{{.resourcePath}}/1").contentType(MediaType.APPLICATION_JSON).content(INVALID_BODY))
+                .andExpect(status().isBadRequest());
+
+        verify({{.serviceVar}}, never()).update(eq(1L), any({{.updateType}}.class));
+    }
+{{- end}}
+
+    @Test
+    void deleteReturnsNoContent() throws Exception {
+        mockMvc.perform(delete("{{.resourcePath}}/1"))
+                .andExpect(status().isNoContent());
+
+        verify({{.serviceVar}}).delete(1L);
+    }
+{{- range .helpers}}
+
+{{.}}
+{{- end}}
+}
+`
+
+const kotlinControllerTestTemplate = `package {{.packageName}}.controller
+{{range .imports}}
+import {{.}}
+{{- end}}
+
+@WebMvcTest({{.controllerName}}::class)
+class {{.controllerName}}Test {
+    @Autowired
+    private lateinit var mockMvc: MockMvc
+
+    @{{.mockBean}}
+    private lateinit var {{.serviceVar}}: {{.serviceName}}
+
+    @Test
+    fun findAllReturnsPage() {
+        ` + "`when`" + `({{.serviceVar}}.findAll({{if .filterable}}anyMap(), {{end}}any(Pageable::class.java)))
+            .thenReturn(PageImpl(listOf(response()), PageRequest.of(0, 20), 1))
+
+        mockMvc.perform(get("{{.resourcePath}}"))
+            .andExpect(status().isOk())
+            .andExpect(jsonPath("$.content.length()").value(1))
+    }
+
+    @Test
+    fun findByIdReturns{{.entityName}}() {
+        ` + "`when`" + `({{.serviceVar}}.findById(1L)).thenReturn(response())
+
+        mockMvc.perform(get("{{.resourcePath}}/1"))
+            .andExpect(status().isOk())
+            .andExpect(jsonPath("$.id").value(1))
+    }
+{{- if .notFoundHandled}}
+
+    @Test
+    fun findByIdReturnsNotFound() {
+        ` + "`when`" + `({{.serviceVar}}.findById(1L)).thenThrow(EntityNotFoundException("{{.entityName}} 1 introuvable"))
+
+        mockMvc.perform(get("{{.resourcePath}}/1"))
+            .andExpect(status().isNotFound())
+    }
+{{- end}}
+
+    @Test
+    fun createReturnsCreated() {
+        ` + "`when`" + `({{.serviceVar}}.create(any({{.createType}}::class.java))).thenReturn(response())
+
+        mockMvc.perform(post("{{.resourcePath}}").contentType(MediaType.APPLICATION_JSON).content(VALID_BODY))
+            .andExpect(status().isCreated())
+            .andExpect(jsonPath("$.id").value(1))
+    }
+{{- if .invalidField}}
+
+    @Test
+    fun createRejectsInvalid{{capitalize .invalidField}}() {
+        mockMvc.perform(post("{{.resourcePath}}").contentType(MediaType.APPLICATION_JSON).content(INVALID_BODY))
+            .andExpect(status().isBadRequest())
+
+        verify({{.serviceVar}}, never()).create(any({{.createType}}::class.java))
+    }
+{{- end}}
+
+    @Test
+    fun updateReturns{{.entityName}}() {
+        ` + "`when`" + `({{.serviceVar}}.update(eq(1L), any({{.updateType}}::class.java))).thenReturn(response())
+
+        mockMvc.perform(put("{{.resourcePath}}/1").contentType(MediaType.APPLICATION_JSON).content(VALID_BODY))
+            .andExpect(status().isOk())
+            .andExpect(jsonPath("$.id").value(1))
+    }
+{{- if .invalidField}}
+
+    @Test
+    fun updateRejectsInvalid{{capitalize .invalidField}}() {
+        mockMvc.perform(put("{{.resourcePath}}/1").contentType(MediaType.APPLICATION_JSON).content(INVALID_BODY))
+            .andExpect(status().isBadRequest())
+
+        verify({{.serviceVar}}, never()).update(eq(1L), any({{.updateType}}::class.java))
+    }
+{{- end}}
+
+    @Test
+    fun deleteReturnsNoContent() {
+        mockMvc.perform(delete("{{.resourcePath}}/1"))
+            .andExpect(status().isNoContent())
+
+        verify({{.serviceVar}}).delete(1L)
+    }
+{{- range .helpers}}
+
+{{.}}
+{{- end}}
+
+    companion object {
+        private val VALID_BODY = """
+{{- range .validBody}}
+            {{.}}
+{{- end}}
+        """.trimIndent()
+{{- if .invalidField}}
+
+        // {{.invalidField}} ne respecte pas ses contraintes de validation
+        private val INVALID_BODY = """
+{{- range .invalidBody}}
+            {{.}}
+{{- end}}
+        """.trimIndent()
+{{- end}}
+    }
+}
+`
+
+// requestField est un champ du corps JSON d'une requête de création.
+type requestField struct {
+	Field
+	Value string
+}
+
+// requestFields renvoie les champs du corps de création: ceux du DTO de création, ou ceux
+// de l'entité lorsqu'elle est exposée directement.
+func requestFields(entityName string, useDto bool) []requestField {
+	_, fields, _, _ := readEntity(entityName)
+	if !useDto {
+		var body []requestField
+		for _, f := range fields {
+			f.JSONName = f.Name
+			body = append(body, requestField{Field: f, Value: sampleValue(f).JSON})
+		}
+		return body
+	}
+
+	entityFields := map[string]Field{}
+	for _, f := range fields {
+		entityFields[f.Name] = f
+	}
+	types, _ := scanSourceTypes(getSourcePath() + "/dto")
+	t, ok := types[dtoClassName(entityName, dtoCreate)]
+	if !ok {
+		return nil
+	}
+	var body []requestField
+	for _, p := range t.properties() {
+		javaType := p.Type
+		if t.Kotlin {
+			javaType = javaTypeFromKotlin(p.Type)
+		}
+		f, ok := entityFields[p.Name]
+		if !ok || f.Type != javaType {
+			f = Field{Name: p.Name, Type: javaType}
+		}
+		f.JSONName = p.Name
+		if a, ok := findAnnotation(p.Annotations, "JsonProperty"); ok {
+			if v, ok := a.value("value"); ok {
+				f.JSONName = v
+			}
+		}
+		body = append(body, requestField{Field: f, Value: sampleValue(f).JSON})
+	}
+	return body
+}
+
+// jsonBodyLines écrit un objet JSON indenté, ligne par ligne.
+func jsonBodyLines(fields []requestField) []string {
+	if len(fields) == 0 {
+		return []string{"{}"}
+	}
+	lines := []string{"{"}
+	for i, f := range fields {
+		line := fmt.Sprintf(`  "%s": %s`, f.JSONName, f.Value)
+		if i < len(fields)-1 {
+			line += ","
+		}
+		lines = append(lines, line)
+	}
+	return append(lines, "}")
+}
+
+// generateControllerTest génère le test @WebMvcTest du contrôleur d'une entité.
+func generateControllerTest(entityName string) {
+	ensureTestDataBuilder(entityName)
+	addTestStarter("spring-boot-starter-webmvc-test")
+
+	params := testParams(entityName)
+	params["controllerName"] = entityName + "Controller"
+	useDto := params["useDto"].(bool)
+	_, webMvcTest := testSliceImports()
+	mockBean := mockBeanImport()
+	params["mockBean"] = simpleTypeName(mockBean)
+
+	imports := map[string]bool{
+		basePackage() + ".service." + entityName + "Service":     true,
+		"org.junit.jupiter.api.Test":                             true,
+		"org.springframework.beans.factory.annotation.Autowired": true,
+		webMvcTest: true,
+		"org.springframework.data.domain.PageImpl":     true,
+		"org.springframework.data.domain.PageRequest":  true,
+		"org.springframework.data.domain.Pageable":     true,
+		"org.springframework.http.MediaType":           true,
+		"org.springframework.test.web.servlet.MockMvc": true,
+		mockBean: true,
+	}
+	if isKotlin() {
+		for _, imp := range []string{
+			"org.mockito.ArgumentMatchers.any",
+			"org.mockito.ArgumentMatchers.eq",
+			"org.mockito.Mockito.verify",
+			"org.mockito.Mockito.`when`",
+			"org.springframework.test.web.servlet.request.MockMvcRequestBuilders.delete",
+			"org.springframework.test.web.servlet.request.MockMvcRequestBuilders.get",
+			"org.springframework.test.web.servlet.request.MockMvcRequestBuilders.post",
+			"org.springframework.test.web.servlet.request.MockMvcRequestBuilders.put",
+			"org.springframework.test.web.servlet.result.MockMvcResultMatchers.jsonPath",
+			"org.springframework.test.web.servlet.result.MockMvcResultMatchers.status",
+		} {
+			imports[imp] = true
+		}
+		if params["filterable"].(bool) {
+			imports["org.mockito.ArgumentMatchers.anyMap"] = true
+		}
+	} else {
+		imports["java.util.List"] = true
+	}
+
+	// Le gestionnaire global d'exceptions traduit EntityNotFoundException en 404
+	notFoundHandled := utils.Exists(getSourcePath() + "/exception/" + sourceFile("GlobalExceptionHandler"))
+	if notFoundHandled {
+		imports["jakarta.persistence.EntityNotFoundException"] = true
+	}
+
+	var helpers []string
+	if useDto {
+		for _, kind := range []string{dtoCreate, dtoUpdate} {
+			imports[basePackage()+".dto."+dtoClassName(entityName, kind)] = true
+		}
+		helper, helperImports := dtoFactoryMethod("response", entityName, dtoClassName(entityName, dtoResponse))
+		helpers = append(helpers, helper)
+		for _, imp := range helperImports {
+			imports[imp] = true
+		}
+	} else {
+		imports[basePackage()+".entity."+entityName] = true
+		if isKotlin() {
+			imports[basePackage()+".entity."+testDataBuilderName(entityName)+".Companion."+testDataFactory(entityName)] = true
+			helpers = append(helpers, fmt.Sprintf("    private fun response() = %s().withId(1L).build()", testDataFactory(entityName)))
+		} else {
+			helpers = append(helpers, fmt.Sprintf("    private static %s response() {\n        return %s().withId(1L).build();\n    }",
+				entityName, testDataFactory(entityName)))
+		}
+	}
+
+	body := requestFields(entityName, useDto)
+	params["validBody"] = jsonBodyLines(body)
+	params["invalidField"] = ""
+	for i, f := range body {
+		if value, ok := invalidJSONValue(f.Field); ok {
+			invalid := append([]requestField{}, body...)
+			invalid[i].Value = value
+			params["invalidField"] = f.Name
+			params["invalidBody"] = jsonBodyLines(invalid)
+			if isKotlin() {
+				imports["org.mockito.Mockito.never"] = true
+			}
+			break
+		}
+	}
+	params["notFoundHandled"] = notFoundHandled
+	params["imports"] = sortedKeys(imports)
+	params["helpers"] = helpers
+
+	buf := renderTemplate("controllerTest", languageTemplate(controllerTestTemplate, kotlinControllerTestTemplate), params)
+	writeNewFile(testSourcePath()+"/controller", sourceFile(entityName+"ControllerTest"), buf)
+}
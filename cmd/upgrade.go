@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"springcli/internal/buildfile"
+	"springcli/internal/maven"
+	"springcli/internal/upgrade"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	upgradeBootCmd.Flags().String("to", "", "Version cible de Spring Boot: exacte (4.0.2) ou dernière d'une série (4.0.x, 4.x)")
+	upgradeBootCmd.Flags().Bool("dry-run", false, "Affiche le rapport sans modifier le projet")
+	_ = upgradeBootCmd.MarkFlagRequired("to")
+	upgradeCmd.AddCommand(upgradeBootCmd)
+	rootCmd.AddCommand(upgradeCmd)
+}
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Assistants de montée de version",
+	Long:  `Cette commande regroupe les assistants de montée de version du projet.`,
+}
+
+// ==================== UPGRADE BOOT ====================
+var upgradeBootCmd = &cobra.Command{
+	Use:   "boot",
+	Short: "Fait passer le projet à une nouvelle version de Spring Boot.",
+	Long: `Cette commande met à jour la version de Spring Boot du projet (parent du pom.xml, BOM
+importé ou plugin Gradle), vérifie la version de Java requise, puis applique les règles de
+migration des versions majeures franchies:
+
+  - imports déplacés (javax.* → jakarta.*, @MockBean → @MockitoBean, tranches de test de
+    Spring Boot 4 et leurs starters)
+  - propriétés renommées dans application.properties et application.yml
+  - starters renommés ou supprimés (spring-boot-starter-web → spring-boot-starter-webmvc)
+
+Un rapport liste les modifications appliquées et les points à vérifier à la main.`,
+	Example: `  springcli upgrade boot --to 4.0.x --dry-run
+  springcli upgrade boot --to 4.0.2`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		to, _ := cmd.Flags().GetString("to")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		current := springBootVersion()
+		if current == "" {
+			utils.PrintError(fmt.Sprintf("Version de Spring Boot introuvable dans %s (parent spring-boot-starter-parent ou plugin org.springframework.boot)", buildFileName()))
+			os.Exit(1)
+		}
+		target, err := resolveBootVersion(to)
+		if err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+		if maven.CompareVersions(target, current) <= 0 {
+			utils.PrintError(fmt.Sprintf("Le projet utilise déjà Spring Boot %s (cible: %s)", current, target))
+			os.Exit(1)
+		}
+
+		utils.PrintTitle(fmt.Sprintf("🚀 SPRING BOOT %s → %s", current, target))
+		report := &upgradeReport{dryRun: dryRun}
+		from, toMajor := maven.Major(current), maven.Major(target)
+		upgradeBuildFile(report, target, from, toMajor)
+		checkJavaVersion(report, toMajor)
+		upgradeSources(report, from, toMajor)
+		upgradeConfiguration(report, from, toMajor)
+		report.print()
+	},
+}
+
+// resolveBootVersion renvoie la version de Spring Boot désignée par --to: la dernière
+// version stable de la série pour 4.0.x ou 4.x, la version elle-même sinon.
+func resolveBootVersion(to string) (string, error) {
+	to = strings.TrimSpace(to)
+	prefix := strings.TrimSuffix(strings.TrimSuffix(to, "x"), ".")
+	exact := prefix == to && strings.Count(to, ".") >= 2
+	if prefix == "" {
+		return "", fmt.Errorf("version cible invalide: %s", to)
+	}
+
+	repository := mavenRepository()
+	metadata, err := repository.Metadata("org.springframework.boot", "spring-boot-starter-parent")
+	if err != nil {
+		if exact {
+			utils.PrintWarning(fmt.Sprintf("Version %s non vérifiée: %v", to, err))
+			return to, nil
+		}
+		return "", fmt.Errorf("impossible de résoudre %s: %v", to, err)
+	}
+	if exact {
+		if !containsString(metadata.Versions, to) {
+			return "", fmt.Errorf("Spring Boot %s introuvable dans %s", to, repository.URL)
+		}
+		return to, nil
+	}
+	latest := ""
+	for _, v := range metadata.Versions {
+		if (v == prefix || strings.HasPrefix(v, prefix+".")) && maven.IsStable(v) &&
+			(latest == "" || maven.CompareVersions(v, latest) > 0) {
+			latest = v
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("aucune version stable de Spring Boot %s dans %s", to, repository.URL)
+	}
+	return latest, nil
+}
+
+// upgradeTableWidths sont les largeurs des colonnes du rapport: fichier, ligne, détail.
+var upgradeTableWidths = []int{36, 7, 96}
+
+// upgradeReport rassemble les modifications et les points à vérifier, fichier par fichier.
+type upgradeReport struct {
+	dryRun  bool
+	changes [][]string
+	reviews [][]string
+}
+
+func (r *upgradeReport) change(file string, line int, detail string) {
+	r.changes = append(r.changes, []string{filepath.Clean(file), lineLabel(line), detail})
+}
+
+func (r *upgradeReport) review(file string, line int, message string) {
+	r.reviews = append(r.reviews, []string{filepath.Clean(file), lineLabel(line), message})
+}
+
+// apply enregistre le résultat d'une migration et écrit le fichier s'il a changé.
+func (r *upgradeReport) apply(path, before string, result upgrade.Result) {
+	for _, c := range result.Changes {
+		r.change(path, c.Line, strings.TrimSpace(c.Before)+" → "+strings.TrimSpace(c.After))
+	}
+	for _, v := range result.Reviews {
+		r.review(path, v.Line, v.Message)
+	}
+	if r.dryRun || result.Content == before {
+		return
+	}
+	if err := os.WriteFile(path, []byte(result.Content), 0o644); err != nil {
+		utils.PrintError(fmt.Sprintf("Impossible d'écrire %s: %v", path, err))
+		os.Exit(1)
+	}
+}
+
+func (r *upgradeReport) print() {
+	if len(r.changes) > 0 {
+		utils.PrintSubtitle("Modifications")
+		fmt.Println(formatQueryTable([]string{"Fichier", "Ligne", "Modification"}, r.changes, upgradeTableWidths...))
+	}
+	if len(r.reviews) > 0 {
+		utils.PrintSubtitle("À vérifier")
+		fmt.Println(formatQueryTable([]string{"Fichier", "Ligne", "Point à vérifier"}, r.reviews, upgradeTableWidths...))
+	}
+	if r.dryRun {
+		utils.PrintInfo(fmt.Sprintf("%d modification(s) à appliquer, relancez sans --dry-run pour modifier le projet", len(r.changes)))
+		return
+	}
+	utils.PrintSuccess(fmt.Sprintf("%d modification(s) appliquée(s), %d point(s) à vérifier", len(r.changes), len(r.reviews)))
+}
+
+func lineLabel(line int) string {
+	if line == 0 {
+		return "-"
+	}
+	return strconv.Itoa(line)
+}
+
+// upgradeBuildFile met à jour la version de Spring Boot et les starters du fichier de build.
+func upgradeBuildFile(report *upgradeReport, target string, from, to int) {
+	path := buildFileName()
+	if gradleBuildFile() == "" {
+		path = pomPath
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		utils.PrintError(fmt.Sprintf("Impossible de lire %s: %v", path, err))
+		os.Exit(1)
+	}
+	content := string(data)
+
+	var artifacts []buildfile.VersionedArtifact
+	if path == pomPath {
+		if artifacts, err = buildfile.VersionedArtifacts(content); err != nil {
+			utils.PrintError(err.Error())
+			os.Exit(1)
+		}
+	} else {
+		artifacts = buildfile.GradleVersionedArtifacts(content)
+	}
+	versions := map[int]string{}
+	for _, a := range artifacts {
+		if (versionUpdate{VersionedArtifact: a}).isBoot() {
+			versions[a.Start] = target
+		}
+	}
+	bumped := buildfile.ReplaceVersions(content, artifacts, versions)
+
+	result := upgrade.MigrateStarters(bumped, from, to)
+	for _, line := range changedLines(content, bumped) {
+		result.Changes = append([]upgrade.Change{line}, result.Changes...)
+	}
+	report.apply(path, content, result)
+}
+
+// changedLines renvoie les lignes qui diffèrent entre deux versions d'un même fichier.
+func changedLines(before, after string) []upgrade.Change {
+	oldLines, newLines := strings.Split(before, "\n"), strings.Split(after, "\n")
+	var changes []upgrade.Change
+	for i := range oldLines {
+		if i < len(newLines) && oldLines[i] != newLines[i] {
+			changes = append(changes, upgrade.Change{Line: i + 1, Before: oldLines[i], After: newLines[i]})
+		}
+	}
+	return changes
+}
+
+var (
+	gradleJavaVersionRegexp = regexp.MustCompile(`(JavaLanguageVersion\.of\(\s*|jvmToolchain\(\s*|JavaVersion\.VERSION_(?:1_)?)(\d+)`)
+	javaRuntimeRegexp       = regexp.MustCompile(`version "(?:1\.)?(\d+)`)
+)
+
+// checkJavaVersion relève la version de Java du projet au minimum requis par la version
+// cible de Spring Boot, et signale un JDK installé trop ancien.
+func checkJavaVersion(report *upgradeReport, to int) {
+	required, ok := upgrade.JavaVersions[to]
+	if !ok {
+		return
+	}
+
+	project := 0
+	if gradleFile := gradleBuildFile(); gradleFile != "" {
+		data, err := os.ReadFile(gradleFile)
+		if err == nil {
+			if m := gradleJavaVersionRegexp.FindStringSubmatchIndex(string(data)); m != nil {
+				project, _ = strconv.Atoi(string(data)[m[4]:m[5]])
+				if project < required {
+					updated := string(data)[:m[4]] + strconv.Itoa(required) + string(data)[m[5]:]
+					report.apply(gradleFile, string(data), upgrade.Result{Content: updated, Changes: changedLines(string(data), updated)})
+				}
+			}
+		}
+	} else if data, err := os.ReadFile(pomPath); err == nil {
+		if value, ok := buildfile.Property(string(data), "java.version"); ok {
+			project, _ = strconv.Atoi(strings.TrimPrefix(value, "1."))
+			if project < required {
+				updated, err := buildfile.SetProperty(string(data), "java.version", strconv.Itoa(required))
+				if err == nil {
+					report.apply(pomPath, string(data), upgrade.Result{Content: updated, Changes: changedLines(string(data), updated)})
+				}
+			}
+		}
+	}
+	if project == 0 {
+		report.review(buildFileName(), 0, fmt.Sprintf("version de Java introuvable: Spring Boot %d demande Java %d ou plus", to, required))
+		project = required
+	} else if project < required {
+		project = required
+	}
+
+	output, err := exec.Command("java", "-version").CombinedOutput()
+	if err != nil {
+		report.review("JDK", 0, fmt.Sprintf("java introuvable: installez un JDK %d ou plus", project))
+		return
+	}
+	if m := javaRuntimeRegexp.FindSubmatch(output); m != nil {
+		if installed, _ := strconv.Atoi(string(m[1])); installed < project {
+			report.review("JDK", 0, fmt.Sprintf("JDK %d installé: le projet demande Java %d", installed, project))
+		}
+	}
+}
+
+// upgradeSources applique les règles d'import aux sources Java et Kotlin, puis déclare les
+// starters de test que demandent les imports migrés.
+func upgradeSources(report *upgradeReport, from, to int) {
+	var starters []string
+	for _, path := range projectFiles("src", ".java", ".kt") {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		result := upgrade.MigrateSource(string(data), from, to)
+		report.apply(path, string(data), result)
+		for _, s := range result.Starters {
+			if !containsString(starters, s) {
+				starters = append(starters, s)
+			}
+		}
+	}
+
+	sort.Strings(starters)
+	for _, artifactID := range starters {
+		if hasBuildDependency("org.springframework.boot", artifactID) {
+			continue
+		}
+		report.change(buildFileName(), 0, artifactID+" ajouté (scope test)")
+		if report.dryRun {
+			continue
+		}
+		err := addBuildDependency(buildfile.Dependency{GroupID: "org.springframework.boot", ArtifactID: artifactID, Scope: "test"}, "testImplementation")
+		if err != nil && !errors.Is(err, buildfile.ErrDuplicate) {
+			utils.PrintError(fmt.Sprintf("Impossible d'ajouter %s à %s: %v", artifactID, buildFileName(), err))
+			os.Exit(1)
+		}
+	}
+}
+
+// upgradeConfiguration applique les règles de propriétés aux fichiers application*.properties
+// et application*.yml.
+func upgradeConfiguration(report *upgradeReport, from, to int) {
+	for _, path := range projectFiles("src", ".properties", ".yml", ".yaml") {
+		if !strings.HasPrefix(filepath.Base(path), "application") {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if strings.HasSuffix(path, ".properties") {
+			report.apply(path, string(data), upgrade.MigrateProperties(string(data), from, to))
+		} else {
+			report.apply(path, string(data), upgrade.MigrateYAML(string(data), from, to))
+		}
+	}
+}
+
+// projectFiles renvoie les fichiers de root ayant l'une des extensions, triés.
+func projectFiles(root string, extensions ...string) []string {
+	var files []string
+	_ = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		if containsString(extensions, filepath.Ext(path)) {
+			files = append(files, filepath.ToSlash(path))
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
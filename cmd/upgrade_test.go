@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"springcli/internal/config"
+)
+
+const bootMetadata = `<?xml version="1.0" encoding="UTF-8"?>
+<metadata>
+  <groupId>org.springframework.boot</groupId>
+  <artifactId>spring-boot-starter-parent</artifactId>
+  <versioning>
+    <latest>4.2.0-M1</latest>
+    <release>4.2.0-M1</release>
+    <versions>
+      <version>3.5.3</version>
+      <version>4.0.0-M2</version>
+      <version>4.0.0</version>
+      <version>4.0.2</version>
+      <version>4.0.10</version>
+      <version>4.1.0-RC1</version>
+      <version>4.1.0</version>
+      <version>4.2.0-M1</version>
+    </versions>
+  </versioning>
+</metadata>`
+
+// TestResolveBootVersion résout --to avec un dépôt Maven simulé, déclaré dans le
+// .springcli.yaml d'un projet temporaire.
+func TestResolveBootVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/springframework/boot/spring-boot-starter-parent/maven-metadata.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(bootMetadata))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	settings := "maven:\n  repository: " + server.URL + "\n"
+	if err := os.WriteFile(filepath.Join(dir, config.FileName), []byte(settings), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, dir)
+
+	cases := []struct {
+		to      string
+		want    string
+		wantErr bool
+	}{
+		{to: "4.x", want: "4.1.0"},
+		{to: "4.0.x", want: "4.0.10"},
+		{to: "4.0.2", want: "4.0.2"},
+		{to: "3.x", want: "3.5.3"},
+		{to: "4.0.3", wantErr: true},
+		{to: "5.x", wantErr: true},
+		{to: "x", wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.to, func(t *testing.T) {
+			got, err := resolveBootVersion(c.to)
+			if c.wantErr {
+				if err == nil {
+					t.Errorf("resolveBootVersion(%q) = %q, erreur attendue", c.to, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveBootVersion(%q): %v", c.to, err)
+			}
+			if got != c.want {
+				t.Errorf("resolveBootVersion(%q) = %q, attendu %q", c.to, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ===================== CONTRAINTES DES CHAMPS ======================
+// Les contraintes sont saisies après le type d'un champ, séparées par des virgules:
+//
+//	email:string:required,email,max=120
+//	birthDate:LocalDate:past
+//	age:int:positive
+var constraintDescriptions = [][]string{
+	{"required", "Champ obligatoire (@NotBlank pour les chaînes, @NotNull sinon)"},
+	{"email", "Adresse e-mail valide (@Email)"},
+	{"min=N", "Longueur ou valeur minimale (@Size / @Min)"},
+	{"max=N", "Longueur ou valeur maximale (@Size / @Max)"},
+	{"past", "Date dans le passé (@Past)"},
+	{"future", "Date dans le futur (@Future)"},
+	{"positive", "Nombre strictement positif (@Positive)"},
+	{"decimalmin=X", "Valeur décimale minimale (@DecimalMin)"},
+	{"decimalmax=X", "Valeur décimale maximale (@DecimalMax)"},
+	{"exclusivemin=X", "Valeur strictement supérieure (@DecimalMin(inclusive = false))"},
+	{"exclusivemax=X", "Valeur strictement inférieure (@DecimalMax(inclusive = false))"},
+	{"pattern=REGEX", "Chaîne respectant une expression régulière (@Pattern)"},
+	{"unique", "Valeur unique en base (@Column(unique = true))"},
+}
+
+// parseConstraints découpe la liste de contraintes saisie par l'utilisateur. Seul le nom
+// des contraintes est insensible à la casse (pattern=^[A-Z]+$).
+func parseConstraints(s string) []string {
+	var constraints []string
+	for _, c := range strings.Split(s, ",") {
+		name, value, hasValue := strings.Cut(strings.TrimSpace(c), "=")
+		c = strings.ToLower(name)
+		if hasValue {
+			c += "=" + value
+		}
+		if c != "" {
+			constraints = append(constraints, c)
+		}
+	}
+	return constraints
+}
+
+// hasConstraint indique si le champ porte la contrainte donnée.
+func (f Field) hasConstraint(name string) bool {
+	for _, c := range f.Constraints {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// constraintText renvoie la valeur textuelle d'une contrainte (pattern=^[a-z]+$ -> ^[a-z]+$).
+func (f Field) constraintText(name string) (string, bool) {
+	for _, c := range f.Constraints {
+		if v, ok := strings.CutPrefix(c, name+"="); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// constraintValue renvoie la valeur d'une contrainte paramétrée (min=3 -> 3).
+func (f Field) constraintValue(name string) (int, bool) {
+	for _, c := range f.Constraints {
+		if v, ok := strings.CutPrefix(c, name+"="); ok {
+			n, err := strconv.Atoi(v)
+			return n, err == nil
+		}
+	}
+	return 0, false
+}
+
+// numericBounds renvoie les bornes entières que doit respecter la valeur d'un champ
+// numérique, bornes décimales et exclues comprises (decimalmin=0.5 -> 1, exclusivemax=10 -> 9).
+func (f Field) numericBounds() (min int, hasMin bool, max int, hasMax bool) {
+	min, hasMin = f.constraintValue("min")
+	max, hasMax = f.constraintValue("max")
+	for _, name := range []string{"decimalmin", "exclusivemin"} {
+		text, _ := f.constraintText(name)
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			continue
+		}
+		n := int(math.Ceil(v))
+		if name == "exclusivemin" && float64(n) == v {
+			n++
+		}
+		if !hasMin || n > min {
+			min, hasMin = n, true
+		}
+	}
+	for _, name := range []string{"decimalmax", "exclusivemax"} {
+		text, _ := f.constraintText(name)
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			continue
+		}
+		n := int(math.Floor(v))
+		if name == "exclusivemax" && float64(n) == v {
+			n--
+		}
+		if !hasMax || n < max {
+			max, hasMax = n, true
+		}
+	}
+	return min, hasMin, max, hasMax
+}
+
+func isTextType(t string) bool {
+	return t == "String"
+}
+
+func isCollectionType(t string) bool {
+	return strings.HasPrefix(t, "List<") || strings.HasPrefix(t, "Set<") || strings.HasPrefix(t, "Map<")
+}
+
+func isNumericType(t string) bool {
+	switch t {
+	case "int", "Integer", "long", "Long", "double", "Double", "float", "Float", "BigDecimal", "short", "Short":
+		return true
+	}
+	return false
+}
+
+// validationAnnotations renvoie les annotations Bean Validation correspondant aux contraintes du champ.
+func validationAnnotations(f Field) []string {
+	var annotations []string
+	if f.hasConstraint("required") {
+		if isTextType(f.Type) {
+			annotations = append(annotations, "@NotBlank")
+		} else if !isPrimitive(f.Type) {
+			annotations = append(annotations, "@NotNull")
+		}
+	}
+	if f.hasConstraint("email") || (isTextType(f.Type) && strings.EqualFold(f.Name, "email")) {
+		annotations = append(annotations, "@Email")
+	}
+
+	min, hasMin := f.constraintValue("min")
+	max, hasMax := f.constraintValue("max")
+	if (isTextType(f.Type) || isCollectionType(f.Type)) && (hasMin || hasMax) {
+		var args []string
+		if hasMin {
+			args = append(args, fmt.Sprintf("min = %d", min))
+		}
+		if hasMax {
+			args = append(args, fmt.Sprintf("max = %d", max))
+		}
+		annotations = append(annotations, "@Size("+strings.Join(args, ", ")+")")
+	} else if isNumericType(f.Type) {
+		if hasMin {
+			annotations = append(annotations, fmt.Sprintf("@Min(%d)", min))
+		}
+		if hasMax {
+			annotations = append(annotations, fmt.Sprintf("@Max(%d)", max))
+		}
+	}
+
+	if isNumericType(f.Type) {
+		for _, c := range []struct {
+			name       string
+			annotation string
+		}{{"decimalmin", "@DecimalMin(%s)"}, {"exclusivemin", "@DecimalMin(value = %s, inclusive = false)"},
+			{"decimalmax", "@DecimalMax(%s)"}, {"exclusivemax", "@DecimalMax(value = %s, inclusive = false)"}} {
+			if value, ok := f.constraintText(c.name); ok {
+				annotations = append(annotations, fmt.Sprintf(c.annotation, stringLiteral(value)))
+			}
+		}
+	}
+
+	if f.hasConstraint("past") {
+		annotations = append(annotations, "@Past")
+	}
+	if f.hasConstraint("future") {
+		annotations = append(annotations, "@Future")
+	}
+	if f.hasConstraint("positive") {
+		annotations = append(annotations, "@Positive")
+	}
+	if pattern, ok := f.constraintText("pattern"); ok && isTextType(f.Type) {
+		annotations = append(annotations, fmt.Sprintf(`@Pattern(regexp = %s)`, stringLiteral(pattern)))
+	}
+	// Validation en cascade des objets imbriqués
+	if f.hasConstraint("valid") {
+		annotations = append(annotations, "@Valid")
+	}
+	return annotations
+}
+
+// stringLiteral renvoie une chaîne littérale Java ou Kotlin.
+func stringLiteral(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	if isKotlin() {
+		s = strings.ReplaceAll(s, "$", `\$`)
+	}
+	return `"` + s + `"`
+}
+
+// columnAnnotation renvoie l'annotation @Column reflétant les contraintes en base, si nécessaire.
+func columnAnnotation(f Field) string {
+	var args []string
+	if f.Column != "" {
+		args = append(args, fmt.Sprintf(`name = "%s"`, f.Column))
+	}
+	if f.hasConstraint("required") {
+		args = append(args, "nullable = false")
+	}
+	if f.hasConstraint("unique") {
+		args = append(args, "unique = true")
+	}
+	if max, ok := f.constraintValue("max"); ok && isTextType(f.Type) {
+		args = append(args, fmt.Sprintf("length = %d", max))
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	return "@Column(" + strings.Join(args, ", ") + ")"
+}
+
+// entityFieldAnnotations renvoie les annotations d'un champ d'entité (JPA puis Bean Validation).
+func entityFieldAnnotations(f Field) []string {
+	var annotations []string
+	if column := columnAnnotation(f); column != "" {
+		annotations = append(annotations, column)
+	}
+	if isEnumType(f.Type) {
+		annotations = append(annotations, "@Enumerated(EnumType.STRING)")
+	}
+	annotations = append(annotations, validationAnnotations(f)...)
+	if isKotlin() {
+		// Sur un paramètre de constructeur Kotlin, l'annotation doit viser le champ
+		for i, a := range annotations {
+			annotations[i] = "@field:" + strings.TrimPrefix(a, "@")
+		}
+	}
+	return annotations
+}
+
+// annotationImports renvoie les imports nécessaires aux annotations données.
+func annotationImports(annotations []string) []string {
+	var imports []string
+	for _, a := range annotations {
+		name := regexp.MustCompile(`^@(?:field:)?(\w+)`).FindStringSubmatch(a)
+		if name == nil {
+			continue
+		}
+		switch name[1] {
+		case "Column":
+			imports = append(imports, "jakarta.persistence.Column")
+		case "Enumerated":
+			imports = append(imports, "jakarta.persistence.Enumerated", "jakarta.persistence.EnumType")
+		case "JsonProperty":
+			imports = append(imports, "com.fasterxml.jackson.annotation.JsonProperty")
+		case "Valid":
+			imports = append(imports, "jakarta.validation.Valid")
+		default:
+			imports = append(imports, "jakarta.validation.constraints."+name[1])
+		}
+	}
+	return imports
+}
+
+// constraintsFromAnnotations reconstruit les contraintes d'un champ à partir des annotations
+// présentes dans le code source (inverse de entityFieldAnnotations).
+func constraintsFromAnnotations(annotations string) []string {
+	var constraints []string
+	add := func(c string) {
+		for _, existing := range constraints {
+			if existing == c {
+				return
+			}
+		}
+		constraints = append(constraints, c)
+	}
+
+	if regexp.MustCompile(`@(?:field:)?(NotBlank|NotNull|NotEmpty)\b`).MatchString(annotations) ||
+		regexp.MustCompile(`nullable\s*=\s*false`).MatchString(annotations) {
+		add("required")
+	}
+	if regexp.MustCompile(`@(?:field:)?Email\b`).MatchString(annotations) {
+		add("email")
+	}
+	if regexp.MustCompile(`unique\s*=\s*true`).MatchString(annotations) {
+		add("unique")
+	}
+	for _, name := range []string{"Past", "Future", "Positive"} {
+		if regexp.MustCompile(`@(?:field:)?` + name + `\b`).MatchString(annotations) {
+			add(strings.ToLower(name))
+		}
+	}
+	if m := regexp.MustCompile(`@(?:field:)?Size\(([^)]*)\)`).FindStringSubmatch(annotations); m != nil {
+		if v := regexp.MustCompile(`min\s*=\s*(\d+)`).FindStringSubmatch(m[1]); v != nil {
+			add("min=" + v[1])
+		}
+		if v := regexp.MustCompile(`max\s*=\s*(\d+)`).FindStringSubmatch(m[1]); v != nil {
+			add("max=" + v[1])
+		}
+	}
+	if v := regexp.MustCompile(`@(?:field:)?Pattern\(regexp\s*=\s*"((?:[^"\\]|\\.)*)"\)`).FindStringSubmatch(annotations); v != nil {
+		add("pattern=" + unquoteLiteral(v[1]))
+	}
+	if v := regexp.MustCompile(`length\s*=\s*(\d+)`).FindStringSubmatch(annotations); v != nil {
+		add("max=" + v[1])
+	}
+	if v := regexp.MustCompile(`@(?:field:)?Min\((\d+)\)`).FindStringSubmatch(annotations); v != nil {
+		add("min=" + v[1])
+	}
+	if v := regexp.MustCompile(`@(?:field:)?Max\((\d+)\)`).FindStringSubmatch(annotations); v != nil {
+		add("max=" + v[1])
+	}
+	for _, c := range []struct{ annotation, name string }{{"DecimalMin", "min"}, {"DecimalMax", "max"}} {
+		v := regexp.MustCompile(`@(?:field:)?` + c.annotation + `\((?:value\s*=\s*)?"([^"]*)"(\s*,\s*inclusive\s*=\s*false)?\)`).FindStringSubmatch(annotations)
+		if v == nil {
+			continue
+		}
+		if v[2] != "" {
+			add("exclusive" + c.name + "=" + v[1])
+		} else {
+			add("decimal" + c.name + "=" + v[1])
+		}
+	}
+	return constraints
+}
+
+// unquoteLiteral est l'inverse de stringLiteral, sans les guillemets.
+func unquoteLiteral(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func isPrimitive(t string) bool {
+	switch t {
+	case "int", "long", "double", "float", "boolean", "short", "byte", "char":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	generateValidatorCmd.Flags().String("target", "", "Champ d'entité validé, sous la forme Entity.champ (ex: User.email)")
+	generateCmd.AddCommand(generateValidatorCmd)
+}
+
+// ==================== GENERATE VALIDATOR ====================
+var generateValidatorCmd = &cobra.Command{
+	Use:   "validator [annotation-name]",
+	Short: "Génère une contrainte Bean Validation personnalisée et son ConstraintValidator.",
+	Long: `Cette commande génère une annotation de contrainte et le ConstraintValidator associé
+dans le package validation. Pour une contrainte Unique* ciblant un champ d'entité
+(--target User.email), le validateur interroge le repository de l'entité et la
+méthode existsBy correspondante y est ajoutée.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		utils.PrintTitle("✅ GÉNÉRATEUR DE VALIDATEUR SPRING BOOT")
+
+		annotationName := capitalize(args[0])
+		target, _ := cmd.Flags().GetString("target")
+
+		params := map[string]interface{}{
+			"annotationName": annotationName,
+			"validatorName":  annotationName + "Validator",
+			"valueType":      "Object",
+			"unique":         false,
+			"message":        "Valeur invalide",
+			"packageName":    basePackage(),
+		}
+
+		if target != "" {
+			entityName, fieldName, ok := strings.Cut(target, ".")
+			if !ok || entityName == "" || fieldName == "" {
+				utils.PrintError(fmt.Sprintf("Cible invalide: %s (format attendu: Entity.champ)", target))
+				os.Exit(1)
+			}
+
+			field, err := entityField(entityName, fieldName)
+			if err != nil {
+				utils.PrintError(err.Error())
+				os.Exit(1)
+			}
+			params["valueType"] = boxedType(field.Type)
+
+			if strings.HasPrefix(annotationName, "Unique") {
+				params["unique"] = true
+				params["entityName"] = entityName
+				params["repositoryName"] = entityName + "Repository"
+				params["repositoryVar"] = uncapitalize(entityName) + "Repository"
+				params["existsMethod"] = "existsBy" + capitalize(field.Name)
+				params["message"] = fmt.Sprintf("La valeur de %s est déjà utilisée", field.Name)
+				addExistsByMethod(entityName, field)
+			}
+		}
+
+		utils.PrintInfo(fmt.Sprintf("Génération de la contrainte: @%s", annotationName))
+		path := getSourcePath() + "/validation"
+		writeNewFile(path, sourceFile(annotationName),
+			renderTemplate("constraint", languageTemplate(constraintAnnotationTemplate, kotlinConstraintAnnotationTemplate), params))
+		writeNewFile(path, sourceFile(annotationName+"Validator"),
+			renderTemplate("validator", languageTemplate(constraintValidatorTemplate, kotlinConstraintValidatorTemplate), params))
+
+		if target != "" {
+			utils.PrintInfo(fmt.Sprintf("Astuce: annotez le champ du DTO de création avec @%s", annotationName))
+		}
+	},
+}
+
+const constraintAnnotationTemplate = `package {{.packageName}}.validation;
+
+import jakarta.validation.Constraint;
+import jakarta.validation.Payload;
+import java.lang.annotation.Documented;
+import java.lang.annotation.ElementType;
+import java.lang.annotation.Retention;
+import java.lang.annotation.RetentionPolicy;
+import java.lang.annotation.Target;
+
+@Documented
+@Constraint(validatedBy = {{.validatorName}}.class)
+@Target({ElementType.FIELD, ElementType.PARAMETER})
+@Retention(RetentionPolicy.RUNTIME)
+public @interface {{.annotationName}} {
+    String message() default "{{.message}}";
+
+    Class<?>[] groups() default {};
+
+    Class<? extends Payload>[] payload() default {};
+}
+`
+
+const constraintValidatorTemplate = `package {{.packageName}}.validation;
+{{if .unique}}
+import {{.packageName}}.repository.{{.repositoryName}};
+{{- end}}
+import jakarta.validation.ConstraintValidator;
+import jakarta.validation.ConstraintValidatorContext;
+
+public class {{.validatorName}} implements ConstraintValidator<{{.annotationName}}, {{.valueType}}> {
+{{- if .unique}}
+    private final {{.repositoryName}} {{.repositoryVar}};
+
+    public {{.validatorName}}({{.repositoryName}} {{.repositoryVar}}) {
+        this.{{.repositoryVar}} = {{.repositoryVar}};
+    }
+
+    @Override
+    public boolean isValid({{.valueType}} value, ConstraintValidatorContext context) {
+        // Les valeurs absentes relèvent de @NotNull / @NotBlank
+        return value == null || !{{.repositoryVar}}.{{.existsMethod}}(value);
+    }
+{{- else}}
+    @Override
+    public boolean isValid({{.valueType}} value, ConstraintValidatorContext context) {
+        // TODO: implémenter la règle de validation
+        return true;
+    }
+{{- end}}
+}
+`
+
+const kotlinConstraintAnnotationTemplate = `package {{.packageName}}.validation
+
+import jakarta.validation.Constraint
+import jakarta.validation.Payload
+import kotlin.reflect.KClass
+
+@MustBeDocumented
+@Constraint(validatedBy = [{{.validatorName}}::class])
+@Target(AnnotationTarget.FIELD, AnnotationTarget.VALUE_PARAMETER)
+@Retention(AnnotationRetention.RUNTIME)
+annotation class {{.annotationName}}(
+    val message: String = "{{.message}}",
+    val groups: Array<KClass<*>> = [],
+    val payload: Array<KClass<out Payload>> = [],
+)
+`
+
+const kotlinConstraintValidatorTemplate = `package {{.packageName}}.validation
+{{if .unique}}
+import {{.packageName}}.repository.{{.repositoryName}}
+{{- end}}
+import jakarta.validation.ConstraintValidator
+import jakarta.validation.ConstraintValidatorContext
+{{if .unique}}
+class {{.validatorName}}(
+    private val {{.repositoryVar}}: {{.repositoryName}},
+) : ConstraintValidator<{{.annotationName}}, {{kotlinValueType .valueType}}> {
+
+    // Les valeurs absentes relèvent de @NotNull / @NotBlank
+    override fun isValid(value: {{kotlinValueType .valueType}}?, context: ConstraintValidatorContext): Boolean =
+        value == null || !{{.repositoryVar}}.{{.existsMethod}}(value)
+}
+{{- else}}
+class {{.validatorName}} : ConstraintValidator<{{.annotationName}}, {{kotlinValueType .valueType}}> {
+
+    // TODO: implémenter la règle de validation
+    override fun isValid(value: {{kotlinValueType .valueType}}?, context: ConstraintValidatorContext): Boolean = true
+}
+{{- end}}
+`
+
+// entityField renvoie la définition d'un champ d'une entité existante.
+func entityField(entityName, fieldName string) (Field, error) {
+	_, fields, _, err := readEntity(entityName)
+	if err != nil {
+		return Field{}, fmt.Errorf("impossible de lire l'entité %s: %v", entityName, err)
+	}
+	for _, f := range fields {
+		if f.Name == fieldName {
+			return f, nil
+		}
+	}
+	return Field{}, fmt.Errorf("le champ %s n'existe pas dans l'entité %s", fieldName, entityName)
+}
+
+// addExistsByMethod ajoute la méthode existsBy<Champ> au repository de l'entité.
+func addExistsByMethod(entityName string, field Field) {
+	if !utils.Exists(repositoryPath(entityName)) {
+		generateRepository(entityName)
+	}
+
+	methodName := "existsBy" + capitalize(field.Name)
+	declaration := fmt.Sprintf("boolean %s(%s %s);", methodName, field.Type, field.Name)
+	if isKotlin() {
+		declaration = fmt.Sprintf("fun %s(%s: %s): Boolean", methodName, field.Name, kotlinType(field.Type))
+	}
+
+	added, err := addRepositoryMethod(entityName, methodName, declaration, nil)
+	if err != nil {
+		utils.PrintWarning(fmt.Sprintf("Méthode %s non ajoutée: %v", methodName, err))
+		return
+	}
+	if added {
+		utils.PrintSuccess(fmt.Sprintf("Méthode %s ajoutée à %sRepository", methodName, entityName))
+	}
+}
+
+// boxedType renvoie le type objet correspondant à un type primitif Java.
+func boxedType(t string) string {
+	switch t {
+	case "int":
+		return "Integer"
+	case "char":
+		return "Character"
+	case "long", "double", "float", "boolean", "short", "byte":
+		return capitalize(t)
+	default:
+		return t
+	}
+}
+
+// kotlinValueType renvoie le type Kotlin validé par un ConstraintValidator.
+func kotlinValueType(t string) string {
+	if t == "Object" {
+		return "Any"
+	}
+	return kotlinType(t)
+}
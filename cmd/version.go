@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"springcli/internal/config"
+	"springcli/internal/maven"
+	"springcli/internal/release"
+	"springcli/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// ==================== INIT ====================
+func init() {
+	versionCmd.Flags().Bool("check", false, "Vérifie sur GitHub si une version plus récente est publiée")
+	rootCmd.AddCommand(versionCmd)
+}
+
+// ==================== VERSION ====================
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Affiche la version de SpringCLI.",
+	Long: `Cette commande affiche la version de SpringCLI, le commit et la date de compilation.
+
+Avec --check, la dernière release publiée sur GitHub est comparée à la version installée.
+L'API interrogée se configure avec ` + "`release.api`" + ` et ` + "`release.repository`" + ` dans la configuration
+de l'utilisateur (~/.config/springcli/config.yaml sous Linux), ou avec les variables
+SPRINGCLI_RELEASE_API et SPRINGCLI_RELEASE_REPOSITORY: jamais dans le .springcli.yaml du
+projet. GITHUB_TOKEN, s'il est défini, n'est envoyé qu'à cette API.`,
+	Example: `  springcli version
+  springcli version --check`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		displayVersion()
+		if check, _ := cmd.Flags().GetBool("check"); !check {
+			return
+		}
+
+		latest, err := latestRelease().Latest()
+		if err != nil {
+			utils.PrintError(fmt.Sprintf("Impossible de lire la dernière release: %v", err))
+			os.Exit(1)
+		}
+		if !isNewerRelease(latest) {
+			utils.PrintSuccess(fmt.Sprintf("SpringCLI est à jour (dernière release: %s)", latest.Version()))
+			return
+		}
+		utils.PrintInfo(fmt.Sprintf("Nouvelle version disponible: %s → %s", version, latest.Version()))
+		if latest.HTMLURL != "" {
+			utils.PrintInfo("Notes de version: " + latest.HTMLURL)
+		}
+		utils.PrintInfo("Mise à jour: springcli self-update")
+	},
+}
+
+// latestRelease renvoie le client des releases de springcli configuré par l'utilisateur.
+func latestRelease() *release.Client {
+	c, err := config.LoadUser()
+	if err != nil {
+		path, _ := config.UserPath()
+		utils.PrintError(fmt.Sprintf("Impossible de lire %s: %v", path, err))
+		os.Exit(1)
+	}
+	return release.NewClient(c.Release.API, c.Release.Repository)
+}
+
+// isNewerRelease indique si la release est plus récente que la version installée. Une
+// version de développement est toujours plus ancienne.
+func isNewerRelease(latest *release.Release) bool {
+	return version == "dev" || maven.CompareVersions(latest.Version(), version) > 0
+}
@@ -0,0 +1,101 @@
+package buildfile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// HasGradleDependency indique si les coordonnées groupId:artifactId apparaissent dans le script Gradle.
+func HasGradleDependency(content, groupID, artifactID string) bool {
+	return strings.Contains(content, "\""+groupID+":"+artifactID) ||
+		strings.Contains(content, "'"+groupID+":"+artifactID)
+}
+
+// AddGradleDependency ajoute une dépendance dans le bloc dependencies { } d'un script
+// Gradle (Groovy ou Kotlin DSL) pour la configuration donnée (implementation, testImplementation...).
+func AddGradleDependency(content, configuration string, dep Dependency, kotlinDSL bool) (string, error) {
+	declared := regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(configuration) + `\s*\(?\s*["']` + regexp.QuoteMeta(dep.Coordinates()) + `[:"']`)
+	if declared.MatchString(content) {
+		return content, fmt.Errorf("%s: %w", dep.Coordinates(), ErrDuplicate)
+	}
+
+	coordinates := dep.Coordinates()
+	if dep.Version != "" {
+		coordinates += ":" + dep.Version
+	}
+	line := configuration + " '" + coordinates + "'"
+	if kotlinDSL {
+		line = configuration + "(\"" + coordinates + "\")"
+	}
+	return appendToBlock(content, "dependencies", line)
+}
+
+// AddGradlePlatform importe un BOM avec platform() dans le bloc dependencies { }. Le script
+// est renvoyé inchangé si le BOM y est déjà mentionné (platform ou mavenBom).
+func AddGradlePlatform(content, configuration string, bom Dependency, kotlinDSL bool) (string, error) {
+	if HasGradleDependency(content, bom.GroupID, bom.ArtifactID) {
+		return content, nil
+	}
+	coordinates := bom.Coordinates() + ":" + bom.Version
+	line := configuration + " platform('" + coordinates + "')"
+	if kotlinDSL {
+		line = configuration + "(platform(\"" + coordinates + "\"))"
+	}
+	return appendToBlock(content, "dependencies", line)
+}
+
+// AddGradleRepository déclare un dépôt Maven dans le bloc repositories { }. Le script est
+// renvoyé inchangé si l'URL y est déjà déclarée.
+func AddGradleRepository(content, url string, kotlinDSL bool) (string, error) {
+	if strings.Contains(content, "\""+url+"\"") || strings.Contains(content, "'"+url+"'") {
+		return content, nil
+	}
+	line := "maven { url '" + url + "' }"
+	if kotlinDSL {
+		line = "maven { url = uri(\"" + url + "\") }"
+	}
+	return appendToBlock(content, "repositories", line)
+}
+
+// appendToBlock ajoute une ligne à la fin d'un bloc de premier niveau (dependencies { }...),
+// créé en fin de script s'il n'existe pas.
+func appendToBlock(content, block, line string) (string, error) {
+	start := regexp.MustCompile(`(?m)^` + block + `\s*\{`).FindStringIndex(content)
+	if start == nil {
+		return strings.TrimRight(content, "\n") + "\n\n" + block + " {\n\t" + line + "\n}\n", nil
+	}
+
+	end := matchingBrace(content, start[1]-1)
+	if end < 0 {
+		return content, fmt.Errorf("bloc %s non fermé", block)
+	}
+
+	indent := "\t"
+	if m := regexp.MustCompile(`\n([ \t]+)\S`).FindStringSubmatch(content[start[1]:end]); m != nil {
+		indent = m[1]
+	}
+	lineStart := strings.LastIndex(content[:end], "\n") + 1
+	if lineStart <= start[0] {
+		// Bloc ouvert et fermé sur la même ligne (dependencies { })
+		return strings.TrimRight(content[:end], " \t") + "\n" + indent + line + "\n" + content[end:], nil
+	}
+	return content[:lineStart] + indent + line + "\n" + content[lineStart:], nil
+}
+
+// matchingBrace renvoie la position de l'accolade fermante correspondant à celle située à open.
+func matchingBrace(content string, open int) int {
+	depth := 0
+	for i := open; i < len(content); i++ {
+		switch content[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,121 @@
+package buildfile
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddGradleDependency(t *testing.T) {
+	mapstruct := Dependency{GroupID: "org.mapstruct", ArtifactID: "mapstruct", Version: "1.6.3"}
+
+	cases := []struct {
+		name          string
+		content       string
+		configuration string
+		dep           Dependency
+		kotlinDSL     bool
+		want          string
+		wantErr       error
+		invalid       bool
+	}{
+		{
+			name:          "Groovy, ajout en fin de bloc",
+			content:       "plugins {\n\tid 'java'\n}\n\ndependencies {\n\timplementation 'org.springframework.boot:spring-boot-starter-web'\n}\n",
+			configuration: "implementation",
+			dep:           mapstruct,
+			want:          "plugins {\n\tid 'java'\n}\n\ndependencies {\n\timplementation 'org.springframework.boot:spring-boot-starter-web'\n\timplementation 'org.mapstruct:mapstruct:1.6.3'\n}\n",
+		},
+		{
+			name:          "Kotlin DSL, indentation du bloc reprise",
+			content:       "dependencies {\n    implementation(\"org.springframework.boot:spring-boot-starter-web\")\n}\n",
+			configuration: "annotationProcessor",
+			dep:           mapstruct,
+			kotlinDSL:     true,
+			want:          "dependencies {\n    implementation(\"org.springframework.boot:spring-boot-starter-web\")\n    annotationProcessor(\"org.mapstruct:mapstruct:1.6.3\")\n}\n",
+		},
+		{
+			name:          "bloc imbriqué ignoré",
+			content:       "dependencies {\n    implementation(\"a:b\") {\n        exclude(group = \"c\")\n    }\n}\n",
+			configuration: "implementation",
+			dep:           Dependency{GroupID: "org.mapstruct", ArtifactID: "mapstruct"},
+			kotlinDSL:     true,
+			want:          "dependencies {\n    implementation(\"a:b\") {\n        exclude(group = \"c\")\n    }\n    implementation(\"org.mapstruct:mapstruct\")\n}\n",
+		},
+		{
+			name:          "bloc vide sur une ligne",
+			content:       "plugins {\n\tid 'java'\n}\n\ndependencies {}\n",
+			configuration: "compileOnly",
+			dep:           Dependency{GroupID: "org.projectlombok", ArtifactID: "lombok"},
+			want:          "plugins {\n\tid 'java'\n}\n\ndependencies {\n\tcompileOnly 'org.projectlombok:lombok'\n}\n",
+		},
+		{
+			name:          "bloc absent",
+			content:       "plugins {\n\tid 'java'\n}\n",
+			configuration: "implementation",
+			dep:           mapstruct,
+			want:          "plugins {\n\tid 'java'\n}\n\ndependencies {\n\timplementation 'org.mapstruct:mapstruct:1.6.3'\n}\n",
+		},
+		{
+			name:          "dépendance déjà déclarée",
+			content:       "dependencies {\n\timplementation 'org.mapstruct:mapstruct:1.5.5.Final'\n}\n",
+			configuration: "implementation",
+			dep:           mapstruct,
+			wantErr:       ErrDuplicate,
+		},
+		{
+			name:          "même dépendance dans une autre configuration",
+			content:       "dependencies {\n\timplementation 'org.mapstruct:mapstruct:1.6.3'\n}\n",
+			configuration: "annotationProcessor",
+			dep:           Dependency{GroupID: "org.mapstruct", ArtifactID: "mapstruct-processor"},
+			want:          "dependencies {\n\timplementation 'org.mapstruct:mapstruct:1.6.3'\n\tannotationProcessor 'org.mapstruct:mapstruct-processor'\n}\n",
+		},
+		{
+			name:          "bloc non fermé",
+			content:       "dependencies {\n\timplementation 'a:b'\n",
+			configuration: "implementation",
+			dep:           mapstruct,
+			invalid:       true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := AddGradleDependency(c.content, c.configuration, c.dep, c.kotlinDSL)
+			if c.invalid {
+				if err == nil {
+					t.Errorf("script accepté, erreur attendue:\n%s", got)
+				}
+				return
+			}
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) {
+					t.Errorf("erreur %v, attendu %v", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c.want {
+				t.Errorf("contenu:\n%s\nattendu:\n%s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestHasGradleDependency(t *testing.T) {
+	content := "dependencies {\n\timplementation 'org.mapstruct:mapstruct:1.6.3'\n\tcompileOnly(\"org.projectlombok:lombok\")\n}\n"
+	cases := []struct {
+		groupID, artifactID string
+		want                bool
+	}{
+		{"org.mapstruct", "mapstruct", true},
+		{"org.projectlombok", "lombok", true},
+		{"org.mapstruct", "mapstruct-processor", false},
+		{"org.springframework.boot", "spring-boot-starter-web", false},
+	}
+	for _, c := range cases {
+		if got := HasGradleDependency(content, c.groupID, c.artifactID); got != c.want {
+			t.Errorf("HasGradleDependency(%s:%s) = %v, attendu %v", c.groupID, c.artifactID, got, c.want)
+		}
+	}
+}
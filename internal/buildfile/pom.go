@@ -0,0 +1,397 @@
+// Package buildfile manipule les fichiers de build Maven sans casser leur mise en forme.
+package buildfile
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrDuplicate est renvoyée lorsqu'une dépendance est déjà déclarée.
+var ErrDuplicate = errors.New("dépendance déjà présente")
+
+// Dependency représente une dépendance Maven.
+type Dependency struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+	Scope      string
+	Optional   bool
+}
+
+// Coordinates renvoie la forme groupId:artifactId de la dépendance.
+func (d Dependency) Coordinates() string {
+	return d.GroupID + ":" + d.ArtifactID
+}
+
+// span localise un élément XML dans le contenu brut du fichier.
+type span struct {
+	Start      int // début de la balise ouvrante
+	InnerStart int // juste après la balise ouvrante
+	InnerEnd   int // début de la balise fermante
+	End        int // juste après la balise fermante
+	// SelfClosing indique un élément vide écrit <nom/>: Start..End couvre alors la seule balise
+	SelfClosing bool
+}
+
+// locate renvoie la position de tous les éléments correspondant au chemin donné
+// (par exemple "project", "dependencies").
+func locate(content string, path ...string) ([]span, error) {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = false
+
+	var stack []string
+	var opened []span
+	var spans []span
+
+	for {
+		offset := int(decoder.InputOffset())
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("pom.xml invalide: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			end := int(decoder.InputOffset())
+			opened = append(opened, span{Start: offset, InnerStart: end, SelfClosing: strings.HasSuffix(content[offset:end], "/>")})
+		case xml.EndElement:
+			current := opened[len(opened)-1]
+			current.InnerEnd = offset
+			current.End = int(decoder.InputOffset())
+			if matchPath(stack, path) {
+				spans = append(spans, current)
+			}
+			stack = stack[:len(stack)-1]
+			opened = opened[:len(opened)-1]
+		}
+	}
+	return spans, nil
+}
+
+func matchPath(stack, path []string) bool {
+	if len(stack) != len(path) {
+		return false
+	}
+	for i := range stack {
+		if stack[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// HasDependency indique si la dépendance est déclarée dans <project><dependencies>.
+func HasDependency(content, groupID, artifactID string) bool {
+	deps, err := Dependencies(content)
+	if err != nil {
+		return false
+	}
+	for _, d := range deps {
+		if d.GroupID == groupID && d.ArtifactID == artifactID {
+			return true
+		}
+	}
+	return false
+}
+
+// Dependencies renvoie les dépendances déclarées dans <project><dependencies>.
+func Dependencies(content string) ([]Dependency, error) {
+	var project struct {
+		Dependencies []struct {
+			GroupID    string `xml:"groupId"`
+			ArtifactID string `xml:"artifactId"`
+			Version    string `xml:"version"`
+			Scope      string `xml:"scope"`
+			Optional   bool   `xml:"optional"`
+		} `xml:"dependencies>dependency"`
+	}
+	if err := xml.Unmarshal([]byte(content), &project); err != nil {
+		return nil, fmt.Errorf("pom.xml invalide: %w", err)
+	}
+
+	deps := make([]Dependency, 0, len(project.Dependencies))
+	for _, d := range project.Dependencies {
+		deps = append(deps, Dependency{
+			GroupID:    strings.TrimSpace(d.GroupID),
+			ArtifactID: strings.TrimSpace(d.ArtifactID),
+			Version:    strings.TrimSpace(d.Version),
+			Scope:      strings.TrimSpace(d.Scope),
+			Optional:   d.Optional,
+		})
+	}
+	return deps, nil
+}
+
+// AddDependency ajoute la dépendance à la fin de <project><dependencies>.
+// Une erreur est renvoyée si elle est déjà présente.
+func AddDependency(content string, dep Dependency) (string, error) {
+	if HasDependency(content, dep.GroupID, dep.ArtifactID) {
+		return content, fmt.Errorf("%s: %w", dep.Coordinates(), ErrDuplicate)
+	}
+	return appendChild(content, []string{"project", "dependencies"}, dependencyXML(dep))
+}
+
+func dependencyXML(dep Dependency) []string {
+	lines := []string{
+		"<dependency>",
+		"\t<groupId>" + dep.GroupID + "</groupId>",
+		"\t<artifactId>" + dep.ArtifactID + "</artifactId>",
+	}
+	if dep.Version != "" {
+		lines = append(lines, "\t<version>"+dep.Version+"</version>")
+	}
+	if dep.Scope != "" && dep.Scope != "compile" {
+		lines = append(lines, "\t<scope>"+dep.Scope+"</scope>")
+	}
+	if dep.Optional {
+		lines = append(lines, "\t<optional>true</optional>")
+	}
+	return append(lines, "</dependency>")
+}
+
+// appendChild insère les lignes données (indentées avec des tabulations) à la fin
+// de l'élément désigné par path. Les éléments parents manquants sont créés.
+func appendChild(content string, path []string, lines []string) (string, error) {
+	spans, err := locate(content, path...)
+	if err != nil {
+		return content, err
+	}
+	if len(spans) > 0 {
+		s := spans[0]
+		if s.SelfClosing {
+			content, s = expand(content, s, path[len(path)-1])
+		}
+		return insertBefore(content, s.InnerEnd, len(path), lines), nil
+	}
+	if len(path) == 1 {
+		return content, fmt.Errorf("élément <%s> introuvable", path[0])
+	}
+
+	// Création de l'élément parent manquant
+	name := path[len(path)-1]
+	wrapped := []string{"<" + name + ">"}
+	for _, l := range lines {
+		wrapped = append(wrapped, "\t"+l)
+	}
+	wrapped = append(wrapped, "</"+name+">")
+	return appendChild(content, path[:len(path)-1], wrapped)
+}
+
+// expand réécrit un élément vide <nom/> en <nom></nom> pour pouvoir y insérer du contenu,
+// et renvoie sa nouvelle position.
+func expand(content string, s span, name string) (string, span) {
+	open := strings.TrimRight(strings.TrimSuffix(content[s.Start:s.End], "/>"), " \t\r\n") + ">"
+	closing := "</" + name + ">"
+	inner := s.Start + len(open)
+	expanded := span{Start: s.Start, InnerStart: inner, InnerEnd: inner, End: inner + len(closing)}
+	return content[:s.Start] + open + closing + content[s.End:], expanded
+}
+
+// insertBefore insère les lignes juste avant la balise fermante située à offset,
+// en reprenant le style d'indentation du fichier.
+func insertBefore(content string, offset, depth int, lines []string) string {
+	unit := indentUnit(content)
+	lineStart := strings.LastIndex(content[:offset], "\n") + 1
+	closingPrefix := content[lineStart:offset]
+
+	var b strings.Builder
+	if strings.TrimSpace(closingPrefix) != "" {
+		// La balise fermante n'est pas seule sur sa ligne: elle est replacée au niveau de
+		// la ligne, ou de la profondeur de l'élément si la ligne n'est pas indentée
+		indent := closingPrefix[:len(closingPrefix)-len(strings.TrimLeft(closingPrefix, " \t"))]
+		if indent == "" {
+			indent = strings.Repeat(unit, depth-1)
+		}
+		b.WriteString(content[:offset])
+		b.WriteString("\n")
+		writeLines(&b, lines, indent+unit, unit)
+		b.WriteString(indent)
+		b.WriteString(content[offset:])
+		return b.String()
+	}
+
+	b.WriteString(content[:lineStart])
+	writeLines(&b, lines, closingPrefix+unit, unit)
+	b.WriteString(content[lineStart:])
+	return b.String()
+}
+
+func writeLines(b *strings.Builder, lines []string, indent, unit string) {
+	for _, l := range lines {
+		tabs := len(l) - len(strings.TrimLeft(l, "\t"))
+		b.WriteString(indent + strings.Repeat(unit, tabs) + strings.TrimLeft(l, "\t") + "\n")
+	}
+}
+
+// indentUnit devine l'unité d'indentation utilisée dans le fichier: le premier écart
+// d'indentation entre une balise et la balise suivante, plus indentée.
+func indentUnit(content string) string {
+	previous, first := "", true
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if !strings.HasPrefix(trimmed, "<") {
+			continue
+		}
+		indent := line[:len(line)-len(trimmed)]
+		if !first && len(indent) > len(previous) && strings.HasPrefix(indent, previous) {
+			return indent[len(previous):]
+		}
+		previous, first = indent, false
+	}
+	return "    "
+}
+
+// SetProperty définit (ou remplace) une propriété dans <project><properties>.
+func SetProperty(content, name, value string) (string, error) {
+	spans, err := locate(content, "project", "properties", name)
+	if err != nil {
+		return content, err
+	}
+	if len(spans) > 0 {
+		s := spans[0]
+		if s.SelfClosing {
+			content, s = expand(content, s, name)
+		}
+		return content[:s.InnerStart] + value + content[s.InnerEnd:], nil
+	}
+	return appendChild(content, []string{"project", "properties"}, []string{"<" + name + ">" + value + "</" + name + ">"})
+}
+
+// Property renvoie la valeur d'une propriété de <project><properties>.
+func Property(content, name string) (string, bool) {
+	spans, err := locate(content, "project", "properties", name)
+	if err != nil || len(spans) == 0 {
+		return "", false
+	}
+	return strings.TrimSpace(content[spans[0].InnerStart:spans[0].InnerEnd]), true
+}
+
+// AddAnnotationProcessor déclare un processeur d'annotations dans la configuration
+// du maven-compiler-plugin, en créant le plugin si nécessaire.
+func AddAnnotationProcessor(content string, dep Dependency) (string, error) {
+	plugin, ok, err := findPlugin(content, "maven-compiler-plugin")
+	if err != nil {
+		return content, err
+	}
+
+	path := []string{
+		"<path>",
+		"\t<groupId>" + dep.GroupID + "</groupId>",
+		"\t<artifactId>" + dep.ArtifactID + "</artifactId>",
+	}
+	if dep.Version != "" {
+		path = append(path, "\t<version>"+dep.Version+"</version>")
+	}
+	path = append(path, "</path>")
+
+	if !ok {
+		lines := []string{
+			"<plugin>",
+			"\t<groupId>org.apache.maven.plugins</groupId>",
+			"\t<artifactId>maven-compiler-plugin</artifactId>",
+			"\t<configuration>",
+			"\t\t<annotationProcessorPaths>",
+		}
+		for _, l := range path {
+			lines = append(lines, "\t\t\t"+l)
+		}
+		lines = append(lines, "\t\t</annotationProcessorPaths>", "\t</configuration>", "</plugin>")
+		return appendChild(content, []string{"project", "build", "plugins"}, lines)
+	}
+
+	// Le plugin est modifié à part, avec l'indentation de sa ligne pour que celle du
+	// fichier soit reconnue
+	start := plugin.Start
+	if lineStart := strings.LastIndex(content[:start], "\n") + 1; strings.TrimSpace(content[lineStart:start]) == "" {
+		start = lineStart
+	}
+	pluginContent := content[start:plugin.End]
+	if strings.Contains(pluginContent, "<artifactId>"+dep.ArtifactID+"</artifactId>") {
+		return content, nil
+	}
+	updated, err := appendChild(pluginContent, []string{"plugin", "configuration", "annotationProcessorPaths"}, path)
+	if err != nil {
+		return content, err
+	}
+	return content[:start] + updated + content[plugin.End:], nil
+}
+
+// findPlugin localise un plugin de <project><build><plugins> par son artifactId.
+func findPlugin(content, artifactID string) (span, bool, error) {
+	spans, err := locate(content, "project", "build", "plugins", "plugin")
+	if err != nil {
+		return span{}, false, err
+	}
+	for _, s := range spans {
+		if strings.Contains(content[s.Start:s.End], "<artifactId>"+artifactID+"</artifactId>") {
+			return s, true, nil
+		}
+	}
+	return span{}, false, nil
+}
+
+// AddManagedImport importe un BOM dans <project><dependencyManagement><dependencies>. Le
+// fichier est renvoyé inchangé si le BOM est déjà importé.
+func AddManagedImport(content string, bom Dependency) (string, error) {
+	spans, err := locate(content, "project", "dependencyManagement", "dependencies", "dependency")
+	if err != nil {
+		return content, err
+	}
+	for _, s := range spans {
+		managed := content[s.Start:s.End]
+		if strings.Contains(managed, "<groupId>"+bom.GroupID+"</groupId>") &&
+			strings.Contains(managed, "<artifactId>"+bom.ArtifactID+"</artifactId>") {
+			return content, nil
+		}
+	}
+	lines := []string{
+		"<dependency>",
+		"\t<groupId>" + bom.GroupID + "</groupId>",
+		"\t<artifactId>" + bom.ArtifactID + "</artifactId>",
+		"\t<version>" + bom.Version + "</version>",
+		"\t<type>pom</type>",
+		"\t<scope>import</scope>",
+		"</dependency>",
+	}
+	return appendChild(content, []string{"project", "dependencyManagement", "dependencies"}, lines)
+}
+
+// Repository représente un dépôt Maven supplémentaire.
+type Repository struct {
+	ID        string
+	Name      string
+	URL       string
+	Snapshots bool
+}
+
+// AddRepository déclare un dépôt dans <project><repositories>. Le fichier est renvoyé
+// inchangé si un dépôt de même identifiant ou de même URL est déjà déclaré.
+func AddRepository(content string, repo Repository) (string, error) {
+	spans, err := locate(content, "project", "repositories", "repository")
+	if err != nil {
+		return content, err
+	}
+	for _, s := range spans {
+		declared := content[s.Start:s.End]
+		if strings.Contains(declared, "<id>"+repo.ID+"</id>") || strings.Contains(declared, "<url>"+repo.URL+"</url>") {
+			return content, nil
+		}
+	}
+	lines := []string{"<repository>", "\t<id>" + repo.ID + "</id>"}
+	if repo.Name != "" {
+		lines = append(lines, "\t<name>"+repo.Name+"</name>")
+	}
+	lines = append(lines, "\t<url>"+repo.URL+"</url>")
+	if !repo.Snapshots {
+		lines = append(lines, "\t<snapshots>", "\t\t<enabled>false</enabled>", "\t</snapshots>")
+	}
+	lines = append(lines, "</repository>")
+	return appendChild(content, []string{"project", "repositories"}, lines)
+}
@@ -0,0 +1,244 @@
+package buildfile
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var lombok = Dependency{GroupID: "org.projectlombok", ArtifactID: "lombok", Version: "1.18.36", Optional: true}
+
+func TestAddDependency(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		dep     Dependency
+		want    string
+		wantErr error
+	}{
+		{
+			name: "ajout en fin de liste",
+			content: "<project>\n" +
+				"    <dependencies>\n" +
+				"        <dependency>\n" +
+				"            <groupId>org.springframework.boot</groupId>\n" +
+				"            <artifactId>spring-boot-starter-web</artifactId>\n" +
+				"        </dependency>\n" +
+				"    </dependencies>\n" +
+				"</project>\n",
+			dep: Dependency{GroupID: "org.mapstruct", ArtifactID: "mapstruct", Version: "1.6.3"},
+			want: "<project>\n" +
+				"    <dependencies>\n" +
+				"        <dependency>\n" +
+				"            <groupId>org.springframework.boot</groupId>\n" +
+				"            <artifactId>spring-boot-starter-web</artifactId>\n" +
+				"        </dependency>\n" +
+				"        <dependency>\n" +
+				"            <groupId>org.mapstruct</groupId>\n" +
+				"            <artifactId>mapstruct</artifactId>\n" +
+				"            <version>1.6.3</version>\n" +
+				"        </dependency>\n" +
+				"    </dependencies>\n" +
+				"</project>\n",
+		},
+		{
+			name:    "indentation par tabulations, scope et optional",
+			content: "<project>\n\t<dependencies>\n\t</dependencies>\n</project>\n",
+			dep:     Dependency{GroupID: "org.projectlombok", ArtifactID: "lombok", Scope: "provided", Optional: true},
+			want: "<project>\n\t<dependencies>\n" +
+				"\t\t<dependency>\n" +
+				"\t\t\t<groupId>org.projectlombok</groupId>\n" +
+				"\t\t\t<artifactId>lombok</artifactId>\n" +
+				"\t\t\t<scope>provided</scope>\n" +
+				"\t\t\t<optional>true</optional>\n" +
+				"\t\t</dependency>\n" +
+				"\t</dependencies>\n</project>\n",
+		},
+		{
+			name:    "élément <dependencies/> vide",
+			content: "<project>\n  <modelVersion>4.0.0</modelVersion>\n  <dependencies/>\n</project>\n",
+			dep:     Dependency{GroupID: "org.mapstruct", ArtifactID: "mapstruct"},
+			want: "<project>\n  <modelVersion>4.0.0</modelVersion>\n  <dependencies>\n" +
+				"    <dependency>\n" +
+				"      <groupId>org.mapstruct</groupId>\n" +
+				"      <artifactId>mapstruct</artifactId>\n" +
+				"    </dependency>\n" +
+				"  </dependencies>\n</project>\n",
+		},
+		{
+			name:    "élément <dependencies /> vide avec espace",
+			content: "<project>\n  <modelVersion>4.0.0</modelVersion>\n  <dependencies />\n</project>\n",
+			dep:     Dependency{GroupID: "org.mapstruct", ArtifactID: "mapstruct"},
+			want: "<project>\n  <modelVersion>4.0.0</modelVersion>\n  <dependencies>\n" +
+				"    <dependency>\n" +
+				"      <groupId>org.mapstruct</groupId>\n" +
+				"      <artifactId>mapstruct</artifactId>\n" +
+				"    </dependency>\n" +
+				"  </dependencies>\n</project>\n",
+		},
+		{
+			name:    "élément <dependencies> absent",
+			content: "<project>\n  <modelVersion>4.0.0</modelVersion>\n</project>\n",
+			dep:     Dependency{GroupID: "org.mapstruct", ArtifactID: "mapstruct"},
+			want: "<project>\n  <modelVersion>4.0.0</modelVersion>\n  <dependencies>\n" +
+				"    <dependency>\n" +
+				"      <groupId>org.mapstruct</groupId>\n" +
+				"      <artifactId>mapstruct</artifactId>\n" +
+				"    </dependency>\n" +
+				"  </dependencies>\n</project>\n",
+		},
+		{
+			name: "dépendance déjà présente",
+			content: "<project>\n  <dependencies>\n    <dependency>\n" +
+				"      <groupId>org.mapstruct</groupId>\n      <artifactId>mapstruct</artifactId>\n" +
+				"    </dependency>\n  </dependencies>\n</project>\n",
+			dep:     Dependency{GroupID: "org.mapstruct", ArtifactID: "mapstruct"},
+			wantErr: ErrDuplicate,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := AddDependency(c.content, c.dep)
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) {
+					t.Errorf("erreur %v, attendu %v", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c.want {
+				t.Errorf("contenu:\n%s\nattendu:\n%s", got, c.want)
+			}
+			assertWellFormed(t, got)
+		})
+	}
+}
+
+func TestSetProperty(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "propriété remplacée",
+			content: "<project>\n  <properties>\n    <lombok.version>1.18.30</lombok.version>\n  </properties>\n</project>\n",
+			want:    "<project>\n  <properties>\n    <lombok.version>1.18.36</lombok.version>\n  </properties>\n</project>\n",
+		},
+		{
+			name:    "propriété ajoutée",
+			content: "<project>\n  <properties>\n    <java.version>21</java.version>\n  </properties>\n</project>\n",
+			want:    "<project>\n  <properties>\n    <java.version>21</java.version>\n    <lombok.version>1.18.36</lombok.version>\n  </properties>\n</project>\n",
+		},
+		{
+			name:    "propriété vide <lombok.version/>",
+			content: "<project>\n  <properties>\n    <lombok.version/>\n  </properties>\n</project>\n",
+			want:    "<project>\n  <properties>\n    <lombok.version>1.18.36</lombok.version>\n  </properties>\n</project>\n",
+		},
+		{
+			name:    "élément <properties/> vide",
+			content: "<project>\n  <properties/>\n</project>\n",
+			want:    "<project>\n  <properties>\n    <lombok.version>1.18.36</lombok.version>\n  </properties>\n</project>\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := SetProperty(c.content, "lombok.version", "1.18.36")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c.want {
+				t.Errorf("contenu:\n%s\nattendu:\n%s", got, c.want)
+			}
+			if value, ok := Property(got, "lombok.version"); !ok || value != "1.18.36" {
+				t.Errorf("Property = %q, %v", value, ok)
+			}
+			assertWellFormed(t, got)
+		})
+	}
+}
+
+func TestAddAnnotationProcessor(t *testing.T) {
+	const compilerPlugin = "<project>\n" +
+		"  <build>\n" +
+		"    <plugins>\n" +
+		"      <plugin>\n" +
+		"        <groupId>org.apache.maven.plugins</groupId>\n" +
+		"        <artifactId>maven-compiler-plugin</artifactId>\n" +
+		"%s" +
+		"      </plugin>\n" +
+		"    </plugins>\n" +
+		"  </build>\n" +
+		"</project>\n"
+	const lombokPath = "            <path>\n" +
+		"              <groupId>org.projectlombok</groupId>\n" +
+		"              <artifactId>lombok</artifactId>\n" +
+		"              <version>1.18.36</version>\n" +
+		"            </path>\n"
+
+	cases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "processeur ajouté à la liste existante",
+			content: fmt.Sprintf(compilerPlugin, "        <configuration>\n          <annotationProcessorPaths>\n          </annotationProcessorPaths>\n        </configuration>\n"),
+			want:    fmt.Sprintf(compilerPlugin, "        <configuration>\n          <annotationProcessorPaths>\n"+lombokPath+"          </annotationProcessorPaths>\n        </configuration>\n"),
+		},
+		{
+			name:    "élément <annotationProcessorPaths/> vide",
+			content: fmt.Sprintf(compilerPlugin, "        <configuration>\n          <annotationProcessorPaths/>\n        </configuration>\n"),
+			want:    fmt.Sprintf(compilerPlugin, "        <configuration>\n          <annotationProcessorPaths>\n"+lombokPath+"          </annotationProcessorPaths>\n        </configuration>\n"),
+		},
+		{
+			name:    "élément <configuration/> vide",
+			content: fmt.Sprintf(compilerPlugin, "        <configuration/>\n"),
+			want:    fmt.Sprintf(compilerPlugin, "        <configuration>\n          <annotationProcessorPaths>\n"+lombokPath+"          </annotationProcessorPaths>\n        </configuration>\n"),
+		},
+		{
+			name:    "processeur déjà déclaré",
+			content: fmt.Sprintf(compilerPlugin, "        <configuration>\n          <annotationProcessorPaths>\n"+lombokPath+"          </annotationProcessorPaths>\n        </configuration>\n"),
+			want:    fmt.Sprintf(compilerPlugin, "        <configuration>\n          <annotationProcessorPaths>\n"+lombokPath+"          </annotationProcessorPaths>\n        </configuration>\n"),
+		},
+		{
+			name:    "plugin créé",
+			content: "<project>\n  <build>\n    <plugins/>\n  </build>\n</project>\n",
+			want: "<project>\n  <build>\n    <plugins>\n" +
+				"      <plugin>\n" +
+				"        <groupId>org.apache.maven.plugins</groupId>\n" +
+				"        <artifactId>maven-compiler-plugin</artifactId>\n" +
+				"        <configuration>\n" +
+				"          <annotationProcessorPaths>\n" +
+				lombokPath +
+				"          </annotationProcessorPaths>\n" +
+				"        </configuration>\n" +
+				"      </plugin>\n" +
+				"    </plugins>\n  </build>\n</project>\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := AddAnnotationProcessor(c.content, lombok)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c.want {
+				t.Errorf("contenu:\n%s\nattendu:\n%s", got, c.want)
+			}
+			assertWellFormed(t, got)
+		})
+	}
+}
+
+// assertWellFormed vérifie que le pom modifié reste un XML bien formé.
+func assertWellFormed(t *testing.T, content string) {
+	t.Helper()
+	var v struct{}
+	if err := xml.Unmarshal([]byte(content), &v); err != nil {
+		t.Errorf("XML mal formé: %v\n%s", err, content)
+	}
+}
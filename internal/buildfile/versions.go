@@ -0,0 +1,158 @@
+package buildfile
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Types d'éléments versionnés du fichier de build.
+const (
+	KindParent     = "parent"
+	KindDependency = "dépendance"
+	KindBOM        = "bom"
+	KindPlugin     = "plugin"
+)
+
+// VersionedArtifact est un artefact dont la version est écrite dans le fichier de build.
+// Start et End délimitent la valeur de la version dans le contenu: celle de <version>, ou
+// celle de la propriété qui la porte (<mapstruct.version>).
+type VersionedArtifact struct {
+	Kind       string
+	GroupID    string
+	ArtifactID string
+	Version    string
+	Property   string
+	Start      int
+	End        int
+}
+
+// Coordinates renvoie la forme groupId:artifactId de l'artefact.
+func (a VersionedArtifact) Coordinates() string {
+	return a.GroupID + ":" + a.ArtifactID
+}
+
+var propertyReferenceRegexp = regexp.MustCompile(`^\$\{([^}]+)\}$`)
+
+// VersionedArtifacts renvoie le parent, les dépendances, BOMs importés et plugins du pom.xml
+// dont la version est fixée dans le fichier. Les dépendances sans version (gérées par un
+// BOM) et les versions portées par une propriété absente de <properties> sont ignorées.
+func VersionedArtifacts(content string) ([]VersionedArtifact, error) {
+	var artifacts []VersionedArtifact
+	sections := []struct {
+		kind string
+		path []string
+	}{
+		{KindParent, []string{"project", "parent"}},
+		{KindDependency, []string{"project", "dependencies", "dependency"}},
+		{KindBOM, []string{"project", "dependencyManagement", "dependencies", "dependency"}},
+		{KindPlugin, []string{"project", "build", "plugins", "plugin"}},
+		{KindPlugin, []string{"project", "build", "pluginManagement", "plugins", "plugin"}},
+	}
+	for _, section := range sections {
+		spans, err := locate(content, section.path...)
+		if err != nil {
+			return nil, err
+		}
+		root := section.path[len(section.path)-1]
+		for _, s := range spans {
+			element := content[s.Start:s.End]
+			groupID, _, _ := childText(element, root, "groupId")
+			artifactID, _, _ := childText(element, root, "artifactId")
+			version, at, ok := childText(element, root, "version")
+			if !ok || artifactID == "" {
+				continue
+			}
+			if groupID == "" && section.kind == KindPlugin {
+				groupID = "org.apache.maven.plugins"
+			}
+			a := VersionedArtifact{
+				Kind:       section.kind,
+				GroupID:    groupID,
+				ArtifactID: artifactID,
+				Version:    version,
+				Start:      s.Start + at.InnerStart,
+				End:        s.Start + at.InnerEnd,
+			}
+			if m := propertyReferenceRegexp.FindStringSubmatch(version); m != nil {
+				properties, err := locate(content, "project", "properties", m[1])
+				if err != nil || len(properties) == 0 {
+					continue
+				}
+				p := properties[0]
+				a.Property = m[1]
+				a.Version = strings.TrimSpace(content[p.InnerStart:p.InnerEnd])
+				a.Start, a.End = p.InnerStart, p.InnerEnd
+			}
+			// Seules les entrées importées de dependencyManagement sont des BOMs
+			if scope, _, _ := childText(element, root, "scope"); section.kind == KindBOM && scope != "import" {
+				a.Kind = KindDependency
+			}
+			artifacts = append(artifacts, a)
+		}
+	}
+	return artifacts, nil
+}
+
+// childText renvoie le texte d'un élément enfant direct de root et sa position dans element.
+func childText(element, root, name string) (string, span, bool) {
+	spans, err := locate(element, root, name)
+	if err != nil || len(spans) == 0 {
+		return "", span{}, false
+	}
+	s := spans[0]
+	return strings.TrimSpace(element[s.InnerStart:s.InnerEnd]), s, true
+}
+
+var (
+	gradleCoordinatesRegexp = regexp.MustCompile(`["']([\w.-]+):([\w.-]+):([\w.+-]+)["']`)
+	gradleBootPluginRegexp  = regexp.MustCompile(`id\s*\(?\s*["']org\.springframework\.boot["']\s*\)?\s*version\s*["']([^"']+)["']`)
+)
+
+// GradleVersionedArtifacts renvoie le plugin Spring Boot (sous les coordonnées de
+// spring-boot-gradle-plugin) et les dépendances du script Gradle écrites avec leur version
+// ("groupId:artifactId:version").
+func GradleVersionedArtifacts(content string) []VersionedArtifact {
+	var artifacts []VersionedArtifact
+	if m := gradleBootPluginRegexp.FindStringSubmatchIndex(content); m != nil {
+		artifacts = append(artifacts, VersionedArtifact{
+			Kind:       KindPlugin,
+			GroupID:    "org.springframework.boot",
+			ArtifactID: "spring-boot-gradle-plugin",
+			Version:    content[m[2]:m[3]],
+			Start:      m[2],
+			End:        m[3],
+		})
+	}
+	for _, m := range gradleCoordinatesRegexp.FindAllStringSubmatchIndex(content, -1) {
+		artifacts = append(artifacts, VersionedArtifact{
+			Kind:       KindDependency,
+			GroupID:    content[m[2]:m[3]],
+			ArtifactID: content[m[4]:m[5]],
+			Version:    content[m[6]:m[7]],
+			Start:      m[6],
+			End:        m[7],
+		})
+	}
+	return artifacts
+}
+
+// ReplaceVersions remplace les versions des artefacts par celles données (indexées par
+// position de début), sans toucher au reste du contenu. Une propriété partagée par plusieurs
+// artefacts n'est remplacée qu'une fois.
+func ReplaceVersions(content string, artifacts []VersionedArtifact, versions map[int]string) string {
+	var targets []VersionedArtifact
+	seen := map[int]bool{}
+	for _, a := range artifacts {
+		if _, ok := versions[a.Start]; ok && !seen[a.Start] {
+			seen[a.Start] = true
+			targets = append(targets, a)
+		}
+	}
+	// Remplacement depuis la fin, pour que les positions restantes restent valides
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Start > targets[j].Start })
+	for _, a := range targets {
+		content = content[:a.Start] + versions[a.Start] + content[a.End:]
+	}
+	return content
+}
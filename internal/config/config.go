@@ -0,0 +1,94 @@
+// Package config lit la configuration du projet (.springcli.yaml), qui fixe les valeurs
+// par défaut des commandes de springcli.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName est le fichier de configuration, à la racine du projet.
+const FileName = ".springcli.yaml"
+
+// Config est le contenu du fichier de configuration.
+//
+//	generate:
+//	  with-tests: true
+//	database:
+//	  url: jdbc:postgresql://localhost:5432/shop
+//	  username: shop
+//	  password: secret
+//	maven:
+//	  repository: https://repo.maven.apache.org/maven2
+//	  search: https://search.maven.org/solrsearch/select
+//	initializr:
+//	  url: https://start.spring.io
+type Config struct {
+	Generate   Generate   `yaml:"generate"`
+	Database   Database   `yaml:"database"`
+	Maven      Maven      `yaml:"maven"`
+	Initializr Initializr `yaml:"initializr"`
+}
+
+// Generate regroupe les valeurs par défaut des commandes generate.
+type Generate struct {
+	// WithTests génère les tests de chaque couche avec son code
+	WithTests bool `yaml:"with-tests"`
+}
+
+// Database est la base de développement sur laquelle springcli exécute ses scripts.
+type Database struct {
+	// URL est l'URL JDBC de la base (jdbc:postgresql://, jdbc:mysql://, jdbc:mariadb://)
+	URL      string `yaml:"url"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// Maven décrit le dépôt interrogé pour résoudre les versions des dépendances et l'API de
+// recherche d'artefacts.
+type Maven struct {
+	// Repository est l'URL du dépôt (Maven Central par défaut), un miroir d'entreprise par exemple
+	Repository string `yaml:"repository"`
+	// Search est l'API de recherche d'artefacts: Maven Central (Solr) par défaut, ou l'API
+	// de recherche d'un Nexus 3 (https://nexus.example.com/service/rest/v1/search) ou
+	// d'Artifactory (https://artifactory.example.com/artifactory/api/search/artifact)
+	Search string `yaml:"search"`
+}
+
+// Initializr désigne l'instance de Spring Initializr qui décrit les starters.
+type Initializr struct {
+	// URL est l'adresse de l'instance (start.spring.io par défaut)
+	URL string `yaml:"url"`
+}
+
+// Load lit le fichier de configuration. Un fichier absent donne la configuration par défaut.
+func Load(path string) (*Config, error) {
+	c := &Config{}
+	if err := decode(path, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// decode lit le fichier YAML dans v. Un fichier absent laisse v inchangé.
+func decode(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Une clé inconnue est le plus souvent une faute de frappe: elle est signalée
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// UserConfig est la configuration de l'utilisateur (~/.config/springcli/config.yaml sous
+// Linux). Elle porte les réglages qui ne doivent pas dépendre du projet courant: le
+// .springcli.yaml d'un dépôt cloné ne peut pas choisir d'où vient le binaire de springcli.
+//
+//	release:
+//	  api: https://api.github.com
+//	  repository: AnaelTech/springcli
+type UserConfig struct {
+	Release Release `yaml:"release"`
+}
+
+// Release désigne les releases de springcli consultées par version --check et self-update.
+// Les variables SPRINGCLI_RELEASE_API et SPRINGCLI_RELEASE_REPOSITORY l'emportent sur le fichier.
+type Release struct {
+	// API est l'adresse de l'API GitHub (api.github.com par défaut), celle d'un GitHub
+	// Enterprise ou d'un serveur local qui la simule
+	API string `yaml:"api"`
+	// Repository est le dépôt qui publie les releases, sous la forme propriétaire/nom
+	Repository string `yaml:"repository"`
+}
+
+// UserPath renvoie le chemin du fichier de configuration de l'utilisateur.
+func UserPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "springcli", "config.yaml"), nil
+}
+
+// LoadUser lit la configuration de l'utilisateur, puis applique les variables
+// d'environnement. Un fichier absent donne la configuration par défaut.
+func LoadUser() (*UserConfig, error) {
+	c := &UserConfig{}
+	if path, err := UserPath(); err == nil {
+		if err := decode(path, c); err != nil {
+			return nil, err
+		}
+	}
+	if api := os.Getenv("SPRINGCLI_RELEASE_API"); api != "" {
+		c.Release.API = api
+	}
+	if repository := os.Getenv("SPRINGCLI_RELEASE_REPOSITORY"); repository != "" {
+		c.Release.Repository = repository
+	}
+	return c, nil
+}
@@ -0,0 +1,282 @@
+// Package diagram produit le diagramme entité-relation des entités JPA d'un projet aux
+// formats Mermaid, PlantUML, Graphviz (dot) et SVG.
+package diagram
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+)
+
+// Diagram regroupe les entités et les relations à représenter.
+type Diagram struct {
+	Entities  []Entity
+	Relations []Relation
+}
+
+type Entity struct {
+	Name       string
+	Attributes []Attribute
+}
+
+// Attribute est une colonne de l'entité: identifiant, champ simple ou clé étrangère.
+type Attribute struct {
+	Name     string
+	Type     string
+	Key      string // PK, FK, UK ou vide
+	Required bool
+}
+
+// Relation relie deux entités. From porte la relation (côté propriétaire), To en est la cible.
+type Relation struct {
+	From     string
+	To       string
+	Label    string
+	FromMany bool
+	ToMany   bool
+}
+
+// Mermaid renvoie le diagramme au format erDiagram de Mermaid.
+func Mermaid(d Diagram) string {
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+	for _, e := range d.Entities {
+		fmt.Fprintf(&b, "    %s {\n", e.Name)
+		for _, a := range e.Attributes {
+			line := fmt.Sprintf("        %s %s", mermaidType(a.Type), a.Name)
+			if a.Key != "" {
+				line += " " + a.Key
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("    }\n")
+	}
+	for _, r := range d.Relations {
+		fmt.Fprintf(&b, "    %s %s--%s %s : %q\n", r.From, crowFoot(r.FromMany, true), crowFoot(r.ToMany, false), r.To, r.Label)
+	}
+	return b.String()
+}
+
+// mermaidType remplace les caractères refusés par Mermaid dans un type (List<Tag> -> List~Tag~).
+func mermaidType(t string) string {
+	return strings.NewReplacer("<", "~", ">", "~", " ", "", ",", "-").Replace(t)
+}
+
+// crowFoot renvoie la cardinalité d'une extrémité en notation « pied de corbeau »
+// (zéro ou plusieurs, zéro ou un), orientée selon le côté de la relation.
+func crowFoot(many, left bool) string {
+	switch {
+	case many && left:
+		return "}o"
+	case many:
+		return "o{"
+	case left:
+		return "|o"
+	default:
+		return "o|"
+	}
+}
+
+// PlantUML renvoie le diagramme au format PlantUML (entités en notation IE).
+func PlantUML(d Diagram) string {
+	var b strings.Builder
+	b.WriteString("@startuml\nhide circle\nskinparam linetype ortho\n\n")
+	for _, e := range d.Entities {
+		fmt.Fprintf(&b, "entity %s {\n", e.Name)
+		for i, a := range e.Attributes {
+			line := "  "
+			if a.Required || a.Key == "PK" {
+				line += "* "
+			}
+			line += a.Name + " : " + a.Type
+			if a.Key != "" {
+				line += " <<" + a.Key + ">>"
+			}
+			b.WriteString(line + "\n")
+			// Séparateur entre la clé primaire et les autres colonnes
+			if a.Key == "PK" && (i+1 == len(e.Attributes) || e.Attributes[i+1].Key != "PK") {
+				b.WriteString("  --\n")
+			}
+		}
+		b.WriteString("}\n\n")
+	}
+	for _, r := range d.Relations {
+		fmt.Fprintf(&b, "%s %s--%s %s : %s\n", r.From, crowFoot(r.FromMany, true), crowFoot(r.ToMany, false), r.To, r.Label)
+	}
+	b.WriteString("@enduml\n")
+	return b.String()
+}
+
+// Dot renvoie le diagramme au format Graphviz, chaque entité étant une table HTML.
+func Dot(d Diagram) string {
+	var b strings.Builder
+	b.WriteString("digraph entities {\n")
+	b.WriteString("    rankdir=LR;\n")
+	b.WriteString("    node [shape=plaintext, fontname=\"Helvetica\", fontsize=11];\n")
+	b.WriteString("    edge [fontname=\"Helvetica\", fontsize=10, dir=none];\n\n")
+	for _, e := range d.Entities {
+		fmt.Fprintf(&b, "    %q [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\" cellpadding=\"4\">\n", e.Name)
+		fmt.Fprintf(&b, "        <tr><td bgcolor=\"#dbe7f3\"><b>%s</b></td></tr>\n", html.EscapeString(e.Name))
+		for _, a := range e.Attributes {
+			fmt.Fprintf(&b, "        <tr><td align=\"left\">%s</td></tr>\n", html.EscapeString(attributeText(a)))
+		}
+		b.WriteString("    </table>>];\n")
+	}
+	if len(d.Relations) > 0 {
+		b.WriteString("\n")
+	}
+	for _, r := range d.Relations {
+		fmt.Fprintf(&b, "    %q -> %q [label=%q, taillabel=%q, headlabel=%q];\n", r.From, r.To, r.Label, multiplicity(r.FromMany), multiplicity(r.ToMany))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func attributeText(a Attribute) string {
+	text := a.Name + ": " + a.Type
+	if a.Key != "" {
+		text += " " + a.Key
+	}
+	return text
+}
+
+func multiplicity(many bool) string {
+	if many {
+		return "*"
+	}
+	return "1"
+}
+
+// ==================== SVG ====================
+// Rendu autonome lorsque Graphviz n'est pas installé: les entités sont placées sur une
+// grille et les relations tracées en ligne droite entre leurs cadres.
+
+const (
+	charWidth    = 7.2
+	rowHeight    = 18.0
+	headerHeight = 26.0
+	padding      = 10.0
+	gap          = 90.0
+)
+
+type box struct {
+	x, y, w, h float64
+}
+
+func (b box) center() (float64, float64) {
+	return b.x + b.w/2, b.y + b.h/2
+}
+
+// border renvoie le point où le segment partant du centre vers (tx, ty) sort du cadre.
+func (b box) border(tx, ty float64) (float64, float64) {
+	cx, cy := b.center()
+	dx, dy := tx-cx, ty-cy
+	if dx == 0 && dy == 0 {
+		return cx, cy
+	}
+	scale := math.Inf(1)
+	if dx != 0 {
+		scale = math.Min(scale, (b.w/2)/math.Abs(dx))
+	}
+	if dy != 0 {
+		scale = math.Min(scale, (b.h/2)/math.Abs(dy))
+	}
+	return cx + dx*scale, cy + dy*scale
+}
+
+// SVG renvoie le diagramme sous forme d'image SVG.
+func SVG(d Diagram) string {
+	boxes := map[string]box{}
+	columns := int(math.Ceil(math.Sqrt(float64(len(d.Entities)))))
+	if columns == 0 {
+		columns = 1
+	}
+
+	// Largeur de chaque colonne et hauteur de chaque ligne de la grille
+	sizes := make([]box, len(d.Entities))
+	colWidths := make([]float64, columns)
+	rowHeights := make([]float64, (len(d.Entities)+columns-1)/columns)
+	for i, e := range d.Entities {
+		width := float64(len(e.Name))
+		for _, a := range e.Attributes {
+			width = math.Max(width, float64(len([]rune(attributeText(a)))))
+		}
+		sizes[i] = box{w: width*charWidth + 2*padding, h: headerHeight + float64(len(e.Attributes))*rowHeight + padding/2}
+		colWidths[i%columns] = math.Max(colWidths[i%columns], sizes[i].w)
+		rowHeights[i/columns] = math.Max(rowHeights[i/columns], sizes[i].h)
+	}
+
+	totalWidth, totalHeight := gap/2, gap/2
+	for _, w := range colWidths {
+		totalWidth += w + gap
+	}
+	for _, h := range rowHeights {
+		totalHeight += h + gap
+	}
+	for i, e := range d.Entities {
+		x, y := gap/2, gap/2
+		for c := 0; c < i%columns; c++ {
+			x += colWidths[c] + gap
+		}
+		for r := 0; r < i/columns; r++ {
+			y += rowHeights[r] + gap
+		}
+		boxes[e.Name] = box{x: x, y: y, w: sizes[i].w, h: sizes[i].h}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"%.0f\" viewBox=\"0 0 %.0f %.0f\" font-family=\"monospace\" font-size=\"12\">\n",
+		totalWidth, totalHeight, totalWidth, totalHeight)
+	b.WriteString("  <rect width=\"100%\" height=\"100%\" fill=\"white\"/>\n")
+
+	// Les relations sont tracées sous les entités
+	for _, r := range d.Relations {
+		from, okFrom := boxes[r.From]
+		to, okTo := boxes[r.To]
+		if !okFrom || !okTo {
+			continue
+		}
+		fcx, fcy := from.center()
+		tcx, tcy := to.center()
+		if r.From == r.To {
+			// Relation réflexive: boucle sur le coin supérieur droit
+			x, y := from.x+from.w, from.y
+			fmt.Fprintf(&b, "  <path d=\"M %.1f %.1f C %.1f %.1f %.1f %.1f %.1f %.1f\" fill=\"none\" stroke=\"#555\"/>\n",
+				x-20, y, x-20, y-40, x+40, y+20, x, y+20)
+			fmt.Fprintf(&b, "  <text x=\"%.1f\" y=\"%.1f\" fill=\"#333\">%s</text>\n", x+8, y-12, html.EscapeString(r.Label))
+			continue
+		}
+		x1, y1 := from.border(tcx, tcy)
+		x2, y2 := to.border(fcx, fcy)
+		fmt.Fprintf(&b, "  <line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\" stroke=\"#555\"/>\n", x1, y1, x2, y2)
+		fmt.Fprintf(&b, "  <text x=\"%.1f\" y=\"%.1f\" text-anchor=\"middle\" fill=\"#333\">%s</text>\n", (x1+x2)/2, (y1+y2)/2-4, html.EscapeString(r.Label))
+		writeMultiplicity(&b, x1, y1, x2, y2, multiplicity(r.FromMany))
+		writeMultiplicity(&b, x2, y2, x1, y1, multiplicity(r.ToMany))
+	}
+
+	for _, e := range d.Entities {
+		bx := boxes[e.Name]
+		fmt.Fprintf(&b, "  <g>\n    <rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"white\" stroke=\"#333\"/>\n", bx.x, bx.y, bx.w, bx.h)
+		fmt.Fprintf(&b, "    <rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"#dbe7f3\" stroke=\"#333\"/>\n", bx.x, bx.y, bx.w, headerHeight)
+		fmt.Fprintf(&b, "    <text x=\"%.1f\" y=\"%.1f\" text-anchor=\"middle\" font-weight=\"bold\">%s</text>\n", bx.x+bx.w/2, bx.y+17, html.EscapeString(e.Name))
+		for i, a := range e.Attributes {
+			fmt.Fprintf(&b, "    <text x=\"%.1f\" y=\"%.1f\">%s</text>\n", bx.x+padding, bx.y+headerHeight+float64(i+1)*rowHeight-5, html.EscapeString(attributeText(a)))
+		}
+		b.WriteString("  </g>\n")
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// writeMultiplicity écrit la multiplicité près de l'extrémité (x, y) d'une relation.
+func writeMultiplicity(b *strings.Builder, x, y, ox, oy float64, text string) {
+	length := math.Hypot(ox-x, oy-y)
+	if length == 0 {
+		return
+	}
+	// Décalage le long de la ligne puis perpendiculairement, pour ne pas chevaucher le trait
+	ux, uy := (ox-x)/length, (oy-y)/length
+	px, py := x+ux*14-uy*8, y+uy*14+ux*8
+	fmt.Fprintf(b, "  <text x=\"%.1f\" y=\"%.1f\" text-anchor=\"middle\" fill=\"#333\">%s</text>\n", px, py+4, text)
+}
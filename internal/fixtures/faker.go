@@ -0,0 +1,340 @@
+// Package fixtures produit des valeurs factices réalistes pour remplir une base de
+// développement: le type et le nom de chaque champ orientent la valeur (email, prénom,
+// téléphone, montant...), qui respecte ses contraintes (longueur, unicité, nullabilité).
+package fixtures
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind est la nature d'une valeur générée, qui détermine son écriture en SQL ou en code.
+type Kind int
+
+const (
+	Null Kind = iota
+	Text
+	Number
+	Boolean
+	Date
+	DateTime
+	Time
+	Instant
+	UUID
+	Enum
+)
+
+// Value est une valeur générée. Text est sa forme canonique: nombre décimal, date ISO 8601
+// (2024-03-15, 2024-03-15T10:30:00, 10:30:00), UUID ou nom de constante d'énumération.
+type Value struct {
+	Kind Kind
+	Text string
+}
+
+// Field décrit le champ à remplir.
+type Field struct {
+	Name string
+	// Type est le type Java du champ (String, Long, LocalDate, BigDecimal...)
+	Type string
+	// Enum liste les constantes lorsque le champ est une énumération
+	Enum []string
+
+	Required bool
+	Unique   bool
+	Email    bool
+	Past     bool
+	Future   bool
+	Positive bool
+	// MinLength et MaxLength bornent la longueur des textes, Min et Max les nombres (nil: pas de borne)
+	MinLength, MaxLength int
+	Min, Max             *int
+}
+
+// Faker génère les valeurs. Une même graine produit toujours les mêmes données.
+type Faker struct {
+	rand *rand.Rand
+	// today est la date de référence des dates passées et futures
+	today time.Time
+}
+
+// New renvoie un générateur initialisé avec la graine donnée.
+func New(seed int64) *Faker {
+	return &Faker{
+		rand:  rand.New(rand.NewSource(seed)),
+		today: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// Intn renvoie un entier dans [0, n), tiré de la même source que les valeurs.
+func (f *Faker) Intn(n int) int {
+	return f.rand.Intn(n)
+}
+
+var (
+	firstNames = []string{"Camille", "Louis", "Emma", "Gabriel", "Jade", "Léo", "Louise", "Raphaël", "Alice", "Arthur",
+		"Chloé", "Jules", "Léa", "Hugo", "Manon", "Adam", "Inès", "Lucas", "Sarah", "Nathan"}
+	lastNames = []string{"Martin", "Bernard", "Dubois", "Thomas", "Robert", "Richard", "Petit", "Durand", "Leroy", "Moreau",
+		"Simon", "Laurent", "Lefebvre", "Michel", "Garcia", "David", "Bertrand", "Roux", "Vincent", "Fournier"}
+	cities    = []string{"Paris", "Lyon", "Marseille", "Toulouse", "Nice", "Nantes", "Strasbourg", "Montpellier", "Bordeaux", "Lille"}
+	countries = []string{"France", "Belgique", "Suisse", "Canada", "Espagne", "Italie", "Allemagne", "Portugal"}
+	streets   = []string{"rue de la République", "avenue Victor Hugo", "boulevard Voltaire", "rue du Moulin", "place de la Mairie",
+		"rue des Lilas", "chemin des Vignes", "avenue Jean Jaurès"}
+	companies = []string{"Acme", "Globex", "Initech", "Umbrella", "Hooli", "Stark Industries", "Wayne Enterprises", "Cyberdyne"}
+	words     = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+		"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua"}
+	colors = []string{"rouge", "vert", "bleu", "jaune", "noir", "blanc", "orange", "violet"}
+)
+
+// person est l'identité partagée par les champs d'une même ligne: l'email d'un client
+// reprend son prénom et son nom.
+type person struct {
+	first, last string
+}
+
+// Row renvoie les valeurs des champs de la ligne row (à partir de 0), qui sert à rendre
+// uniques les valeurs des champs uniques.
+func (f *Faker) Row(fields []Field, row int) []Value {
+	p := person{first: pick(f, firstNames), last: pick(f, lastNames)}
+	values := make([]Value, len(fields))
+	for i, field := range fields {
+		values[i] = f.value(field, row, p)
+	}
+	return values
+}
+
+func (f *Faker) value(field Field, row int, p person) Value {
+	// Un champ facultatif est parfois vide, pour que les données ressemblent à la réalité
+	if !field.Required && !field.Unique && f.rand.Intn(10) == 0 {
+		return Value{Kind: Null}
+	}
+	if len(field.Enum) > 0 {
+		return Value{Kind: Enum, Text: field.Enum[f.rand.Intn(len(field.Enum))]}
+	}
+
+	switch field.Type {
+	case "String":
+		return Value{Kind: Text, Text: f.text(field, row, p)}
+	case "int", "Integer", "long", "Long", "short", "Short":
+		return Value{Kind: Number, Text: strconv.Itoa(f.integer(field, row))}
+	case "double", "Double", "float", "Float", "BigDecimal":
+		return Value{Kind: Number, Text: f.decimal(field)}
+	case "boolean", "Boolean":
+		return Value{Kind: Boolean, Text: strconv.FormatBool(f.rand.Intn(2) == 0)}
+	case "LocalDate":
+		return Value{Kind: Date, Text: f.date(field, row).Format("2006-01-02")}
+	case "LocalDateTime":
+		return Value{Kind: DateTime, Text: f.date(field, row).Format("2006-01-02T15:04:05")}
+	case "Instant", "OffsetDateTime":
+		return Value{Kind: Instant, Text: f.date(field, row).Format("2006-01-02T15:04:05Z")}
+	case "LocalTime":
+		return Value{Kind: Time, Text: fmt.Sprintf("%02d:%02d:00", 8+f.rand.Intn(10), 15*f.rand.Intn(4))}
+	case "UUID":
+		return Value{Kind: UUID, Text: f.UUID()}
+	}
+	return Value{Kind: Null}
+}
+
+// UUID renvoie un UUID version 4.
+func (f *Faker) UUID() string {
+	b := make([]byte, 16)
+	f.rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// text choisit un texte d'après le nom du champ, puis applique unicité et longueurs.
+func (f *Faker) text(field Field, row int, p person) string {
+	name := strings.ToLower(field.Name)
+	first, last := p.first, p.last
+
+	var text string
+	switch {
+	case field.Email || strings.Contains(name, "email") || strings.Contains(name, "mail"):
+		local := asciiLower(first) + "." + asciiLower(last)
+		if field.Unique {
+			local += strconv.Itoa(row + 1)
+		}
+		return fitEmail(local, "example.com", field.MaxLength)
+	case strings.Contains(name, "firstname") || name == "prenom" || name == "prénom":
+		text = first
+	case strings.Contains(name, "lastname") || strings.Contains(name, "surname") || name == "nom":
+		text = last
+	case strings.Contains(name, "username") || strings.Contains(name, "login"):
+		text = asciiLower(first[:1] + last)
+	case strings.Contains(name, "phone") || strings.Contains(name, "mobile") || strings.Contains(name, "tel"):
+		text = fmt.Sprintf("+336%08d", f.rand.Intn(100000000))
+	case strings.Contains(name, "url") || strings.Contains(name, "website") || strings.Contains(name, "site"):
+		text = "https://www." + asciiLower(strings.ReplaceAll(pick(f, companies), " ", "-")) + ".example.com"
+	case strings.Contains(name, "city") || strings.Contains(name, "ville"):
+		text = pick(f, cities)
+	case strings.Contains(name, "country") || strings.Contains(name, "pays"):
+		text = pick(f, countries)
+	case strings.Contains(name, "street") || strings.Contains(name, "address") || strings.Contains(name, "adresse"):
+		text = fmt.Sprintf("%d %s", 1+f.rand.Intn(150), pick(f, streets))
+	case strings.Contains(name, "zip") || strings.Contains(name, "postal"):
+		text = fmt.Sprintf("%05d", 1000+f.rand.Intn(94000))
+	case strings.Contains(name, "company") || strings.Contains(name, "societe") || strings.Contains(name, "organization"):
+		text = pick(f, companies)
+	case strings.Contains(name, "color") || strings.Contains(name, "couleur"):
+		text = pick(f, colors)
+	case strings.Contains(name, "password"):
+		text = "Secret" + strconv.Itoa(1000+f.rand.Intn(9000)) + "!"
+	case strings.Contains(name, "reference") || strings.Contains(name, "code") || strings.Contains(name, "sku"):
+		text = fmt.Sprintf("%s-%05d", strings.ToUpper(firstLetters(field.Name, 3)), row+1)
+	case strings.Contains(name, "description") || strings.Contains(name, "comment") || strings.Contains(name, "content") ||
+		strings.Contains(name, "bio") || strings.Contains(name, "text") || strings.Contains(name, "message"):
+		text = f.sentence(6 + f.rand.Intn(10))
+	case strings.Contains(name, "title") || strings.Contains(name, "label") || strings.Contains(name, "subject"):
+		text = f.sentence(2 + f.rand.Intn(3))
+	case strings.HasSuffix(name, "name"):
+		text = first + " " + last
+	default:
+		text = f.sentence(2)
+	}
+
+	suffix := ""
+	if field.Unique && !strings.HasSuffix(text, fmt.Sprintf("%05d", row+1)) {
+		suffix = " " + strconv.Itoa(row+1)
+	}
+	return fitLength(text, suffix, field.MinLength, field.MaxLength)
+}
+
+// sentence renvoie une phrase de n mots, commençant par une majuscule.
+func (f *Faker) sentence(n int) string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = pick(f, words)
+	}
+	s := strings.Join(parts, " ")
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// integer choisit un entier d'après le nom du champ, dans les bornes du champ.
+func (f *Faker) integer(field Field, row int) int {
+	name := strings.ToLower(field.Name)
+	low, high := 1, 1000
+	switch {
+	case strings.Contains(name, "age"):
+		low, high = 18, 80
+	case strings.Contains(name, "year") || strings.Contains(name, "annee"):
+		low, high = 1990, f.today.Year()
+	case strings.Contains(name, "rating") || strings.Contains(name, "note") || strings.Contains(name, "score"):
+		low, high = 1, 5
+	case strings.Contains(name, "quantity") || strings.Contains(name, "stock") || strings.Contains(name, "count"):
+		low, high = 0, 100
+	}
+	if field.Positive && low < 1 {
+		low = 1
+	}
+	if field.Min != nil && *field.Min > low {
+		low = *field.Min
+	}
+	if field.Max != nil && *field.Max < high {
+		high = *field.Max
+	}
+	if high < low {
+		high = low
+	}
+	if field.Unique {
+		return low + row
+	}
+	return low + f.rand.Intn(high-low+1)
+}
+
+// decimal choisit un nombre à deux décimales (prix, montant...) dans les bornes du champ.
+func (f *Faker) decimal(field Field) string {
+	name := strings.ToLower(field.Name)
+	low, high := 1, 500
+	switch {
+	case strings.Contains(name, "latitude"):
+		low, high = -90, 90
+	case strings.Contains(name, "longitude"):
+		low, high = -180, 180
+	case strings.Contains(name, "rate") || strings.Contains(name, "percent") || strings.Contains(name, "taux"):
+		low, high = 0, 100
+	}
+	if field.Min != nil && *field.Min > low {
+		low = *field.Min
+	}
+	if field.Max != nil && *field.Max < high {
+		high = *field.Max
+	}
+	if high <= low {
+		return strconv.Itoa(low) + ".00"
+	}
+	cents := low*100 + f.rand.Intn((high-low)*100)
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// date choisit une date cohérente avec le nom du champ et ses contraintes past/future.
+func (f *Faker) date(field Field, row int) time.Time {
+	name := strings.ToLower(field.Name)
+	var t time.Time
+	switch {
+	case !field.Past && (field.Future || strings.Contains(name, "expir") || strings.Contains(name, "due") || strings.Contains(name, "deadline")):
+		t = f.today.AddDate(0, 0, 1+f.rand.Intn(365))
+	case strings.Contains(name, "birth") || strings.Contains(name, "naissance"):
+		t = f.today.AddDate(-18-f.rand.Intn(60), 0, -f.rand.Intn(365))
+	default:
+		// Dates passées récentes: création, mise à jour, commande...
+		t = f.today.AddDate(0, 0, -1-f.rand.Intn(730))
+	}
+	if field.Unique {
+		t = t.AddDate(0, 0, row)
+	}
+	return t.Add(time.Duration(8+f.rand.Intn(10))*time.Hour + time.Duration(f.rand.Intn(60))*time.Minute)
+}
+
+func pick(f *Faker, values []string) string {
+	return values[f.rand.Intn(len(values))]
+}
+
+// fitLength ajoute le suffixe d'unicité et ramène le texte dans ses bornes de longueur.
+func fitLength(text, suffix string, min, max int) string {
+	if max > 0 && len([]rune(text))+len(suffix) > max {
+		keep := max - len(suffix)
+		if keep < 0 {
+			keep = 0
+		}
+		text = strings.TrimSpace(string([]rune(text)[:keep]))
+	}
+	text += suffix
+	if n := len([]rune(text)); n < min {
+		text += strings.Repeat("x", min-n)
+	}
+	return text
+}
+
+// fitEmail raccourcit la partie locale d'une adresse trop longue.
+func fitEmail(local, domain string, max int) string {
+	if max > 0 && len(local)+1+len(domain) > max {
+		domain = "ex.io"
+		if keep := max - 1 - len(domain); keep > 0 && keep < len(local) {
+			local = local[len(local)-keep:]
+		}
+	}
+	return local + "@" + domain
+}
+
+// asciiLower met en minuscules sans accents, pour les emails et identifiants.
+func asciiLower(s string) string {
+	replacer := strings.NewReplacer("é", "e", "è", "e", "ê", "e", "ë", "e", "à", "a", "â", "a", "ï", "i", "î", "i",
+		"ô", "o", "ö", "o", "ù", "u", "û", "u", "ü", "u", "ç", "c", "É", "e", " ", "")
+	return replacer.Replace(strings.ToLower(s))
+}
+
+// firstLetters renvoie les n premières lettres d'un nom (REF pour reference).
+func firstLetters(s string, n int) string {
+	if len(s) < n {
+		return s
+	}
+	return s[:n]
+}
@@ -34,7 +34,7 @@ func GeneratePublicPrivateKey() {
     }
     privateKeyFile, err := os.Create("jwt/private.key")
     if err != nil {
-        utils.PrintError(fmt.Sprintf("Erreur lors de la création du fichier: %v",))
+        utils.PrintError(fmt.Sprintf("Erreur lors de la création du fichier: %v", err))
         os.Exit(1)
     }
     pem.Encode(privateKeyFile, privateKeyPEM)
@@ -50,7 +50,7 @@ func GeneratePublicPrivateKey() {
     }
     publicKeyFile, err := os.Create("jwt/public.key")
     if err != nil {
-        utils.PrintError(fmt.Sprintf("Erreur lors de la création du fichier: %v"))
+        utils.PrintError(fmt.Sprintf("Erreur lors de la création du fichier: %v", err))
         os.Exit(1)
     }
 		pem.Encode(publicKeyFile, publicKeyPEM)
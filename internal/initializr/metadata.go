@@ -0,0 +1,94 @@
+// Package initializr lit les métadonnées de Spring Initializr: coordonnées des starters
+// (security, kafka...), BOMs et dépôts dont ils ont besoin.
+package initializr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultURL est l'instance de Spring Initializr interrogée lorsqu'aucune n'est configurée.
+const DefaultURL = "https://start.spring.io"
+
+// Dependency est un starter d'Initializr, désigné par son identifiant (security, kafka...).
+type Dependency struct {
+	GroupID    string `json:"groupId"`
+	ArtifactID string `json:"artifactId"`
+	Version    string `json:"version"`
+	// Scope vaut compile, runtime, provided, test ou annotationProcessor
+	Scope      string `json:"scope"`
+	BOM        string `json:"bom"`
+	Repository string `json:"repository"`
+}
+
+// BOM est un BOM importé par certains starters (spring-cloud, vaadin...).
+type BOM struct {
+	GroupID      string   `json:"groupId"`
+	ArtifactID   string   `json:"artifactId"`
+	Version      string   `json:"version"`
+	Repositories []string `json:"repositories"`
+}
+
+// Repository est un dépôt Maven supplémentaire (spring-milestones...).
+type Repository struct {
+	Name            string `json:"name"`
+	URL             string `json:"url"`
+	SnapshotEnabled bool   `json:"snapshotEnabled"`
+}
+
+// Metadata décrit les starters disponibles pour une version de Spring Boot.
+type Metadata struct {
+	BootVersion  string                `json:"bootVersion"`
+	Dependencies map[string]Dependency `json:"dependencies"`
+	BOMs         map[string]BOM        `json:"boms"`
+	Repositories map[string]Repository `json:"repositories"`
+}
+
+// Fetch lit les métadonnées des starters compatibles avec la version de Spring Boot donnée
+// (la version par défaut d'Initializr si elle est vide).
+func Fetch(baseURL, bootVersion string) (*Metadata, error) {
+	if baseURL == "" {
+		baseURL = DefaultURL
+	}
+	endpoint := strings.TrimRight(baseURL, "/") + "/dependencies"
+	if bootVersion != "" {
+		endpoint += "?bootVersion=" + url.QueryEscape(bootVersion)
+	}
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.initializr.v2.2+json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Initializr explique son refus (version de Spring Boot non prise en charge...)
+		var problem struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &problem) == nil && problem.Message != "" {
+			return nil, fmt.Errorf("%s: %s", baseURL, problem.Message)
+		}
+		return nil, fmt.Errorf("%s: statut %d", baseURL, resp.StatusCode)
+	}
+
+	m := &Metadata{}
+	if err := json.Unmarshal(body, m); err != nil {
+		return nil, fmt.Errorf("métadonnées de %s invalides: %w", baseURL, err)
+	}
+	return m, nil
+}
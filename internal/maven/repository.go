@@ -0,0 +1,266 @@
+// Package maven interroge un dépôt Maven (Maven Central ou un miroir) pour connaître les
+// versions publiées d'un artefact et les dépendances gérées par un BOM.
+package maven
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRepository est le dépôt interrogé lorsqu'aucun n'est configuré.
+const DefaultRepository = "https://repo.maven.apache.org/maven2"
+
+// ErrNotFound est renvoyée lorsqu'un artefact n'existe pas dans le dépôt.
+var ErrNotFound = errors.New("introuvable dans le dépôt")
+
+// Repository est un dépôt Maven accessible en HTTP, ou un dossier servi en local.
+type Repository struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewRepository renvoie le dépôt situé à l'URL donnée, le dépôt par défaut si elle est vide.
+func NewRepository(url string) *Repository {
+	if url == "" {
+		url = DefaultRepository
+	}
+	return &Repository{URL: strings.TrimRight(url, "/"), Client: &http.Client{Timeout: 15 * time.Second}}
+}
+
+// Metadata est le contenu du fichier maven-metadata.xml d'un artefact.
+type Metadata struct {
+	Latest   string   `xml:"versioning>latest"`
+	Release  string   `xml:"versioning>release"`
+	Versions []string `xml:"versioning>versions>version"`
+}
+
+// Metadata lit le fichier maven-metadata.xml de l'artefact.
+func (r *Repository) Metadata(groupID, artifactID string) (*Metadata, error) {
+	data, err := r.get(artifactPath(groupID, artifactID) + "/maven-metadata.xml")
+	if err != nil {
+		return nil, fmt.Errorf("%s:%s: %w", groupID, artifactID, err)
+	}
+	m := &Metadata{}
+	if err := xml.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("maven-metadata.xml de %s:%s invalide: %w", groupID, artifactID, err)
+	}
+	return m, nil
+}
+
+// LatestVersion renvoie la dernière version stable de l'artefact: les versions de
+// développement (SNAPSHOT, milestones, release candidates...) sont ignorées.
+func (r *Repository) LatestVersion(groupID, artifactID string) (string, error) {
+	m, err := r.Metadata(groupID, artifactID)
+	if err != nil {
+		return "", err
+	}
+	latest := m.LatestStable("")
+	if latest == "" {
+		return "", fmt.Errorf("%s:%s: aucune version stable publiée", groupID, artifactID)
+	}
+	return latest, nil
+}
+
+// LatestStable renvoie la dernière version stable publiée, limitée à la version majeure de
+// sameMajor s'il est renseigné (1.6.3 pour 1.5.5.Final), vide s'il n'y en a aucune.
+func (m *Metadata) LatestStable(sameMajor string) string {
+	latest := ""
+	for _, v := range append(m.Versions, m.Release) {
+		v = strings.TrimSpace(v)
+		if v == "" || !IsStable(v) || (sameMajor != "" && Major(v) != Major(sameMajor)) {
+			continue
+		}
+		if latest == "" || CompareVersions(v, latest) > 0 {
+			latest = v
+		}
+	}
+	return latest
+}
+
+// BOM est la liste des dépendances gérées par un BOM (dependencyManagement), y compris
+// celles des BOMs qu'il importe.
+type BOM struct {
+	Dependencies []ManagedDependency
+}
+
+// ManagedDependency est une entrée de dependencyManagement. Scope vaut "import" pour les
+// BOMs importés.
+type ManagedDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+}
+
+// BOM lit le pom d'un BOM, par exemple org.springframework.boot:spring-boot-dependencies,
+// puis les BOMs qu'il importe, récursivement. Un BOM importé illisible est ignoré: les
+// artefacts qu'il gère sont considérés comme non gérés.
+func (r *Repository) BOM(groupID, artifactID, version string) (*BOM, error) {
+	dependencies, imports, err := r.readBOM(groupID, artifactID, version)
+	if err != nil {
+		return nil, err
+	}
+	bom := &BOM{Dependencies: dependencies}
+	seen := map[string]bool{groupID + ":" + artifactID: true}
+	imports = unseen(imports, seen)
+	for len(imports) > 0 {
+		// Les BOMs importés d'un même niveau sont lus en parallèle
+		type result struct {
+			dependencies, imports []ManagedDependency
+			err                   error
+		}
+		results := make([]result, len(imports))
+		var wg sync.WaitGroup
+		for i, d := range imports {
+			wg.Add(1)
+			go func(res *result, d ManagedDependency) {
+				defer wg.Done()
+				res.dependencies, res.imports, res.err = r.readBOM(d.GroupID, d.ArtifactID, d.Version)
+			}(&results[i], d)
+		}
+		wg.Wait()
+
+		imports = nil
+		for _, res := range results {
+			if res.err != nil {
+				continue
+			}
+			bom.Dependencies = append(bom.Dependencies, res.dependencies...)
+			imports = append(imports, unseen(res.imports, seen)...)
+		}
+	}
+	return bom, nil
+}
+
+// readBOM lit les entrées de dependencyManagement d'un pom, versions résolues avec ses
+// propriétés, et renvoie à part les BOMs importés dont la version est connue.
+func (r *Repository) readBOM(groupID, artifactID, version string) (dependencies, imports []ManagedDependency, err error) {
+	data, err := r.get(pomPath(groupID, artifactID, version))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s:%s:%s: %w", groupID, artifactID, version, err)
+	}
+	var project struct {
+		Properties struct {
+			Entries []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"properties"`
+		Dependencies []ManagedDependency `xml:"dependencyManagement>dependencies>dependency"`
+	}
+	if err := xml.Unmarshal(data, &project); err != nil {
+		return nil, nil, fmt.Errorf("pom de %s:%s:%s invalide: %w", groupID, artifactID, version, err)
+	}
+
+	properties := map[string]string{"project.version": version, "project.groupId": groupID}
+	for _, p := range project.Properties.Entries {
+		properties[p.XMLName.Local] = strings.TrimSpace(p.Value)
+	}
+	for _, d := range project.Dependencies {
+		d = ManagedDependency{
+			GroupID:    resolveProperties(strings.TrimSpace(d.GroupID), properties),
+			ArtifactID: strings.TrimSpace(d.ArtifactID),
+			Version:    resolveProperties(strings.TrimSpace(d.Version), properties),
+			Scope:      strings.TrimSpace(d.Scope),
+		}
+		dependencies = append(dependencies, d)
+		if d.Scope == "import" && d.Version != "" && !strings.Contains(d.Version, "${") {
+			imports = append(imports, d)
+		}
+	}
+	return dependencies, imports, nil
+}
+
+var propertyRegexp = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// resolveProperties remplace les références ${nom} par la valeur des propriétés du pom;
+// une propriété inconnue (définie par un pom parent) est laissée telle quelle.
+func resolveProperties(value string, properties map[string]string) string {
+	for i := 0; i < 5 && strings.Contains(value, "${"); i++ {
+		value = propertyRegexp.ReplaceAllStringFunc(value, func(ref string) string {
+			if v, ok := properties[ref[2:len(ref)-1]]; ok {
+				return v
+			}
+			return ref
+		})
+	}
+	return value
+}
+
+// unseen renvoie les BOMs qui n'ont pas encore été lus et les marque comme lus.
+func unseen(imports []ManagedDependency, seen map[string]bool) []ManagedDependency {
+	var result []ManagedDependency
+	for _, d := range imports {
+		key := d.GroupID + ":" + d.ArtifactID
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// Manages indique si le BOM, ou l'un des BOMs qu'il importe, fixe la version de la
+// dépendance. Seules les entrées réelles de dependencyManagement comptent: importer
+// org.springframework:spring-framework-bom ne gère pas org.springframework.cloud.
+func (b *BOM) Manages(groupID, artifactID string) bool {
+	for _, d := range b.Dependencies {
+		if d.Scope != "import" && d.GroupID == groupID && d.ArtifactID == artifactID {
+			return true
+		}
+	}
+	return false
+}
+
+// Description renvoie la description du pom de l'artefact (à défaut son nom), vide si le pom
+// est illisible.
+func (r *Repository) Description(groupID, artifactID, version string) string {
+	data, err := r.get(pomPath(groupID, artifactID, version))
+	if err != nil {
+		return ""
+	}
+	var project struct {
+		Name        string `xml:"name"`
+		Description string `xml:"description"`
+	}
+	if xml.Unmarshal(data, &project) != nil {
+		return ""
+	}
+	if description := strings.Join(strings.Fields(project.Description), " "); description != "" {
+		return description
+	}
+	return strings.TrimSpace(project.Name)
+}
+
+// get télécharge un fichier du dépôt, chemin relatif à sa racine.
+func (r *Repository) get(path string) ([]byte, error) {
+	resp, err := r.Client.Get(r.URL + "/" + path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: statut %d", r.URL, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// pomPath renvoie le chemin du pom d'une version de l'artefact.
+func pomPath(groupID, artifactID, version string) string {
+	return fmt.Sprintf("%s/%s/%s-%s.pom", artifactPath(groupID, artifactID), version, artifactID, version)
+}
+
+// artifactPath renvoie le dossier de l'artefact dans le dépôt (org/mapstruct/mapstruct).
+func artifactPath(groupID, artifactID string) string {
+	return strings.ReplaceAll(groupID, ".", "/") + "/" + artifactID
+}
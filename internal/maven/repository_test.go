@@ -0,0 +1,216 @@
+package maven
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// repositoryServer simule un dépôt Maven qui sert les fichiers donnés, par chemin.
+func repositoryServer(t *testing.T, files map[string]string) *Repository {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(server.Close)
+	return NewRepository(server.URL + "/")
+}
+
+const mapstructMetadata = `<?xml version="1.0" encoding="UTF-8"?>
+<metadata>
+  <groupId>org.mapstruct</groupId>
+  <artifactId>mapstruct</artifactId>
+  <versioning>
+    <latest>1.7.0.Beta1</latest>
+    <release>1.7.0.Beta1</release>
+    <versions>
+      <version>1.5.5.Final</version>
+      <version>1.6.0.Beta1</version>
+      <version>1.6.3</version>
+      <version>1.10.0-SNAPSHOT</version>
+      <version>1.7.0.Beta1</version>
+      <version>2.0.0.M1</version>
+    </versions>
+  </versioning>
+</metadata>`
+
+const bootDependencies = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <name>spring-boot-dependencies</name>
+  <description>
+    Spring Boot
+    Dependencies
+  </description>
+  <properties>
+    <jackson-bom.version>2.18.2</jackson-bom.version>
+    <spring-framework.version>6.2.1</spring-framework.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.postgresql</groupId>
+        <artifactId>postgresql</artifactId>
+        <version>42.7.4</version>
+      </dependency>
+      <dependency>
+        <groupId>com.fasterxml.jackson</groupId>
+        <artifactId>jackson-bom</artifactId>
+        <version>${jackson-bom.version}</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+      <dependency>
+        <groupId>org.springframework</groupId>
+        <artifactId>spring-framework-bom</artifactId>
+        <version>${spring-framework.version}</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+      <dependency>
+        <groupId>org.example</groupId>
+        <artifactId>absent-bom</artifactId>
+        <version>1.0</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`
+
+const jacksonBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <properties>
+    <jackson.version>2.18.2</jackson.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>com.fasterxml.jackson.core</groupId>
+        <artifactId>jackson-databind</artifactId>
+        <version>${jackson.version}</version>
+      </dependency>
+      <dependency>
+        <groupId>com.fasterxml.jackson.datatype</groupId>
+        <artifactId>jackson-datatype-jsr310</artifactId>
+        <version>${jackson.version}</version>
+      </dependency>
+      <dependency>
+        <groupId>org.example</groupId>
+        <artifactId>nested-bom</artifactId>
+        <version>${project.version}</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`
+
+const springFrameworkBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.springframework</groupId>
+        <artifactId>spring-core</artifactId>
+        <version>6.2.1</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`
+
+const nestedBOM = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.example.nested</groupId>
+        <artifactId>nested-lib</artifactId>
+        <version>1.0</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`
+
+func TestMetadata(t *testing.T) {
+	repository := repositoryServer(t, map[string]string{
+		"/org/mapstruct/mapstruct/maven-metadata.xml": mapstructMetadata,
+		"/org/example/broken/maven-metadata.xml":      "<metadata>",
+	})
+
+	m, err := repository.Metadata("org.mapstruct", "mapstruct")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		sameMajor string
+		want      string
+	}{
+		{sameMajor: "", want: "1.6.3"},
+		{sameMajor: "1.5.5.Final", want: "1.6.3"},
+		{sameMajor: "2.0.0", want: ""},
+		{sameMajor: "3.0.0", want: ""},
+	}
+	for _, c := range cases {
+		if got := m.LatestStable(c.sameMajor); got != c.want {
+			t.Errorf("LatestStable(%q) = %q, attendu %q", c.sameMajor, got, c.want)
+		}
+	}
+
+	latest, err := repository.LatestVersion("org.mapstruct", "mapstruct")
+	if err != nil || latest != "1.6.3" {
+		t.Errorf("LatestVersion = %q, %v, attendu 1.6.3", latest, err)
+	}
+	if _, err := repository.Metadata("org.example", "absent"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("artefact absent: %v, attendu %v", err, ErrNotFound)
+	}
+	if _, err := repository.Metadata("org.example", "broken"); err == nil {
+		t.Error("maven-metadata.xml invalide accepté")
+	}
+}
+
+func TestBOM(t *testing.T) {
+	repository := repositoryServer(t, map[string]string{
+		"/org/springframework/boot/spring-boot-dependencies/3.4.1/spring-boot-dependencies-3.4.1.pom": bootDependencies,
+		"/com/fasterxml/jackson/jackson-bom/2.18.2/jackson-bom-2.18.2.pom":                            jacksonBOM,
+		"/org/springframework/spring-framework-bom/6.2.1/spring-framework-bom-6.2.1.pom":              springFrameworkBOM,
+		"/org/example/nested-bom/2.18.2/nested-bom-2.18.2.pom":                                        nestedBOM,
+	})
+
+	bom, err := repository.BOM("org.springframework.boot", "spring-boot-dependencies", "3.4.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		groupID, artifactID string
+		want                bool
+	}{
+		{"org.postgresql", "postgresql", true},
+		{"org.postgresql", "r2dbc-postgresql", false},
+		{"com.fasterxml.jackson.core", "jackson-databind", true},
+		{"com.fasterxml.jackson.datatype", "jackson-datatype-jsr310", true},
+		{"com.fasterxml.jackson.core", "jackson-unknown", false},
+		{"com.fasterxml.jackson", "jackson-bom", false},
+		{"org.springframework", "spring-core", true},
+		{"org.springframework.cloud", "spring-cloud-starter-config", false},
+		{"org.example.nested", "nested-lib", true},
+		{"org.mapstruct", "mapstruct", false},
+	}
+	for _, c := range cases {
+		if got := bom.Manages(c.groupID, c.artifactID); got != c.want {
+			t.Errorf("Manages(%s:%s) = %v, attendu %v", c.groupID, c.artifactID, got, c.want)
+		}
+	}
+
+	if got := repository.Description("org.springframework.boot", "spring-boot-dependencies", "3.4.1"); got != "Spring Boot Dependencies" {
+		t.Errorf("Description = %q", got)
+	}
+	if _, err := repository.BOM("org.springframework.boot", "spring-boot-dependencies", "9.9.9"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("BOM absent: %v, attendu %v", err, ErrNotFound)
+	}
+}
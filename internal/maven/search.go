@@ -0,0 +1,162 @@
+package maven
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSearch est l'API de recherche de Maven Central.
+const DefaultSearch = "https://search.maven.org/solrsearch/select"
+
+// Artifact est un résultat de recherche.
+type Artifact struct {
+	GroupID     string
+	ArtifactID  string
+	Version     string
+	Description string
+}
+
+// Coordinates renvoie la forme groupId:artifactId de l'artefact.
+func (a Artifact) Coordinates() string {
+	return a.GroupID + ":" + a.ArtifactID
+}
+
+// Search cherche les artefacts correspondant au terme avec l'API de recherche donnée (celle
+// de Maven Central si elle est vide). Les API de Maven Central (Solr), de Nexus 3
+// (/service/rest/v1/search) et d'Artifactory (/api/search/artifact) sont reconnues; une
+// réponse d'un autre format est une erreur. Un artefact n'apparaît qu'une fois, avec sa
+// dernière version.
+func Search(endpoint, term string, limit int) ([]Artifact, error) {
+	if endpoint == "" {
+		endpoint = DefaultSearch
+	}
+	query := url.Values{}
+	switch {
+	case strings.Contains(endpoint, "/service/rest/"):
+		query.Set("q", term)
+		query.Set("format", "maven2")
+	case strings.Contains(endpoint, "/api/search/"):
+		// Recherche rapide d'Artifactory: le nom des fichiers, avec des jokers
+		query.Set("name", "*"+term+"*")
+	default:
+		query.Set("q", term)
+		query.Set("rows", strconv.Itoa(limit))
+		query.Set("wt", "json")
+	}
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	req, err := http.NewRequest("GET", endpoint+separator+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: statut %d", endpoint, resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		// Maven Central
+		Response *struct {
+			Docs []struct {
+				GroupID       string `json:"g"`
+				ArtifactID    string `json:"a"`
+				LatestVersion string `json:"latestVersion"`
+				Version       string `json:"v"`
+			} `json:"docs"`
+		} `json:"response"`
+		// Nexus 3: un élément par version
+		Items *[]struct {
+			Group   string `json:"group"`
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"items"`
+		// Artifactory: un élément par fichier
+		Results *[]struct {
+			URI string `json:"uri"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("réponse de %s invalide: %w", endpoint, err)
+	}
+	if result.Response == nil && result.Items == nil && result.Results == nil {
+		return nil, fmt.Errorf("réponse de %s non reconnue: ni Maven Central (Solr), ni Nexus 3, ni Artifactory", endpoint)
+	}
+
+	var artifacts []Artifact
+	index := map[string]int{}
+	add := func(a Artifact) {
+		if i, ok := index[a.Coordinates()]; ok {
+			current := artifacts[i].Version
+			if IsStable(a.Version) && (!IsStable(current) || CompareVersions(a.Version, current) > 0) {
+				artifacts[i].Version = a.Version
+			}
+			return
+		}
+		if len(artifacts) < limit {
+			index[a.Coordinates()] = len(artifacts)
+			artifacts = append(artifacts, a)
+		}
+	}
+	if result.Response != nil {
+		for _, d := range result.Response.Docs {
+			version := d.LatestVersion
+			if version == "" {
+				version = d.Version
+			}
+			add(Artifact{GroupID: d.GroupID, ArtifactID: d.ArtifactID, Version: version})
+		}
+	}
+	if result.Items != nil {
+		for _, item := range *result.Items {
+			add(Artifact{GroupID: item.Group, ArtifactID: item.Name, Version: item.Version})
+		}
+	}
+	if result.Results != nil {
+		for _, r := range *result.Results {
+			if a, ok := artifactFromStorageURI(r.URI); ok {
+				add(a)
+			}
+		}
+	}
+	return artifacts, nil
+}
+
+// artifactFromStorageURI lit les coordonnées d'un fichier renvoyé par Artifactory
+// (.../api/storage/libs-release/org/mapstruct/mapstruct/1.6.3/mapstruct-1.6.3.jar). Les
+// fichiers qui ne sont pas ceux d'une version (maven-metadata.xml...) sont ignorés.
+func artifactFromStorageURI(uri string) (Artifact, bool) {
+	_, path, ok := strings.Cut(uri, "/api/storage/")
+	if !ok {
+		return Artifact{}, false
+	}
+	// Le premier élément est le dépôt
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 5 {
+		return Artifact{}, false
+	}
+	n := len(parts)
+	artifactID, version, file := parts[n-3], parts[n-2], parts[n-1]
+	if !strings.HasPrefix(file, artifactID+"-"+version) {
+		return Artifact{}, false
+	}
+	return Artifact{GroupID: strings.Join(parts[1:n-3], "."), ArtifactID: artifactID, Version: version}, true
+}
@@ -0,0 +1,121 @@
+package maven
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	cases := []struct {
+		name     string
+		path     string
+		query    string
+		response string
+		want     []Artifact
+		wantErr  string
+	}{
+		{
+			name:  "Maven Central",
+			path:  "/solrsearch/select",
+			query: "q=mapstruct&rows=10&wt=json",
+			response: `{"response":{"numFound":2,"docs":[
+				{"g":"org.mapstruct","a":"mapstruct","latestVersion":"1.6.3"},
+				{"g":"org.mapstruct","a":"mapstruct-processor","v":"1.6.3"}]}}`,
+			want: []Artifact{
+				{GroupID: "org.mapstruct", ArtifactID: "mapstruct", Version: "1.6.3"},
+				{GroupID: "org.mapstruct", ArtifactID: "mapstruct-processor", Version: "1.6.3"},
+			},
+		},
+		{
+			name:  "Nexus 3",
+			path:  "/service/rest/v1/search",
+			query: "format=maven2&q=mapstruct",
+			response: `{"items":[
+				{"group":"org.mapstruct","name":"mapstruct","version":"1.5.5.Final"},
+				{"group":"org.mapstruct","name":"mapstruct","version":"1.7.0.Beta1"},
+				{"group":"org.mapstruct","name":"mapstruct","version":"1.6.3"}],"continuationToken":null}`,
+			want: []Artifact{{GroupID: "org.mapstruct", ArtifactID: "mapstruct", Version: "1.6.3"}},
+		},
+		{
+			name:  "Artifactory",
+			path:  "/artifactory/api/search/artifact",
+			query: "name=%2Amapstruct%2A",
+			response: `{"results":[
+				{"uri":"https://repo.example.com/artifactory/api/storage/libs-release/org/mapstruct/mapstruct/1.6.3/mapstruct-1.6.3.jar"},
+				{"uri":"https://repo.example.com/artifactory/api/storage/libs-release/org/mapstruct/mapstruct/1.6.3/mapstruct-1.6.3.pom"},
+				{"uri":"https://repo.example.com/artifactory/api/storage/libs-release/org/mapstruct/mapstruct/1.5.5.Final/mapstruct-1.5.5.Final.jar"},
+				{"uri":"https://repo.example.com/artifactory/api/storage/libs-release/org/mapstruct/mapstruct/maven-metadata.xml"},
+				{"uri":"https://repo.example.com/artifactory/api/storage/libs-release/org/mapstruct/mapstruct-processor/1.6.3/mapstruct-processor-1.6.3.jar"}]}`,
+			want: []Artifact{
+				{GroupID: "org.mapstruct", ArtifactID: "mapstruct", Version: "1.6.3"},
+				{GroupID: "org.mapstruct", ArtifactID: "mapstruct-processor", Version: "1.6.3"},
+			},
+		},
+		{
+			name:     "aucun résultat",
+			path:     "/artifactory/api/search/artifact",
+			query:    "name=%2Amapstruct%2A",
+			response: `{"results":[]}`,
+		},
+		{
+			name:     "format inconnu",
+			path:     "/api/v2/search",
+			query:    "q=mapstruct&rows=10&wt=json",
+			response: `{"hits":[{"id":"org.mapstruct:mapstruct"}]}`,
+			wantErr:  "non reconnue",
+		},
+		{
+			name:     "réponse invalide",
+			path:     "/solrsearch/select",
+			query:    "q=mapstruct&rows=10&wt=json",
+			response: `<html>`,
+			wantErr:  "invalide",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != c.path || r.URL.RawQuery != c.query {
+					t.Errorf("requête %s?%s, attendue %s?%s", r.URL.Path, r.URL.RawQuery, c.path, c.query)
+				}
+				_, _ = w.Write([]byte(c.response))
+			}))
+			defer server.Close()
+
+			got, err := Search(server.URL+c.path, "mapstruct", 10)
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					t.Errorf("Search = %v, %v, erreur %q attendue", got, err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Search = %+v, attendu %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSearchLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":[
+			{"group":"org.a","name":"a","version":"1.0"},
+			{"group":"org.b","name":"b","version":"1.0"},
+			{"group":"org.c","name":"c","version":"1.0"}]}`))
+	}))
+	defer server.Close()
+
+	got, err := Search(server.URL+"/service/rest/v1/search", "x", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("%d résultats, attendus 2: %+v", len(got), got)
+	}
+}
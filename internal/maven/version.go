@@ -0,0 +1,161 @@
+package maven
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Rang des qualificatifs, dans l'ordre de Maven: les versions de développement précèdent la
+// version finale, elle-même antérieure aux service packs et qualificatifs inconnus (jre...).
+var qualifierRanks = map[string]int{
+	"dev":       0,
+	"ea":        0,
+	"preview":   0,
+	"alpha":     1,
+	"a":         1,
+	"beta":      2,
+	"b":         2,
+	"milestone": 3,
+	"m":         3,
+	"rc":        4,
+	"cr":        4,
+	"snapshot":  5,
+	"":          6,
+	"final":     6,
+	"ga":        6,
+	"release":   6,
+	"sp":        7,
+}
+
+const (
+	releaseRank = 6
+	unknownRank = 8
+)
+
+// versionToken est un élément d'une version: un nombre ou un qualificatif.
+type versionToken struct {
+	number  int
+	numeric bool
+	text    string
+}
+
+// IsStable indique si la version est une version finale (ni SNAPSHOT, ni milestone, ni
+// release candidate...).
+func IsStable(version string) bool {
+	for _, t := range tokenize(version) {
+		if !t.numeric && qualifierRank(t.text) < releaseRank {
+			return false
+		}
+	}
+	return true
+}
+
+// CompareVersions compare deux versions Maven et renvoie -1, 0 ou 1
+// (1.10.0 > 1.9.2, 2.0.0 > 2.0.0-RC1, 6.6.4.Final = 6.6.4).
+func CompareVersions(a, b string) int {
+	ta, tb := tokenize(a), tokenize(b)
+	n := len(ta)
+	if len(tb) > n {
+		n = len(tb)
+	}
+	for i := 0; i < n; i++ {
+		var c int
+		switch {
+		case i >= len(ta):
+			c = -compareMissing(tb[i])
+		case i >= len(tb):
+			c = compareMissing(ta[i])
+		default:
+			c = compareTokens(ta[i], tb[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// Major renvoie la version majeure (3 pour 3.4.1), -1 si la version ne commence pas par
+// un nombre.
+func Major(version string) int {
+	tokens := tokenize(version)
+	if len(tokens) == 0 || !tokens[0].numeric {
+		return -1
+	}
+	return tokens[0].number
+}
+
+// compareMissing compare un élément à un élément absent, équivalent à 0 ou à la version finale.
+func compareMissing(t versionToken) int {
+	if t.numeric {
+		return sign(t.number)
+	}
+	return sign(qualifierRank(t.text) - releaseRank)
+}
+
+func compareTokens(a, b versionToken) int {
+	switch {
+	case a.numeric && b.numeric:
+		return sign(a.number - b.number)
+	case a.numeric:
+		return 1
+	case b.numeric:
+		return -1
+	}
+	if c := sign(qualifierRank(a.text) - qualifierRank(b.text)); c != 0 {
+		return c
+	}
+	return strings.Compare(a.text, b.text)
+}
+
+func qualifierRank(text string) int {
+	if rank, ok := qualifierRanks[text]; ok {
+		return rank
+	}
+	return unknownRank
+}
+
+// tokenize découpe une version sur les séparateurs et entre chiffres et lettres
+// (2.0.0-RC1 donne 2, 0, 0, rc, 1).
+func tokenize(version string) []versionToken {
+	var tokens []versionToken
+	var current strings.Builder
+	flush := func() {
+		if current.Len() == 0 {
+			return
+		}
+		text := current.String()
+		if n, err := strconv.Atoi(text); err == nil {
+			tokens = append(tokens, versionToken{number: n, numeric: true})
+		} else {
+			tokens = append(tokens, versionToken{text: text})
+		}
+		current.Reset()
+	}
+	previousDigit := false
+	for i, r := range strings.ToLower(strings.TrimSpace(version)) {
+		if r == '.' || r == '-' || r == '_' || r == '+' {
+			flush()
+			continue
+		}
+		digit := unicode.IsDigit(r)
+		if i > 0 && current.Len() > 0 && digit != previousDigit {
+			flush()
+		}
+		current.WriteRune(r)
+		previousDigit = digit
+	}
+	flush()
+	return tokens
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
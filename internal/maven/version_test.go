@@ -0,0 +1,66 @@
+package maven
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.10.0", "1.9.2", 1},
+		{"2.0.0", "2.0.0-RC1", 1},
+		{"2.0.0-RC1", "2.0.0-M3", 1},
+		{"2.0.0-M3", "2.0.0-SNAPSHOT", -1},
+		{"6.6.4.Final", "6.6.4", 0},
+		{"5.3.31.RELEASE", "5.3.31", 0},
+		{"3.4", "3.4.0", 0},
+		{"3.4.1", "3.4", 1},
+		{"33.0.0-jre", "32.1.3-jre", 1},
+		{"33.0.0-jre", "33.0.0", 1},
+		{"33.0.0-jre", "33.0.0-android", 1},
+		{"1.2.0.Beta1", "1.2.0.Alpha2", 1},
+		{"1.0-SP1", "1.0", 1},
+		{"4.0.10", "4.0.2", 1},
+	}
+	for _, c := range cases {
+		if got := CompareVersions(c.a, c.b); got != c.want {
+			t.Errorf("CompareVersions(%s, %s) = %d, attendu %d", c.a, c.b, got, c.want)
+		}
+		if got := CompareVersions(c.b, c.a); got != -c.want {
+			t.Errorf("CompareVersions(%s, %s) = %d, attendu %d", c.b, c.a, got, -c.want)
+		}
+	}
+}
+
+func TestIsStable(t *testing.T) {
+	cases := []struct {
+		version string
+		want    bool
+	}{
+		{"3.4.1", true},
+		{"6.6.4.Final", true},
+		{"5.3.31.RELEASE", true},
+		{"33.0.0-jre", true},
+		{"1.0-SP1", true},
+		{"4.0.0-M2", false},
+		{"4.0.0-RC1", false},
+		{"1.6.0.Beta1", false},
+		{"1.0.0-alpha", false},
+		{"1.0-SNAPSHOT", false},
+		{"21-ea", false},
+	}
+	for _, c := range cases {
+		if got := IsStable(c.version); got != c.want {
+			t.Errorf("IsStable(%s) = %v, attendu %v", c.version, got, c.want)
+		}
+	}
+}
+
+func TestMajor(t *testing.T) {
+	cases := map[string]int{"3.4.1": 3, "33.0.0-jre": 33, "4": 4, "v1.2": -1, "": -1}
+	for version, want := range cases {
+		if got := Major(version); got != want {
+			t.Errorf("Major(%q) = %d, attendu %d", version, got, want)
+		}
+	}
+}
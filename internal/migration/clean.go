@@ -0,0 +1,74 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CleanTable est une table à vider. Identity est sa colonne auto-incrémentée, vide si la
+// clé n'est pas générée par la base.
+type CleanTable struct {
+	Name     string
+	Identity string
+}
+
+// CleanSQL génère le script qui vide les tables, données dans l'ordre de suppression (les
+// tables qui référencent d'abord), et remet leurs compteurs d'identifiants à 1.
+//
+// Avec truncate, TRUNCATE remplace DELETE: plus rapide, mais MySQL, MariaDB et H2 refusent
+// de vider une table référencée par une clé étrangère, contrôle désactivé le temps du script.
+func CleanSQL(d Dialect, tables []CleanTable, truncate bool) string {
+	mysqlLike := d == MySQL || d == MariaDB
+	var lines []string
+
+	if truncate {
+		if d == Postgres {
+			names := make([]string, len(tables))
+			for i, t := range tables {
+				names[i] = Quote(d, t.Name)
+			}
+			return fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY;\n", strings.Join(names, ", "))
+		}
+		if mysqlLike {
+			lines = append(lines, "SET FOREIGN_KEY_CHECKS = 0;")
+		} else {
+			lines = append(lines, "SET REFERENTIAL_INTEGRITY FALSE;")
+		}
+		for _, t := range tables {
+			statement := "TRUNCATE TABLE " + Quote(d, t.Name)
+			if d == H2 && t.Identity != "" {
+				statement += " RESTART IDENTITY"
+			}
+			lines = append(lines, statement+";")
+		}
+		if mysqlLike {
+			lines = append(lines, "SET FOREIGN_KEY_CHECKS = 1;")
+		} else {
+			lines = append(lines, "SET REFERENTIAL_INTEGRITY TRUE;")
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	if mysqlLike {
+		lines = append(lines, "START TRANSACTION;")
+	} else {
+		lines = append(lines, "BEGIN;")
+	}
+	for _, t := range tables {
+		lines = append(lines, fmt.Sprintf("DELETE FROM %s;", Quote(d, t.Name)))
+	}
+	lines = append(lines, "COMMIT;")
+	// Sous MySQL et MariaDB, ALTER TABLE valide implicitement la transaction: les compteurs
+	// sont remis à zéro une fois les suppressions validées
+	for _, t := range tables {
+		if t.Identity == "" {
+			continue
+		}
+		if mysqlLike {
+			lines = append(lines, fmt.Sprintf("ALTER TABLE %s AUTO_INCREMENT = 1;", Quote(d, t.Name)))
+		} else {
+			lines = append(lines, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s RESTART WITH 1;", Quote(d, t.Name), Quote(d, t.Identity)))
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,164 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Difference est un écart entre le schéma attendu et celui produit par les migrations,
+// accompagné du changement qui le corrige.
+type Difference struct {
+	Table       string
+	Element     string
+	Description string
+	Change      Change
+}
+
+// Diff compare les tables attendues au schéma reconstitué. Seuls les éléments manquants ou
+// différents sont signalés: les tables et colonnes supplémentaires sont conservées.
+func Diff(d Dialect, expected []Table, actual *Schema) []Difference {
+	var diffs []Difference
+	for _, want := range expected {
+		have := actual.Table(want.Name)
+		if have == nil {
+			diffs = append(diffs, Difference{Table: want.Name, Description: "table manquante", Change: CreateTable{Table: want}})
+			continue
+		}
+
+		for _, column := range want.Columns {
+			got, ok := have.Column(column.Name)
+			if !ok {
+				change := AddColumn{Table: want.Name, Column: column}
+				if fk, ok := want.foreignKey(column.Name); ok {
+					change.ForeignKey = &fk
+				}
+				diffs = append(diffs, Difference{Table: want.Name, Element: column.Name, Description: "colonne manquante", Change: change})
+				continue
+			}
+
+			var problems []string
+			if !SameType(column.Type, got.Type) {
+				problems = append(problems, fmt.Sprintf("type %s au lieu de %s", got.Type, column.Type))
+			}
+			if column.Nullable != got.Nullable {
+				if column.Nullable {
+					problems = append(problems, "NOT NULL non attendu")
+				} else {
+					problems = append(problems, "NOT NULL attendu")
+				}
+			}
+			if len(problems) > 0 {
+				column.Default = got.Default
+				diffs = append(diffs, Difference{
+					Table:       want.Name,
+					Element:     column.Name,
+					Description: strings.Join(problems, ", "),
+					Change:      AlterColumn{Table: want.Name, Column: column, Previous: got},
+				})
+			}
+		}
+
+		for _, fk := range want.ForeignKeys {
+			if _, ok := have.Column(fk.Column); !ok || have.hasForeignKey(fk) {
+				continue
+			}
+			diffs = append(diffs, Difference{
+				Table:       want.Name,
+				Element:     fk.Name,
+				Description: fmt.Sprintf("clé étrangère manquante (%s -> %s)", fk.Column, fk.RefTable),
+				Change:      AddForeignKey{Table: want.Name, ForeignKey: fk},
+			})
+		}
+
+		for _, idx := range want.Indexes {
+			if have.hasIndex(d, idx) {
+				continue
+			}
+			diffs = append(diffs, Difference{
+				Table:       want.Name,
+				Element:     idx.Name,
+				Description: fmt.Sprintf("index manquant (%s)", strings.Join(idx.Columns, ", ")),
+				Change:      CreateIndex{Index: idx},
+			})
+		}
+	}
+	return diffs
+}
+
+// Changes ordonne les changements corrigeant les écarts: les tables sont créées avant que
+// leurs clés étrangères ne soient ajoutées, afin qu'elles puissent se référencer.
+func Changes(diffs []Difference) []Change {
+	var tables, columns, foreignKeys []Change
+	for _, diff := range diffs {
+		switch c := diff.Change.(type) {
+		case CreateTable:
+			table := c.Table
+			for _, fk := range table.ForeignKeys {
+				foreignKeys = append(foreignKeys, AddForeignKey{Table: table.Name, ForeignKey: fk})
+			}
+			table.ForeignKeys = nil
+			tables = append(tables, CreateTable{Table: table})
+		case AddForeignKey:
+			foreignKeys = append(foreignKeys, c)
+		default:
+			columns = append(columns, c)
+		}
+	}
+	return append(append(tables, columns...), foreignKeys...)
+}
+
+func (t Table) foreignKey(column string) (ForeignKey, bool) {
+	for _, fk := range t.ForeignKeys {
+		if strings.EqualFold(fk.Column, column) {
+			return fk, true
+		}
+	}
+	return ForeignKey{}, false
+}
+
+// hasForeignKey compare les clés étrangères par colonne et table référencée, leur nom
+// dépendant de l'outil qui les a créées.
+func (t Table) hasForeignKey(want ForeignKey) bool {
+	for _, fk := range t.ForeignKeys {
+		if strings.EqualFold(fk.Column, want.Column) && strings.EqualFold(fk.RefTable, want.RefTable) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasIndex indique si les colonnes de l'index sont déjà indexées: par un index, une
+// contrainte d'unicité, la clé primaire ou, avec MySQL/MariaDB, une clé étrangère.
+func (t Table) hasIndex(d Dialect, want Index) bool {
+	for _, idx := range t.Indexes {
+		if sameColumns(idx.Columns, want.Columns) {
+			return true
+		}
+	}
+	if len(t.PrimaryKey) >= len(want.Columns) && sameColumns(t.PrimaryKey[:len(want.Columns)], want.Columns) {
+		return true
+	}
+	if len(want.Columns) != 1 {
+		return false
+	}
+	if c, ok := t.Column(want.Columns[0]); ok && c.Unique {
+		return true
+	}
+	if d == MySQL || d == MariaDB {
+		_, ok := t.foreignKey(want.Columns[0])
+		return ok
+	}
+	return false
+}
+
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package migration
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	const migrations = "CREATE TABLE `user` (\n" +
+		"  `id` BIGINT NOT NULL AUTO_INCREMENT,\n" +
+		"  `email` VARCHAR(80),\n" +
+		"  `legacy` VARCHAR(20),\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n" +
+		"CREATE TABLE `order` (\n" +
+		"  `id` BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,\n" +
+		"  `reference` VARCHAR(40) NOT NULL UNIQUE\n" +
+		") ENGINE=InnoDB;\n" +
+		"ALTER TABLE `order` ADD COLUMN `user_id` BIGINT NOT NULL REFERENCES `user`(`id`);\n" +
+		"CREATE TABLE order_line (order_id BIGINT NOT NULL, line_no INT NOT NULL, PRIMARY KEY (order_id, line_no)) ENGINE=InnoDB;\n"
+
+	user := Table{
+		Name: "user",
+		Columns: []Column{
+			{Name: "id", Type: "BIGINT", AutoIncrement: true},
+			{Name: "email", Type: "VARCHAR(120)"},
+			{Name: "created_at", Type: "DATETIME(6)", Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+	}
+	orderLine := Table{
+		Name: "order_line",
+		Columns: []Column{
+			{Name: "order_id", Type: "bigint"},
+			{Name: "line_no", Type: "int(11)"},
+		},
+		PrimaryKey:  []string{"order_id", "line_no"},
+		ForeignKeys: []ForeignKey{{Name: "fk_order_line_order_id", Column: "order_id", RefTable: "order", RefColumn: "id"}},
+		Indexes:     []Index{{Name: "idx_order_line_order_id", Table: "order_line", Columns: []string{"order_id"}}},
+	}
+	product := Table{Name: "product", Columns: []Column{{Name: "id", Type: "BIGINT", AutoIncrement: true}}, PrimaryKey: []string{"id"}}
+
+	cases := []struct {
+		name     string
+		dialect  Dialect
+		expected []Table
+		want     []string
+	}{
+		{
+			name:     "schéma à jour",
+			dialect:  MySQL,
+			expected: []Table{orderTable},
+		},
+		{
+			name:     "colonnes manquantes ou différentes",
+			dialect:  MySQL,
+			expected: []Table{user},
+			want: []string{
+				"user.email: type VARCHAR(80) au lieu de VARCHAR(120), NOT NULL attendu",
+				"user.created_at: colonne manquante",
+			},
+		},
+		{
+			name:     "clé composite, clé étrangère et table manquantes",
+			dialect:  Postgres,
+			expected: []Table{orderLine, product},
+			want: []string{
+				"order_line.fk_order_line_order_id: clé étrangère manquante (order_id -> order)",
+				"product.: table manquante",
+			},
+		},
+		{
+			name:     "index manquant hors MySQL",
+			dialect:  Postgres,
+			expected: []Table{orderTable},
+			want:     []string{"order.idx_order_user_id: index manquant (user_id)"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			schema := NewSchema()
+			schema.ReplaySQL(migrations)
+			var got []string
+			for _, diff := range Diff(c.dialect, c.expected, schema) {
+				got = append(got, diff.Table+"."+diff.Element+": "+diff.Description)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("écarts %q, attendu %q", got, c.want)
+			}
+		})
+	}
+}
+
+// TestChanges vérifie que les clés étrangères sont ajoutées après la création des tables et
+// qu'une fois rejoués, les changements font disparaître les écarts.
+func TestChanges(t *testing.T) {
+	user := Table{Name: "user", Columns: []Column{{Name: "id", Type: "BIGINT", AutoIncrement: true}}, PrimaryKey: []string{"id"}}
+	expected := []Table{orderTable, user}
+
+	schema := NewSchema()
+	changes := Changes(Diff(Postgres, expected, schema))
+	var kinds []string
+	for _, change := range changes {
+		kinds = append(kinds, reflect.TypeOf(change).Name())
+	}
+	if want := []string{"CreateTable", "CreateTable", "AddForeignKey"}; !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("changements %v, attendu %v", kinds, want)
+	}
+	if fks := changes[0].(CreateTable).Table.ForeignKeys; len(fks) != 0 {
+		t.Errorf("clés étrangères dans CREATE TABLE: %+v", fks)
+	}
+
+	schema.ReplaySQL(SQL(Postgres, changes))
+	if diffs := Diff(Postgres, expected, schema); len(diffs) != 0 {
+		t.Errorf("écarts restants après migration: %+v", diffs)
+	}
+}
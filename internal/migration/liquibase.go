@@ -0,0 +1,362 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LiquibaseChangelog génère un changelog Liquibase (YAML) contenant un changeSet
+// regroupant les changements.
+func LiquibaseChangelog(id, author string, changes []Change) string {
+	var b strings.Builder
+	b.WriteString("databaseChangeLog:\n")
+	b.WriteString("  - changeSet:\n")
+	fmt.Fprintf(&b, "      id: %s\n", id)
+	fmt.Fprintf(&b, "      author: %s\n", author)
+	b.WriteString("      changes:\n")
+
+	for _, change := range changes {
+		switch c := change.(type) {
+		case CreateTable:
+			writeCreateTable(&b, c.Table)
+			for _, fk := range c.Table.ForeignKeys {
+				writeForeignKey(&b, c.Table.Name, fk)
+			}
+			for _, idx := range c.Table.Indexes {
+				writeIndex(&b, idx)
+			}
+		case AddColumn:
+			b.WriteString("        - addColumn:\n")
+			fmt.Fprintf(&b, "            tableName: %s\n", c.Table)
+			b.WriteString("            columns:\n")
+			writeColumn(&b, c.Column, false, "              ")
+			if c.ForeignKey != nil {
+				writeForeignKey(&b, c.Table, *c.ForeignKey)
+			}
+		case CreateIndex:
+			writeIndex(&b, c.Index)
+		case AlterColumn:
+			writeAlterColumn(&b, c)
+		case AddForeignKey:
+			writeForeignKey(&b, c.Table, c.ForeignKey)
+		}
+	}
+	return b.String()
+}
+
+func writeCreateTable(b *strings.Builder, t Table) {
+	b.WriteString("        - createTable:\n")
+	fmt.Fprintf(b, "            tableName: %s\n", t.Name)
+	b.WriteString("            columns:\n")
+	for _, c := range t.Columns {
+		primary := len(t.PrimaryKey) == 1 && strings.EqualFold(t.PrimaryKey[0], c.Name)
+		writeColumn(b, c, primary, "              ")
+	}
+	// Une clé primaire composite (table de jointure) est ajoutée séparément
+	if len(t.PrimaryKey) > 1 {
+		b.WriteString("        - addPrimaryKey:\n")
+		fmt.Fprintf(b, "            tableName: %s\n", t.Name)
+		fmt.Fprintf(b, "            columnNames: %s\n", strings.Join(t.PrimaryKey, ", "))
+		fmt.Fprintf(b, "            constraintName: pk_%s\n", t.Name)
+	}
+}
+
+func writeColumn(b *strings.Builder, c Column, primaryKey bool, indent string) {
+	fmt.Fprintf(b, "%s- column:\n", indent)
+	fmt.Fprintf(b, "%s    name: %s\n", indent, c.Name)
+	fmt.Fprintf(b, "%s    type: %s\n", indent, c.Type)
+	if c.AutoIncrement {
+		fmt.Fprintf(b, "%s    autoIncrement: true\n", indent)
+	}
+	if c.Default != "" {
+		fmt.Fprintf(b, "%s    defaultValueComputed: %s\n", indent, c.Default)
+	}
+	if primaryKey || !c.Nullable || c.Unique {
+		fmt.Fprintf(b, "%s    constraints:\n", indent)
+		if primaryKey {
+			fmt.Fprintf(b, "%s      primaryKey: true\n", indent)
+		}
+		if primaryKey || !c.Nullable {
+			fmt.Fprintf(b, "%s      nullable: false\n", indent)
+		}
+		if c.Unique {
+			fmt.Fprintf(b, "%s      unique: true\n", indent)
+		}
+	}
+}
+
+func writeAlterColumn(b *strings.Builder, c AlterColumn) {
+	if !SameType(c.Column.Type, c.Previous.Type) {
+		b.WriteString("        - modifyDataType:\n")
+		fmt.Fprintf(b, "            tableName: %s\n", c.Table)
+		fmt.Fprintf(b, "            columnName: %s\n", c.Column.Name)
+		fmt.Fprintf(b, "            newDataType: %s\n", c.Column.Type)
+	}
+	if c.Column.Nullable != c.Previous.Nullable {
+		change := "addNotNullConstraint"
+		if c.Column.Nullable {
+			change = "dropNotNullConstraint"
+		}
+		fmt.Fprintf(b, "        - %s:\n", change)
+		fmt.Fprintf(b, "            tableName: %s\n", c.Table)
+		fmt.Fprintf(b, "            columnName: %s\n", c.Column.Name)
+		fmt.Fprintf(b, "            columnDataType: %s\n", c.Column.Type)
+	}
+}
+
+func writeForeignKey(b *strings.Builder, table string, fk ForeignKey) {
+	b.WriteString("        - addForeignKeyConstraint:\n")
+	fmt.Fprintf(b, "            baseTableName: %s\n", table)
+	fmt.Fprintf(b, "            baseColumnNames: %s\n", fk.Column)
+	fmt.Fprintf(b, "            referencedTableName: %s\n", fk.RefTable)
+	fmt.Fprintf(b, "            referencedColumnNames: %s\n", fk.RefColumn)
+	fmt.Fprintf(b, "            constraintName: %s\n", fk.Name)
+}
+
+func writeIndex(b *strings.Builder, idx Index) {
+	b.WriteString("        - createIndex:\n")
+	fmt.Fprintf(b, "            tableName: %s\n", idx.Table)
+	fmt.Fprintf(b, "            indexName: %s\n", idx.Name)
+	if idx.Unique {
+		b.WriteString("            unique: true\n")
+	}
+	b.WriteString("            columns:\n")
+	for _, c := range idx.Columns {
+		b.WriteString("              - column:\n")
+		fmt.Fprintf(b, "                  name: %s\n", c)
+	}
+}
+
+// ReplayLiquibase rejoue sur le schéma un changelog Liquibase (YAML ou SQL formaté) ainsi
+// que les changelogs qu'il inclut. root est la racine du classpath (src/main/resources),
+// à partir de laquelle sont résolus les chemins non relatifs.
+func (s *Schema) ReplayLiquibase(root, file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".sql":
+		s.ReplaySQL(string(data))
+		return nil
+	case ".yaml", ".yml":
+	default:
+		return fmt.Errorf("%s: seuls les changelogs YAML et SQL sont pris en charge", file)
+	}
+
+	var changelog struct {
+		DatabaseChangeLog []map[string]yamlNode `yaml:"databaseChangeLog"`
+	}
+	if err := yaml.Unmarshal(data, &changelog); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	resolve := func(n yamlNode, key string) string {
+		path := strings.TrimPrefix(n.str(key), "classpath:")
+		if n.bool("relativeToChangelogFile") {
+			return filepath.Join(filepath.Dir(file), path)
+		}
+		return filepath.Join(root, path)
+	}
+
+	for _, entry := range changelog.DatabaseChangeLog {
+		if include, ok := entry["include"]; ok {
+			if err := s.ReplayLiquibase(root, resolve(include, "file")); err != nil {
+				return err
+			}
+		}
+		if includeAll, ok := entry["includeAll"]; ok {
+			dir := resolve(includeAll, "path")
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				return err
+			}
+			// includeAll applique les fichiers par ordre alphabétique
+			for _, e := range entries {
+				switch strings.ToLower(filepath.Ext(e.Name())) {
+				case ".yaml", ".yml", ".sql":
+					if err := s.ReplayLiquibase(root, filepath.Join(dir, e.Name())); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if changeSet, ok := entry["changeSet"]; ok {
+			for _, change := range changeSet.list("changes") {
+				for kind, c := range change {
+					if err := s.replayLiquibaseChange(root, file, kind, c); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) replayLiquibaseChange(root, file, kind string, c yamlNode) error {
+	table := c.str("tableName")
+	switch kind {
+	case "createTable":
+		s.createTable(Table{Name: table})
+		for _, column := range c.list("columns") {
+			s.addLiquibaseColumn(table, column["column"])
+		}
+	case "addColumn":
+		for _, column := range c.list("columns") {
+			s.addLiquibaseColumn(table, column["column"])
+		}
+	case "dropColumn":
+		if name := c.str("columnName"); name != "" {
+			s.dropColumn(table, name)
+		}
+		for _, column := range c.list("columns") {
+			s.dropColumn(table, column["column"].str("name"))
+		}
+	case "dropTable":
+		s.dropTable(table)
+	case "renameTable":
+		s.renameTable(c.str("oldTableName"), c.str("newTableName"))
+	case "renameColumn":
+		s.renameColumn(table, c.str("oldColumnName"), c.str("newColumnName"))
+	case "modifyDataType":
+		if col := s.column(table, c.str("columnName")); col != nil {
+			col.Type = c.str("newDataType")
+		}
+	case "addNotNullConstraint", "dropNotNullConstraint":
+		if col := s.column(table, c.str("columnName")); col != nil {
+			col.Nullable = kind == "dropNotNullConstraint"
+		}
+	case "addPrimaryKey":
+		s.setPrimaryKey(table, splitNames(c.str("columnNames")))
+	case "addUniqueConstraint":
+		s.addUnique(table, c.str("constraintName"), splitNames(c.str("columnNames")))
+	case "addForeignKeyConstraint":
+		s.Apply(AddForeignKey{Table: c.str("baseTableName"), ForeignKey: ForeignKey{
+			Name:      c.str("constraintName"),
+			Column:    splitNames(c.str("baseColumnNames"))[0],
+			RefTable:  c.str("referencedTableName"),
+			RefColumn: splitNames(c.str("referencedColumnNames"))[0],
+		}})
+	case "dropForeignKeyConstraint":
+		s.dropConstraint(c.str("baseTableName"), c.str("constraintName"))
+	case "createIndex":
+		idx := Index{Name: c.str("indexName"), Table: table, Unique: c.bool("unique")}
+		for _, column := range c.list("columns") {
+			idx.Columns = append(idx.Columns, column["column"].str("name"))
+		}
+		s.Apply(CreateIndex{Index: idx})
+	case "dropIndex":
+		s.dropIndex(table, c.str("indexName"))
+	case "sql":
+		s.ReplaySQL(c.str("sql"))
+	case "sqlFile":
+		path := strings.TrimPrefix(c.str("path"), "classpath:")
+		if c.bool("relativeToChangelogFile") {
+			path = filepath.Join(filepath.Dir(file), path)
+		} else {
+			path = filepath.Join(root, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		s.ReplaySQL(string(data))
+	}
+	return nil
+}
+
+func (s *Schema) addLiquibaseColumn(table string, n yamlNode) {
+	constraints := n.node("constraints")
+	column := Column{
+		Name:          n.str("name"),
+		Type:          n.str("type"),
+		Nullable:      true,
+		Unique:        constraints.bool("unique"),
+		AutoIncrement: n.bool("autoIncrement"),
+		Default:       n.str("defaultValueComputed"),
+	}
+	if v, ok := constraints["nullable"].(bool); ok {
+		column.Nullable = v
+	}
+	var fk *ForeignKey
+	if ref := constraints.str("referencedTableName"); ref != "" {
+		refColumn := constraints.str("referencedColumnNames")
+		if refColumn == "" {
+			refColumn = "id"
+		}
+		fk = &ForeignKey{Name: constraints.str("foreignKeyName"), Column: column.Name, RefTable: ref, RefColumn: refColumn}
+	} else if ref := constraints.str("references"); ref != "" {
+		// Ancienne syntaxe: references: table(colonne)
+		refTable, refColumn, _ := strings.Cut(strings.TrimSuffix(ref, ")"), "(")
+		fk = &ForeignKey{Name: constraints.str("foreignKeyName"), Column: column.Name, RefTable: refTable, RefColumn: refColumn}
+	}
+	if constraints.bool("primaryKey") {
+		column.Nullable = false
+	}
+	s.Apply(AddColumn{Table: table, Column: column, ForeignKey: fk})
+	if constraints.bool("primaryKey") {
+		t := s.Table(table)
+		s.setPrimaryKey(table, append(t.PrimaryKey, column.Name))
+	}
+}
+
+// yamlNode est un objet YAML décodé sans schéma.
+type yamlNode map[string]interface{}
+
+func (n yamlNode) str(key string) string {
+	switch v := n[key].(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (n yamlNode) bool(key string) bool {
+	v, _ := n[key].(bool)
+	return v
+}
+
+func (n yamlNode) node(key string) yamlNode {
+	return asNode(n[key])
+}
+
+func (n yamlNode) list(key string) []map[string]yamlNode {
+	items, _ := n[key].([]interface{})
+	var nodes []map[string]yamlNode
+	for _, item := range items {
+		node := map[string]yamlNode{}
+		for k, v := range asNode(item) {
+			node[k] = asNode(v)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// asNode convertit un objet décodé: yaml.v3 reprend pour les objets imbriqués le type de
+// map de la cible (yamlNode) plutôt que map[string]interface{}.
+func asNode(v interface{}) yamlNode {
+	switch m := v.(type) {
+	case yamlNode:
+		return m
+	case map[string]interface{}:
+		return m
+	}
+	return nil
+}
+
+func splitNames(names string) []string {
+	parts := strings.Split(names, ",")
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+	}
+	return parts
+}
@@ -0,0 +1,571 @@
+package migration
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ReplaySQL rejoue sur le schéma les instructions DDL d'un script SQL (CREATE/ALTER/DROP
+// TABLE, CREATE/DROP INDEX...). Les autres instructions (INSERT, UPDATE, fonctions...)
+// sont ignorées.
+func (s *Schema) ReplaySQL(script string) {
+	for _, statement := range splitStatements(tokenize(script)) {
+		p := &sqlParser{tokens: statement}
+		switch {
+		case p.accept("CREATE"):
+			p.accept("OR", "REPLACE")
+			p.accept("GLOBAL")
+			p.accept("LOCAL")
+			p.accept("TEMPORARY")
+			switch {
+			case p.accept("TABLE"):
+				s.createTableStatement(p)
+			case p.accept("UNIQUE", "INDEX"):
+				s.createIndexStatement(p, true)
+			case p.accept("INDEX"):
+				s.createIndexStatement(p, false)
+			}
+		case p.accept("ALTER", "TABLE"):
+			s.alterTableStatement(p)
+		case p.accept("DROP", "TABLE"):
+			p.accept("IF", "EXISTS")
+			for !p.done() {
+				s.dropTable(p.ident())
+				p.accept(",")
+				if p.accept("CASCADE") || p.accept("RESTRICT") {
+					break
+				}
+			}
+		case p.accept("DROP", "INDEX"):
+			p.accept("CONCURRENTLY")
+			p.accept("IF", "EXISTS")
+			name := p.ident()
+			table := ""
+			if p.accept("ON") {
+				table = p.ident()
+			}
+			s.dropIndex(table, name)
+		case p.accept("RENAME", "TABLE"):
+			oldName := p.ident()
+			p.accept("TO")
+			s.renameTable(oldName, p.ident())
+		}
+	}
+}
+
+func (s *Schema) createTableStatement(p *sqlParser) {
+	p.accept("IF", "NOT", "EXISTS")
+	table := Table{Name: p.ident()}
+	if !p.accept("(") {
+		// CREATE TABLE ... AS SELECT: structure inconnue
+		return
+	}
+	s.createTable(table)
+	for _, element := range p.elements() {
+		s.tableElement(table.Name, element)
+	}
+}
+
+// tableElement traite une colonne ou une contrainte de table.
+func (s *Schema) tableElement(table string, p *sqlParser) {
+	if s.tableConstraint(table, p) {
+		return
+	}
+	column, primaryKey, fk := p.columnDefinition(table)
+	s.Apply(AddColumn{Table: table, Column: column, ForeignKey: fk})
+	if primaryKey {
+		s.setPrimaryKey(table, []string{column.Name})
+	}
+}
+
+// tableConstraint traite une contrainte de table (PRIMARY KEY, FOREIGN KEY, UNIQUE, INDEX...)
+// et indique si l'élément en était une.
+func (s *Schema) tableConstraint(table string, p *sqlParser) bool {
+	name := ""
+	if p.accept("CONSTRAINT") {
+		name = p.ident()
+	}
+	switch {
+	case p.accept("PRIMARY", "KEY"):
+		s.setPrimaryKey(table, p.identList())
+	case p.accept("FOREIGN", "KEY"):
+		columns := p.identList()
+		p.accept("REFERENCES")
+		refTable := p.ident()
+		refColumns := []string{"id"}
+		if p.peek() == "(" {
+			refColumns = p.identList()
+		}
+		if len(columns) == 0 {
+			return true
+		}
+		if name == "" {
+			name = ForeignKeyName(table, columns[0])
+		}
+		s.Apply(AddForeignKey{Table: table, ForeignKey: ForeignKey{Name: name, Column: columns[0], RefTable: refTable, RefColumn: refColumns[0]}})
+	case p.accept("UNIQUE"):
+		if !p.accept("KEY") {
+			p.accept("INDEX")
+		}
+		if p.peek() != "(" {
+			name = p.ident()
+		}
+		s.addUnique(table, name, p.identList())
+	case p.accept("KEY"), p.accept("INDEX"):
+		if p.peek() != "(" {
+			name = p.ident()
+		}
+		columns := p.identList()
+		if name == "" {
+			name = IndexName(table, columns...)
+		}
+		s.Apply(CreateIndex{Index: Index{Name: name, Table: table, Columns: columns}})
+	case p.accept("CHECK"):
+	default:
+		return name != ""
+	}
+	return true
+}
+
+func (s *Schema) createIndexStatement(p *sqlParser, unique bool) {
+	p.accept("CONCURRENTLY")
+	p.accept("IF", "NOT", "EXISTS")
+	name := ""
+	if !p.accept("ON") {
+		name = p.ident()
+		p.accept("ON")
+	}
+	p.accept("ONLY")
+	table := p.ident()
+	if p.accept("USING") {
+		p.next()
+	}
+	columns := p.identList()
+	if name == "" {
+		name = IndexName(table, columns...)
+	}
+	s.Apply(CreateIndex{Index: Index{Name: name, Table: table, Columns: columns, Unique: unique}})
+}
+
+func (s *Schema) alterTableStatement(p *sqlParser) {
+	p.accept("IF", "EXISTS")
+	p.accept("ONLY")
+	table := p.ident()
+
+	for _, action := range p.actions() {
+		switch {
+		case action.accept("ADD"):
+			if s.tableConstraint(table, action) {
+				continue
+			}
+			action.accept("COLUMN")
+			action.accept("IF", "NOT", "EXISTS")
+			s.tableElement(table, action)
+		case action.accept("DROP", "CONSTRAINT"), action.accept("DROP", "FOREIGN", "KEY"), action.accept("DROP", "INDEX"), action.accept("DROP", "KEY"):
+			action.accept("IF", "EXISTS")
+			s.dropConstraint(table, action.ident())
+		case action.accept("DROP", "PRIMARY", "KEY"):
+			s.setPrimaryKey(table, nil)
+		case action.accept("DROP"):
+			action.accept("COLUMN")
+			action.accept("IF", "EXISTS")
+			s.dropColumn(table, action.ident())
+		case action.accept("ALTER"):
+			action.accept("COLUMN")
+			s.alterColumnAction(table, action)
+		case action.accept("MODIFY"):
+			action.accept("COLUMN")
+			column, _, _ := action.columnDefinition(table)
+			s.replaceColumn(table, column.Name, column)
+		case action.accept("CHANGE"):
+			action.accept("COLUMN")
+			oldName := action.ident()
+			column, _, _ := action.columnDefinition(table)
+			s.replaceColumn(table, oldName, column)
+		case action.accept("RENAME", "COLUMN"):
+			oldName := action.ident()
+			action.accept("TO")
+			s.renameColumn(table, oldName, action.ident())
+		case action.accept("RENAME"):
+			if !action.accept("TO") {
+				action.accept("AS")
+			}
+			newName := action.ident()
+			s.renameTable(table, newName)
+			table = newName
+		}
+	}
+}
+
+func (s *Schema) alterColumnAction(table string, p *sqlParser) {
+	name := p.ident()
+	col := s.column(table, name)
+	if col == nil {
+		return
+	}
+	switch {
+	case p.accept("SET", "DATA", "TYPE"), p.accept("TYPE"):
+		col.Type = p.columnType()
+	case p.accept("SET", "NOT", "NULL"):
+		col.Nullable = false
+	case p.accept("DROP", "NOT", "NULL"), p.accept("SET", "NULL"):
+		col.Nullable = true
+	case p.accept("SET", "DEFAULT"):
+		col.Default = p.rest()
+	case p.accept("DROP", "DEFAULT"):
+		col.Default = ""
+	case p.accept("RENAME", "TO"):
+		s.renameColumn(table, name, p.ident())
+	case p.accept("SET"), p.accept("RESTART"), p.accept("ADD"), p.accept("DROP"):
+		// Options d'identité ou de statistiques sans effet sur la structure
+	default:
+		// H2: ALTER COLUMN nom type [NOT NULL]
+		column, _, _ := p.columnDefinitionNamed(table, name)
+		*col = column
+	}
+}
+
+// ==================== ANALYSE DES INSTRUCTIONS ====================
+
+type sqlToken struct {
+	text   string
+	quoted bool
+}
+
+type sqlParser struct {
+	tokens []sqlToken
+	pos    int
+}
+
+// columnKeywords terminent le type d'une colonne.
+var columnKeywords = map[string]bool{
+	"NOT": true, "NULL": true, "PRIMARY": true, "UNIQUE": true, "DEFAULT": true,
+	"REFERENCES": true, "GENERATED": true, "AUTO_INCREMENT": true, "AUTOINCREMENT": true,
+	"IDENTITY": true, "CONSTRAINT": true, "CHECK": true, "COLLATE": true, "COMMENT": true,
+	"ON": true, "AS": true, "FIRST": true, "AFTER": true, "USING": true,
+}
+
+func (p *sqlParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// peek renvoie le prochain mot (en majuscules) ou signe de ponctuation.
+func (p *sqlParser) peek() string {
+	if p.done() || p.tokens[p.pos].quoted {
+		return ""
+	}
+	return strings.ToUpper(p.tokens[p.pos].text)
+}
+
+func (p *sqlParser) next() sqlToken {
+	if p.done() {
+		return sqlToken{}
+	}
+	p.pos++
+	return p.tokens[p.pos-1]
+}
+
+// accept consomme la séquence de mots-clés si elle suit.
+func (p *sqlParser) accept(words ...string) bool {
+	for i, w := range words {
+		t := p.pos + i
+		if t >= len(p.tokens) || p.tokens[t].quoted || !strings.EqualFold(p.tokens[t].text, w) {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+// ident lit un identifiant, éventuellement qualifié (schema.table), sans son schéma.
+func (p *sqlParser) ident() string {
+	t := p.next()
+	name := t.text
+	if !t.quoted {
+		name = strings.ToLower(name[strings.LastIndex(name, ".")+1:])
+	}
+	for p.accept(".") {
+		name = p.ident()
+	}
+	return name
+}
+
+// identList lit une liste d'identifiants entre parenthèses, en ignorant les options
+// (ASC, DESC, longueur de préfixe...) et les expressions.
+func (p *sqlParser) identList() []string {
+	if !p.accept("(") {
+		return nil
+	}
+	var names []string
+	for _, element := range p.elements() {
+		if !element.done() && element.peek() != "(" {
+			names = append(names, element.ident())
+		}
+	}
+	return names
+}
+
+// elements lit un groupe entre parenthèses (la parenthèse ouvrante étant consommée)
+// et renvoie ses éléments séparés par des virgules de premier niveau.
+func (p *sqlParser) elements() []*sqlParser {
+	var elements []*sqlParser
+	start, depth := p.pos, 0
+	for !p.done() {
+		t := p.next()
+		if t.quoted {
+			continue
+		}
+		switch t.text {
+		case "(":
+			depth++
+		case ")":
+			if depth == 0 {
+				elements = append(elements, &sqlParser{tokens: p.tokens[start : p.pos-1]})
+				return elements
+			}
+			depth--
+		case ",":
+			if depth == 0 {
+				elements = append(elements, &sqlParser{tokens: p.tokens[start : p.pos-1]})
+				start = p.pos
+			}
+		}
+	}
+	return append(elements, &sqlParser{tokens: p.tokens[start:]})
+}
+
+// actions découpe la suite d'une instruction ALTER TABLE en actions séparées par des virgules.
+func (p *sqlParser) actions() []*sqlParser {
+	rest := &sqlParser{tokens: append(append([]sqlToken{}, p.tokens[p.pos:]...), sqlToken{text: ")"})}
+	return rest.elements()
+}
+
+func (p *sqlParser) clone() *sqlParser {
+	return &sqlParser{tokens: p.tokens, pos: p.pos}
+}
+
+// skipGroup ignore un groupe entre parenthèses s'il suit.
+func (p *sqlParser) skipGroup() string {
+	if p.peek() != "(" {
+		return ""
+	}
+	p.next()
+	var parts []string
+	for _, element := range p.elements() {
+		parts = append(parts, element.rest())
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// rest renvoie le texte des jetons restants.
+func (p *sqlParser) rest() string {
+	var b strings.Builder
+	for !p.done() {
+		t := p.next()
+		if b.Len() > 0 && t.text != "(" && t.text != ")" && !strings.HasSuffix(b.String(), "(") {
+			b.WriteString(" ")
+		}
+		b.WriteString(t.text)
+	}
+	return b.String()
+}
+
+// columnType lit le type d'une colonne (DOUBLE PRECISION, VARCHAR(255), NUMERIC(19, 2)...).
+func (p *sqlParser) columnType() string {
+	var words []string
+	for !p.done() {
+		word := p.peek()
+		if word == "" || word == "," || word == ")" {
+			break
+		}
+		if len(words) > 0 && (columnKeywords[word] || word == "CHARACTER" && p.clone().accept("CHARACTER", "SET")) {
+			break
+		}
+		if word == "(" {
+			words[len(words)-1] += p.skipGroup()
+			continue
+		}
+		words = append(words, p.next().text)
+	}
+	return strings.ToUpper(strings.Join(words, " "))
+}
+
+// columnDefinition lit la définition d'une colonne et ses contraintes.
+func (p *sqlParser) columnDefinition(table string) (Column, bool, *ForeignKey) {
+	return p.columnDefinitionNamed(table, p.ident())
+}
+
+func (p *sqlParser) columnDefinitionNamed(table, name string) (Column, bool, *ForeignKey) {
+	column := Column{Name: name, Nullable: true}
+	column.Type = p.columnType()
+	if strings.HasSuffix(column.Type, "SERIAL") {
+		column.Type = NormalizeType(column.Type)
+		column.AutoIncrement = true
+		column.Nullable = false
+	}
+
+	primaryKey := false
+	var fk *ForeignKey
+	for !p.done() {
+		switch {
+		case p.accept("NOT", "NULL"):
+			column.Nullable = false
+		case p.accept("NULL"):
+			column.Nullable = true
+		case p.accept("PRIMARY", "KEY"):
+			primaryKey = true
+			column.Nullable = false
+		case p.accept("UNIQUE"):
+			p.accept("KEY")
+			column.Unique = true
+		case p.accept("DEFAULT"):
+			column.Default = p.defaultValue()
+		case p.accept("REFERENCES"):
+			ref := ForeignKey{Name: ForeignKeyName(table, name), Column: name, RefTable: p.ident(), RefColumn: "id"}
+			if p.peek() == "(" {
+				if columns := p.identList(); len(columns) > 0 {
+					ref.RefColumn = columns[0]
+				}
+			}
+			fk = &ref
+		case p.accept("GENERATED"), p.accept("AUTO_INCREMENT"), p.accept("AUTOINCREMENT"), p.accept("IDENTITY"):
+			column.AutoIncrement = true
+			column.Nullable = false
+			for !p.done() && (p.accept("ALWAYS") || p.accept("BY") || p.accept("DEFAULT") || p.accept("AS") || p.accept("IDENTITY")) {
+			}
+			p.skipGroup()
+		case p.accept("ON", "UPDATE"), p.accept("ON", "DELETE"):
+			if !p.accept("SET", "NULL") && !p.accept("SET", "DEFAULT") && !p.accept("NO", "ACTION") {
+				p.next()
+			}
+		case p.accept("CONSTRAINT"), p.accept("COLLATE"), p.accept("COMMENT"), p.accept("CHARACTER", "SET"):
+			p.next()
+		default:
+			p.next()
+			p.skipGroup()
+		}
+	}
+	return column, primaryKey, fk
+}
+
+// defaultValue lit l'expression d'une clause DEFAULT.
+func (p *sqlParser) defaultValue() string {
+	var parts []string
+	for !p.done() {
+		word := p.peek()
+		if columnKeywords[word] && word != "NULL" || word == "," || word == ")" {
+			break
+		}
+		if word == "(" {
+			if len(parts) == 0 {
+				parts = append(parts, "")
+			}
+			parts[len(parts)-1] += p.skipGroup()
+			continue
+		}
+		parts = append(parts, p.next().text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ==================== DÉCOUPAGE LEXICAL ====================
+
+// tokenize découpe un script SQL en jetons, en ignorant les commentaires.
+func tokenize(script string) []sqlToken {
+	var tokens []sqlToken
+	for i := 0; i < len(script); {
+		c := script[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(script[i:], "--"), c == '#':
+			i += lineEnd(script[i:])
+		case strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				return tokens
+			}
+			i += end + 4
+		case c == '\'':
+			j := i + 1
+			for j < len(script) && (script[j] != '\'' || strings.HasPrefix(script[j:], "''")) {
+				if script[j] == '\'' {
+					j++
+				}
+				j++
+			}
+			j = minIndex(j+1, len(script))
+			tokens = append(tokens, sqlToken{text: script[i:j]})
+			i = j
+		case c == '"' || c == '`' || c == '[':
+			closing := map[byte]string{'"': `"`, '`': "`", '[': "]"}[c]
+			end := strings.Index(script[i+1:], closing)
+			if end < 0 {
+				end = len(script) - i - 1
+			}
+			tokens = append(tokens, sqlToken{text: script[i+1 : i+1+end], quoted: true})
+			i += end + 2
+		case c == '$' && dollarTagRegexp.MatchString(script[i:]):
+			// Corps de fonction PostgreSQL: $$ ... $$ ou $tag$ ... $tag$
+			tag := dollarTagRegexp.FindString(script[i:])
+			end := strings.Index(script[i+len(tag):], tag)
+			if end < 0 {
+				return tokens
+			}
+			j := i + len(tag) + end + len(tag)
+			tokens = append(tokens, sqlToken{text: script[i:j]})
+			i = j
+		case isWordByte(c):
+			j := i
+			for j < len(script) && isWordByte(script[j]) {
+				j++
+			}
+			tokens = append(tokens, sqlToken{text: script[i:j]})
+			i = j
+		default:
+			tokens = append(tokens, sqlToken{text: string(c)})
+			i++
+		}
+	}
+	return tokens
+}
+
+var dollarTagRegexp = regexp.MustCompile(`^\$\w*\$`)
+
+func lineEnd(s string) int {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return i
+	}
+	return len(s)
+}
+
+func minIndex(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// isWordByte accepte les lettres (y compris non ASCII), chiffres, _, $ et le point
+// des noms qualifiés.
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c >= 0x80
+}
+
+// splitStatements regroupe les jetons par instruction.
+func splitStatements(tokens []sqlToken) [][]sqlToken {
+	var statements [][]sqlToken
+	start := 0
+	for i, t := range tokens {
+		if t.text == ";" && !t.quoted {
+			if i > start {
+				statements = append(statements, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		statements = append(statements, tokens[start:])
+	}
+	return statements
+}
@@ -0,0 +1,253 @@
+package migration
+
+import (
+	"strings"
+)
+
+// Schema est l'état d'une base de données reconstitué en rejouant des migrations.
+type Schema struct {
+	tables []*Table
+}
+
+// NewSchema crée un schéma vide.
+func NewSchema() *Schema {
+	return &Schema{}
+}
+
+// Table renvoie la table portant le nom donné, nil si elle n'existe pas.
+func (s *Schema) Table(name string) *Table {
+	for _, t := range s.tables {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+	return nil
+}
+
+// Tables renvoie les tables du schéma dans leur ordre de création.
+func (s *Schema) Tables() []Table {
+	tables := make([]Table, len(s.tables))
+	for i, t := range s.tables {
+		tables[i] = *t
+	}
+	return tables
+}
+
+// Apply rejoue des changements sur le schéma.
+func (s *Schema) Apply(changes ...Change) {
+	for _, change := range changes {
+		switch c := change.(type) {
+		case CreateTable:
+			s.createTable(c.Table)
+		case AddColumn:
+			if t := s.Table(c.Table); t != nil {
+				t.Columns = append(t.Columns, c.Column)
+				if c.ForeignKey != nil {
+					t.ForeignKeys = append(t.ForeignKeys, *c.ForeignKey)
+				}
+			}
+		case CreateIndex:
+			if t := s.Table(c.Index.Table); t != nil {
+				t.Indexes = append(t.Indexes, c.Index)
+			}
+		case AlterColumn:
+			if col := s.column(c.Table, c.Column.Name); col != nil {
+				col.Type = c.Column.Type
+				col.Nullable = c.Column.Nullable
+			}
+		case AddForeignKey:
+			if t := s.Table(c.Table); t != nil {
+				t.ForeignKeys = append(t.ForeignKeys, c.ForeignKey)
+			}
+		}
+	}
+}
+
+func (s *Schema) createTable(t Table) {
+	s.dropTable(t.Name)
+	table := Table{
+		Name:        t.Name,
+		Columns:     append([]Column(nil), t.Columns...),
+		PrimaryKey:  append([]string(nil), t.PrimaryKey...),
+		ForeignKeys: append([]ForeignKey(nil), t.ForeignKeys...),
+		Indexes:     append([]Index(nil), t.Indexes...),
+	}
+	for i := range table.Indexes {
+		table.Indexes[i].Table = t.Name
+	}
+	s.tables = append(s.tables, &table)
+}
+
+func (s *Schema) dropTable(name string) {
+	for i, t := range s.tables {
+		if strings.EqualFold(t.Name, name) {
+			s.tables = append(s.tables[:i], s.tables[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Schema) renameTable(oldName, newName string) {
+	for _, t := range s.tables {
+		if strings.EqualFold(t.Name, oldName) {
+			t.Name = newName
+			for i := range t.Indexes {
+				t.Indexes[i].Table = newName
+			}
+		}
+		for i := range t.ForeignKeys {
+			if strings.EqualFold(t.ForeignKeys[i].RefTable, oldName) {
+				t.ForeignKeys[i].RefTable = newName
+			}
+		}
+	}
+}
+
+func (s *Schema) column(table, name string) *Column {
+	t := s.Table(table)
+	if t == nil {
+		return nil
+	}
+	for i := range t.Columns {
+		if strings.EqualFold(t.Columns[i].Name, name) {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// replaceColumn remplace la définition d'une colonne en conservant sa position.
+func (s *Schema) replaceColumn(table, name string, c Column) {
+	if col := s.column(table, name); col != nil {
+		s.renameColumn(table, name, c.Name)
+		*col = c
+	}
+}
+
+func (s *Schema) dropColumn(table, name string) {
+	t := s.Table(table)
+	if t == nil {
+		return
+	}
+	var columns []Column
+	for _, c := range t.Columns {
+		if !strings.EqualFold(c.Name, name) {
+			columns = append(columns, c)
+		}
+	}
+	t.Columns = columns
+
+	var foreignKeys []ForeignKey
+	for _, fk := range t.ForeignKeys {
+		if !strings.EqualFold(fk.Column, name) {
+			foreignKeys = append(foreignKeys, fk)
+		}
+	}
+	t.ForeignKeys = foreignKeys
+
+	var indexes []Index
+	for _, idx := range t.Indexes {
+		if !containsFold(idx.Columns, name) {
+			indexes = append(indexes, idx)
+		}
+	}
+	t.Indexes = indexes
+}
+
+func (s *Schema) renameColumn(table, oldName, newName string) {
+	t := s.Table(table)
+	if t == nil || strings.EqualFold(oldName, newName) {
+		return
+	}
+	for i := range t.Columns {
+		if strings.EqualFold(t.Columns[i].Name, oldName) {
+			t.Columns[i].Name = newName
+		}
+	}
+	renameIn(t.PrimaryKey, oldName, newName)
+	for i := range t.ForeignKeys {
+		if strings.EqualFold(t.ForeignKeys[i].Column, oldName) {
+			t.ForeignKeys[i].Column = newName
+		}
+	}
+	for _, idx := range t.Indexes {
+		renameIn(idx.Columns, oldName, newName)
+	}
+}
+
+func (s *Schema) setPrimaryKey(table string, columns []string) {
+	t := s.Table(table)
+	if t == nil {
+		return
+	}
+	t.PrimaryKey = columns
+	for _, name := range columns {
+		if col := s.column(table, name); col != nil {
+			col.Nullable = false
+		}
+	}
+}
+
+// addUnique enregistre une contrainte d'unicité: sur une colonne elle est portée par la
+// colonne, sur plusieurs colonnes par un index unique.
+func (s *Schema) addUnique(table, name string, columns []string) {
+	if len(columns) == 1 {
+		if col := s.column(table, columns[0]); col != nil {
+			col.Unique = true
+		}
+		return
+	}
+	if name == "" {
+		name = "uk_" + table + "_" + strings.Join(columns, "_")
+	}
+	s.Apply(CreateIndex{Index: Index{Name: name, Table: table, Columns: columns, Unique: true}})
+}
+
+// dropConstraint supprime une clé étrangère ou un index nommé d'une table.
+func (s *Schema) dropConstraint(table, name string) {
+	t := s.Table(table)
+	if t == nil {
+		return
+	}
+	var foreignKeys []ForeignKey
+	for _, fk := range t.ForeignKeys {
+		if !strings.EqualFold(fk.Name, name) {
+			foreignKeys = append(foreignKeys, fk)
+		}
+	}
+	t.ForeignKeys = foreignKeys
+	s.dropIndex(table, name)
+}
+
+// dropIndex supprime un index, cherché dans toutes les tables si table est vide.
+func (s *Schema) dropIndex(table, name string) {
+	for _, t := range s.tables {
+		if table != "" && !strings.EqualFold(t.Name, table) {
+			continue
+		}
+		var indexes []Index
+		for _, idx := range t.Indexes {
+			if !strings.EqualFold(idx.Name, name) {
+				indexes = append(indexes, idx)
+			}
+		}
+		t.Indexes = indexes
+	}
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func renameIn(values []string, oldName, newName string) {
+	for i, v := range values {
+		if strings.EqualFold(v, oldName) {
+			values[i] = newName
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package migration
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReplaySQL(t *testing.T) {
+	cases := []struct {
+		name   string
+		script string
+		want   []Table
+	}{
+		{
+			name: "identifiants entre guillemets et REFERENCES en ligne",
+			script: `CREATE TABLE "user" ("id" BIGSERIAL PRIMARY KEY, "email" VARCHAR(120) NOT NULL UNIQUE);
+CREATE TABLE "order" (
+  "id" BIGSERIAL PRIMARY KEY,
+  "user_id" BIGINT NOT NULL REFERENCES "user"("id"),
+  total DECIMAL(10,2) DEFAULT 0
+);`,
+			want: []Table{
+				{
+					Name: "user",
+					Columns: []Column{
+						{Name: "id", Type: "BIGINT", AutoIncrement: true},
+						{Name: "email", Type: "VARCHAR(120)", Unique: true},
+					},
+					PrimaryKey: []string{"id"},
+				},
+				{
+					Name: "order",
+					Columns: []Column{
+						{Name: "id", Type: "BIGINT", AutoIncrement: true},
+						{Name: "user_id", Type: "BIGINT"},
+						{Name: "total", Type: "DECIMAL(10, 2)", Nullable: true, Default: "0"},
+					},
+					PrimaryKey:  []string{"id"},
+					ForeignKeys: []ForeignKey{{Name: "fk_order_user_id", Column: "user_id", RefTable: "user", RefColumn: "id"}},
+				},
+			},
+		},
+		{
+			name: "identifiants entre backticks et clauses ENGINE",
+			script: "CREATE TABLE `customer` (\n" +
+				"  `id` BIGINT NOT NULL AUTO_INCREMENT,\n" +
+				"  `name` VARCHAR(80) NOT NULL,\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY `idx_customer_name` (`name`)\n" +
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;\n" +
+				"CREATE TABLE `invoice` (`id` BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY, `customer_id` BIGINT,\n" +
+				"  CONSTRAINT `fk_invoice_customer` FOREIGN KEY (`customer_id`) REFERENCES `customer` (`id`)) ENGINE = InnoDB;",
+			want: []Table{
+				{
+					Name: "customer",
+					Columns: []Column{
+						{Name: "id", Type: "BIGINT", AutoIncrement: true},
+						{Name: "name", Type: "VARCHAR(80)"},
+					},
+					PrimaryKey: []string{"id"},
+					Indexes:    []Index{{Name: "idx_customer_name", Table: "customer", Columns: []string{"name"}}},
+				},
+				{
+					Name: "invoice",
+					Columns: []Column{
+						{Name: "id", Type: "BIGINT", AutoIncrement: true},
+						{Name: "customer_id", Type: "BIGINT", Nullable: true},
+					},
+					PrimaryKey:  []string{"id"},
+					ForeignKeys: []ForeignKey{{Name: "fk_invoice_customer", Column: "customer_id", RefTable: "customer", RefColumn: "id"}},
+				},
+			},
+		},
+		{
+			name: "clé primaire composite",
+			script: `CREATE TABLE order_line (
+  order_id BIGINT NOT NULL,
+  line_no INT NOT NULL,
+  quantity INTEGER NOT NULL,
+  PRIMARY KEY (order_id, line_no),
+  FOREIGN KEY (order_id) REFERENCES orders (id)
+);`,
+			want: []Table{
+				{
+					Name: "order_line",
+					Columns: []Column{
+						{Name: "order_id", Type: "BIGINT"},
+						{Name: "line_no", Type: "INT"},
+						{Name: "quantity", Type: "INTEGER"},
+					},
+					PrimaryKey:  []string{"order_id", "line_no"},
+					ForeignKeys: []ForeignKey{{Name: "fk_order_line_order_id", Column: "order_id", RefTable: "orders", RefColumn: "id"}},
+				},
+			},
+		},
+		{
+			name: "ALTER TABLE ... ADD COLUMN",
+			script: `CREATE TABLE product (id BIGINT PRIMARY KEY);
+CREATE TABLE stock (id BIGINT PRIMARY KEY, quantity INT);
+ALTER TABLE stock ADD COLUMN product_id BIGINT NOT NULL REFERENCES product(id);
+ALTER TABLE stock ADD COLUMN IF NOT EXISTS "location" VARCHAR(40), ADD warehouse VARCHAR(20) DEFAULT 'main';
+ALTER TABLE stock DROP COLUMN quantity;`,
+			want: []Table{
+				{
+					Name:       "product",
+					Columns:    []Column{{Name: "id", Type: "BIGINT"}},
+					PrimaryKey: []string{"id"},
+				},
+				{
+					Name: "stock",
+					Columns: []Column{
+						{Name: "id", Type: "BIGINT"},
+						{Name: "product_id", Type: "BIGINT"},
+						{Name: "location", Type: "VARCHAR(40)", Nullable: true},
+						{Name: "warehouse", Type: "VARCHAR(20)", Nullable: true, Default: "'main'"},
+					},
+					PrimaryKey:  []string{"id"},
+					ForeignKeys: []ForeignKey{{Name: "fk_stock_product_id", Column: "product_id", RefTable: "product", RefColumn: "id"}},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			schema := NewSchema()
+			schema.ReplaySQL(c.script)
+			got := schema.Tables()
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("tables:\n%+v\nattendu:\n%+v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestApply vérifie que le schéma rejoué à partir du SQL généré redonne les tables de départ.
+func TestApply(t *testing.T) {
+	for _, d := range Dialects() {
+		t.Run(string(d), func(t *testing.T) {
+			schema := NewSchema()
+			schema.Apply(CreateTable{Table: orderTable})
+			replayed := NewSchema()
+			replayed.ReplaySQL(SQL(d, []Change{CreateTable{Table: orderTable}}))
+
+			want, got := schema.Table("order"), replayed.Table("order")
+			if got == nil {
+				t.Fatal("table order absente du schéma rejoué")
+			}
+			if !reflect.DeepEqual(got.PrimaryKey, want.PrimaryKey) || !reflect.DeepEqual(got.ForeignKeys, want.ForeignKeys) ||
+				!reflect.DeepEqual(got.Indexes, want.Indexes) {
+				t.Errorf("table rejouée %+v, attendu %+v", *got, *want)
+			}
+			for _, column := range want.Columns {
+				c, ok := got.Column(column.Name)
+				if !ok || !SameType(c.Type, column.Type) || c.Nullable != column.Nullable {
+					t.Errorf("colonne %s rejouée %+v, attendu %+v", column.Name, c, column)
+				}
+			}
+		})
+	}
+}
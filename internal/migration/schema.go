@@ -0,0 +1,262 @@
+// Package migration : modèle de schéma relationnel et génération de migrations Flyway / Liquibase
+package migration
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Dialect désigne la base de données cible des migrations SQL.
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	MySQL    Dialect = "mysql"
+	MariaDB  Dialect = "mariadb"
+	H2       Dialect = "h2"
+)
+
+// Dialects renvoie les dialectes pris en charge.
+func Dialects() []Dialect {
+	return []Dialect{Postgres, MySQL, MariaDB, H2}
+}
+
+// ParseDialect convertit un nom de dialecte (postgres, postgresql, mysql...) en Dialect.
+func ParseDialect(name string) (Dialect, error) {
+	switch strings.ToLower(name) {
+	case "postgres", "postgresql", "pg":
+		return Postgres, nil
+	case "mysql":
+		return MySQL, nil
+	case "mariadb":
+		return MariaDB, nil
+	case "h2":
+		return H2, nil
+	}
+	return "", fmt.Errorf("dialecte inconnu: %s (valeurs possibles: postgres, mysql, mariadb, h2)", name)
+}
+
+// Column est une colonne de table.
+type Column struct {
+	Name          string
+	Type          string
+	Nullable      bool
+	Unique        bool
+	AutoIncrement bool
+	Default       string
+}
+
+// ForeignKey est une contrainte de clé étrangère d'une colonne vers la clé primaire d'une table.
+type ForeignKey struct {
+	Name      string
+	Column    string
+	RefTable  string
+	RefColumn string
+}
+
+// Index est un index sur une ou plusieurs colonnes d'une table.
+type Index struct {
+	Name    string
+	Table   string
+	Columns []string
+	Unique  bool
+}
+
+// Table décrit une table, ses contraintes et ses index.
+type Table struct {
+	Name        string
+	Columns     []Column
+	PrimaryKey  []string
+	ForeignKeys []ForeignKey
+	Indexes     []Index
+}
+
+// Column renvoie la colonne portant le nom donné.
+func (t Table) Column(name string) (Column, bool) {
+	for _, c := range t.Columns {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+// Change est une opération de migration.
+type Change interface {
+	isChange()
+}
+
+// CreateTable crée une table avec ses clés étrangères et ses index.
+type CreateTable struct {
+	Table Table
+}
+
+// AddColumn ajoute une colonne (et éventuellement sa clé étrangère) à une table existante.
+type AddColumn struct {
+	Table      string
+	Column     Column
+	ForeignKey *ForeignKey
+}
+
+// CreateIndex crée un index sur une table existante.
+type CreateIndex struct {
+	Index Index
+}
+
+// AlterColumn modifie le type et/ou la nullabilité d'une colonne existante.
+type AlterColumn struct {
+	Table    string
+	Column   Column
+	Previous Column
+}
+
+// AddForeignKey ajoute une clé étrangère sur une colonne existante.
+type AddForeignKey struct {
+	Table      string
+	ForeignKey ForeignKey
+}
+
+func (CreateTable) isChange()   {}
+func (AddColumn) isChange()     {}
+func (CreateIndex) isChange()   {}
+func (AlterColumn) isChange()   {}
+func (AddForeignKey) isChange() {}
+
+// ForeignKeyName renvoie le nom conventionnel d'une clé étrangère.
+func ForeignKeyName(table, column string) string {
+	return "fk_" + table + "_" + column
+}
+
+// IndexName renvoie le nom conventionnel d'un index.
+func IndexName(table string, columns ...string) string {
+	return "idx_" + table + "_" + strings.Join(columns, "_")
+}
+
+// SQLType renvoie le type de colonne correspondant à un type Java pour un dialecte.
+// length n'est utilisé que pour les chaînes (255 par défaut).
+func SQLType(d Dialect, javaType string, length int) (string, error) {
+	mysqlLike := d == MySQL || d == MariaDB
+	switch javaType {
+	case "String":
+		if length <= 0 {
+			length = 255
+		}
+		return fmt.Sprintf("VARCHAR(%d)", length), nil
+	case "int", "Integer":
+		return "INTEGER", nil
+	case "long", "Long":
+		return "BIGINT", nil
+	case "short", "Short":
+		return "SMALLINT", nil
+	case "double", "Double":
+		if mysqlLike {
+			return "DOUBLE", nil
+		}
+		return "DOUBLE PRECISION", nil
+	case "float", "Float":
+		if mysqlLike {
+			return "FLOAT", nil
+		}
+		return "REAL", nil
+	case "boolean", "Boolean":
+		return "BOOLEAN", nil
+	case "BigDecimal":
+		return "NUMERIC(19, 2)", nil
+	case "LocalDate":
+		return "DATE", nil
+	case "LocalTime":
+		return "TIME", nil
+	case "LocalDateTime":
+		if mysqlLike {
+			return "DATETIME(6)", nil
+		}
+		return "TIMESTAMP", nil
+	case "Instant":
+		if mysqlLike {
+			return "TIMESTAMP(6)", nil
+		}
+		return "TIMESTAMP WITH TIME ZONE", nil
+	case "UUID":
+		if d == MySQL {
+			return "BINARY(16)", nil
+		}
+		return "UUID", nil
+	}
+	return "", fmt.Errorf("type %s non pris en charge par les migrations", javaType)
+}
+
+// typeAliases associe les synonymes de types SQL à la forme utilisée par SQLType.
+var typeAliases = map[string]string{
+	"INT": "INTEGER", "INT4": "INTEGER", "MEDIUMINT": "INTEGER", "SERIAL": "INTEGER",
+	"INT8": "BIGINT", "BIGSERIAL": "BIGINT",
+	"INT2": "SMALLINT", "SMALLSERIAL": "SMALLINT",
+	"BOOL": "BOOLEAN", "TINYINT(1)": "BOOLEAN", "BIT(1)": "BOOLEAN",
+	"FLOAT4": "REAL", "FLOAT": "REAL",
+	"FLOAT8": "DOUBLE PRECISION", "DOUBLE": "DOUBLE PRECISION",
+	"TIMESTAMP WITHOUT TIME ZONE": "TIMESTAMP", "TIMESTAMPTZ": "TIMESTAMP WITH TIME ZONE",
+	"TIME WITHOUT TIME ZONE": "TIME",
+}
+
+var (
+	spacesRegexp       = regexp.MustCompile(`\s+`)
+	typeArgsRegexp     = regexp.MustCompile(`\s*\(\s*([^)]*?)\s*\)`)
+	displayWidthRegexp = regexp.MustCompile(`^(INTEGER|INT|MEDIUMINT|BIGINT|SMALLINT)\(\d+\)`)
+	temporalRegexp     = regexp.MustCompile(`^(TIMESTAMP|DATETIME|TIME)\(\d\)`)
+	varcharRegexp      = regexp.MustCompile(`^(CHARACTER VARYING|VARCHAR2|NVARCHAR)\b`)
+)
+
+// NormalizeType ramène un type SQL à une forme canonique (int4 -> INTEGER,
+// character varying(50) -> VARCHAR(50), decimal(19,2) -> NUMERIC(19, 2)...).
+func NormalizeType(t string) string {
+	t = strings.ToUpper(strings.TrimSpace(spacesRegexp.ReplaceAllString(t, " ")))
+	t = typeArgsRegexp.ReplaceAllStringFunc(t, func(args string) string {
+		inner := typeArgsRegexp.FindStringSubmatch(args)[1]
+		parts := strings.Split(inner, ",")
+		for i, p := range parts {
+			parts[i] = strings.TrimSpace(p)
+		}
+		return "(" + strings.Join(parts, ", ") + ")"
+	})
+	t = varcharRegexp.ReplaceAllString(t, "VARCHAR")
+	t = strings.Replace(t, "DECIMAL", "NUMERIC", 1)
+	if alias, ok := typeAliases[t]; ok {
+		return alias
+	}
+	// Largeur d'affichage MySQL (int(11)) et précision des types temporels sans effet sur le mapping
+	if m := displayWidthRegexp.FindStringSubmatch(t); m != nil {
+		t = m[1]
+	}
+	t = temporalRegexp.ReplaceAllString(t, "$1")
+	if alias, ok := typeAliases[t]; ok {
+		return alias
+	}
+	return t
+}
+
+// SameType indique si deux types SQL sont équivalents.
+func SameType(a, b string) bool {
+	return NormalizeType(a) == NormalizeType(b)
+}
+
+// reservedWords contient les mots réservés couramment utilisés comme noms de table.
+var reservedWords = map[string]bool{
+	"user": true, "order": true, "group": true, "select": true,
+	"table": true, "column": true, "check": true, "key": true, "value": true,
+}
+
+// IsReserved indique si un identifiant doit être protégé par des guillemets.
+func IsReserved(name string) bool {
+	return reservedWords[strings.ToLower(name)]
+}
+
+// Quote protège un identifiant réservé selon le dialecte.
+func Quote(d Dialect, name string) string {
+	if !IsReserved(name) {
+		return name
+	}
+	if d == MySQL || d == MariaDB {
+		return "`" + name + "`"
+	}
+	return `"` + name + `"`
+}
@@ -0,0 +1,42 @@
+package migration
+
+import "testing"
+
+func TestNormalizeType(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"int4", "INTEGER"},
+		{"character varying(50)", "VARCHAR(50)"},
+		{"decimal(19,2)", "NUMERIC(19, 2)"},
+		{"int(11)", "INTEGER"},
+		{"bigint(20)", "BIGINT"},
+		{"datetime(6)", "DATETIME"},
+		{"mediumint(8)", "INTEGER"},
+		{"VARCHAR( 120 )", "VARCHAR(120)"},
+	}
+	for _, c := range cases {
+		if got := NormalizeType(c.in); got != c.want {
+			t.Errorf("NormalizeType(%q) = %q, attendu %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	cases := []struct {
+		dialect Dialect
+		name    string
+		want    string
+	}{
+		{Postgres, "order", `"order"`},
+		{H2, "user", `"user"`},
+		{MySQL, "order", "`order`"},
+		{MariaDB, "Group", "`Group`"},
+		{Postgres, "customer", "customer"},
+	}
+	for _, c := range cases {
+		if got := Quote(c.dialect, c.name); got != c.want {
+			t.Errorf("Quote(%s, %q) = %q, attendu %q", c.dialect, c.name, got, c.want)
+		}
+	}
+}
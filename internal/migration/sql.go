@@ -0,0 +1,119 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SQL génère le script de migration des changements pour le dialecte donné.
+func SQL(d Dialect, changes []Change) string {
+	var statements []string
+	for _, change := range changes {
+		switch c := change.(type) {
+		case CreateTable:
+			statements = append(statements, createTableSQL(d, c.Table))
+			for _, idx := range c.Table.Indexes {
+				statements = append(statements, createIndexSQL(d, idx))
+			}
+		case AddColumn:
+			statements = append(statements, addColumnSQL(d, c))
+		case CreateIndex:
+			statements = append(statements, createIndexSQL(d, c.Index))
+		case AlterColumn:
+			statements = append(statements, alterColumnSQL(d, c))
+		case AddForeignKey:
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD %s;", Quote(d, c.Table), foreignKeySQL(d, c.ForeignKey)))
+		}
+	}
+	return strings.Join(statements, "\n\n") + "\n"
+}
+
+func createTableSQL(d Dialect, t Table) string {
+	var lines []string
+	for _, c := range t.Columns {
+		lines = append(lines, "    "+columnSQL(d, c))
+	}
+	if len(t.PrimaryKey) > 0 {
+		lines = append(lines, fmt.Sprintf("    CONSTRAINT pk_%s PRIMARY KEY (%s)", t.Name, quoteAll(d, t.PrimaryKey)))
+	}
+	for _, fk := range t.ForeignKeys {
+		lines = append(lines, "    "+foreignKeySQL(d, fk))
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n);", Quote(d, t.Name), strings.Join(lines, ",\n"))
+}
+
+func columnSQL(d Dialect, c Column) string {
+	var b strings.Builder
+	b.WriteString(Quote(d, c.Name) + " " + c.Type)
+	if c.AutoIncrement {
+		if d == MySQL || d == MariaDB {
+			b.WriteString(" NOT NULL AUTO_INCREMENT")
+		} else {
+			b.WriteString(" GENERATED BY DEFAULT AS IDENTITY")
+		}
+	} else if !c.Nullable {
+		b.WriteString(" NOT NULL")
+	}
+	if c.Default != "" {
+		b.WriteString(" DEFAULT " + c.Default)
+	}
+	if c.Unique {
+		b.WriteString(" UNIQUE")
+	}
+	return b.String()
+}
+
+func foreignKeySQL(d Dialect, fk ForeignKey) string {
+	return fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		fk.Name, Quote(d, fk.Column), Quote(d, fk.RefTable), Quote(d, fk.RefColumn))
+}
+
+func addColumnSQL(d Dialect, c AddColumn) string {
+	statement := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", Quote(d, c.Table), columnSQL(d, c.Column))
+	if !c.Column.Nullable && c.Column.Default == "" && !c.Column.AutoIncrement {
+		statement = "-- Attention: colonne NOT NULL sans valeur par défaut, à compléter si la table contient des données\n" + statement
+	}
+	if c.ForeignKey != nil {
+		statement += fmt.Sprintf("\nALTER TABLE %s ADD %s;", Quote(d, c.Table), foreignKeySQL(d, *c.ForeignKey))
+	}
+	return statement
+}
+
+func alterColumnSQL(d Dialect, c AlterColumn) string {
+	table, column := Quote(d, c.Table), Quote(d, c.Column.Name)
+	if d == MySQL || d == MariaDB {
+		// MODIFY redéfinit entièrement la colonne; l'unicité est portée par un index distinct
+		def := c.Column
+		def.Unique = false
+		return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", table, columnSQL(d, def))
+	}
+
+	var statements []string
+	if !SameType(c.Column.Type, c.Previous.Type) {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DATA TYPE %s;", table, column, c.Column.Type))
+	}
+	if c.Column.Nullable != c.Previous.Nullable {
+		action := "SET NOT NULL"
+		if c.Column.Nullable {
+			action = "DROP NOT NULL"
+		}
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", table, column, action))
+	}
+	return strings.Join(statements, "\n")
+}
+
+func createIndexSQL(d Dialect, idx Index) string {
+	unique := ""
+	if idx.Unique {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s);", unique, idx.Name, Quote(d, idx.Table), quoteAll(d, idx.Columns))
+}
+
+func quoteAll(d Dialect, names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = Quote(d, n)
+	}
+	return strings.Join(quoted, ", ")
+}
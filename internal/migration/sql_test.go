@@ -0,0 +1,107 @@
+package migration
+
+import "testing"
+
+// orderTable est une table dont le nom est un mot réservé, avec une clé étrangère et un index.
+var orderTable = Table{
+	Name: "order",
+	Columns: []Column{
+		{Name: "id", Type: "BIGINT", AutoIncrement: true},
+		{Name: "reference", Type: "VARCHAR(40)", Unique: true},
+		{Name: "user_id", Type: "BIGINT"},
+	},
+	PrimaryKey:  []string{"id"},
+	ForeignKeys: []ForeignKey{{Name: "fk_order_user_id", Column: "user_id", RefTable: "user", RefColumn: "id"}},
+	Indexes:     []Index{{Name: "idx_order_user_id", Table: "order", Columns: []string{"user_id"}}},
+}
+
+func TestSQL(t *testing.T) {
+	note := Column{Name: "note", Type: "VARCHAR(255)", Nullable: true}
+	cases := []struct {
+		name    string
+		dialect Dialect
+		changes []Change
+		want    string
+	}{
+		{
+			name:    "CREATE TABLE postgres",
+			dialect: Postgres,
+			changes: []Change{CreateTable{Table: orderTable}},
+			want: `CREATE TABLE "order" (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY,
+    reference VARCHAR(40) NOT NULL UNIQUE,
+    user_id BIGINT NOT NULL,
+    CONSTRAINT pk_order PRIMARY KEY (id),
+    CONSTRAINT fk_order_user_id FOREIGN KEY (user_id) REFERENCES "user" (id)
+);
+
+CREATE INDEX idx_order_user_id ON "order" (user_id);
+`,
+		},
+		{
+			name:    "CREATE TABLE mysql",
+			dialect: MySQL,
+			changes: []Change{CreateTable{Table: orderTable}},
+			want: "CREATE TABLE `order` (\n" +
+				"    id BIGINT NOT NULL AUTO_INCREMENT,\n" +
+				"    reference VARCHAR(40) NOT NULL UNIQUE,\n" +
+				"    user_id BIGINT NOT NULL,\n" +
+				"    CONSTRAINT pk_order PRIMARY KEY (id),\n" +
+				"    CONSTRAINT fk_order_user_id FOREIGN KEY (user_id) REFERENCES `user` (id)\n" +
+				");\n\n" +
+				"CREATE INDEX idx_order_user_id ON `order` (user_id);\n",
+		},
+		{
+			name:    "ADD COLUMN avec clé étrangère",
+			dialect: Postgres,
+			changes: []Change{AddColumn{
+				Table:      "invoice",
+				Column:     Column{Name: "order_id", Type: "BIGINT", Nullable: true},
+				ForeignKey: &ForeignKey{Name: "fk_invoice_order_id", Column: "order_id", RefTable: "order", RefColumn: "id"},
+			}},
+			want: "ALTER TABLE invoice ADD COLUMN order_id BIGINT;\n" +
+				"ALTER TABLE invoice ADD CONSTRAINT fk_invoice_order_id FOREIGN KEY (order_id) REFERENCES \"order\" (id);\n",
+		},
+		{
+			name:    "ADD COLUMN NOT NULL sans défaut",
+			dialect: H2,
+			changes: []Change{AddColumn{Table: "invoice", Column: Column{Name: "total", Type: "NUMERIC(10, 2)"}}},
+			want: "-- Attention: colonne NOT NULL sans valeur par défaut, à compléter si la table contient des données\n" +
+				"ALTER TABLE invoice ADD COLUMN total NUMERIC(10, 2) NOT NULL;\n",
+		},
+		{
+			name:    "ALTER COLUMN postgres",
+			dialect: Postgres,
+			changes: []Change{AlterColumn{
+				Table:    "invoice",
+				Column:   Column{Name: "note", Type: "VARCHAR(500)"},
+				Previous: note,
+			}},
+			want: "ALTER TABLE invoice ALTER COLUMN note SET DATA TYPE VARCHAR(500);\n" +
+				"ALTER TABLE invoice ALTER COLUMN note SET NOT NULL;\n",
+		},
+		{
+			name:    "MODIFY COLUMN mariadb",
+			dialect: MariaDB,
+			changes: []Change{AlterColumn{
+				Table:    "invoice",
+				Column:   Column{Name: "note", Type: "VARCHAR(500)", Unique: true},
+				Previous: note,
+			}},
+			want: "ALTER TABLE invoice MODIFY COLUMN note VARCHAR(500) NOT NULL;\n",
+		},
+		{
+			name:    "index unique",
+			dialect: MySQL,
+			changes: []Change{CreateIndex{Index: Index{Name: "idx_user_email", Table: "user", Columns: []string{"email"}, Unique: true}}},
+			want:    "CREATE UNIQUE INDEX idx_user_email ON `user` (email);\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := SQL(c.dialect, c.changes); got != c.want {
+				t.Errorf("SQL:\n%s\nattendu:\n%s", got, c.want)
+			}
+		})
+	}
+}
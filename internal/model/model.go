@@ -0,0 +1,226 @@
+// Package model lit le fichier de modèle déclaratif (model.yaml) décrivant les entités,
+// les énumérations et les couches à générer d'une application.
+package model
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Couches générées pour chaque entité, dans l'ordre de génération.
+var Layers = []string{"dto", "mapper", "repository", "service", "controller"}
+
+// Model est le contenu d'un fichier de modèle.
+type Model struct {
+	Options  Options
+	Enums    []Enum
+	Entities []Entity
+}
+
+// Options remplace les valeurs par défaut des options de la ligne de commande.
+type Options struct {
+	Style     string   `yaml:"style"`
+	Migration string   `yaml:"migration"`
+	Dialect   string   `yaml:"dialect"`
+	Mapper    string   `yaml:"mapper"`
+	Layers    []string `yaml:"layers"`
+}
+
+type Enum struct {
+	Name   string
+	Values []string
+}
+
+// Entity décrit une entité et ses propriétés, dans l'ordre du fichier.
+type Entity struct {
+	Name       string
+	Properties []Property
+}
+
+// Property est un champ (type[:contraintes]) ou une relation (Relation:Cible), écrit
+// comme les arguments de la commande generate entity.
+type Property struct {
+	Name       string
+	Definition string
+}
+
+// Arg renvoie la propriété sous la forme nom:définition attendue par generate entity.
+func (p Property) Arg() string {
+	return p.Name + ":" + p.Definition
+}
+
+var (
+	classNameRegexp  = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	identifierRegexp = regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`)
+	constantRegexp   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Load lit un fichier de modèle.
+func Load(path string) (*Model, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse lit un modèle YAML en conservant l'ordre des entités et des propriétés.
+func Parse(data []byte) (*Model, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	m := &Model{}
+	if len(root.Content) == 0 {
+		return m, nil
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("ligne %d: le modèle doit être un objet (options, enums, entities)", doc.Line)
+	}
+
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		var err error
+		switch key.Value {
+		case "options":
+			err = m.parseOptions(value)
+		case "enums":
+			err = m.parseEnums(value)
+		case "entities":
+			err = m.parseEntities(value)
+		default:
+			err = fmt.Errorf("ligne %d: section inconnue %q (valeurs possibles: options, enums, entities)", key.Line, key.Value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, m.validate()
+}
+
+func (m *Model) parseOptions(node *yaml.Node) error {
+	if err := node.Decode(&m.Options); err != nil {
+		return err
+	}
+	for _, layer := range m.Options.Layers {
+		if !isLayer(layer) {
+			return fmt.Errorf("ligne %d: couche inconnue %q (valeurs possibles: %s)", node.Line, layer, strings.Join(Layers, ", "))
+		}
+	}
+	return nil
+}
+
+func isLayer(name string) bool {
+	for _, l := range Layers {
+		if l == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Model) parseEnums(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("ligne %d: enums doit associer chaque énumération à ses valeurs", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		e := Enum{Name: key.Value}
+		if err := value.Decode(&e.Values); err != nil || len(e.Values) == 0 {
+			return fmt.Errorf("ligne %d: l'énumération %s doit lister ses valeurs (ex: [ACTIVE, DISABLED])", key.Line, key.Value)
+		}
+		for _, v := range e.Values {
+			if !constantRegexp.MatchString(v) {
+				return fmt.Errorf("ligne %d: valeur invalide %q pour l'énumération %s", key.Line, v, key.Value)
+			}
+		}
+		m.Enums = append(m.Enums, e)
+	}
+	return nil
+}
+
+func (m *Model) parseEntities(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("ligne %d: entities doit associer chaque entité à ses propriétés", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		e := Entity{Name: key.Value}
+		if value.Kind != yaml.MappingNode && value.Tag != "!!null" {
+			return fmt.Errorf("ligne %d: l'entité %s doit associer chaque propriété à son type (ex: name: string:required)", key.Line, key.Value)
+		}
+		for j := 0; j+1 < len(value.Content); j += 2 {
+			name, definition := value.Content[j], value.Content[j+1]
+			if definition.Kind != yaml.ScalarNode || strings.TrimSpace(definition.Value) == "" {
+				return fmt.Errorf("ligne %d: la propriété %s.%s doit avoir un type (ex: string:required)", name.Line, e.Name, name.Value)
+			}
+			if !identifierRegexp.MatchString(name.Value) {
+				return fmt.Errorf("ligne %d: nom de propriété invalide %q dans %s", name.Line, name.Value, e.Name)
+			}
+			e.Properties = append(e.Properties, Property{Name: name.Value, Definition: strings.ReplaceAll(definition.Value, " ", "")})
+		}
+		m.Entities = append(m.Entities, e)
+	}
+	return nil
+}
+
+// validate vérifie les noms et l'unicité des énumérations, entités et propriétés.
+func (m *Model) validate() error {
+	seen := map[string]string{}
+	declare := func(name, kind string) error {
+		if !classNameRegexp.MatchString(name) {
+			return fmt.Errorf("nom de %s invalide: %q (ex: OrderStatus)", kind, name)
+		}
+		if previous, ok := seen[name]; ok {
+			return fmt.Errorf("%s déclaré deux fois (%s et %s)", name, previous, kind)
+		}
+		seen[name] = kind
+		return nil
+	}
+	for _, e := range m.Enums {
+		if err := declare(e.Name, "énumération"); err != nil {
+			return err
+		}
+	}
+	for _, e := range m.Entities {
+		if err := declare(e.Name, "entité"); err != nil {
+			return err
+		}
+		properties := map[string]bool{}
+		for _, p := range e.Properties {
+			if strings.EqualFold(p.Name, "id") {
+				return fmt.Errorf("propriété %s.%s inutile: l'identifiant id est généré", e.Name, p.Name)
+			}
+			if properties[p.Name] {
+				return fmt.Errorf("propriété %s.%s déclarée deux fois", e.Name, p.Name)
+			}
+			properties[p.Name] = true
+		}
+	}
+	return nil
+}
+
+// Enum renvoie l'énumération de nom donné, nil si elle n'est pas déclarée.
+func (m *Model) Enum(name string) *Enum {
+	for i := range m.Enums {
+		if m.Enums[i].Name == name {
+			return &m.Enums[i]
+		}
+	}
+	return nil
+}
+
+// Entity renvoie l'entité de nom donné, nil si elle n'est pas déclarée.
+func (m *Model) Entity(name string) *Entity {
+	for i := range m.Entities {
+		if m.Entities[i].Name == name {
+			return &m.Entities[i]
+		}
+	}
+	return nil
+}
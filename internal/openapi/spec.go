@@ -0,0 +1,452 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document est le sous-ensemble d'une spécification OpenAPI 3.x utilisé par le générateur.
+type Document struct {
+	OpenAPI    string     `yaml:"openapi"`
+	Info       Info       `yaml:"info"`
+	Servers    []Server   `yaml:"servers,omitempty"`
+	Paths      Paths      `yaml:"paths"`
+	Components Components `yaml:"components,omitempty"`
+}
+
+type Info struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description,omitempty"`
+	Version     string `yaml:"version"`
+}
+
+type Server struct {
+	URL string `yaml:"url"`
+}
+
+type Components struct {
+	Schemas       Schemas                 `yaml:"schemas,omitempty"`
+	Parameters    map[string]*Parameter   `yaml:"parameters,omitempty"`
+	RequestBodies map[string]*RequestBody `yaml:"requestBodies,omitempty"`
+	Responses     map[string]*Response    `yaml:"responses,omitempty"`
+}
+
+// PathItem regroupe les opérations d'un chemin.
+type PathItem struct {
+	Parameters []*Parameter `yaml:"parameters,omitempty"`
+	Get        *Operation   `yaml:"get,omitempty"`
+	Put        *Operation   `yaml:"put,omitempty"`
+	Post       *Operation   `yaml:"post,omitempty"`
+	Delete     *Operation   `yaml:"delete,omitempty"`
+	Patch      *Operation   `yaml:"patch,omitempty"`
+}
+
+// Operations renvoie les opérations du chemin par méthode HTTP, dans un ordre stable.
+func (p *PathItem) Operations() []MethodOperation {
+	var operations []MethodOperation
+	for _, o := range []MethodOperation{{"GET", p.Get}, {"POST", p.Post}, {"PUT", p.Put}, {"PATCH", p.Patch}, {"DELETE", p.Delete}} {
+		if o.Operation != nil {
+			operations = append(operations, o)
+		}
+	}
+	return operations
+}
+
+type MethodOperation struct {
+	Method    string
+	Operation *Operation
+}
+
+type Operation struct {
+	OperationID string       `yaml:"operationId,omitempty"`
+	Summary     string       `yaml:"summary,omitempty"`
+	Tags        []string     `yaml:"tags,omitempty"`
+	Parameters  []*Parameter `yaml:"parameters,omitempty"`
+	RequestBody *RequestBody `yaml:"requestBody,omitempty"`
+	Responses   Responses    `yaml:"responses"`
+}
+
+type Parameter struct {
+	Ref         string  `yaml:"$ref,omitempty"`
+	Name        string  `yaml:"name,omitempty"`
+	In          string  `yaml:"in,omitempty"`
+	Description string  `yaml:"description,omitempty"`
+	Required    bool    `yaml:"required,omitempty"`
+	Style       string  `yaml:"style,omitempty"`
+	Explode     *bool   `yaml:"explode,omitempty"`
+	Schema      *Schema `yaml:"schema,omitempty"`
+}
+
+type RequestBody struct {
+	Ref      string  `yaml:"$ref,omitempty"`
+	Required bool    `yaml:"required,omitempty"`
+	Content  Content `yaml:"content,omitempty"`
+}
+
+type Response struct {
+	Ref         string  `yaml:"$ref,omitempty"`
+	Description string  `yaml:"description"`
+	Content     Content `yaml:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `yaml:"schema,omitempty"`
+}
+
+// Schema décrit un type de données (objet, tableau, scalaire ou référence).
+type Schema struct {
+	Ref                  string    `yaml:"$ref,omitempty"`
+	Type                 Types     `yaml:"type,omitempty"`
+	Format               string    `yaml:"format,omitempty"`
+	Description          string    `yaml:"description,omitempty"`
+	Enum                 []string  `yaml:"enum,omitempty"`
+	Required             []string  `yaml:"required,omitempty"`
+	Properties           Schemas   `yaml:"properties,omitempty"`
+	Items                *Schema   `yaml:"items,omitempty"`
+	AdditionalProperties *Schema   `yaml:"additionalProperties,omitempty"`
+	AllOf                []*Schema `yaml:"allOf,omitempty"`
+	OneOf                []*Schema `yaml:"oneOf,omitempty"`
+	AnyOf                []*Schema `yaml:"anyOf,omitempty"`
+	MinLength            *int      `yaml:"minLength,omitempty"`
+	MaxLength            *int      `yaml:"maxLength,omitempty"`
+	Pattern              string    `yaml:"pattern,omitempty"`
+	Minimum              *float64  `yaml:"minimum,omitempty"`
+	Maximum              *float64  `yaml:"maximum,omitempty"`
+	// ExclusiveMinimum et ExclusiveMaximum sont des booléens en OpenAPI 3.0, des nombres en 3.1
+	ExclusiveMinimum interface{} `yaml:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum interface{} `yaml:"exclusiveMaximum,omitempty"`
+	MinItems         *int        `yaml:"minItems,omitempty"`
+	MaxItems         *int        `yaml:"maxItems,omitempty"`
+	Nullable         bool        `yaml:"nullable,omitempty"`
+	ReadOnly         bool        `yaml:"readOnly,omitempty"`
+	Default          interface{} `yaml:"default,omitempty"`
+}
+
+// Is indique si le schéma a le type donné (OpenAPI 3.1 autorise une liste de types).
+func (s *Schema) Is(t string) bool {
+	for _, typ := range s.Type {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+// LowerBound renvoie la borne inférieure d'un nombre (minimum ou exclusiveMinimum), nil
+// s'il n'en a pas, et indique si elle est exclue.
+func (s *Schema) LowerBound() (*float64, bool) {
+	return bound(s.Minimum, s.ExclusiveMinimum)
+}
+
+// UpperBound renvoie la borne supérieure d'un nombre (maximum ou exclusiveMaximum), nil
+// s'il n'en a pas, et indique si elle est exclue.
+func (s *Schema) UpperBound() (*float64, bool) {
+	return bound(s.Maximum, s.ExclusiveMaximum)
+}
+
+func bound(limit *float64, exclusive interface{}) (*float64, bool) {
+	switch e := exclusive.(type) {
+	case bool:
+		// OpenAPI 3.0: exclusiveMinimum: true exclut la valeur de minimum
+		return limit, e && limit != nil
+	case int:
+		v := float64(e)
+		return &v, true
+	case float64:
+		return &e, true
+	}
+	return limit, false
+}
+
+// IsObject indique si le schéma décrit un objet avec des propriétés.
+func (s *Schema) IsObject() bool {
+	return s.Is("object") || (len(s.Type) == 0 && (len(s.Properties) > 0 || len(s.AllOf) > 0))
+}
+
+// IsRequired indique si la propriété donnée est obligatoire.
+func (s *Schema) IsRequired(property string) bool {
+	for _, name := range s.Required {
+		if name == property {
+			return true
+		}
+	}
+	return false
+}
+
+// RefName renvoie le nom du composant désigné par une référence locale
+// (#/components/schemas/Pet -> Pet).
+func RefName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// Types est le type d'un schéma: une chaîne en OpenAPI 3.0, une liste en 3.1
+// (type: [string, "null"]).
+type Types []string
+
+func (t Types) MarshalYAML() (interface{}, error) {
+	if len(t) == 1 {
+		return t[0], nil
+	}
+	return []string(t), nil
+}
+
+func (t *Types) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = Types{node.Value}
+		return nil
+	}
+	var types []string
+	if err := node.Decode(&types); err != nil {
+		return err
+	}
+	*t = types
+	return nil
+}
+
+// ==================== DICTIONNAIRES ORDONNÉS ====================
+// Les schémas, propriétés, chemins et réponses conservent l'ordre de la spécification,
+// qui détermine l'ordre des champs et des méthodes générés.
+
+type NamedSchema struct {
+	Name   string
+	Schema *Schema
+}
+
+type Schemas []NamedSchema
+
+func (s Schemas) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, n := range s {
+		if err := appendOrdered(node, n.Name, n.Schema); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func (s *Schemas) UnmarshalYAML(node *yaml.Node) error {
+	return decodeOrdered(node, func(key string, value *yaml.Node) error {
+		schema := &Schema{}
+		if err := value.Decode(schema); err != nil {
+			return err
+		}
+		*s = append(*s, NamedSchema{key, schema})
+		return nil
+	})
+}
+
+// Get renvoie le schéma de nom donné.
+func (s Schemas) Get(name string) *Schema {
+	for _, n := range s {
+		if n.Name == name {
+			return n.Schema
+		}
+	}
+	return nil
+}
+
+type NamedPath struct {
+	Path string
+	Item *PathItem
+}
+
+type Paths []NamedPath
+
+func (p Paths) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, n := range p {
+		if err := appendOrdered(node, n.Path, n.Item); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func (p *Paths) UnmarshalYAML(node *yaml.Node) error {
+	return decodeOrdered(node, func(key string, value *yaml.Node) error {
+		item := &PathItem{}
+		if err := value.Decode(item); err != nil {
+			return err
+		}
+		*p = append(*p, NamedPath{key, item})
+		return nil
+	})
+}
+
+type NamedResponse struct {
+	Status   string
+	Response *Response
+}
+
+type Responses []NamedResponse
+
+func (r Responses) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, n := range r {
+		if err := appendOrdered(node, n.Status, n.Response); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func (r *Responses) UnmarshalYAML(node *yaml.Node) error {
+	return decodeOrdered(node, func(key string, value *yaml.Node) error {
+		response := &Response{}
+		if err := value.Decode(response); err != nil {
+			return err
+		}
+		*r = append(*r, NamedResponse{key, response})
+		return nil
+	})
+}
+
+type NamedMediaType struct {
+	Type      string
+	MediaType *MediaType
+}
+
+type Content []NamedMediaType
+
+func (c Content) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, n := range c {
+		if err := appendOrdered(node, n.Type, n.MediaType); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func (c *Content) UnmarshalYAML(node *yaml.Node) error {
+	return decodeOrdered(node, func(key string, value *yaml.Node) error {
+		mediaType := &MediaType{}
+		if err := value.Decode(mediaType); err != nil {
+			return err
+		}
+		*c = append(*c, NamedMediaType{key, mediaType})
+		return nil
+	})
+}
+
+// Schema renvoie le schéma du contenu JSON, ou à défaut du premier type de contenu, ainsi
+// que ce type de contenu.
+func (c Content) Schema() (*Schema, string) {
+	for _, m := range c {
+		if strings.Contains(m.Type, "json") {
+			return m.MediaType.Schema, m.Type
+		}
+	}
+	if len(c) > 0 {
+		return c[0].MediaType.Schema, c[0].Type
+	}
+	return nil, ""
+}
+
+func appendOrdered(node *yaml.Node, key string, value interface{}) error {
+	valueNode := &yaml.Node{}
+	if err := valueNode.Encode(value); err != nil {
+		return err
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, valueNode)
+	return nil
+}
+
+func decodeOrdered(node *yaml.Node, add func(key string, value *yaml.Node) error) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("ligne %d: objet attendu", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if err := add(node.Content[i].Value, node.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ==================== LECTURE ET ÉCRITURE ====================
+
+// Load lit une spécification OpenAPI au format YAML ou JSON.
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse analyse une spécification OpenAPI au format YAML ou JSON.
+func Parse(data []byte) (*Document, error) {
+	content := string(data)
+	if strings.HasPrefix(strings.TrimSpace(content), "{") {
+		// Le JSON est lu comme du YAML, qui n'autorise pas les tabulations d'indentation;
+		// une chaîne JSON ne peut pas contenir de tabulation littérale.
+		content = strings.ReplaceAll(content, "\t", " ")
+	}
+
+	doc := &Document{}
+	if err := yaml.Unmarshal([]byte(content), doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("version OpenAPI non supportée: %q (3.0 ou 3.1 attendu)", doc.OpenAPI)
+	}
+	return doc, nil
+}
+
+// Marshal écrit une spécification au format YAML.
+func Marshal(doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Resolve renvoie le schéma désigné par une référence #/components/schemas/..., ou le
+// schéma lui-même.
+func (d *Document) Resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = d.Components.Schemas.Get(RefName(s.Ref))
+	}
+	return s
+}
+
+// Parameter résout une référence #/components/parameters/...
+func (d *Document) Parameter(p *Parameter) *Parameter {
+	if p.Ref != "" {
+		if resolved, ok := d.Components.Parameters[RefName(p.Ref)]; ok {
+			return resolved
+		}
+	}
+	return p
+}
+
+// RequestBody résout une référence #/components/requestBodies/...
+func (d *Document) RequestBody(b *RequestBody) *RequestBody {
+	if b != nil && b.Ref != "" {
+		if resolved, ok := d.Components.RequestBodies[RefName(b.Ref)]; ok {
+			return resolved
+		}
+	}
+	return b
+}
+
+// Response résout une référence #/components/responses/...
+func (d *Document) Response(r *Response) *Response {
+	if r.Ref != "" {
+		if resolved, ok := d.Components.Responses[RefName(r.Ref)]; ok {
+			return resolved
+		}
+	}
+	return r
+}
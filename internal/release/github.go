@@ -0,0 +1,132 @@
+// Package release lit les releases de springcli publiées sur GitHub et remplace le binaire
+// installé par celui de la dernière release.
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultAPI est l'API GitHub interrogée lorsqu'aucune n'est configurée.
+const DefaultAPI = "https://api.github.com"
+
+// DefaultRepository est le dépôt GitHub qui publie les releases de springcli.
+const DefaultRepository = "AnaelTech/springcli"
+
+// ErrNoRelease est renvoyée lorsque le dépôt n'a publié aucune release.
+var ErrNoRelease = errors.New("aucune release publiée")
+
+// Asset est un fichier attaché à une release (binaire, archive, fichier de sommes de contrôle).
+type Asset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+	Size        int64  `json:"size"`
+}
+
+// Release est une release publiée sur GitHub.
+type Release struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	HTMLURL     string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []Asset   `json:"assets"`
+}
+
+// Version renvoie la version de la release, sans le préfixe v du tag (v1.2.0 → 1.2.0).
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+// Client interroge l'API des releases d'un dépôt GitHub, ou un serveur qui la simule.
+type Client struct {
+	API        string
+	Repository string
+	HTTP       *http.Client
+}
+
+// NewClient renvoie le client de l'API et du dépôt donnés, ceux par défaut s'ils sont vides.
+func NewClient(api, repository string) *Client {
+	if api == "" {
+		api = DefaultAPI
+	}
+	if repository == "" {
+		repository = DefaultRepository
+	}
+	return &Client{
+		API:        strings.TrimRight(api, "/"),
+		Repository: strings.Trim(repository, "/"),
+		HTTP:       &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Latest renvoie la dernière release publiée (GitHub ignore les brouillons et les
+// pré-releases).
+func (c *Client) Latest() (*Release, error) {
+	resp, err := c.get(c.HTTP, c.API+"/repos/"+c.Repository+"/releases/latest", "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", c.Repository, ErrNoRelease)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// GitHub explique son refus (limite de requêtes atteinte...)
+		var problem struct {
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&problem) == nil && problem.Message != "" {
+			return nil, fmt.Errorf("API GitHub: %s (HTTP %d)", problem.Message, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("API GitHub: HTTP %d", resp.StatusCode)
+	}
+	release := &Release{}
+	if err := json.NewDecoder(resp.Body).Decode(release); err != nil {
+		return nil, fmt.Errorf("réponse de l'API GitHub invalide: %w", err)
+	}
+	return release, nil
+}
+
+// Download lit le contenu d'un fichier de la release.
+func (c *Client) Download(asset Asset) ([]byte, error) {
+	// Un binaire se télécharge moins vite qu'une réponse de l'API
+	client := *c.HTTP
+	client.Timeout = 5 * time.Minute
+	resp, err := c.get(&client, asset.DownloadURL, "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("téléchargement de %s: HTTP %d", asset.Name, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// get envoie la requête, authentifiée par GITHUB_TOKEN s'il est défini (la limite de
+// requêtes anonymes est basse). Le jeton n'est envoyé qu'à l'hôte de l'API: les fichiers
+// des releases peuvent être servis par un autre hôte.
+func (c *Client) get(client *http.Client, target, accept string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" && c.isAPIHost(req.URL) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return client.Do(req)
+}
+
+// isAPIHost indique si l'URL désigne l'hôte de l'API, avec le même protocole.
+func (c *Client) isAPIHost(target *url.URL) bool {
+	api, err := url.Parse(c.API)
+	return err == nil && api.Scheme == target.Scheme && strings.EqualFold(api.Host, target.Host)
+}
@@ -0,0 +1,159 @@
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// releaseServers démarre l'API simulée et l'hôte distinct qui sert les fichiers de la
+// release. authorizations reçoit l'en-tête Authorization de chaque requête, par chemin.
+func releaseServers(t *testing.T, files map[string][]byte) (api, downloads *httptest.Server, authorizations map[string]string) {
+	t.Helper()
+	authorizations = map[string]string{}
+	downloads = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations[r.URL.Path] = r.Header.Get("Authorization")
+		data, ok := files[strings.TrimPrefix(r.URL.Path, "/dl/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(downloads.Close)
+
+	api = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations[r.URL.Path] = r.Header.Get("Authorization")
+		if r.URL.Path != "/repos/AnaelTech/springcli/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		release := Release{TagName: "v1.2.0", HTMLURL: "https://github.com/AnaelTech/springcli/releases/tag/v1.2.0"}
+		for name := range files {
+			release.Assets = append(release.Assets, Asset{Name: name, DownloadURL: downloads.URL + "/dl/" + name})
+		}
+		_ = json.NewEncoder(w).Encode(release)
+	}))
+	t.Cleanup(api.Close)
+	return api, downloads, authorizations
+}
+
+func TestLatestAndDownload(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "secret")
+	binary := []byte("elf")
+	archive := tarGz(t, map[string]string{"springcli": string(binary)})
+	files := map[string][]byte{
+		"springcli_1.2.0_linux_amd64.tar.gz": archive,
+		"checksums.txt":                      []byte(checksumLine("springcli_1.2.0_linux_amd64.tar.gz", archive)),
+	}
+	api, _, authorizations := releaseServers(t, files)
+
+	client := NewClient(api.URL+"/", "AnaelTech/springcli")
+	latest, err := client.Latest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Version() != "1.2.0" {
+		t.Errorf("version %s, attendue 1.2.0", latest.Version())
+	}
+	asset, err := latest.AssetFor("linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksums, ok := latest.ChecksumsAsset()
+	if !ok {
+		t.Fatal("fichier des sommes de contrôle introuvable")
+	}
+
+	sums, err := client.Download(checksums)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := client.Download(asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyChecksum(sums, asset.Name, data); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Extract(asset.Name, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(binary) {
+		t.Errorf("binaire %q, attendu %q", got, binary)
+	}
+
+	// Le jeton n'est envoyé qu'à l'API, pas à l'hôte des fichiers
+	if auth := authorizations["/repos/AnaelTech/springcli/releases/latest"]; auth != "Bearer secret" {
+		t.Errorf("API: Authorization %q, attendu %q", auth, "Bearer secret")
+	}
+	for _, path := range []string{"/dl/checksums.txt", "/dl/springcli_1.2.0_linux_amd64.tar.gz"} {
+		if auth := authorizations[path]; auth != "" {
+			t.Errorf("%s: jeton envoyé à l'hôte des fichiers (%q)", path, auth)
+		}
+	}
+}
+
+func TestDownloadChecksumMismatch(t *testing.T) {
+	archive := tarGz(t, map[string]string{"springcli": "elf"})
+	files := map[string][]byte{
+		"springcli_1.2.0_linux_amd64.tar.gz": archive,
+		"checksums.txt":                      []byte(checksumLine("springcli_1.2.0_linux_amd64.tar.gz", []byte("autre contenu"))),
+	}
+	api, _, _ := releaseServers(t, files)
+
+	client := NewClient(api.URL, "")
+	latest, err := client.Latest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	asset, err := latest.AssetFor("linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksums, _ := latest.ChecksumsAsset()
+	sums, err := client.Download(checksums)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := client.Download(asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyChecksum(sums, asset.Name, data); err == nil || !strings.Contains(err.Error(), "invalide") {
+		t.Errorf("VerifyChecksum = %v, somme invalide attendue", err)
+	}
+
+	if _, err := client.Download(Asset{Name: "absent", DownloadURL: strings.Replace(asset.DownloadURL, asset.Name, "absent", 1)}); err == nil {
+		t.Error("téléchargement d'un fichier absent accepté")
+	}
+}
+
+func TestLatestErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/empty/repo/releases/latest":
+			http.NotFound(w, r)
+		case "/repos/limited/repo/releases/latest":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+		default:
+			_, _ = w.Write([]byte("pas du json"))
+		}
+	}))
+	defer server.Close()
+
+	if _, err := NewClient(server.URL, "empty/repo").Latest(); !errors.Is(err, ErrNoRelease) {
+		t.Errorf("dépôt sans release: %v, attendu %v", err, ErrNoRelease)
+	}
+	if _, err := NewClient(server.URL, "limited/repo").Latest(); err == nil || !strings.Contains(err.Error(), "API rate limit exceeded") {
+		t.Errorf("limite de requêtes: %v", err)
+	}
+	if _, err := NewClient(server.URL, "invalid/repo").Latest(); err == nil {
+		t.Error("réponse invalide acceptée")
+	}
+}
@@ -0,0 +1,202 @@
+package release
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BinaryName est le nom du binaire de springcli dans les archives des releases.
+const BinaryName = "springcli"
+
+// AssetFor renvoie le fichier de la release construit pour le système et l'architecture
+// donnés: springcli_1.2.0_linux_amd64.tar.gz, springcli_windows_amd64.zip,
+// springcli-darwin-arm64... Les archives sont préférées aux binaires nus.
+func (r *Release) AssetFor(goos, goarch string) (Asset, error) {
+	var found *Asset
+	for i, a := range r.Assets {
+		name := strings.ToLower(a.Name)
+		if isChecksums(name) || !strings.HasPrefix(name, BinaryName) || !hasPlatform(name, goos, goarch) {
+			continue
+		}
+		if found == nil || isArchive(name) && !isArchive(strings.ToLower(found.Name)) {
+			found = &r.Assets[i]
+		}
+	}
+	if found == nil {
+		return Asset{}, fmt.Errorf("la release %s ne contient pas de binaire pour %s/%s", r.TagName, goos, goarch)
+	}
+	return *found, nil
+}
+
+// ChecksumsAsset renvoie le fichier des sommes de contrôle SHA-256 de la release
+// (checksums.txt, springcli_1.2.0_checksums.txt, SHA256SUMS).
+func (r *Release) ChecksumsAsset() (Asset, bool) {
+	for _, a := range r.Assets {
+		if isChecksums(strings.ToLower(a.Name)) {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// hasPlatform indique si le nom contient le système et l'architecture, séparés par _ ou -.
+func hasPlatform(name, goos, goarch string) bool {
+	aliases := map[string][]string{
+		"amd64":  {"amd64", "x86_64"},
+		"arm64":  {"arm64", "aarch64"},
+		"386":    {"386", "i386"},
+		"darwin": {"darwin", "macos"},
+	}
+	systems, archs := aliases[goos], aliases[goarch]
+	if systems == nil {
+		systems = []string{goos}
+	}
+	if archs == nil {
+		archs = []string{goarch}
+	}
+	for _, s := range systems {
+		for _, a := range archs {
+			for _, sep := range []string{"_", "-"} {
+				platform := sep + s + sep + a
+				if i := strings.Index(name, platform); i >= 0 {
+					// linux_arm ne doit pas désigner linux_arm64
+					rest := name[i+len(platform):]
+					if rest == "" || rest[0] == '.' || rest[0] == '_' || rest[0] == '-' {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+func isArchive(name string) bool {
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz") || strings.HasSuffix(name, ".zip")
+}
+
+func isChecksums(name string) bool {
+	return strings.HasSuffix(name, "checksums.txt") || strings.HasPrefix(name, "sha256sums")
+}
+
+// VerifyChecksum compare la somme SHA-256 du contenu à celle que le fichier des sommes de
+// contrôle donne pour name (lignes « <somme>  <fichier> » de sha256sum).
+func VerifyChecksum(checksums []byte, name string, data []byte) error {
+	expected := ""
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			expected = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if expected == "" {
+		return fmt.Errorf("aucune somme de contrôle pour %s", name)
+	}
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return fmt.Errorf("somme de contrôle de %s invalide: %s attendue, %s obtenue", name, expected, actual)
+	}
+	return nil
+}
+
+// Extract renvoie le binaire contenu dans le fichier téléchargé: l'entrée springcli (ou
+// springcli.exe) d'une archive tar.gz ou zip, le fichier lui-même sinon.
+func Extract(name string, data []byte) ([]byte, error) {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz"):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("archive %s invalide: %w", name, err)
+		}
+		archive := tar.NewReader(gz)
+		for {
+			header, err := archive.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("archive %s invalide: %w", name, err)
+			}
+			if header.Typeflag == tar.TypeReg && isBinary(header.Name) {
+				return io.ReadAll(archive)
+			}
+		}
+	case strings.HasSuffix(name, ".zip"):
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("archive %s invalide: %w", name, err)
+		}
+		for _, f := range archive.File {
+			if f.FileInfo().IsDir() || !isBinary(f.Name) {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return io.ReadAll(rc)
+		}
+	default:
+		return data, nil
+	}
+	return nil, fmt.Errorf("l'archive %s ne contient pas le binaire %s", name, BinaryName)
+}
+
+func isBinary(path string) bool {
+	base := filepath.Base(path)
+	return base == BinaryName || base == BinaryName+".exe"
+}
+
+// Replace remplace le binaire situé à path. Le nouveau binaire est écrit à côté de l'ancien
+// puis renommé par-dessus: le remplacement est atomique et un échec laisse l'ancien intact.
+func Replace(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".new-*")
+	if err != nil {
+		return fmt.Errorf("impossible d'écrire dans %s: %w", dir, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+
+	// Windows refuse de remplacer un exécutable en cours d'utilisation, mais accepte de
+	// le renommer: l'ancien binaire est mis de côté avant le renommage
+	old := path + ".old"
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(old)
+		if renameErr := os.Rename(path, old); renameErr != nil {
+			return err
+		}
+		if err := os.Rename(tmp.Name(), path); err != nil {
+			_ = os.Rename(old, path)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package release
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func checksumLine(name string, data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]) + "  " + name + "\n"
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("binaire")
+	checksums := []byte(checksumLine("springcli_linux_amd64.tar.gz", data) +
+		checksumLine("springcli_windows_amd64.zip", []byte("autre")))
+
+	cases := []struct {
+		name    string
+		file    string
+		data    []byte
+		wantErr bool
+	}{
+		{name: "somme correcte", file: "springcli_linux_amd64.tar.gz", data: data},
+		{name: "somme différente", file: "springcli_linux_amd64.tar.gz", data: []byte("modifié"), wantErr: true},
+		{name: "fichier absent", file: "springcli_darwin_arm64.tar.gz", data: data, wantErr: true},
+		{name: "nom préfixe d'un autre", file: "springcli_linux_amd64.tar", data: data, wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := VerifyChecksum(checksums, c.file, c.data)
+			if (err != nil) != c.wantErr {
+				t.Errorf("VerifyChecksum(%s) = %v, erreur attendue: %v", c.file, err, c.wantErr)
+			}
+		})
+	}
+
+	// Format binaire de sha256sum (« <somme> *<fichier> »)
+	sum := sha256.Sum256(data)
+	binary := []byte(hex.EncodeToString(sum[:]) + " *springcli_linux_amd64.tar.gz\n")
+	if err := VerifyChecksum(binary, "springcli_linux_amd64.tar.gz", data); err != nil {
+		t.Errorf("format binaire: %v", err)
+	}
+}
+
+func TestAssetFor(t *testing.T) {
+	release := &Release{TagName: "v1.2.0", Assets: []Asset{
+		{Name: "checksums.txt"},
+		{Name: "springcli_1.2.0_linux_arm"},
+		{Name: "springcli_1.2.0_linux_arm64.tar.gz"},
+		{Name: "springcli_1.2.0_linux_x86_64.tar.gz"},
+		{Name: "springcli-darwin-arm64"},
+		{Name: "springcli_1.2.0_darwin_arm64.zip"},
+		{Name: "springcli_1.2.0_windows_amd64.zip"},
+		{Name: "other_1.2.0_freebsd_amd64.tar.gz"},
+	}}
+
+	cases := []struct {
+		goos, goarch string
+		want         string
+	}{
+		{goos: "linux", goarch: "arm", want: "springcli_1.2.0_linux_arm"},
+		{goos: "linux", goarch: "arm64", want: "springcli_1.2.0_linux_arm64.tar.gz"},
+		{goos: "linux", goarch: "amd64", want: "springcli_1.2.0_linux_x86_64.tar.gz"},
+		{goos: "darwin", goarch: "arm64", want: "springcli_1.2.0_darwin_arm64.zip"},
+		{goos: "windows", goarch: "amd64", want: "springcli_1.2.0_windows_amd64.zip"},
+		{goos: "freebsd", goarch: "amd64"},
+		{goos: "linux", goarch: "386"},
+	}
+	for _, c := range cases {
+		t.Run(c.goos+"/"+c.goarch, func(t *testing.T) {
+			asset, err := release.AssetFor(c.goos, c.goarch)
+			if c.want == "" {
+				if err == nil {
+					t.Errorf("AssetFor = %s, erreur attendue", asset.Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if asset.Name != c.want {
+				t.Errorf("AssetFor = %s, attendu %s", asset.Name, c.want)
+			}
+		})
+	}
+
+	checksums, ok := release.ChecksumsAsset()
+	if !ok || checksums.Name != "checksums.txt" {
+		t.Errorf("ChecksumsAsset = %s, %v", checksums.Name, ok)
+	}
+}
+
+func TestHasPlatform(t *testing.T) {
+	cases := []struct {
+		name, goos, goarch string
+		want               bool
+	}{
+		{"springcli_linux_arm.tar.gz", "linux", "arm", true},
+		{"springcli_linux_arm64.tar.gz", "linux", "arm", false},
+		{"springcli_linux_arm64.tar.gz", "linux", "arm64", true},
+		{"springcli-linux-aarch64", "linux", "arm64", true},
+		{"springcli_macos_x86_64.zip", "darwin", "amd64", true},
+		{"springcli_linux_i386", "linux", "386", true},
+		{"springcli_linux_amd64v3.tar.gz", "linux", "amd64", false},
+	}
+	for _, c := range cases {
+		if got := hasPlatform(c.name, c.goos, c.goarch); got != c.want {
+			t.Errorf("hasPlatform(%s, %s/%s) = %v, attendu %v", c.name, c.goos, c.goarch, got, c.want)
+		}
+	}
+}
+
+// tarGz renvoie une archive tar.gz contenant les fichiers donnés.
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := archive.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// zipArchive renvoie une archive zip contenant les fichiers donnés.
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtract(t *testing.T) {
+	cases := []struct {
+		name    string
+		file    string
+		data    []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "tar.gz",
+			file: "springcli_linux_amd64.tar.gz",
+			data: tarGz(t, map[string]string{"README.md": "lisez-moi", "springcli_1.2.0/springcli": "elf"}),
+			want: "elf",
+		},
+		{
+			name: "zip",
+			file: "springcli_windows_amd64.zip",
+			data: zipArchive(t, map[string]string{"LICENSE": "mit", "springcli.exe": "pe"}),
+			want: "pe",
+		},
+		{
+			name: "binaire nu",
+			file: "springcli-linux-amd64",
+			data: []byte("elf"),
+			want: "elf",
+		},
+		{
+			name:    "archive sans binaire",
+			file:    "springcli_linux_amd64.tgz",
+			data:    tarGz(t, map[string]string{"README.md": "lisez-moi"}),
+			wantErr: true,
+		},
+		{
+			name:    "archive invalide",
+			file:    "springcli_linux_amd64.zip",
+			data:    []byte("pas une archive"),
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Extract(c.file, c.data)
+			if c.wantErr {
+				if err == nil {
+					t.Errorf("Extract(%s) = %q, erreur attendue", c.file, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != c.want {
+				t.Errorf("Extract(%s) = %q, attendu %q", c.file, got, c.want)
+			}
+		})
+	}
+}
+
+func TestReplace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "springcli")
+	if err := os.WriteFile(path, []byte("ancien"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Replace(path, []byte("nouveau")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "nouveau" {
+		t.Errorf("contenu %q, attendu %q", data, "nouveau")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("binaire non exécutable: %v", info.Mode())
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("fichiers temporaires laissés dans %s: %v", dir, entries)
+	}
+
+	if err := Replace(filepath.Join(dir, "absent"), []byte("nouveau")); err == nil {
+		t.Error("remplacement d'un binaire absent accepté")
+	}
+}
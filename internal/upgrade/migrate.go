@@ -0,0 +1,290 @@
+package upgrade
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Change est une ligne modifiée par une règle.
+type Change struct {
+	Line   int
+	Before string
+	After  string
+}
+
+// Review est une ligne à vérifier à la main.
+type Review struct {
+	Line    int
+	Message string
+}
+
+// Result est le contenu d'un fichier après migration, avec ses modifications et les points
+// à vérifier. Starters liste les starters de test requis par les imports migrés.
+type Result struct {
+	Content  string
+	Changes  []Change
+	Reviews  []Review
+	Starters []string
+}
+
+// MigrateSource applique les règles d'import à un fichier Java ou Kotlin pour passer de la
+// version majeure from à to.
+func MigrateSource(content string, from, to int) Result {
+	lines := strings.Split(content, "\n")
+	migrated := make([]string, len(lines))
+	copy(migrated, lines)
+
+	result := Result{}
+	renames := map[string]string{}
+	reviewed := map[string]bool{}
+	for _, rule := range ImportRules {
+		if !applies(rule.Since, from, to) {
+			continue
+		}
+		for i, line := range migrated {
+			if !containsName(line, rule.From) {
+				continue
+			}
+			if rule.To == "" {
+				if !reviewed[rule.From] {
+					reviewed[rule.From] = true
+					result.Reviews = append(result.Reviews, Review{Line: i + 1, Message: rule.Review})
+				}
+				continue
+			}
+			migrated[i] = replaceName(line, rule.From, rule.To)
+			if !strings.HasSuffix(rule.From, ".") {
+				if fromName, toName := simpleName(rule.From), simpleName(rule.To); fromName != toName {
+					renames[fromName] = toName
+				}
+			}
+			if rule.Starter != "" && !contains(result.Starters, rule.Starter) {
+				result.Starters = append(result.Starters, rule.Starter)
+			}
+		}
+	}
+
+	// Classes renommées: les usages suivent l'import (@MockBean → @MockitoBean)
+	for fromName, toName := range renames {
+		usage := regexp.MustCompile(`\b` + regexp.QuoteMeta(fromName) + `\b`)
+		for i, line := range migrated {
+			if !strings.HasPrefix(strings.TrimSpace(line), "import ") {
+				migrated[i] = usage.ReplaceAllString(line, toName)
+			}
+		}
+	}
+
+	for i := range lines {
+		if lines[i] != migrated[i] {
+			result.Changes = append(result.Changes, Change{Line: i + 1, Before: lines[i], After: migrated[i]})
+		}
+	}
+	result.Content = strings.Join(migrated, "\n")
+	return result
+}
+
+// MigrateProperties applique les règles de propriétés à un fichier application.properties.
+func MigrateProperties(content string, from, to int) Result {
+	lines := strings.Split(content, "\n")
+	result := Result{}
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "!") {
+			continue
+		}
+		key := trimmed
+		if end := strings.IndexAny(trimmed, "=: \t"); end >= 0 {
+			key = trimmed[:end]
+		}
+		rule, renamed, ok := matchProperty(key, from, to)
+		if !ok {
+			continue
+		}
+		if rule.To == "" {
+			result.Reviews = append(result.Reviews, Review{Line: i + 1, Message: key + ": " + rule.Review})
+			continue
+		}
+		updated := strings.Replace(line, key, renamed, 1)
+		result.Changes = append(result.Changes, Change{Line: i + 1, Before: line, After: updated})
+		lines[i] = updated
+	}
+	result.Content = strings.Join(lines, "\n")
+	return result
+}
+
+var yamlKeyRegexp = regexp.MustCompile(`^(\s*)(?:- )?([^\s#:'"][^:#]*?|'[^']*'|"[^"]*"):(?:\s|$)`)
+
+// MigrateYAML applique les règles de propriétés à un fichier application.yml. Une clé n'est
+// renommée sur place que si son chemin parent ne change pas (clé écrite avec des points);
+// sinon, le déplacement est signalé, une fois par règle.
+func MigrateYAML(content string, from, to int) Result {
+	type entry struct {
+		indent int
+		key    string
+	}
+	lines := strings.Split(content, "\n")
+	result := Result{}
+	reviewed := map[string]bool{}
+	var stack []entry
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "---" {
+			stack = nil
+			continue
+		}
+		m := yamlKeyRegexp.FindStringSubmatch(line)
+		if m == nil || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		indent, key := len(m[1]), strings.Trim(m[2], `'"`)
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		var parents []string
+		for _, e := range stack {
+			parents = append(parents, e.key)
+		}
+		stack = append(stack, entry{indent: indent, key: key})
+		parent := strings.Join(parents, ".")
+		full := key
+		if parent != "" {
+			full = parent + "." + key
+		}
+
+		rule, renamed, ok := matchProperty(full, from, to)
+		if !ok {
+			continue
+		}
+		switch {
+		case rule.To == "":
+			if !reviewed[rule.From] {
+				reviewed[rule.From] = true
+				result.Reviews = append(result.Reviews, Review{Line: i + 1, Message: full + ": " + rule.Review})
+			}
+		case parent == "" || strings.HasPrefix(renamed, parent+"."):
+			newKey := strings.TrimPrefix(renamed, parent+".")
+			if parent == "" {
+				newKey = renamed
+			}
+			updated := strings.Replace(line, m[2], newKey, 1)
+			result.Changes = append(result.Changes, Change{Line: i + 1, Before: line, After: updated})
+			lines[i] = updated
+		default:
+			if !reviewed[rule.From] {
+				reviewed[rule.From] = true
+				result.Reviews = append(result.Reviews, Review{Line: i + 1, Message: full + " renommée en " + renamed + ": déplacez la clé"})
+			}
+		}
+	}
+	result.Content = strings.Join(lines, "\n")
+	return result
+}
+
+// matchProperty cherche la règle qui s'applique à la propriété et renvoie son nouveau nom.
+func matchProperty(key string, from, to int) (PropertyRule, string, bool) {
+	for _, rule := range PropertyRules {
+		if !applies(rule.Since, from, to) {
+			continue
+		}
+		if strings.HasSuffix(rule.From, ".") && strings.HasPrefix(key, rule.From) {
+			if rule.To == "" {
+				return rule, "", true
+			}
+			return rule, rule.To + strings.TrimPrefix(key, rule.From), true
+		}
+		if key == rule.From {
+			return rule, rule.To, true
+		}
+	}
+	return PropertyRule{}, "", false
+}
+
+// MigrateStarters applique les règles de starters à un pom.xml ou un script Gradle. Un
+// starter dont le remplaçant est déjà déclaré est signalé plutôt que renommé.
+func MigrateStarters(content string, from, to int) Result {
+	lines := strings.Split(content, "\n")
+	result := Result{}
+	for _, rule := range StarterRules {
+		if !applies(rule.Since, from, to) {
+			continue
+		}
+		declaration := starterRegexp(rule.From)
+		for i, line := range lines {
+			if !declaration.MatchString(line) {
+				continue
+			}
+			switch {
+			case rule.To == "":
+				result.Reviews = append(result.Reviews, Review{Line: i + 1, Message: rule.From + ": " + rule.Review})
+			case starterRegexp(rule.To).MatchString(content):
+				result.Reviews = append(result.Reviews, Review{Line: i + 1, Message: rule.From + " remplacé par " + rule.To + ", déjà déclaré: supprimez-le"})
+			default:
+				updated := declaration.ReplaceAllString(line, "${1}"+rule.To+"${2}")
+				result.Changes = append(result.Changes, Change{Line: i + 1, Before: line, After: updated})
+				lines[i] = updated
+			}
+		}
+	}
+	result.Content = strings.Join(lines, "\n")
+	return result
+}
+
+// starterRegexp reconnaît la déclaration d'un starter dans un pom.xml
+// (<artifactId>spring-boot-starter-web</artifactId>) ou un script Gradle
+// ("org.springframework.boot:spring-boot-starter-web").
+func starterRegexp(artifactID string) *regexp.Regexp {
+	name := regexp.QuoteMeta(artifactID)
+	return regexp.MustCompile(`(<artifactId>\s*|org\.springframework\.boot:)` + name + `(\s*</artifactId>|["':])`)
+}
+
+// containsName indique si la ligne mentionne le package ou la classe name (une classe
+// n'est pas reconnue comme préfixe d'une autre: MockBean ne désigne pas MockBeans).
+func containsName(line, name string) bool {
+	return indexName(line, name, 0) >= 0
+}
+
+func replaceName(line, from, to string) string {
+	for offset := 0; ; {
+		i := indexName(line, from, offset)
+		if i < 0 {
+			return line
+		}
+		line = line[:i] + to + line[i+len(from):]
+		offset = i + len(to)
+	}
+}
+
+// indexName renvoie la position de name dans la ligne à partir de offset, -1 s'il n'y
+// apparaît pas comme nom complet.
+func indexName(line, name string, offset int) int {
+	for {
+		i := strings.Index(line[offset:], name)
+		if i < 0 {
+			return -1
+		}
+		i += offset
+		end := i + len(name)
+		startOK := i == 0 || !isIdentifierChar(line[i-1])
+		endOK := strings.HasSuffix(name, ".") || end == len(line) || !isIdentifierChar(line[end])
+		if startOK && endOK {
+			return i
+		}
+		offset = i + 1
+	}
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '.' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func simpleName(qualified string) string {
+	return qualified[strings.LastIndex(qualified, ".")+1:]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,254 @@
+package upgrade
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMigrateSource(t *testing.T) {
+	cases := []struct {
+		name     string
+		from, to int
+		content  string
+		want     string
+		changes  int
+		reviews  int
+		starters []string
+	}{
+		{
+			name:    "javax vers jakarta",
+			from:    2,
+			to:      3,
+			content: "import javax.persistence.Entity;\nimport javax.validation.constraints.NotNull;\nimport javax.annotation.PostConstruct;",
+			want:    "import jakarta.persistence.Entity;\nimport jakarta.validation.constraints.NotNull;\nimport jakarta.annotation.PostConstruct;",
+			changes: 3,
+		},
+		{
+			name:    "javax hors règles conservé",
+			from:    2,
+			to:      3,
+			content: "import javax.crypto.Cipher;",
+			want:    "import javax.crypto.Cipher;",
+		},
+		{
+			name:    "règles de Spring Boot 3 déjà franchies",
+			from:    3,
+			to:      4,
+			content: "import javax.persistence.Entity;",
+			want:    "import javax.persistence.Entity;",
+		},
+		{
+			name: "MockBean et ses usages vers MockitoBean",
+			from: 3,
+			to:   4,
+			content: "import org.springframework.boot.test.mock.mockito.MockBean;\n" +
+				"class T {\n    @MockBean\n    private Service service;\n}",
+			want: "import org.springframework.test.context.bean.override.mockito.MockitoBean;\n" +
+				"class T {\n    @MockitoBean\n    private Service service;\n}",
+			changes: 2,
+		},
+		{
+			name:    "MockBean ne désigne pas MockBeans",
+			from:    3,
+			to:      4,
+			content: "import org.springframework.boot.test.mock.mockito.MockBeans;\n@MockBeans({})",
+			want:    "import org.springframework.boot.test.mock.mockito.MockBeans;\n@MockBeans({})",
+		},
+		{
+			name:    "LocalServerPort déplacé deux fois de 2 à 4",
+			from:    2,
+			to:      4,
+			content: "import org.springframework.boot.web.server.LocalServerPort;",
+			want:    "import org.springframework.boot.web.server.test.LocalServerPort;",
+			changes: 1,
+		},
+		{
+			name:     "tranche de test et son starter",
+			from:     3,
+			to:       4,
+			content:  "import org.springframework.boot.test.autoconfigure.web.servlet.WebMvcTest",
+			want:     "import org.springframework.boot.webmvc.test.autoconfigure.WebMvcTest",
+			changes:  1,
+			starters: []string{"spring-boot-starter-webmvc-test"},
+		},
+		{
+			name:    "import signalé une seule fois",
+			from:    3,
+			to:      4,
+			content: "import com.fasterxml.jackson.databind.ObjectMapper;\nimport com.fasterxml.jackson.databind.JsonNode;",
+			want:    "import com.fasterxml.jackson.databind.ObjectMapper;\nimport com.fasterxml.jackson.databind.JsonNode;",
+			reviews: 1,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := MigrateSource(c.content, c.from, c.to)
+			if result.Content != c.want {
+				t.Errorf("contenu:\n%s\nattendu:\n%s", result.Content, c.want)
+			}
+			if len(result.Changes) != c.changes {
+				t.Errorf("%d modification(s), %d attendue(s): %+v", len(result.Changes), c.changes, result.Changes)
+			}
+			if len(result.Reviews) != c.reviews {
+				t.Errorf("%d point(s) à vérifier, %d attendu(s): %+v", len(result.Reviews), c.reviews, result.Reviews)
+			}
+			if !reflect.DeepEqual(result.Starters, c.starters) {
+				t.Errorf("starters %v, attendus %v", result.Starters, c.starters)
+			}
+		})
+	}
+}
+
+func TestMigrateProperties(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    string
+		reviews []string
+	}{
+		{
+			name:    "préfixe renommé",
+			content: "spring.redis.host=localhost\nspring.redis.port: 6379",
+			want:    "spring.data.redis.host=localhost\nspring.data.redis.port: 6379",
+		},
+		{
+			name:    "propriété exacte",
+			content: "server.max-http-header-size=16KB",
+			want:    "server.max-http-request-header-size=16KB",
+		},
+		{
+			name:    "nom qui n'est pas un préfixe conservé",
+			content: "spring.elasticsearch.rest.uris-extra=x\nspring.redisson.host=x",
+			want:    "spring.elasticsearch.rest.uris-extra=x\nspring.redisson.host=x",
+		},
+		{
+			name:    "commentaires ignorés",
+			content: "# spring.redis.host=localhost\n! spring.redis.port=6379",
+			want:    "# spring.redis.host=localhost\n! spring.redis.port=6379",
+		},
+		{
+			name:    "propriété supprimée signalée",
+			content: "spring.jpa.hibernate.use-new-id-generator-mappings=true",
+			want:    "spring.jpa.hibernate.use-new-id-generator-mappings=true",
+			reviews: []string{"spring.jpa.hibernate.use-new-id-generator-mappings"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := MigrateProperties(c.content, 2, 3)
+			if result.Content != c.want {
+				t.Errorf("contenu:\n%s\nattendu:\n%s", result.Content, c.want)
+			}
+			assertReviews(t, result.Reviews, c.reviews)
+		})
+	}
+}
+
+func TestMigrateYAML(t *testing.T) {
+	cases := []struct {
+		name     string
+		from, to int
+		content  string
+		want     string
+		reviews  []string
+	}{
+		{
+			name:    "clé écrite avec des points renommée",
+			from:    2,
+			to:      3,
+			content: "spring.redis.host: localhost",
+			want:    "spring.data.redis.host: localhost",
+		},
+		{
+			name:    "clé imbriquée renommée sous le même parent",
+			from:    3,
+			to:      4,
+			content: "management:\n  tracing:\n    enabled: false",
+			want:    "management:\n  tracing:\n    export.enabled: false",
+		},
+		{
+			name:    "clé imbriquée déplacée signalée, une fois par règle",
+			from:    2,
+			to:      3,
+			content: "spring:\n  redis:\n    host: localhost\n    port: 6379",
+			want:    "spring:\n  redis:\n    host: localhost\n    port: 6379",
+			reviews: []string{"spring.redis.host renommée en spring.data.redis.host"},
+		},
+		{
+			name:    "documents séparés",
+			from:    2,
+			to:      3,
+			content: "server:\n  port: 8080\n---\nspring.redis.host: localhost",
+			want:    "server:\n  port: 8080\n---\nspring.data.redis.host: localhost",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := MigrateYAML(c.content, c.from, c.to)
+			if result.Content != c.want {
+				t.Errorf("contenu:\n%s\nattendu:\n%s", result.Content, c.want)
+			}
+			assertReviews(t, result.Reviews, c.reviews)
+		})
+	}
+}
+
+func TestMigrateStarters(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    string
+		reviews []string
+	}{
+		{
+			name:    "starter Maven renommé",
+			content: "<artifactId>spring-boot-starter-web</artifactId>",
+			want:    "<artifactId>spring-boot-starter-webmvc</artifactId>",
+		},
+		{
+			name:    "starter Gradle renommé",
+			content: `implementation("org.springframework.boot:spring-boot-starter-aop")`,
+			want:    `implementation("org.springframework.boot:spring-boot-starter-aspectj")`,
+		},
+		{
+			name:    "spring-boot-starter-web ne désigne pas web-services",
+			content: "<artifactId>spring-boot-starter-web-services</artifactId>",
+			want:    "<artifactId>spring-boot-starter-webservices</artifactId>",
+		},
+		{
+			name:    "remplaçant déjà déclaré",
+			content: "<artifactId>spring-boot-starter-web</artifactId>\n<artifactId>spring-boot-starter-webmvc</artifactId>",
+			want:    "<artifactId>spring-boot-starter-web</artifactId>\n<artifactId>spring-boot-starter-webmvc</artifactId>",
+			reviews: []string{"spring-boot-starter-web remplacé par spring-boot-starter-webmvc, déjà déclaré"},
+		},
+		{
+			name:    "starter supprimé signalé",
+			content: "<artifactId>spring-boot-starter-undertow</artifactId>",
+			want:    "<artifactId>spring-boot-starter-undertow</artifactId>",
+			reviews: []string{"spring-boot-starter-undertow"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := MigrateStarters(c.content, 3, 4)
+			if result.Content != c.want {
+				t.Errorf("contenu:\n%s\nattendu:\n%s", result.Content, c.want)
+			}
+			assertReviews(t, result.Reviews, c.reviews)
+		})
+	}
+}
+
+// assertReviews vérifie que chaque point à vérifier commence par le message attendu.
+func assertReviews(t *testing.T, reviews []Review, want []string) {
+	t.Helper()
+	if len(reviews) != len(want) {
+		t.Fatalf("points à vérifier %+v, attendus %q", reviews, want)
+	}
+	for i, r := range reviews {
+		if !strings.HasPrefix(r.Message, want[i]) {
+			t.Errorf("point à vérifier %q, attendu %q...", r.Message, want[i])
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Package upgrade décrit les migrations d'un projet vers une nouvelle version majeure de
+// Spring Boot: imports et annotations déplacés, propriétés de configuration renommées,
+// starters remplacés. Les règles s'appliquent au contenu des fichiers, sans accès réseau.
+package upgrade
+
+// ImportRule déplace un package ou une classe. Pour une classe renommée
+// (MockBean → MockitoBean), l'annotation est renommée dans les fichiers qui l'importent.
+// Sans To, l'import est seulement signalé avec Review.
+type ImportRule struct {
+	Since  int
+	From   string
+	To     string
+	Review string
+	// Starter est le starter de test à déclarer lorsque la règle s'applique
+	Starter string
+}
+
+// PropertyRule renomme une propriété de configuration, ou toutes celles d'un préfixe
+// lorsque From se termine par un point. Sans To, la propriété est seulement signalée.
+type PropertyRule struct {
+	Since  int
+	From   string
+	To     string
+	Review string
+}
+
+// StarterRule remplace un starter de Spring Boot. Sans To, le starter est seulement signalé.
+type StarterRule struct {
+	Since  int
+	From   string
+	To     string
+	Review string
+}
+
+// JavaVersions donne la version minimale de Java de chaque version majeure de Spring Boot.
+var JavaVersions = map[int]int{2: 8, 3: 17, 4: 17}
+
+// ImportRules sont les déplacements de packages et de classes.
+var ImportRules = []ImportRule{
+	// Spring Boot 3: Jakarta EE 9+
+	{Since: 3, From: "javax.persistence.", To: "jakarta.persistence."},
+	{Since: 3, From: "javax.validation.", To: "jakarta.validation."},
+	{Since: 3, From: "javax.servlet.", To: "jakarta.servlet."},
+	{Since: 3, From: "javax.transaction.Transactional", To: "jakarta.transaction.Transactional"},
+	{Since: 3, From: "javax.annotation.PostConstruct", To: "jakarta.annotation.PostConstruct"},
+	{Since: 3, From: "javax.annotation.PreDestroy", To: "jakarta.annotation.PreDestroy"},
+	{Since: 3, From: "javax.annotation.Resource", To: "jakarta.annotation.Resource"},
+	{Since: 3, From: "javax.inject.", To: "jakarta.inject."},
+	{Since: 3, From: "javax.mail.", To: "jakarta.mail."},
+	{Since: 3, From: "javax.websocket.", To: "jakarta.websocket."},
+	{Since: 3, From: "javax.ws.rs.", To: "jakarta.ws.rs."},
+	{Since: 3, From: "javax.xml.bind.", To: "jakarta.xml.bind."},
+	{Since: 3, From: "javax.jms.", To: "jakarta.jms."},
+	{Since: 3, From: "org.springframework.boot.web.server.LocalServerPort", To: "org.springframework.boot.test.web.server.LocalServerPort"},
+
+	// Spring Boot 4: modules dédiés et annotations de test de Spring Framework
+	{Since: 4, From: "org.springframework.boot.test.mock.mockito.MockBean", To: "org.springframework.test.context.bean.override.mockito.MockitoBean"},
+	{Since: 4, From: "org.springframework.boot.test.mock.mockito.SpyBean", To: "org.springframework.test.context.bean.override.mockito.MockitoSpyBean"},
+	{Since: 4, From: "org.springframework.boot.test.autoconfigure.orm.jpa.DataJpaTest", To: "org.springframework.boot.data.jpa.test.autoconfigure.DataJpaTest", Starter: "spring-boot-starter-data-jpa-test"},
+	{Since: 4, From: "org.springframework.boot.test.autoconfigure.web.servlet.WebMvcTest", To: "org.springframework.boot.webmvc.test.autoconfigure.WebMvcTest", Starter: "spring-boot-starter-webmvc-test"},
+	{Since: 4, From: "org.springframework.boot.test.autoconfigure.web.servlet.AutoConfigureMockMvc", To: "org.springframework.boot.webmvc.test.autoconfigure.AutoConfigureMockMvc", Starter: "spring-boot-starter-webmvc-test"},
+	{Since: 4, From: "org.springframework.boot.test.web.server.LocalServerPort", To: "org.springframework.boot.web.server.test.LocalServerPort"},
+	{Since: 4, From: "org.springframework.boot.autoconfigure.domain.EntityScan", To: "org.springframework.boot.persistence.autoconfigure.EntityScan"},
+	{Since: 4, From: "org.springframework.boot.test.web.client.TestRestTemplate", Review: "TestRestTemplate demande @AutoConfigureTestRestTemplate: préférez RestTestClient"},
+	{Since: 4, From: "com.fasterxml.jackson.databind.", Review: "Spring Boot 4 utilise Jackson 3 (tools.jackson.databind): Jackson 2 reste pris en charge mais déprécié"},
+}
+
+// PropertyRules sont les propriétés de configuration renommées ou supprimées.
+var PropertyRules = []PropertyRule{
+	// Spring Boot 3
+	{Since: 3, From: "spring.redis.", To: "spring.data.redis."},
+	{Since: 3, From: "spring.elasticsearch.rest.uris", To: "spring.elasticsearch.uris"},
+	{Since: 3, From: "spring.elasticsearch.rest.username", To: "spring.elasticsearch.username"},
+	{Since: 3, From: "spring.elasticsearch.rest.password", To: "spring.elasticsearch.password"},
+	{Since: 3, From: "server.max-http-header-size", To: "server.max-http-request-header-size"},
+	{Since: 3, From: "management.metrics.export.prometheus.enabled", To: "management.prometheus.metrics.export.enabled"},
+	{Since: 3, From: "spring.jpa.hibernate.use-new-id-generator-mappings", Review: "propriété supprimée: Hibernate 6 utilise toujours les nouveaux générateurs"},
+
+	// Spring Boot 4
+	{Since: 4, From: "spring.data.mongodb.host", To: "spring.mongodb.host"},
+	{Since: 4, From: "spring.data.mongodb.port", To: "spring.mongodb.port"},
+	{Since: 4, From: "spring.data.mongodb.uri", To: "spring.mongodb.uri"},
+	{Since: 4, From: "spring.data.mongodb.database", To: "spring.mongodb.database"},
+	{Since: 4, From: "spring.data.mongodb.username", To: "spring.mongodb.username"},
+	{Since: 4, From: "spring.data.mongodb.password", To: "spring.mongodb.password"},
+	{Since: 4, From: "spring.data.mongodb.authentication-database", To: "spring.mongodb.authentication-database"},
+	{Since: 4, From: "spring.data.mongodb.replica-set-name", To: "spring.mongodb.replica-set-name"},
+	{Since: 4, From: "spring.dao.exceptiontranslation.enabled", To: "spring.persistence.exceptiontranslation.enabled"},
+	{Since: 4, From: "spring.jackson.read.", To: "spring.jackson.json.read."},
+	{Since: 4, From: "spring.jackson.write.", To: "spring.jackson.json.write."},
+	{Since: 4, From: "management.tracing.enabled", To: "management.tracing.export.enabled"},
+	{Since: 4, From: "management.endpoints.enabled-by-default", Review: "remplacée par management.endpoints.access.default (none, read-only ou unrestricted)"},
+	{Since: 4, From: "server.undertow.", Review: "Undertow n'est plus pris en charge par Spring Boot 4"},
+}
+
+// StarterRules sont les starters renommés ou supprimés.
+var StarterRules = []StarterRule{
+	{Since: 4, From: "spring-boot-starter-web", To: "spring-boot-starter-webmvc"},
+	{Since: 4, From: "spring-boot-starter-aop", To: "spring-boot-starter-aspectj"},
+	{Since: 4, From: "spring-boot-starter-web-services", To: "spring-boot-starter-webservices"},
+	{Since: 4, From: "spring-boot-starter-oauth2-client", To: "spring-boot-starter-security-oauth2-client"},
+	{Since: 4, From: "spring-boot-starter-oauth2-resource-server", To: "spring-boot-starter-security-oauth2-resource-server"},
+	{Since: 4, From: "spring-boot-starter-oauth2-authorization-server", To: "spring-boot-starter-security-oauth2-authorization-server"},
+	{Since: 4, From: "spring-boot-starter-undertow", Review: "Undertow n'est plus pris en charge: utilisez Tomcat ou Jetty"},
+}
+
+// applies indique si une règle introduite par la version majeure since concerne le
+// passage de from à to.
+func applies(since, from, to int) bool {
+	return from < since && since <= to
+}